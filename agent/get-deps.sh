@@ -1,4 +1,4 @@
 go install mvdan.cc/gofumpt@v0.3.1
-go install github.com/golangci/golangci-lint/cmd/golangci-lint@v1.45.2
+go install github.com/golangci/golangci-lint/cmd/golangci-lint@v1.54.2
 go install golang.org/x/tools/cmd/goimports@v0.1.5
 go install github.com/goreleaser/goreleaser@v1.1.0
@@ -6,8 +6,9 @@ from typing import Any, Dict, List, Optional
 from requests import Response
 from termcolor import colored
 
+from determined.cli import setup_session
 from determined.common import api
-from determined.common.api import authentication
+from determined.common.api import authentication, bindings
 from determined.common.declarative_argparse import Arg, Cmd
 
 from . import render
@@ -128,16 +129,25 @@ def change_password(parsed_args: Namespace) -> None:
         print(colored("Passwords do not match", "red"))
         return
 
-    # Hash the password to avoid sending it in cleartext.
-    password = api.salt_and_hash(password)
-
-    update_user(username, parsed_args.master, password=password)
+    # Send the password itself rather than its hash so that the master can check it against the
+    # password policy.
+    session = setup_session(parsed_args)
+    user_id: Optional[int] = None
+    if parsed_args.target_user is None:
+        user_id = bindings.get_CurrentUser(session).user.id
+    else:
+        for u in bindings.get_GetUsers(session).users or []:
+            if u.username == username:
+                user_id = u.id
+    if user_id is None:
+        raise api.errors.BadRequestException(f"could not find user {username}")
+    bindings.post_SetUserPassword(session, body=password, userId=user_id)
 
     # If the target user's password isn't being changed by another user, reauthenticate after
     # password change so that the user doesn't have to do so manually.
     if parsed_args.target_user is None:
         token_store = authentication.TokenStore(parsed_args.master)
-        token = authentication.do_login(parsed_args.master, username, password)
+        token = authentication.do_login(parsed_args.master, username, api.salt_and_hash(password))
         token_store.set_token(username, token)
         token_store.set_active(username)
 
@@ -3720,8 +3720,10 @@ class v1Job:
         submissionTime: str,
         type: "determinedjobv1Type",
         username: str,
+        blockedByQuota: "typing.Optional[bool]" = None,
         priority: "typing.Optional[int]" = None,
         progress: "typing.Optional[float]" = None,
+        reservedStart: "typing.Optional[str]" = None,
         summary: "typing.Optional[v1JobSummary]" = None,
         userId: "typing.Optional[int]" = None,
         weight: "typing.Optional[float]" = None,
@@ -3741,6 +3743,8 @@ class v1Job:
         self.allocatedSlots = allocatedSlots
         self.name = name
         self.progress = progress
+        self.reservedStart = reservedStart
+        self.blockedByQuota = blockedByQuota
 
     @classmethod
     def from_json(cls, obj: Json) -> "v1Job":
@@ -3760,6 +3764,8 @@ class v1Job:
             allocatedSlots=obj["allocatedSlots"],
             name=obj["name"],
             progress=float(obj["progress"]) if obj.get("progress", None) is not None else None,
+            reservedStart=obj.get("reservedStart", None),
+            blockedByQuota=obj.get("blockedByQuota", None),
         )
 
     def to_json(self) -> typing.Any:
@@ -3779,6 +3785,8 @@ class v1Job:
             "allocatedSlots": self.allocatedSlots,
             "name": self.name,
             "progress": dump_float(self.progress) if self.progress is not None else None,
+            "reservedStart": self.reservedStart if self.reservedStart is not None else None,
+            "blockedByQuota": self.blockedByQuota if self.blockedByQuota is not None else None,
         }
 
 class v1JobSummary:
@@ -6557,6 +6565,7 @@ class v1SchedulerType(enum.Enum):
     SCHEDULER_TYPE_KUBERNETES = "SCHEDULER_TYPE_KUBERNETES"
     SCHEDULER_TYPE_SLURM = "SCHEDULER_TYPE_SLURM"
     SCHEDULER_TYPE_PBS = "SCHEDULER_TYPE_PBS"
+    SCHEDULER_TYPE_BACKFILL = "SCHEDULER_TYPE_BACKFILL"
 
 class v1SearchRolesAssignableToScopeRequest:
     def __init__(
@@ -1182,6 +1182,14 @@ schemas = {
             "default": {},
             "optionalRef": "http://determined.ai/schemas/expconf/v0/profiling.json"
         },
+        "progress_timeout": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": {},
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/progress-timeout.json"
+        },
         "project": {
             "type": [
                 "string",
@@ -1556,6 +1564,27 @@ schemas = {
         "vals": {
             "type": "array",
             "minLength": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     }
 }
@@ -1578,7 +1607,28 @@ schemas = {
         "type": {
             "const": "const"
         },
-        "val": true
+        "val": true,
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
+        }
     }
 }
 
@@ -1614,6 +1664,27 @@ schemas = {
             ],
             "default": null,
             "minimum": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     },
     "compareProperties": {
@@ -1655,6 +1726,27 @@ schemas = {
             ],
             "default": null,
             "minimum": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     },
     "compareProperties": {
@@ -1701,6 +1793,27 @@ schemas = {
             ],
             "default": null,
             "minimum": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     },
     "compareProperties": {
@@ -1995,6 +2108,37 @@ schemas = {
     }
 }
 
+"""
+    ),
+    "http://determined.ai/schemas/expconf/v0/progress-timeout.json": json.loads(
+        r"""
+{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/progress-timeout.json",
+    "title": "ProgressTimeoutConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [],
+    "properties": {
+        "seconds": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "action": {
+            "enum": [
+                null,
+                "kill",
+                "restart"
+            ],
+            "default": "kill"
+        }
+    }
+}
+
 """
     ),
     "http://determined.ai/schemas/expconf/v0/registry-auth.json": json.loads(
@@ -2111,6 +2255,14 @@ schemas = {
             "default": [],
             "optionalRef": "http://determined.ai/schemas/expconf/v0/devices.json"
         },
+        "expected_runtime_seconds": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 1,
+            "default": null
+        },
         "max_slots": {
             "type": [
                 "integer",
@@ -2388,6 +2540,16 @@ schemas = {
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -2697,6 +2859,16 @@ schemas = {
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -2853,6 +3025,180 @@ schemas = {
     }
 }
 
+"""
+    ),
+    "http://determined.ai/schemas/expconf/v0/searcher-metric.json": json.loads(
+        r"""
+{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-metric.json",
+    "title": "SearcherMetric",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "name"
+    ],
+    "properties": {
+        "name": {
+            "type": "string"
+        },
+        "smaller_is_better": {
+            "type": [
+                "boolean",
+                "null"
+            ],
+            "default": true
+        }
+    }
+}
+
+"""
+    ),
+    "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json": json.loads(
+        r"""
+{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json",
+    "title": "PBTExploreConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "resample_probability",
+        "perturb_factor"
+    ],
+    "properties": {
+        "resample_probability": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 1
+        },
+        "perturb_factor": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 1
+        }
+    }
+}
+
+"""
+    ),
+    "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json": json.loads(
+        r"""
+{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json",
+    "title": "PBTReplaceConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "truncate_fraction"
+    ],
+    "properties": {
+        "truncate_fraction": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 0.5
+        }
+    }
+}
+
+"""
+    ),
+    "http://determined.ai/schemas/expconf/v0/searcher-pbt.json": json.loads(
+        r"""
+{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-pbt.json",
+    "title": "PBTConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "name"
+    ],
+    "eventuallyRequired": [
+        "population_size",
+        "num_rounds",
+        "length_per_round",
+        "replace_function",
+        "explore_function",
+        "metric"
+    ],
+    "properties": {
+        "name": {
+            "const": "pbt"
+        },
+        "population_size": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "num_rounds": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "length_per_round": {
+            "type": [
+                "object",
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-length.json"
+        },
+        "replace_function": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json"
+        },
+        "explore_function": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json"
+        },
+        "metric": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        },
+        "smaller_is_better": {
+            "type": [
+                "boolean",
+                "null"
+            ],
+            "default": true
+        },
+        "source_trial_id": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null
+        },
+        "source_checkpoint_uuid": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        }
+    }
+}
+
 """
     ),
     "http://determined.ai/schemas/expconf/v0/searcher-random.json": json.loads(
@@ -2914,6 +3260,16 @@ schemas = {
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -3086,6 +3442,109 @@ schemas = {
     }
 }
 
+"""
+    ),
+    "http://determined.ai/schemas/expconf/v0/searcher-tpe.json": json.loads(
+        r"""
+{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-tpe.json",
+    "title": "TPEConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "name"
+    ],
+    "eventuallyRequired": [
+        "max_trials",
+        "max_length",
+        "metric"
+    ],
+    "properties": {
+        "name": {
+            "const": "tpe"
+        },
+        "max_concurrent_trials": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 0,
+            "default": 4
+        },
+        "max_trials": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "max_length": {
+            "type": [
+                "object",
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-length.json"
+        },
+        "n_startup_trials": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 1,
+            "default": 10
+        },
+        "gamma": {
+            "type": [
+                "number",
+                "null"
+            ],
+            "exclusiveMinimum": 0,
+            "exclusiveMaximum": 1,
+            "default": 0.25
+        },
+        "n_ei_candidates": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 1,
+            "default": 24
+        },
+        "metric": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        },
+        "smaller_is_better": {
+            "type": [
+                "boolean",
+                "null"
+            ],
+            "default": true
+        },
+        "source_trial_id": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null
+        },
+        "source_checkpoint_uuid": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        }
+    }
+}
+
 """
     ),
     "http://determined.ai/schemas/expconf/v0/searcher.json": json.loads(
@@ -3102,7 +3561,7 @@ schemas = {
     },
     "then": {
         "union": {
-            "defaultMessage": "is not an object where object[\"name\"] is one of 'single', 'random', 'grid', 'custom', or 'adaptive_asha'",
+            "defaultMessage": "is not an object where object[\"name\"] is one of 'single', 'random', 'grid', 'tpe', 'pbt', 'custom', or 'adaptive_asha'",
             "items": [
                 {
                     "unionKey": "const:name=single",
@@ -3116,6 +3575,14 @@ schemas = {
                     "unionKey": "const:name=grid",
                     "$ref": "http://determined.ai/schemas/expconf/v0/searcher-grid.json"
                 },
+                {
+                    "unionKey": "const:name=tpe",
+                    "$ref": "http://determined.ai/schemas/expconf/v0/searcher-tpe.json"
+                },
+                {
+                    "unionKey": "const:name=pbt",
+                    "$ref": "http://determined.ai/schemas/expconf/v0/searcher-pbt.json"
+                },
                 {
                     "unionKey": "const:name=custom",
                     "$ref": "http://determined.ai/schemas/expconf/v0/searcher-custom.json"
@@ -3154,13 +3621,21 @@ schemas = {
     "properties": {
         "bracket_rungs": true,
         "divisor": true,
+        "explore_function": true,
+        "gamma": true,
+        "length_per_round": true,
         "max_concurrent_trials": true,
         "max_length": true,
         "max_rungs": true,
         "max_trials": true,
         "mode": true,
+        "n_ei_candidates": true,
+        "n_startup_trials": true,
         "name": true,
+        "num_rounds": true,
         "num_rungs": true,
+        "population_size": true,
+        "replace_function": true,
         "stop_once": true,
         "metric": {
             "type": [
@@ -3176,6 +3651,16 @@ schemas = {
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
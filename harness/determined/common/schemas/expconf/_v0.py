@@ -40,6 +40,7 @@ class ResourcesConfigV0(schemas.SchemaBase):
     _id = "http://determined.ai/schemas/expconf/v0/resources.json"
     agent_label: Optional[str] = None
     devices: Optional[List[DeviceV0]] = None
+    expected_runtime_seconds: Optional[int] = None
     max_slots: Optional[int] = None
     native_parallel: Optional[bool] = None
     priority: Optional[int] = None
@@ -53,6 +54,7 @@ class ResourcesConfigV0(schemas.SchemaBase):
         self,
         agent_label: Optional[str] = None,
         devices: Optional[List[DeviceV0]] = None,
+        expected_runtime_seconds: Optional[int] = None,
         max_slots: Optional[int] = None,
         native_parallel: Optional[bool] = None,
         priority: Optional[int] = None,
@@ -164,6 +166,20 @@ class ProfilingConfigV0(schemas.SchemaBase):
         pass
 
 
+class ProgressTimeoutConfigV0(schemas.SchemaBase):
+    _id = "http://determined.ai/schemas/expconf/v0/progress-timeout.json"
+    seconds: Optional[int] = None
+    action: Optional[str] = None
+
+    @schemas.auto_init
+    def __init__(
+        self,
+        seconds: Optional[int] = None,
+        action: Optional[str] = None,
+    ) -> None:
+        pass
+
+
 class LengthV0(schemas.SchemaBase):
     _id = "http://determined.ai/schemas/expconf/v0/length.json"
     batches: Optional[int] = None
@@ -404,11 +420,15 @@ class HyperparameterV0(schemas.UnionBase):
 class ConstHyperparameterV0(schemas.SchemaBase):
     _id = "http://determined.ai/schemas/expconf/v0/hyperparameter-const.json"
     val: Any
+    conditions: Optional[Dict[str, List[Any]]] = None
+    constraints: Optional[List[str]] = None
 
     @schemas.auto_init
     def __init__(
         self,
         val: Any,
+        conditions: Optional[Dict[str, List[Any]]] = None,
+        constraints: Optional[List[str]] = None,
     ) -> None:
         pass
 
@@ -419,6 +439,8 @@ class IntHyperparameterV0(schemas.SchemaBase):
     minval: int
     maxval: int
     count: Optional[int] = None
+    conditions: Optional[Dict[str, List[Any]]] = None
+    constraints: Optional[List[str]] = None
 
     @schemas.auto_init
     def __init__(
@@ -426,6 +448,8 @@ class IntHyperparameterV0(schemas.SchemaBase):
         minval: int,
         maxval: int,
         count: Optional[int] = None,
+        conditions: Optional[Dict[str, List[Any]]] = None,
+        constraints: Optional[List[str]] = None,
     ) -> None:
         pass
 
@@ -436,6 +460,8 @@ class DoubleHyperparameterV0(schemas.SchemaBase):
     minval: float
     maxval: float
     count: Optional[int] = None
+    conditions: Optional[Dict[str, List[Any]]] = None
+    constraints: Optional[List[str]] = None
 
     @schemas.auto_init
     def __init__(
@@ -443,6 +469,8 @@ class DoubleHyperparameterV0(schemas.SchemaBase):
         minval: float,
         maxval: float,
         count: Optional[int] = None,
+        conditions: Optional[Dict[str, List[Any]]] = None,
+        constraints: Optional[List[str]] = None,
     ) -> None:
         pass
 
@@ -454,6 +482,8 @@ class LogHyperparameterV0(schemas.SchemaBase):
     maxval: float
     base: float
     count: Optional[int] = None
+    conditions: Optional[Dict[str, List[Any]]] = None
+    constraints: Optional[List[str]] = None
 
     @schemas.auto_init
     def __init__(
@@ -462,6 +492,8 @@ class LogHyperparameterV0(schemas.SchemaBase):
         maxval: float,
         base: float,
         count: Optional[int] = None,
+        conditions: Optional[Dict[str, List[Any]]] = None,
+        constraints: Optional[List[str]] = None,
     ) -> None:
         pass
 
@@ -470,11 +502,15 @@ class LogHyperparameterV0(schemas.SchemaBase):
 class CategoricalHyperparameterV0(schemas.SchemaBase):
     _id = "http://determined.ai/schemas/expconf/v0/hyperparameter-categorical.json"
     vals: List[Any]
+    conditions: Optional[Dict[str, List[Any]]] = None
+    constraints: Optional[List[str]] = None
 
     @schemas.auto_init
     def __init__(
         self,
         vals: List[Any],
+        conditions: Optional[Dict[str, List[Any]]] = None,
+        constraints: Optional[List[str]] = None,
     ) -> None:
         pass
 
@@ -503,6 +539,20 @@ class Unit(enum.Enum):
     RECORDS = "records"
 
 
+class SearcherMetricV0(schemas.SchemaBase):
+    _id = "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+    name: str
+    smaller_is_better: Optional[bool] = None
+
+    @schemas.auto_init
+    def __init__(
+        self,
+        name: str,
+        smaller_is_better: Optional[bool] = None,
+    ) -> None:
+        pass
+
+
 class SearcherConfigV0(schemas.UnionBase):
     _id = "http://determined.ai/schemas/expconf/v0/searcher.json"
     _union_key = "name"
@@ -553,6 +603,36 @@ class RandomConfigV0(schemas.SchemaBase):
     max_trials: int
     metric: str
     max_concurrent_trials: Optional[int] = None
+    metrics: Optional[List[SearcherMetricV0]] = None
+    smaller_is_better: Optional[bool] = None
+    source_checkpoint_uuid: Optional[str] = None
+    source_trial_id: Optional[int] = None
+
+    @schemas.auto_init
+    def __init__(
+        self,
+        max_length: Union[int, LengthV0],
+        max_trials: int,
+        metric: str,
+        max_concurrent_trials: Optional[int] = None,
+        metrics: Optional[List[SearcherMetricV0]] = None,
+        smaller_is_better: Optional[bool] = None,
+        source_checkpoint_uuid: Optional[str] = None,
+        source_trial_id: Optional[int] = None,
+    ) -> None:
+        pass
+
+
+@SearcherConfigV0.member("tpe")
+class TPEConfigV0(schemas.SchemaBase):
+    _id = "http://determined.ai/schemas/expconf/v0/searcher-tpe.json"
+    max_length: Union[int, LengthV0]
+    max_trials: int
+    metric: str
+    gamma: Optional[float] = None
+    max_concurrent_trials: Optional[int] = None
+    n_ei_candidates: Optional[int] = None
+    n_startup_trials: Optional[int] = None
     smaller_is_better: Optional[bool] = None
     source_checkpoint_uuid: Optional[str] = None
     source_trial_id: Optional[int] = None
@@ -563,7 +643,65 @@ class RandomConfigV0(schemas.SchemaBase):
         max_length: Union[int, LengthV0],
         max_trials: int,
         metric: str,
+        gamma: Optional[float] = None,
         max_concurrent_trials: Optional[int] = None,
+        n_ei_candidates: Optional[int] = None,
+        n_startup_trials: Optional[int] = None,
+        smaller_is_better: Optional[bool] = None,
+        source_checkpoint_uuid: Optional[str] = None,
+        source_trial_id: Optional[int] = None,
+    ) -> None:
+        pass
+
+
+class PBTReplaceConfigV0(schemas.SchemaBase):
+    _id = "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json"
+    truncate_fraction: float
+
+    @schemas.auto_init
+    def __init__(
+        self,
+        truncate_fraction: float,
+    ) -> None:
+        pass
+
+
+class PBTExploreConfigV0(schemas.SchemaBase):
+    _id = "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json"
+    perturb_factor: float
+    resample_probability: float
+
+    @schemas.auto_init
+    def __init__(
+        self,
+        perturb_factor: float,
+        resample_probability: float,
+    ) -> None:
+        pass
+
+
+@SearcherConfigV0.member("pbt")
+class PBTConfigV0(schemas.SchemaBase):
+    _id = "http://determined.ai/schemas/expconf/v0/searcher-pbt.json"
+    explore_function: PBTExploreConfigV0
+    length_per_round: Union[int, LengthV0]
+    metric: str
+    num_rounds: int
+    population_size: int
+    replace_function: PBTReplaceConfigV0
+    smaller_is_better: Optional[bool] = None
+    source_checkpoint_uuid: Optional[str] = None
+    source_trial_id: Optional[int] = None
+
+    @schemas.auto_init
+    def __init__(
+        self,
+        explore_function: PBTExploreConfigV0,
+        length_per_round: Union[int, LengthV0],
+        metric: str,
+        num_rounds: int,
+        population_size: int,
+        replace_function: PBTReplaceConfigV0,
         smaller_is_better: Optional[bool] = None,
         source_checkpoint_uuid: Optional[str] = None,
         source_trial_id: Optional[int] = None,
@@ -603,6 +741,7 @@ class AsyncHalvingConfigV0(schemas.SchemaBase):
     num_rungs: int
     divisor: Optional[float] = None
     max_concurrent_trials: Optional[int] = None
+    metrics: Optional[List[SearcherMetricV0]] = None
     smaller_is_better: Optional[bool] = None
     source_checkpoint_uuid: Optional[str] = None
     source_trial_id: Optional[int] = None
@@ -617,6 +756,7 @@ class AsyncHalvingConfigV0(schemas.SchemaBase):
         num_rungs: int,
         divisor: Optional[float] = None,
         max_concurrent_trials: Optional[int] = None,
+        metrics: Optional[List[SearcherMetricV0]] = None,
         smaller_is_better: Optional[bool] = None,
         source_checkpoint_uuid: Optional[str] = None,
         source_trial_id: Optional[int] = None,
@@ -635,6 +775,7 @@ class AdaptiveASHAConfigV0(schemas.SchemaBase):
     divisor: Optional[float] = None
     max_concurrent_trials: Optional[int] = None
     max_rungs: Optional[int] = None
+    metrics: Optional[List[SearcherMetricV0]] = None
     mode: Optional[AdaptiveMode] = None
     smaller_is_better: Optional[bool] = None
     source_checkpoint_uuid: Optional[str] = None
@@ -651,6 +792,7 @@ class AdaptiveASHAConfigV0(schemas.SchemaBase):
         divisor: Optional[float] = None,
         max_concurrent_trials: Optional[int] = None,
         max_rungs: Optional[int] = None,
+        metrics: Optional[List[SearcherMetricV0]] = None,
         mode: Optional[AdaptiveMode] = None,
         smaller_is_better: Optional[bool] = None,
         source_checkpoint_uuid: Optional[str] = None,
@@ -758,6 +900,8 @@ SearcherConfigV0_Type = Union[
     SingleConfigV0,
     RandomConfigV0,
     GridConfigV0,
+    TPEConfigV0,
+    PBTConfigV0,
     AsyncHalvingConfigV0,
     AdaptiveASHAConfigV0,
     # EOL searchers:
@@ -941,6 +1085,7 @@ class ExperimentConfigV0(schemas.SchemaBase):
     pbs: Optional[PbsClusterConfigV0] = None
     perform_initial_validation: Optional[bool] = None
     profiling: Optional[ProfilingConfigV0] = None
+    progress_timeout: Optional[ProgressTimeoutConfigV0] = None
     project: Optional[str] = None
     records_per_epoch: Optional[int] = None
     reproducibility: Optional[ReproducibilityConfigV0] = None
@@ -974,6 +1119,7 @@ class ExperimentConfigV0(schemas.SchemaBase):
         pbs: Optional[PbsClusterConfigV0] = None,
         perform_initial_validation: Optional[bool] = None,
         profiling: Optional[ProfilingConfigV0] = None,
+        progress_timeout: Optional[ProgressTimeoutConfigV0] = None,
         project: Optional[str] = None,
         records_per_epoch: Optional[int] = None,
         reproducibility: Optional[ReproducibilityConfigV0] = None,
@@ -43,8 +43,11 @@ def launch(experiment_config: det.ExperimentConfig) -> int:
     if isinstance(entrypoint, str):
         entrypoint = ["sh", "-c", entrypoint]
 
-    if os.environ.get("DET_RESOURCES_TYPE") == prep_container.RESOURCES_TYPE_SLURM_JOB:
-        # SLURM sends SIGTERM to notify of pending preemption
+    if os.environ.get("DET_RESOURCES_TYPE") in (
+        prep_container.RESOURCES_TYPE_SLURM_JOB,
+        prep_container.RESOURCES_TYPE_PBS_JOB,
+    ):
+        # SLURM and PBS send SIGTERM to notify of pending preemption
         signal.signal(signal.SIGTERM, trigger_preemption)
 
     logging.info(f"Launching: {entrypoint}")
@@ -85,6 +85,19 @@ def do_rendezvous_slurm(
     assert num_peers_str, "Unable to complete rendezvous without SLURM_NPROCS"
     num_peers = int(num_peers_str)
 
+    return do_rendezvous_all_gather(sess, allocation_id, rank, num_peers)
+
+
+def do_rendezvous_pbs(
+    sess: api.Session, allocation_id: str, resources_id: str
+) -> "det.RendezvousInfo":
+    # PBS jobs run a single task on a single node.
+    return do_rendezvous_all_gather(sess, allocation_id, rank=0, num_peers=1)
+
+
+def do_rendezvous_all_gather(
+    sess: api.Session, allocation_id: str, rank: int, num_peers: int
+) -> "det.RendezvousInfo":
     rendezvous_ip = socket.gethostbyname(socket.gethostname())
     for rendezvous_iface in rendezvous_ifaces():
         try:
@@ -159,6 +172,7 @@ def get_eth_interface_name() -> Optional[str]:
 RESOURCES_TYPE_K8S_POD = "k8s-pod"
 RESOURCES_TYPE_DOCKER_CONTAINER = "docker-container"
 RESOURCES_TYPE_SLURM_JOB = "slurm-job"
+RESOURCES_TYPE_PBS_JOB = "pbs-job"
 
 
 def do_rendezvous(sess: api.Session, allocation_id: str) -> None:
@@ -173,6 +187,8 @@ def do_rendezvous(sess: api.Session, allocation_id: str) -> None:
         rendezvous_info = do_rendezvous_rm_provided(sess, allocation_id, r_id)
     elif r_type == RESOURCES_TYPE_SLURM_JOB:
         rendezvous_info = do_rendezvous_slurm(sess, allocation_id, r_id)
+    elif r_type == RESOURCES_TYPE_PBS_JOB:
+        rendezvous_info = do_rendezvous_pbs(sess, allocation_id, r_id)
     else:
         raise ValueError(f"unsupported resources type: {r_type}")
 
@@ -219,7 +235,7 @@ def do_proxy(sess: api.Session, allocation_id: str) -> None:
 
     if r_type == RESOURCES_TYPE_DOCKER_CONTAINER or r_type == RESOURCES_TYPE_K8S_POD:
         return
-    elif r_type == RESOURCES_TYPE_SLURM_JOB:
+    elif r_type == RESOURCES_TYPE_SLURM_JOB or r_type == RESOURCES_TYPE_PBS_JOB:
         set_proxy_address(sess, allocation_id)
     else:
         raise ValueError(f"unsupported resources type: {r_type}")
@@ -133,4 +133,9 @@ func registerConfig() {
 		defaults.Telemetry.OtelEnabled, "enable otel")
 	registerString(flags, name("telemetry", "otel-endpoint"),
 		defaults.Telemetry.OtelExportedOtlpEndpoint, "set otel endpoint")
+
+	registerString(flags, name("webhooks", "signing-key"),
+		defaults.Webhooks.SigningKey, "the key used to sign outgoing webhooks")
+	registerString(flags, name("webhooks", "base-url"),
+		defaults.Webhooks.BaseURL, "the URL used to access Determined, for generating hyperlinks")
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/sha512"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -29,7 +30,7 @@ func replicateClientSideSaltAndHash(password string) string {
 }
 
 func (a *apiServer) Login(
-	_ context.Context, req *apiv1.LoginRequest,
+	ctx context.Context, req *apiv1.LoginRequest,
 ) (*apiv1.LoginResponse, error) {
 	if a.m.config.InternalConfig.ExternalSessions.JwtKey != "" {
 		return nil, status.Error(codes.FailedPrecondition, "authentication is configured to be external")
@@ -39,27 +40,27 @@ func (a *apiServer) Login(
 		return nil, status.Error(codes.InvalidArgument, "missing argument: username")
 	}
 
-	userModel, err := user.UserByUsername(req.Username)
-	switch err {
-	case nil:
-	case db.ErrNotFound:
-		return nil, grpcutil.ErrInvalidCredentials
-	default:
-		return nil, err
-	}
-
 	var hashedPassword string
 	if req.IsHashed {
 		hashedPassword = req.Password
 	} else {
 		hashedPassword = replicateClientSideSaltAndHash(req.Password)
 	}
-	if !userModel.ValidatePassword(hashedPassword) {
-		return nil, grpcutil.ErrInvalidCredentials
-	}
 
-	if !userModel.Active {
+	userModel, err := user.Authenticate(ctx, req.Username, hashedPassword, grpcutil.RemoteAddr(ctx))
+	var lockedOut user.LockedOutError
+	switch {
+	case err == nil:
+	case errors.Is(err, db.ErrNotFound), errors.Is(err, user.ErrInvalidCredentials):
+		return nil, grpcutil.ErrInvalidCredentials
+	case errors.As(err, &lockedOut):
+		return nil, status.Error(codes.ResourceExhausted, lockedOut.Error())
+	case errors.Is(err, user.ErrUserInactive):
 		return nil, grpcutil.ErrPermissionDenied
+	case errors.Is(err, user.ErrServiceAccountLogin):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return nil, err
 	}
 
 	token, err := a.m.db.StartUserSession(userModel)
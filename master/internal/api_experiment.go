@@ -36,6 +36,7 @@ import (
 	"github.com/determined-ai/determined/master/internal/hpimportance"
 	"github.com/determined-ai/determined/master/internal/lttb"
 	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/check"
 	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/master/pkg/protoutils"
 	"github.com/determined-ai/determined/master/pkg/protoutils/protoless"
@@ -745,6 +746,11 @@ func (a *apiServer) PreviewHPSearch(
 			codes.InvalidArgument, "invalid hyperparameters configuration: %s", err,
 		)
 	}
+	if err = check.Validate(hc); err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument, "invalid hyperparameters configuration: %s", err,
+		)
+	}
 
 	// Disallow EOL searchers.
 	if err = sc.AssertCurrent(); err != nil {
@@ -1510,6 +1516,10 @@ func (a *apiServer) topTrials(experimentID int, maxTrials int, s expconf.Searche
 		ranking = ByMetricOfInterest
 	case expconf.GridConfig:
 		ranking = ByMetricOfInterest
+	case expconf.TPEConfig:
+		ranking = ByMetricOfInterest
+	case expconf.PBTConfig:
+		ranking = ByMetricOfInterest
 	case expconf.CustomConfig:
 		ranking = ByMetricOfInterest
 	case expconf.AsyncHalvingConfig:
@@ -2081,9 +2091,15 @@ func (a *apiServer) MoveExperiment(
 		return nil, errors.Wrapf(err, "experiment (%d) does not exist or not moveable by this user",
 			req.ExperimentId)
 	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error moving experiment (%d)", req.ExperimentId)
+	}
+
+	// The workspace quotas that apply to a running experiment follow it to its new project.
+	a.m.system.TellAt(experimentsAddr.Child(req.ExperimentId),
+		experimentMoved{projectID: int(req.DestinationProjectId)})
 
-	return &apiv1.MoveExperimentResponse{},
-		errors.Wrapf(err, "error moving experiment (%d)", req.ExperimentId)
+	return &apiv1.MoveExperimentResponse{}, nil
 }
 
 func (a *apiServer) GetModelDefTree(
@@ -24,6 +24,7 @@ import (
 	"github.com/determined-ai/determined/master/internal/lttb"
 	"github.com/determined-ai/determined/master/internal/sproto"
 	"github.com/determined-ai/determined/master/internal/task"
+	"github.com/determined-ai/determined/master/internal/webhooks"
 	"github.com/determined-ai/determined/master/pkg/actor"
 	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/master/pkg/protoutils"
@@ -1106,6 +1107,7 @@ func (a *apiServer) ReportTrialProgress(
 		expauth.AuthZProvider.Get().CanEditExperiment); err != nil {
 		return nil, err
 	}
+	noteTrialProgress(a.m.system, int(req.TrialId))
 	eID, rID, err := a.m.db.TrialExperimentAndRequestID(int(req.TrialId))
 	if err != nil {
 		return nil, err
@@ -1131,6 +1133,10 @@ func (a *apiServer) ReportTrialTrainingMetrics(
 	if err := a.m.db.AddTrainingMetrics(ctx, req.TrainingMetrics); err != nil {
 		return nil, err
 	}
+	noteTrialProgress(a.m.system, int(req.TrainingMetrics.TrialId))
+	webhooks.ReportTrialMetrics(ctx, int(req.TrainingMetrics.TrialId),
+		int(req.TrainingMetrics.StepsCompleted), webhooks.MetricTypeTraining,
+		req.TrainingMetrics.Metrics.AvgMetrics.AsMap())
 	return &apiv1.ReportTrialTrainingMetricsResponse{}, nil
 }
 
@@ -1144,6 +1150,10 @@ func (a *apiServer) ReportTrialValidationMetrics(
 	if err := a.m.db.AddValidationMetrics(ctx, req.ValidationMetrics); err != nil {
 		return nil, err
 	}
+	noteTrialProgress(a.m.system, int(req.ValidationMetrics.TrialId))
+	webhooks.ReportTrialMetrics(ctx, int(req.ValidationMetrics.TrialId),
+		int(req.ValidationMetrics.StepsCompleted), webhooks.MetricTypeValidation,
+		req.ValidationMetrics.Metrics.AvgMetrics.AsMap())
 	return &apiv1.ReportTrialValidationMetricsResponse{}, nil
 }
 
@@ -1171,6 +1181,7 @@ func (a *apiServer) ReportCheckpoint(
 	if err := a.m.db.AddCheckpointMetadata(ctx, c); err != nil {
 		return nil, err
 	}
+	noteAllocationProgress(a.m.system, c.AllocationID)
 	return &apiv1.ReportCheckpointResponse{}, nil
 }
 
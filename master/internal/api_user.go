@@ -228,6 +228,13 @@ func (a *apiServer) PostUser(
 	); err != nil {
 		return nil, err
 	}
+	if req.Password == "" {
+		userToAdd.PasswordChangeRequired = user.NewUserPasswordChangeRequired()
+	} else if err = user.CheckPasswordPolicy(
+		a.m.config.Security.PasswordPolicy, req.Password,
+	); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err = userToAdd.UpdatePasswordHash(replicateClientSideSaltAndHash(req.Password)); err != nil {
 		return nil, err
 	}
@@ -267,10 +274,23 @@ func (a *apiServer) SetUserPassword(
 		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
 
-	if err = targetUser.UpdatePasswordHash(replicateClientSideSaltAndHash(req.Password)); err != nil {
+	hashedPassword := replicateClientSideSaltAndHash(req.Password)
+	err = user.ValidatePasswordChange(ctx, targetUser, &req.Password, hashedPassword)
+	if errors.Is(err, user.ErrPasswordPolicy) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = targetUser.UpdatePasswordHash(hashedPassword); err != nil {
 		return nil, err
 	}
-	switch err = a.m.db.UpdateUser(&targetUser, []string{"password_hash"}, nil); {
+	toUpdate := []string{"password_hash"}
+	if curUser.ID == targetUser.ID {
+		targetUser.PasswordChangeRequired = false
+		toUpdate = append(toUpdate, "password_change_required")
+	}
+	switch err = a.m.db.UpdateUser(&targetUser, toUpdate, nil); {
 	case err == db.ErrNotFound:
 		return nil, errUserNotFound
 	case err != nil:
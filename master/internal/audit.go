@@ -1,19 +1,28 @@
 package internal
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/determined-ai/determined/master/internal/audit"
 	detContext "github.com/determined-ai/determined/master/internal/context"
+	"github.com/determined-ai/determined/master/pkg/model"
 )
 
 // LogrusLogFn is an interface for all the logrus Levelf log functions.
 type LogrusLogFn func(format string, args ...interface{})
 
-const proxyPrefix = "/proxy"
+const (
+	proxyPrefix = "/proxy"
+	// grpcGatewayPrefix is where the gRPC API is served over REST; calls to it are recorded in the
+	// audit log by the gRPC server.
+	grpcGatewayPrefix = "/api/v1/"
+)
 
 var debugMethods = map[string]bool{
 	http.MethodGet:     true,
@@ -83,3 +92,71 @@ func auditLogMiddleware() echo.MiddlewareFunc {
 		}
 	})
 }
+
+// auditEventMiddleware records the REST calls that may change something in the audit log.
+func auditEventMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			req := c.Request()
+			if !infoMethods[req.Method] ||
+				strings.HasPrefix(req.URL.Path, proxyPrefix) ||
+				strings.HasPrefix(req.URL.Path, grpcGatewayPrefix) {
+				return err
+			}
+			audit.Record(restAuditEvent(c, err))
+			return err
+		}
+	}
+}
+
+// restAuditEvent describes a REST call for the audit log, given the error its handler returned.
+func restAuditEvent(c echo.Context, err error) *audit.Event {
+	req := c.Request()
+	e := &audit.Event{
+		Protocol:   audit.ProtocolREST,
+		Method:     req.Method + " " + c.Path(),
+		Path:       req.URL.Path,
+		RemoteAddr: c.RealIP(),
+	}
+	// This depends on the auth middleware running within this one.
+	if user, ok := c.Get("user").(model.User); ok {
+		var session *model.UserSession
+		if s, ok := c.Get("user-session").(model.UserSession); ok {
+			session = &s
+		}
+		e.SetSession(&user, session)
+	}
+	for i, name := range c.ParamNames() {
+		if !strings.HasSuffix(name, "_id") || i >= len(c.ParamValues()) {
+			continue
+		}
+		if id, err := strconv.Atoi(c.ParamValues()[i]); err == nil {
+			if e.Targets == nil {
+				e.Targets = map[string][]int{}
+			}
+			kind := strings.TrimSuffix(name, "_id")
+			e.Targets[kind] = append(e.Targets[kind], id)
+		}
+	}
+
+	code := c.Response().Status
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		code = httpErr.Code
+	case err != nil && !c.Response().Committed:
+		code = http.StatusInternalServerError
+	}
+	e.Status = strconv.Itoa(code)
+	switch {
+	case unauthorizedStatuses[code]:
+		e.Outcome = audit.OutcomeDenied
+	case code >= http.StatusBadRequest:
+		e.Outcome = audit.OutcomeFailed
+	default:
+		e.Outcome = audit.OutcomeSuccess
+	}
+	return e
+}
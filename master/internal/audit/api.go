@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+const (
+	defaultEventsLimit = 100
+	maxEventsLimit     = 1000
+)
+
+// RegisterAPIHandler registers the REST handlers for querying the audit log. They're only
+// accessible to admins.
+func RegisterAPIHandler(e *echo.Echo) {
+	e.GET("/audit-events", api.Route(getEvents))
+	e.GET("/audit-events/export", exportEvents)
+}
+
+// filterArgs are the query parameters that filter events.
+type filterArgs struct {
+	UserID     *int    `query:"user_id"`
+	Username   *string `query:"username"`
+	Protocol   *string `query:"protocol"`
+	Method     *string `query:"method"`
+	Outcome    *string `query:"outcome"`
+	TargetKind *string `query:"target_kind"`
+	TargetID   *int    `query:"target_id"`
+	Since      *string `query:"since"`
+	Until      *string `query:"until"`
+}
+
+func (a filterArgs) filter() (Filter, error) {
+	var f Filter
+	if a.UserID != nil {
+		f.UserID = (*model.UserID)(a.UserID)
+	}
+	if a.Username != nil {
+		f.Username = *a.Username
+	}
+	if a.Protocol != nil {
+		f.Protocol = Protocol(*a.Protocol)
+	}
+	if a.Method != nil {
+		f.Method = *a.Method
+	}
+	if a.Outcome != nil {
+		switch o := Outcome(*a.Outcome); o {
+		case OutcomeSuccess, OutcomeDenied, OutcomeFailed:
+			f.Outcome = o
+		default:
+			return Filter{}, echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("outcome must be one of %s, %s or %s",
+					OutcomeSuccess, OutcomeDenied, OutcomeFailed))
+		}
+	}
+	if a.TargetKind != nil {
+		f.TargetKind = *a.TargetKind
+	}
+	if a.TargetID != nil {
+		if f.TargetKind == "" {
+			return Filter{}, echo.NewHTTPError(http.StatusBadRequest,
+				"target_id requires target_kind")
+		}
+		f.TargetID = a.TargetID
+	}
+	for _, t := range []struct {
+		name  string
+		value *string
+		dest  **time.Time
+	}{
+		{"since", a.Since, &f.Since},
+		{"until", a.Until, &f.Until},
+	} {
+		if t.value == nil {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, *t.value)
+		if err != nil {
+			return Filter{}, echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("%s must be an RFC 3339 timestamp", t.name))
+		}
+		*t.dest = &parsed
+	}
+	return f, nil
+}
+
+func getEvents(c echo.Context) (interface{}, error) {
+	var args filterArgs
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	page := struct {
+		Limit  *int `query:"limit"`
+		Offset *int `query:"offset"`
+	}{}
+	if err := api.BindArgs(&page, c); err != nil {
+		return nil, err
+	}
+	f, err := args.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := defaultEventsLimit, 0
+	if page.Limit != nil {
+		limit = *page.Limit
+	}
+	if page.Offset != nil {
+		offset = *page.Offset
+	}
+	if limit <= 0 || limit > maxEventsLimit || offset < 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"limit must be between 1 and %d and offset must not be negative", maxEventsLimit))
+	}
+	return Events(c.Request().Context(), f, limit, offset)
+}
+
+// exportEvents writes every event that matches the filter as newline-delimited JSON, oldest first.
+func exportEvents(c echo.Context) error {
+	var args filterArgs
+	if err := api.BindArgs(&args, c); err != nil {
+		return err
+	}
+	f, err := args.filter()
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit-events.ndjson"`)
+	res.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(res)
+	return EachEvent(c.Request().Context(), f, func(e Event) error {
+		return enc.Encode(e)
+	})
+}
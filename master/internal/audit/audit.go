@@ -0,0 +1,135 @@
+// Package audit records the calls that change something in the cluster, who made them and how
+// they turned out, so that they can be reviewed later.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// Protocol is the API a call was made through.
+type Protocol string
+
+const (
+	// ProtocolREST is the echo REST API.
+	ProtocolREST Protocol = "REST"
+	// ProtocolGRPC is the gRPC API, including calls made through its REST gateway.
+	ProtocolGRPC Protocol = "gRPC"
+)
+
+// Outcome is how a call turned out.
+type Outcome string
+
+const (
+	// OutcomeSuccess is a call that succeeded.
+	OutcomeSuccess Outcome = "SUCCESS"
+	// OutcomeDenied is a call that was refused because the caller couldn't be authenticated or
+	// wasn't allowed to make it.
+	OutcomeDenied Outcome = "DENIED"
+	// OutcomeFailed is a call that failed for any other reason.
+	OutcomeFailed Outcome = "FAILED"
+)
+
+// Event is a call that changes something in the cluster as it's stored in the database.
+type Event struct {
+	bun.BaseModel `bun:"table:audit_events"`
+
+	ID   int64     `bun:"id,pk,autoincrement" json:"id"`
+	Time time.Time `bun:"time,notnull"        json:"time"`
+	// UserID and Username are who made the call, if they were authenticated. The username is kept
+	// since users may be renamed or deleted later.
+	UserID   *model.UserID `bun:"user_id"           json:"user_id,omitempty"`
+	Username string        `bun:"username,nullzero" json:"username,omitempty"`
+	// SessionID is the session the call was authenticated with, which is an access token if
+	// AccessToken is set.
+	SessionID   *model.SessionID `bun:"session_id"           json:"session_id,omitempty"`
+	AccessToken bool             `bun:"access_token,notnull" json:"access_token"`
+	Protocol    Protocol         `bun:"protocol,notnull"     json:"protocol"`
+	// Method is the HTTP method and route of REST calls or the full method name of gRPC calls.
+	Method string `bun:"method,notnull" json:"method"`
+	// Path is the path of REST calls.
+	Path string `bun:"path,nullzero" json:"path,omitempty"`
+	// Targets are the IDs of the entities the call is about, by kind of entity.
+	Targets    map[string][]int `bun:"targets,type:jsonb"   json:"targets,omitempty"`
+	Outcome    Outcome          `bun:"outcome,notnull"      json:"outcome"`
+	Status     string           `bun:"status,notnull"       json:"status"`
+	RemoteAddr string           `bun:"remote_addr,nullzero" json:"remote_addr,omitempty"`
+}
+
+// SetSession sets who made the call from the user and session it was authenticated with, if any.
+func (e *Event) SetSession(user *model.User, session *model.UserSession) {
+	if user != nil {
+		e.UserID = &user.ID
+		e.Username = user.Username
+	}
+	if session != nil {
+		e.SessionID = &session.ID
+		e.AccessToken = session.AccessToken
+	}
+}
+
+// retentionInterval is how often events past their retention are deleted.
+const retentionInterval = time.Hour
+
+var (
+	mu      sync.Mutex
+	enabled bool
+)
+
+// Init starts recording events and deleting the ones past their retention. It must be called once;
+// events recorded before Init, or when the audit log is disabled, are dropped.
+func Init(ctx context.Context, conf config.AuditLogConfig) {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = conf.Enabled
+	if enabled && conf.Retention > 0 {
+		go deleteExpiredEvents(ctx, time.Duration(conf.Retention))
+	}
+}
+
+// Enabled returns whether events are recorded.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// Record stores an event if the audit log is enabled. Events are recorded regardless of whether
+// the context of the call they're about is canceled once it's done.
+func Record(e *Event) {
+	if !Enabled() {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if err := AddEvent(context.Background(), e); err != nil {
+		log.WithError(err).WithField("method", e.Method).Error("failed to record audit event")
+	}
+}
+
+func deleteExpiredEvents(ctx context.Context, retention time.Duration) {
+	t := time.NewTicker(retentionInterval)
+	defer t.Stop()
+	for {
+		deleted, err := DeleteEventsBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.WithError(err).Error("failed to delete expired audit events")
+		} else if deleted > 0 {
+			log.Infof("deleted %d audit events older than %s", deleted, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
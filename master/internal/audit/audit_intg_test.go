@@ -0,0 +1,122 @@
+//go:build integration
+// +build integration
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+const pathToMigrations = "file://../../static/migrations"
+
+func TestAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	pgDB := db.MustResolveTestPostgres(t)
+	db.MustMigrateTestPostgres(t, pgDB, pathToMigrations)
+	_, err := db.Bun().NewDelete().Model((*Event)(nil)).Where("TRUE").Exec(ctx)
+	require.NoError(t, err)
+
+	userID := model.UserID(1)
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	events := []Event{
+		{
+			Time: start, UserID: &userID, Username: "admin", Protocol: ProtocolGRPC,
+			Method:  "/determined.api.v1.Determined/KillExperiment",
+			Targets: map[string][]int{"experiment": {5}}, Outcome: OutcomeSuccess, Status: "OK",
+		},
+		{
+			Time: start.Add(time.Minute), Username: "bob", Protocol: ProtocolGRPC,
+			Method:  "/determined.api.v1.Determined/Login",
+			Outcome: OutcomeDenied, Status: "Unauthenticated",
+		},
+		{
+			Time: start.Add(2 * time.Minute), UserID: &userID, Username: "admin",
+			Protocol: ProtocolREST, Method: "DELETE /projects/:project_id/role-assignments/:assignment_id",
+			Path:    "/projects/4/role-assignments/9",
+			Targets: map[string][]int{"project": {4}, "assignment": {9}},
+			Outcome: OutcomeFailed, Status: "404",
+		},
+	}
+	for i := range events {
+		require.NoError(t, AddEvent(ctx, &events[i]))
+	}
+
+	methods := func(es []Event) []string {
+		var out []string
+		for _, e := range es {
+			out = append(out, e.Method)
+		}
+		return out
+	}
+	for name, c := range map[string]struct {
+		filter   Filter
+		expected []Event
+	}{
+		"all, newest first": {Filter{}, []Event{events[2], events[1], events[0]}},
+		"by user":           {Filter{UserID: &userID}, []Event{events[2], events[0]}},
+		"by username":       {Filter{Username: "bob"}, []Event{events[1]}},
+		"by protocol":       {Filter{Protocol: ProtocolREST}, []Event{events[2]}},
+		"by method":         {Filter{Method: "Kill"}, []Event{events[0]}},
+		"by outcome":        {Filter{Outcome: OutcomeDenied}, []Event{events[1]}},
+		"by target kind":    {Filter{TargetKind: "project"}, []Event{events[2]}},
+		"by target": {
+			Filter{TargetKind: "experiment", TargetID: ptr(5)}, []Event{events[0]},
+		},
+		"by other target": {Filter{TargetKind: "experiment", TargetID: ptr(6)}, nil},
+		"by time": {
+			Filter{Since: ptrTime(start.Add(time.Minute)), Until: ptrTime(start.Add(2 * time.Minute))},
+			[]Event{events[1]},
+		},
+	} {
+		actual, err := Events(ctx, c.filter, 10, 0)
+		require.NoError(t, err, name)
+		require.Equal(t, methods(c.expected), methods(actual), name)
+	}
+
+	page, err := Events(ctx, Filter{}, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, methods(events[1:2]), methods(page))
+
+	t.Run("export", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(
+			httptest.NewRequest(http.MethodGet, "/audit-events/export?username=admin", nil), rec)
+		require.NoError(t, exportEvents(c))
+		require.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+
+		var exported []Event
+		scanner := bufio.NewScanner(rec.Body)
+		for scanner.Scan() {
+			var e Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+			exported = append(exported, e)
+		}
+		require.Equal(t, methods([]Event{events[0], events[2]}), methods(exported))
+		require.Equal(t, events[2].Targets, exported[1].Targets)
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		deleted, err := DeleteEventsBefore(ctx, start.Add(90*time.Second))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), deleted)
+		remaining, err := Events(ctx, Filter{}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, methods(events[2:]), methods(remaining))
+	})
+}
+
+func ptr(i int) *int { return &i }
+
+func ptrTime(t time.Time) *time.Time { return &t }
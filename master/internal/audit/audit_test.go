@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func parseFilter(t *testing.T, query string) (Filter, error) {
+	c := echo.New().NewContext(
+		httptest.NewRequest(http.MethodGet, "/audit-events?"+query, nil), httptest.NewRecorder())
+	var args filterArgs
+	require.NoError(t, api.BindArgs(&args, c))
+	return args.filter()
+}
+
+func TestFilterArgs(t *testing.T) {
+	f, err := parseFilter(t, "")
+	require.NoError(t, err)
+	require.Equal(t, Filter{}, f)
+
+	f, err = parseFilter(t, "user_id=3&username=alice&protocol=gRPC&method=Kill&outcome=DENIED"+
+		"&target_kind=experiment&target_id=5&since=2022-11-01T00:00:00Z&until=2022-11-02T00:00:00Z")
+	require.NoError(t, err)
+	userID, targetID := model.UserID(3), 5
+	since := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2022, 11, 2, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, Filter{
+		UserID:     &userID,
+		Username:   "alice",
+		Protocol:   ProtocolGRPC,
+		Method:     "Kill",
+		Outcome:    OutcomeDenied,
+		TargetKind: "experiment",
+		TargetID:   &targetID,
+		Since:      &since,
+		Until:      &until,
+	}, f)
+
+	for _, query := range []string{
+		"outcome=MAYBE",
+		"target_id=5",
+		"since=yesterday",
+	} {
+		_, err := parseFilter(t, query)
+		require.Error(t, err, query)
+	}
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// Filter narrows down the events that are returned. Zero fields match every event.
+type Filter struct {
+	UserID   *model.UserID
+	Username string
+	Protocol Protocol
+	// Method matches events whose method contains it.
+	Method  string
+	Outcome Outcome
+	// TargetKind and TargetID match events about the entity; TargetID requires TargetKind.
+	TargetKind string
+	TargetID   *int
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// AddEvent stores an event.
+func AddEvent(ctx context.Context, e *Event) error {
+	_, err := db.Bun().NewInsert().Model(e).Exec(ctx)
+	return err
+}
+
+// Events returns the events that match the filter, newest first.
+func Events(ctx context.Context, f Filter, limit, offset int) ([]Event, error) {
+	events := []Event{}
+	err := eventsQuery(f).
+		Model(&events).
+		Order("time DESC", "id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// EachEvent calls fn with every event that matches the filter, oldest first, without loading them
+// all at once.
+func EachEvent(ctx context.Context, f Filter, fn func(Event) error) error {
+	rows, err := eventsQuery(f).Model((*Event)(nil)).Order("time ASC", "id ASC").Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e Event
+		if err := db.Bun().ScanRow(ctx, rows, &e); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// DeleteEventsBefore deletes the events that happened before the given time and returns how many
+// were deleted.
+func DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := db.Bun().NewDelete().Model((*Event)(nil)).Where("time < ?", before).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func eventsQuery(f Filter) *bun.SelectQuery {
+	q := db.Bun().NewSelect()
+	if f.UserID != nil {
+		q = q.Where("user_id = ?", *f.UserID)
+	}
+	if f.Username != "" {
+		q = q.Where("username = ?", f.Username)
+	}
+	if f.Protocol != "" {
+		q = q.Where("protocol = ?", f.Protocol)
+	}
+	if f.Method != "" {
+		q = q.Where("strpos(method, ?) > 0", f.Method)
+	}
+	if f.Outcome != "" {
+		q = q.Where("outcome = ?", f.Outcome)
+	}
+	switch {
+	case f.TargetKind != "" && f.TargetID != nil:
+		// Marshaling a map of a string and an int can't fail.
+		target, _ := json.Marshal(map[string][]int{f.TargetKind: {*f.TargetID}})
+		q = q.Where("targets @> ?::jsonb", string(target))
+	case f.TargetKind != "":
+		q = q.Where("jsonb_exists(targets, ?)", f.TargetKind)
+	}
+	if f.Since != nil {
+		q = q.Where("time >= ?", *f.Since)
+	}
+	if f.Until != nil {
+		q = q.Where("time < ?", *f.Until)
+	}
+	return q
+}
@@ -2,7 +2,9 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/determined-ai/determined/master/internal/audit"
 	detContext "github.com/determined-ai/determined/master/internal/context"
 	"github.com/determined-ai/determined/master/pkg/model"
 )
@@ -123,3 +126,33 @@ func TestAuditLogMiddleware(t *testing.T) {
 	require.Contains(t, logs.inner[2].Message, "/notok")
 	require.Equal(t, logs.inner[2].Data["unauthorized"], true)
 }
+
+func TestRestAuditEvent(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/projects/4/role-assignments/9", nil)
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+	c := &detContext.DetContext{Context: e.NewContext(req, httptest.NewRecorder())}
+	c.SetPath("/projects/:project_id/role-assignments/:assignment_id")
+	c.SetParamNames("project_id", "assignment_id")
+	c.SetParamValues("4", "9")
+	c.SetUser(model.User{ID: 3, Username: "alice"})
+	c.SetUserSession(model.UserSession{ID: 7})
+
+	event := restAuditEvent(c, nil)
+	require.Equal(t, audit.ProtocolREST, event.Protocol)
+	require.Equal(t, "DELETE /projects/:project_id/role-assignments/:assignment_id", event.Method)
+	require.Equal(t, "/projects/4/role-assignments/9", event.Path)
+	require.Equal(t, map[string][]int{"project": {4}, "assignment": {9}}, event.Targets)
+	require.Equal(t, model.UserID(3), *event.UserID)
+	require.Equal(t, model.SessionID(7), *event.SessionID)
+	require.Equal(t, "10.0.0.1", event.RemoteAddr)
+	require.Equal(t, audit.OutcomeSuccess, event.Outcome)
+
+	event = restAuditEvent(c, echo.NewHTTPError(http.StatusForbidden))
+	require.Equal(t, audit.OutcomeDenied, event.Outcome)
+	require.Equal(t, "403", event.Status)
+
+	event = restAuditEvent(c, errors.New("boom"))
+	require.Equal(t, audit.OutcomeFailed, event.Outcome)
+	require.Equal(t, "500", event.Status)
+}
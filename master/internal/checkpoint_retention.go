@@ -0,0 +1,455 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	detContext "github.com/determined-ai/determined/master/internal/context"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/project"
+	"github.com/determined-ai/determined/master/internal/rm"
+	"github.com/determined-ai/determined/master/internal/task"
+	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/internal/workspace"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/actor/actors"
+	"github.com/determined-ai/determined/master/pkg/check"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/tasks"
+	"github.com/determined-ai/determined/proto/pkg/projectv1"
+	"github.com/determined-ai/determined/proto/pkg/workspacev1"
+)
+
+// retiredCheckpoint is a checkpoint that a retention policy deletes, along with why.
+type retiredCheckpoint struct {
+	db.RetainedCheckpoint
+	Reason string `json:"reason"`
+}
+
+// checkpointsToRetire returns the checkpoints that a retention policy deletes from a project,
+// given all of the completed checkpoints in the project, oldest first. Only checkpoints of
+// finished experiments that no trial started from are deleted, and registered checkpoints are
+// kept if the policy says so; the others still count towards the size of the project.
+func checkpointsToRetire(
+	policy model.CheckpointRetentionPolicy, checkpoints []db.RetainedCheckpoint, now time.Time,
+) []retiredCheckpoint {
+	retirable := func(c db.RetainedCheckpoint) bool {
+		return model.TerminalStates[c.ExperimentState] && !c.WarmStarted &&
+			!(policy.KeepRegistered && c.Registered)
+	}
+
+	var retired []retiredCheckpoint
+	var kept []db.RetainedCheckpoint
+	var totalBytes int64
+	maxAge := policy.MaxAge()
+	for _, c := range checkpoints {
+		if maxAge > 0 && now.Sub(c.ReportTime) > maxAge && retirable(c) {
+			retired = append(retired, retiredCheckpoint{
+				RetainedCheckpoint: c,
+				Reason:             fmt.Sprintf("older than %s", maxAge),
+			})
+			continue
+		}
+		kept = append(kept, c)
+		totalBytes += c.SizeBytes
+	}
+
+	if policy.MaxTotalBytes == nil {
+		return retired
+	}
+	for _, c := range kept {
+		if totalBytes <= *policy.MaxTotalBytes {
+			break
+		}
+		if !retirable(c) {
+			continue
+		}
+		retired = append(retired, retiredCheckpoint{
+			RetainedCheckpoint: c,
+			Reason:             fmt.Sprintf("project larger than %d bytes", *policy.MaxTotalBytes),
+		})
+		totalBytes -= c.SizeBytes
+	}
+	return retired
+}
+
+type checkpointRetentionTick struct{}
+
+// checkpointRetentionSweeper periodically deletes the checkpoints that the retention policies of
+// workspaces and projects don't keep. The checkpoints of each experiment are deleted by a
+// checkpoint GC task that is a child of the sweeper, so that an experiment isn't swept again
+// while its checkpoints are being deleted.
+type checkpointRetentionSweeper struct {
+	db         *db.PgDB
+	rm         rm.ResourceManager
+	taskLogger *task.Logger
+	taskSpec   *tasks.TaskSpec
+	interval   time.Duration
+}
+
+func (s *checkpointRetentionSweeper) Receive(ctx *actor.Context) error {
+	switch msg := ctx.Message().(type) {
+	case actor.PreStart:
+		actors.NotifyAfter(ctx, s.interval, checkpointRetentionTick{})
+
+	case checkpointRetentionTick:
+		// Don't return the error, since we want to keep this actor alive and try again next time.
+		if err := s.sweep(ctx); err != nil {
+			ctx.Log().WithError(err).Error("failed to enforce checkpoint retention policies")
+		}
+		actors.NotifyAfter(ctx, s.interval, checkpointRetentionTick{})
+
+	case actor.ChildFailed:
+		ctx.Log().WithError(msg.Error).Errorf("checkpoint GC task %s failed", msg.Child.Address())
+
+	case actor.ChildStopped, actor.PostStop:
+
+	default:
+		return actor.ErrUnexpectedMessage(ctx)
+	}
+	return nil
+}
+
+func (s *checkpointRetentionSweeper) sweep(ctx *actor.Context) error {
+	policies, err := db.CheckpointRetentionPoliciesByProject(context.TODO())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for projectID, policy := range policies {
+		if policy.MaxAgeSeconds == nil && policy.MaxTotalBytes == nil {
+			continue
+		}
+		checkpoints, err := db.ProjectRetainedCheckpoints(context.TODO(), projectID)
+		if err != nil {
+			return errors.Wrapf(err, "getting checkpoints of project %d", projectID)
+		}
+		byExperiment := make(map[int][]uuid.UUID)
+		for _, c := range checkpointsToRetire(policy, checkpoints, now) {
+			byExperiment[c.ExperimentID] = append(byExperiment[c.ExperimentID], c.UUID)
+		}
+		for expID, toDelete := range byExperiment {
+			if err := s.deleteCheckpoints(ctx, expID, toDelete); err != nil {
+				ctx.Log().WithError(err).Errorf(
+					"failed to delete checkpoints of experiment %d", expID)
+				continue
+			}
+			ctx.Log().Infof(
+				"deleting %d checkpoints of experiment %d by the retention policy of project %d",
+				len(toDelete), expID, projectID)
+		}
+	}
+	return nil
+}
+
+func (s *checkpointRetentionSweeper) deleteCheckpoints(
+	ctx *actor.Context, expID int, toDelete []uuid.UUID,
+) error {
+	name := fmt.Sprintf("experiment-%d", expID)
+	if ctx.Child(name) != nil {
+		// The checkpoints from the last sweep are still being deleted.
+		return nil
+	}
+
+	exp, err := s.db.ExperimentByID(expID)
+	if err != nil {
+		return err
+	}
+	if exp.OwnerID == nil {
+		return errors.Errorf("experiment %d has no owner", expID)
+	}
+	agentUserGroup, err := user.GetAgentUserGroup(*exp.OwnerID, exp)
+	if err != nil {
+		return err
+	}
+	owner, err := user.UserByID(*exp.OwnerID)
+	if err != nil {
+		return errors.Wrapf(err, "cannot find user %v who owns experiment", *exp.OwnerID)
+	}
+
+	ckptGCTask := newCheckpointGCTask(
+		s.rm, s.db, s.taskLogger, model.NewTaskID(), exp.JobID, exp.StartTime, *s.taskSpec,
+		exp.ID, exp.Config.AsLegacy(), toDelete, false, agentUserGroup,
+		&model.User{ID: owner.ID, Username: owner.Username}, nil,
+	)
+	ctx.ActorOf(name, ckptGCTask)
+	return nil
+}
+
+// echoGetProject returns a project that the user can view.
+func (m *Master) echoGetProject(c echo.Context, projectID int) (*projectv1.Project, error) {
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	notFound := echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("project (%d) not found",
+		projectID))
+	p := &projectv1.Project{}
+	if err := m.db.QueryProto("get_project", p, projectID); errors.Is(err, db.ErrNotFound) {
+		return nil, notFound
+	} else if err != nil {
+		return nil, err
+	}
+	if ok, err := project.AuthZProvider.Get().CanGetProject(curUser, p); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, notFound
+	}
+	return p, nil
+}
+
+// echoGetWorkspace returns a workspace that the user can view.
+func (m *Master) echoGetWorkspace(
+	c echo.Context, workspaceID int,
+) (*workspacev1.Workspace, error) {
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	notFound := echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("workspace (%d) not found",
+		workspaceID))
+	w := &workspacev1.Workspace{}
+	err := m.db.QueryProto("get_workspace", w, workspaceID, curUser.ID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, notFound
+	} else if err != nil {
+		return nil, err
+	}
+	if ok, err := workspace.AuthZProvider.Get().CanGetWorkspace(curUser, w); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, notFound
+	}
+	return w, nil
+}
+
+// bindCheckpointRetentionPolicy reads a retention policy from the body of a request.
+func bindCheckpointRetentionPolicy(c echo.Context) (*model.CheckpointRetentionPolicy, error) {
+	policy := model.DefaultCheckpointRetentionPolicy()
+	if err := json.NewDecoder(c.Request().Body).Decode(&policy); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	policy.ID = 0
+	policy.WorkspaceID = nil
+	policy.ProjectID = nil
+	return &policy, nil
+}
+
+// setCheckpointRetentionPolicy validates and stores the retention policy of a workspace or
+// project.
+func setCheckpointRetentionPolicy(
+	c echo.Context, policy *model.CheckpointRetentionPolicy,
+) (interface{}, error) {
+	if err := check.Validate(policy); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := db.SetCheckpointRetentionPolicy(c.Request().Context(), policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// getWorkspaceCheckpointRetention returns the checkpoint retention policy of a workspace.
+func (m *Master) getWorkspaceCheckpointRetention(c echo.Context) (interface{}, error) {
+	args := struct {
+		WorkspaceID int `path:"workspace_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	if _, err := m.echoGetWorkspace(c, args.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	policy, err := db.WorkspaceCheckpointRetentionPolicy(c.Request().Context(), args.WorkspaceID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound,
+			"workspace has no checkpoint retention policy")
+	}
+	return policy, err
+}
+
+// putWorkspaceCheckpointRetention sets the checkpoint retention policy of a workspace.
+func (m *Master) putWorkspaceCheckpointRetention(c echo.Context) (interface{}, error) {
+	args := struct {
+		WorkspaceID int `path:"workspace_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	w, err := m.echoGetWorkspace(c, args.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	if err := workspace.AuthZProvider.Get().
+		CanSetWorkspacesCheckpointRetention(curUser, w); err != nil {
+		return nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	policy, err := bindCheckpointRetentionPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	policy.WorkspaceID = &args.WorkspaceID
+	return setCheckpointRetentionPolicy(c, policy)
+}
+
+// deleteWorkspaceCheckpointRetention deletes the checkpoint retention policy of a workspace.
+func (m *Master) deleteWorkspaceCheckpointRetention(c echo.Context) (interface{}, error) {
+	args := struct {
+		WorkspaceID int `path:"workspace_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	w, err := m.echoGetWorkspace(c, args.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	if err := workspace.AuthZProvider.Get().
+		CanSetWorkspacesCheckpointRetention(curUser, w); err != nil {
+		return nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	err = db.DeleteWorkspaceCheckpointRetentionPolicy(c.Request().Context(), args.WorkspaceID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound,
+			"workspace has no checkpoint retention policy")
+	}
+	return nil, err
+}
+
+// getProjectCheckpointRetention returns the checkpoint retention policy that applies to a
+// project, which is its workspace's unless the project has its own.
+func (m *Master) getProjectCheckpointRetention(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID int `path:"project_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	if _, err := m.echoGetProject(c, args.ProjectID); err != nil {
+		return nil, err
+	}
+
+	policy, err := db.ProjectCheckpointRetentionPolicy(c.Request().Context(), args.ProjectID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound,
+			"project has no checkpoint retention policy")
+	}
+	return policy, err
+}
+
+// putProjectCheckpointRetention sets the checkpoint retention policy of a project.
+func (m *Master) putProjectCheckpointRetention(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID int `path:"project_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	p, err := m.echoGetProject(c, args.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	if err := project.AuthZProvider.Get().
+		CanSetProjectCheckpointRetention(curUser, p); err != nil {
+		return nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	policy, err := bindCheckpointRetentionPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	policy.ProjectID = &args.ProjectID
+	return setCheckpointRetentionPolicy(c, policy)
+}
+
+// deleteProjectCheckpointRetention deletes the checkpoint retention policy of a project, after
+// which its workspace's applies to it, if any.
+func (m *Master) deleteProjectCheckpointRetention(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID int `path:"project_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	p, err := m.echoGetProject(c, args.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	if err := project.AuthZProvider.Get().
+		CanSetProjectCheckpointRetention(curUser, p); err != nil {
+		return nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	err = db.DeleteProjectCheckpointRetentionPolicy(c.Request().Context(), args.ProjectID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound,
+			"project has no checkpoint retention policy of its own")
+	}
+	return nil, err
+}
+
+// getProjectCheckpointsToGC returns the checkpoints that the retention policy of a project would
+// delete, without deleting them. The settings of the policy can be overridden to preview a change.
+func (m *Master) getProjectCheckpointsToGC(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID      int    `path:"project_id"`
+		MaxAgeSeconds  *int64 `query:"max_age_seconds"`
+		MaxTotalBytes  *int64 `query:"max_total_bytes"`
+		KeepRegistered *bool  `query:"keep_registered"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	if _, err := m.echoGetProject(c, args.ProjectID); err != nil {
+		return nil, err
+	}
+
+	ctx := c.Request().Context()
+	policy, err := db.ProjectCheckpointRetentionPolicy(ctx, args.ProjectID)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		policy = &model.CheckpointRetentionPolicy{KeepRegistered: true, ProjectID: &args.ProjectID}
+	case err != nil:
+		return nil, err
+	}
+	if args.MaxAgeSeconds != nil {
+		policy.MaxAgeSeconds = args.MaxAgeSeconds
+	}
+	if args.MaxTotalBytes != nil {
+		policy.MaxTotalBytes = args.MaxTotalBytes
+	}
+	if args.KeepRegistered != nil {
+		policy.KeepRegistered = *args.KeepRegistered
+	}
+	if err := check.Validate(policy); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	checkpoints, err := db.ProjectRetainedCheckpoints(ctx, args.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	retired := checkpointsToRetire(*policy, checkpoints, time.Now())
+	preview := struct {
+		Policy       *model.CheckpointRetentionPolicy `json:"policy"`
+		Checkpoints  []retiredCheckpoint              `json:"checkpoints"`
+		TotalBytes   int64                            `json:"total_bytes"`
+		RetiredBytes int64                            `json:"retired_bytes"`
+	}{Policy: policy, Checkpoints: append([]retiredCheckpoint{}, retired...)}
+	for _, ckpt := range checkpoints {
+		preview.TotalBytes += ckpt.SizeBytes
+	}
+	for _, ckpt := range retired {
+		preview.RetiredBytes += ckpt.SizeBytes
+	}
+	return preview, nil
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func TestCheckpointsToRetire(t *testing.T) {
+	now := time.Now()
+	checkpoint := func(age time.Duration, size int64) db.RetainedCheckpoint {
+		return db.RetainedCheckpoint{
+			UUID:            uuid.New(),
+			ExperimentID:    1,
+			ExperimentState: model.CompletedState,
+			ReportTime:      now.Add(-age),
+			SizeBytes:       size,
+		}
+	}
+	old := checkpoint(72*time.Hour, 10)
+	oldRegistered := checkpoint(60*time.Hour, 10)
+	oldRegistered.Registered = true
+	oldWarmStarted := checkpoint(48*time.Hour, 10)
+	oldWarmStarted.WarmStarted = true
+	oldRunning := checkpoint(36*time.Hour, 10)
+	oldRunning.ExperimentState = model.ActiveState
+	recent := checkpoint(2*time.Hour, 10)
+	newest := checkpoint(time.Hour, 10)
+	checkpoints := []db.RetainedCheckpoint{
+		old, oldRegistered, oldWarmStarted, oldRunning, recent, newest,
+	}
+
+	uuids := func(retired []retiredCheckpoint) []uuid.UUID {
+		var ids []uuid.UUID
+		for _, c := range retired {
+			ids = append(ids, c.UUID)
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name     string
+		policy   model.CheckpointRetentionPolicy
+		expected []uuid.UUID
+	}{
+		{
+			name:   "no limits",
+			policy: model.DefaultCheckpointRetentionPolicy(),
+		},
+		{
+			name: "max age keeps registered",
+			policy: model.CheckpointRetentionPolicy{
+				MaxAgeSeconds: ptrs.Ptr(int64(24 * 60 * 60)), KeepRegistered: true,
+			},
+			expected: []uuid.UUID{old.UUID},
+		},
+		{
+			name: "max age deletes registered",
+			policy: model.CheckpointRetentionPolicy{
+				MaxAgeSeconds: ptrs.Ptr(int64(24 * 60 * 60)),
+			},
+			expected: []uuid.UUID{old.UUID, oldRegistered.UUID},
+		},
+		{
+			name: "max total bytes deletes oldest first",
+			policy: model.CheckpointRetentionPolicy{
+				MaxTotalBytes: ptrs.Ptr(int64(35)), KeepRegistered: true,
+			},
+			expected: []uuid.UUID{old.UUID, recent.UUID, newest.UUID},
+		},
+		{
+			name: "max total bytes after max age",
+			policy: model.CheckpointRetentionPolicy{
+				MaxAgeSeconds: ptrs.Ptr(int64(24 * 60 * 60)),
+				MaxTotalBytes: ptrs.Ptr(int64(30)),
+			},
+			expected: []uuid.UUID{old.UUID, oldRegistered.UUID, recent.UUID},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, uuids(checkpointsToRetire(tc.policy, checkpoints, now)))
+		})
+	}
+}
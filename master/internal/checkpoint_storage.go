@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/internal/db"
+)
+
+// getStorageReport returns the checkpoint storage of every trial, experiment, project, workspace or
+// user, largest first.
+func (m *Master) getStorageReport(c echo.Context) (interface{}, error) {
+	args := struct {
+		GroupBy *string `query:"group_by"`
+		Limit   *int    `query:"limit"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+
+	groupBy := db.StorageByWorkspace
+	if args.GroupBy != nil {
+		groupBy = db.StorageGroupBy(*args.GroupBy)
+	}
+	switch groupBy {
+	case db.StorageByTrial, db.StorageByExperiment, db.StorageByProject, db.StorageByWorkspace,
+		db.StorageByUser:
+	default:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"group_by must be one of %s, %s, %s, %s or %s", db.StorageByTrial,
+			db.StorageByExperiment, db.StorageByProject, db.StorageByWorkspace, db.StorageByUser))
+	}
+	var limit int
+	if args.Limit != nil {
+		if *args.Limit <= 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "limit must be positive")
+		}
+		limit = *args.Limit
+	}
+
+	return db.StorageReport(c.Request().Context(), groupBy, limit)
+}
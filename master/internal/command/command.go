@@ -258,6 +258,11 @@ func (c *command) Receive(ctx *actor.Context) error {
 				return errors.Wrapf(err, "setting priority of task %v", c.taskID)
 			}
 		}
+		c.rm.SetGroupOwner(ctx, sproto.SetGroupOwner{
+			UserID:       &c.Base.Owner.ID,
+			ResourcePool: c.Config.Resources.ResourcePool,
+			Handler:      ctx.Self(),
+		})
 
 		var proxyPortConf *sproto.ProxyPortConfig
 		if c.GenericCommandSpec.Port != nil {
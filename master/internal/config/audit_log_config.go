@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// AuditLogConfig is the configuration for recording the calls that change something in the
+// cluster.
+type AuditLogConfig struct {
+	Enabled bool `json:"enabled"`
+	// Retention is how long events are kept; they're kept forever if it's zero.
+	Retention model.Duration `json:"retention"`
+}
+
+// DefaultAuditLogConfig returns the default configuration of the audit log.
+func DefaultAuditLogConfig() AuditLogConfig {
+	return AuditLogConfig{
+		Retention: model.Duration(90 * 24 * time.Hour),
+	}
+}
+
+// Validate implements the check.Validatable interface.
+func (a AuditLogConfig) Validate() []error {
+	if a.Retention < 0 {
+		return []error{errors.New("audit_log retention must not be negative")}
+	}
+	return nil
+}
@@ -15,8 +15,12 @@ var (
 	authZConfigMutex sync.Mutex
 )
 
-// BasicAuthZType is the default authz string id.
-const BasicAuthZType = "basic"
+const (
+	// BasicAuthZType is the default authz string id.
+	BasicAuthZType = "basic"
+	// RBACAuthZType is the authz string id of role-based access control.
+	RBACAuthZType = "rbac"
+)
 
 // AuthZConfig is a authz-related section of master config.
 type AuthZConfig struct {
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// CheckpointRetentionConfig is the configuration for enforcing the checkpoint retention policies
+// of workspaces and projects.
+type CheckpointRetentionConfig struct {
+	// SweepInterval is how often the checkpoints of finished experiments are checked against the
+	// policies.
+	SweepInterval model.Duration `json:"sweep_interval"`
+}
+
+// DefaultCheckpointRetentionConfig returns the default configuration of checkpoint retention.
+func DefaultCheckpointRetentionConfig() CheckpointRetentionConfig {
+	return CheckpointRetentionConfig{
+		SweepInterval: model.Duration(time.Hour),
+	}
+}
+
+// Validate implements the check.Validatable interface.
+func (c CheckpointRetentionConfig) Validate() []error {
+	if c.SweepInterval <= 0 {
+		return []error{errors.New("checkpoint_retention sweep_interval must be positive")}
+	}
+	return nil
+}
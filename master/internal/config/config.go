@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -90,7 +91,8 @@ func DefaultConfig() *Config {
 			SSH: SSHConfig{
 				RsaKeySize: 1024,
 			},
-			AuthZ: *DefaultAuthZConfig(),
+			AuthZ:        *DefaultAuthZConfig(),
+			LoginLockout: DefaultLoginLockoutConfig(),
 		},
 		// If left unspecified, the port is later filled in with 8080 (no TLS) or 8443 (TLS).
 		Port:        0,
@@ -120,6 +122,14 @@ func DefaultConfig() *Config {
 			MaxTrees:       100,
 		},
 		ResourceConfig: DefaultResourceConfig(),
+		Webhooks: WebhooksConfig{
+			MaxAttempts:    5,
+			InitialBackoff: model.Duration(time.Second),
+			Timeout:        model.Duration(10 * time.Second),
+		},
+		OIDC:                DefaultOIDCConfig(),
+		AuditLog:            DefaultAuditLogConfig(),
+		CheckpointRetention: DefaultCheckpointRetentionConfig(),
 	}
 }
 
@@ -146,6 +156,10 @@ type Config struct {
 	Observability         ObservabilityConfig               `json:"observability"`
 	Cache                 CacheConfig                       `json:"cache"`
 	FeatureSwitches       []string                          `json:"feature_switches"`
+	Webhooks              WebhooksConfig                    `json:"webhooks"`
+	OIDC                  OIDCConfig                        `json:"oidc"`
+	AuditLog              AuditLogConfig                    `json:"audit_log"`
+	CheckpointRetention   CheckpointRetentionConfig         `json:"checkpoint_retention"`
 	*ResourceConfig
 
 	// Internal contains "hidden" useful debugging configurations.
@@ -194,6 +208,10 @@ func (c Config) Printable() ([]byte, error) {
 		}
 	}
 
+	if c.OIDC.ClientSecret != "" {
+		c.OIDC.ClientSecret = hiddenValue
+	}
+
 	c.CheckpointStorage = c.CheckpointStorage.Printable()
 
 	optJSON, err := json.Marshal(c)
@@ -238,10 +256,13 @@ func (c *Config) Resolve() error {
 
 // SecurityConfig is the security configuration for the master.
 type SecurityConfig struct {
-	DefaultTask model.AgentUserGroup `json:"default_task"`
-	TLS         TLSConfig            `json:"tls"`
-	SSH         SSHConfig            `json:"ssh"`
-	AuthZ       AuthZConfig          `json:"authz"`
+	DefaultTask    model.AgentUserGroup `json:"default_task"`
+	TLS            TLSConfig            `json:"tls"`
+	SSH            SSHConfig            `json:"ssh"`
+	AuthZ          AuthZConfig          `json:"authz"`
+	PasswordPolicy PasswordPolicyConfig `json:"password_policy"`
+	LoginLockout   LoginLockoutConfig   `json:"login_lockout"`
+	TrustedProxies TrustedProxiesConfig `json:"trusted_proxies"`
 }
 
 // SSHConfig is the configuration setting for SSH.
@@ -298,6 +319,33 @@ type InternalConfig struct {
 	ExternalSessions    model.ExternalSessions `json:"external_sessions"`
 }
 
+// WebhooksConfig is the configuration for delivering webhook events.
+type WebhooksConfig struct {
+	// SigningKey is used to sign every delivery. If it is empty, a key is generated and stored in
+	// the database the first time the master starts.
+	SigningKey string `json:"signing_key"`
+	// BaseURL is the address users use to access Determined, used to generate hyperlinks.
+	BaseURL        string         `json:"base_url"`
+	MaxAttempts    int            `json:"max_attempts"`
+	InitialBackoff model.Duration `json:"initial_backoff"`
+	Timeout        model.Duration `json:"timeout"`
+}
+
+// Validate implements the check.Validatable interface.
+func (w WebhooksConfig) Validate() []error {
+	var errs []error
+	if w.MaxAttempts < 1 {
+		errs = append(errs, errors.New("webhooks max_attempts must be at least 1"))
+	}
+	if w.InitialBackoff < 0 {
+		errs = append(errs, errors.New("webhooks initial_backoff must not be negative"))
+	}
+	if w.Timeout <= 0 {
+		errs = append(errs, errors.New("webhooks timeout must be positive"))
+	}
+	return errs
+}
+
 // ObservabilityConfig is the configuration for observability metrics.
 type ObservabilityConfig struct {
 	EnablePrometheus bool `json:"enable_prometheus"`
@@ -337,6 +385,9 @@ func readRMPreemptionStatus(config *Config, rpName string) bool {
 		return config.ResourceManager.AgentRM.Scheduler.GetPreemption()
 	case config.ResourceManager.KubernetesRM != nil:
 		return config.ResourceManager.KubernetesRM.GetPreemption()
+	case config.ResourceManager.DispatcherRM() != nil:
+		// Jobs are preempted by the workload manager, if at all.
+		return false
 	default:
 		panic("unexpected resource configuration")
 	}
@@ -4,6 +4,7 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -206,6 +207,37 @@ db:
 	assert.DeepEqual(t, unmarshaled, expected)
 }
 
+func TestUnmarshalConfigWithLauncherSettings(t *testing.T) {
+	raw := `
+type: slurm
+master_host: master
+master_port: 8080
+job_storage_root: /shared/jobs
+host: launcher
+port: 8181
+protocol: http
+security:
+  tls:
+    enabled: true
+tres_supported: false
+partition_overrides:
+  gpus:
+    slot_type: cuda
+`
+	var unmarshaled ResourceManagerConfig
+	err := yaml.Unmarshal([]byte(raw), &unmarshaled, yaml.DisallowUnknownFields)
+	assert.NilError(t, err)
+	assert.Assert(t, unmarshaled.SlurmRM != nil)
+	assert.Equal(t, unmarshaled.SlurmRM.JobStorageRoot, "/shared/jobs")
+	assert.DeepEqual(t, unmarshaled.SlurmRM.LauncherSettings(), []string{
+		"host", "port", "protocol", "security", "tres_supported", "partition_overrides",
+	})
+
+	var withoutLauncher DispatcherResourceManagerConfig
+	assert.NilError(t, yaml.Unmarshal([]byte(`job_storage_root: /shared/jobs`), &withoutLauncher))
+	assert.Equal(t, len(withoutLauncher.LauncherSettings()), 0)
+}
+
 func TestUnmarshalConfigWithExperiment(t *testing.T) {
 	raw := `
 log:
@@ -490,3 +522,22 @@ resource_manager:
 		})
 	}
 }
+
+func TestTrustedProxies(t *testing.T) {
+	proxies := TrustedProxiesConfig{"10.1.0.0/16", "192.0.2.1", "2001:db8::1"}
+	assert.Equal(t, len(proxies.Validate()), 0)
+	for ip, trusted := range map[string]bool{
+		"127.0.0.1":   true,
+		"::1":         true,
+		"10.1.5.5":    true,
+		"10.2.5.5":    false,
+		"192.0.2.1":   true,
+		"192.0.2.2":   false,
+		"2001:db8::1": true,
+		"2001:db8::2": false,
+	} {
+		assert.Equal(t, proxies.Trusts(net.ParseIP(ip)), trusted, ip)
+	}
+
+	assert.Equal(t, len(TrustedProxiesConfig{"10.1.0.0/33", "proxy.example.com"}.Validate()), 2)
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// OIDCConfig is the configuration for signing in with an OpenID Connect provider.
+type OIDCConfig struct {
+	Enabled bool `json:"enabled"`
+	// Provider is the name of the provider shown to users.
+	Provider string `json:"provider"`
+	// IDPSSOURL is the issuer URL of the provider, where its discovery document is served.
+	IDPSSOURL    string `json:"idp_sso_url"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	// IDPRecipientURL is the address users use to access the master; the provider redirects users
+	// back to <idp_recipient_url>/oidc/callback after they sign in.
+	IDPRecipientURL string `json:"idp_recipient_url"`
+	// AuthenticationClaim is the claim of the ID token that holds the username.
+	AuthenticationClaim string `json:"authentication_claim"`
+	// GroupsClaim is the claim of the ID token that lists the groups of the user, if any; the
+	// groups of users are synced with it every time they sign in.
+	GroupsClaim string   `json:"groups_claim"`
+	Scopes      []string `json:"scopes"`
+	// AutoProvisionUsers creates users the first time they sign in if they don't exist yet.
+	AutoProvisionUsers bool `json:"auto_provision_users"`
+}
+
+// DefaultOIDCConfig returns the default configuration of OpenID Connect.
+func DefaultOIDCConfig() OIDCConfig {
+	return OIDCConfig{
+		Provider:            "OIDC",
+		AuthenticationClaim: "email",
+		Scopes:              []string{"openid", "profile", "email"},
+	}
+}
+
+// Validate implements the check.Validatable interface.
+func (c OIDCConfig) Validate() []error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var errs []error
+	for _, f := range []struct{ name, value string }{
+		{"idp_sso_url", c.IDPSSOURL},
+		{"client_id", c.ClientID},
+		{"client_secret", c.ClientSecret},
+		{"idp_recipient_url", c.IDPRecipientURL},
+		{"authentication_claim", c.AuthenticationClaim},
+	} {
+		if f.value == "" {
+			errs = append(errs, errors.Errorf("oidc %s must be set", f.name))
+		}
+	}
+	for _, f := range []struct{ name, value string }{
+		{"idp_sso_url", c.IDPSSOURL},
+		{"idp_recipient_url", c.IDPRecipientURL},
+	} {
+		if f.value == "" {
+			continue
+		}
+		if u, err := url.Parse(f.value); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, errors.Errorf("oidc %s must be an absolute URL", f.name))
+		}
+	}
+	return errs
+}
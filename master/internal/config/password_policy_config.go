@@ -0,0 +1,82 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// PasswordPolicyConfig is the configuration of the passwords users may set.
+type PasswordPolicyConfig struct {
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol"`
+	// HistorySize is how many of their most recent passwords, including the current one, users
+	// may not reuse.
+	HistorySize int `json:"history_size"`
+}
+
+// HasRules returns whether the policy restricts what passwords look like, which can only be
+// checked for passwords that aren't hashed by the client.
+func (p PasswordPolicyConfig) HasRules() bool {
+	return p.MinLength > 0 || p.RequireUppercase || p.RequireLowercase || p.RequireDigit ||
+		p.RequireSymbol
+}
+
+// Validate implements the check.Validatable interface.
+func (p PasswordPolicyConfig) Validate() []error {
+	var errs []error
+	if p.MinLength < 0 {
+		errs = append(errs, errors.New("password_policy min_length must not be negative"))
+	}
+	if p.HistorySize < 0 {
+		errs = append(errs, errors.New("password_policy history_size must not be negative"))
+	}
+	return errs
+}
+
+// LoginLockoutConfig is the configuration for locking users out after failed login attempts.
+type LoginLockoutConfig struct {
+	// MaxFailedAttempts is how many times logging in as a user from an address may fail within
+	// Window before further attempts from the address are refused for Duration; 0 disables these
+	// lockouts.
+	MaxFailedAttempts int `json:"max_failed_attempts"`
+	// MaxFailedAttemptsPerUser is the same for attempts from any address, so that changing
+	// addresses doesn't allow guessing passwords indefinitely; 0 disables these lockouts.
+	MaxFailedAttemptsPerUser int            `json:"max_failed_attempts_per_user"`
+	Window                   model.Duration `json:"window"`
+	Duration                 model.Duration `json:"duration"`
+}
+
+// DefaultLoginLockoutConfig returns the default configuration of login lockouts.
+func DefaultLoginLockoutConfig() LoginLockoutConfig {
+	return LoginLockoutConfig{
+		Window:   model.Duration(15 * time.Minute),
+		Duration: model.Duration(15 * time.Minute),
+	}
+}
+
+// Validate implements the check.Validatable interface.
+func (l LoginLockoutConfig) Validate() []error {
+	var errs []error
+	if l.MaxFailedAttempts < 0 {
+		errs = append(errs, errors.New("login_lockout max_failed_attempts must not be negative"))
+	}
+	if l.MaxFailedAttemptsPerUser < 0 {
+		errs = append(errs,
+			errors.New("login_lockout max_failed_attempts_per_user must not be negative"))
+	}
+	if l.Enabled() && (l.Window <= 0 || l.Duration <= 0) {
+		errs = append(errs, errors.New("login_lockout window and duration must be positive"))
+	}
+	return errs
+}
+
+// Enabled returns whether users are locked out after failed login attempts at all.
+func (l LoginLockoutConfig) Enabled() bool {
+	return l.MaxFailedAttempts > 0 || l.MaxFailedAttemptsPerUser > 0
+}
@@ -2,12 +2,14 @@ package config
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/determined-ai/determined/master/internal/rm/kubernetes"
 	"github.com/determined-ai/determined/master/pkg/check"
 	"github.com/determined-ai/determined/master/pkg/device"
+	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/master/pkg/union"
 )
 
@@ -17,6 +19,8 @@ const defaultResourcePoolName = "default"
 type ResourceManagerConfig struct {
 	AgentRM      *AgentResourceManagerConfig      `union:"type,agent" json:"-"`
 	KubernetesRM *KubernetesResourceManagerConfig `union:"type,kubernetes" json:"-"`
+	SlurmRM      *DispatcherResourceManagerConfig `union:"type,slurm" json:"-"`
+	PbsRM        *DispatcherResourceManagerConfig `union:"type,pbs" json:"-"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -36,7 +40,7 @@ func (r *ResourceManagerConfig) UnmarshalJSON(data []byte) error {
 	}
 
 	// Fill in the default config.
-	if r.AgentRM == nil && r.KubernetesRM == nil {
+	if r.AgentRM == nil && r.KubernetesRM == nil && r.DispatcherRM() == nil {
 		r.AgentRM = &AgentResourceManagerConfig{
 			Scheduler: &SchedulerConfig{
 				FittingPolicy: defaultFitPolicy,
@@ -48,6 +52,14 @@ func (r *ResourceManagerConfig) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// DispatcherRM returns the configuration of the Slurm or PBS resource manager, if either is used.
+func (r ResourceManagerConfig) DispatcherRM() *DispatcherResourceManagerConfig {
+	if r.SlurmRM != nil {
+		return r.SlurmRM
+	}
+	return r.PbsRM
+}
+
 // AgentResourceManagerConfig hosts configuration fields for the determined resource manager.
 type AgentResourceManagerConfig struct {
 	Scheduler                  *SchedulerConfig `json:"scheduler"`
@@ -152,3 +164,113 @@ func (k KubernetesResourceManagerConfig) Validate() []error {
 		checkCPUResource,
 	}
 }
+
+const (
+	// SingularityContainerRunType runs task containers with singularity (or apptainer).
+	SingularityContainerRunType = "singularity"
+	// PodmanContainerRunType runs task containers with podman.
+	PodmanContainerRunType = "podman"
+
+	defaultDispatcherPollInterval = 10 * time.Second
+)
+
+// DispatcherResourceManagerConfig hosts configuration fields for the resource managers that
+// dispatch tasks as batch jobs to an HPC workload manager, i.e., Slurm or PBS.
+type DispatcherResourceManagerConfig struct {
+	// MasterHost and MasterPort are how task containers on the compute nodes reach the master.
+	MasterHost string `json:"master_host"`
+	MasterPort int    `json:"master_port"`
+	// JobStorageRoot is a directory shared by the master and the compute nodes in which the batch
+	// scripts, files and output of the jobs are stored.
+	JobStorageRoot   string         `json:"job_storage_root"`
+	ContainerRunType string         `json:"container_run_type"`
+	SlotType         device.Type    `json:"slot_type"`
+	PollInterval     model.Duration `json:"poll_interval"`
+	// DefaultComputeResourcePool and DefaultAuxResourcePool are the partitions (Slurm) or queues
+	// (PBS) that jobs are submitted to when they do not name a resource pool. If empty, the
+	// default of the workload manager is used.
+	DefaultComputeResourcePool string `json:"default_compute_resource_pool"`
+	DefaultAuxResourcePool     string `json:"default_aux_resource_pool"`
+
+	// The settings of the HPC Launcher of the Enterprise Edition are accepted so that its
+	// configurations keep loading, but they have no effect since the master submits jobs itself.
+	LauncherHost               string          `json:"host,omitempty"`
+	LauncherPort               int             `json:"port,omitempty"`
+	LauncherProtocol           string          `json:"protocol,omitempty"`
+	LauncherSecurity           json.RawMessage `json:"security,omitempty"`
+	LauncherAuthFile           string          `json:"auth_file,omitempty"`
+	RendezvousNetworkInterface string          `json:"rendezvous_network_interface,omitempty"`
+	ProxyNetworkInterface      string          `json:"proxy_network_interface,omitempty"`
+	UserName                   string          `json:"user_name,omitempty"`
+	GroupName                  string          `json:"group_name,omitempty"`
+	SingularityImageRoot       string          `json:"singularity_image_root,omitempty"`
+	Path                       string          `json:"path,omitempty"`
+	LdLibraryPath              string          `json:"ld_library_path,omitempty"`
+	TresSupported              *bool           `json:"tres_supported,omitempty"`
+	GresSupported              *bool           `json:"gres_supported,omitempty"`
+	PartitionOverrides         json.RawMessage `json:"partition_overrides,omitempty"`
+}
+
+// launcherSettings are the names of the HPC Launcher settings.
+var launcherSettings = []string{
+	"host", "port", "protocol", "security", "auth_file", "rendezvous_network_interface",
+	"proxy_network_interface", "user_name", "group_name", "singularity_image_root", "path",
+	"ld_library_path", "tres_supported", "gres_supported", "partition_overrides",
+}
+
+// LauncherSettings returns the names of the HPC Launcher settings that are set, which are ignored.
+func (d DispatcherResourceManagerConfig) LauncherSettings() []string {
+	bs, err := json.Marshal(d)
+	if err != nil {
+		return nil
+	}
+	var set map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &set); err != nil {
+		return nil
+	}
+	var names []string
+	for _, name := range launcherSettings {
+		if _, ok := set[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+var defaultDispatcherResourceManagerConfig = DispatcherResourceManagerConfig{
+	ContainerRunType: SingularityContainerRunType,
+	SlotType:         device.CUDA,
+	PollInterval:     model.Duration(defaultDispatcherPollInterval),
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *DispatcherResourceManagerConfig) UnmarshalJSON(data []byte) error {
+	*d = defaultDispatcherResourceManagerConfig
+	type DefaultParser *DispatcherResourceManagerConfig
+	err := json.Unmarshal(data, DefaultParser(d))
+	if err == nil && d.SlotType == "gpu" {
+		d.SlotType = device.CUDA
+	}
+	return err
+}
+
+// Validate implements the check.Validatable interface.
+func (d DispatcherResourceManagerConfig) Validate() []error {
+	var checkSlotType error
+	switch d.SlotType {
+	case device.CPU, device.CUDA, device.ROCM:
+		break
+	default:
+		checkSlotType = errors.Errorf("slot_type must be one of cuda, rocm or cpu")
+	}
+	return []error{
+		check.NotEmpty(d.MasterHost, "master_host must be set"),
+		check.GreaterThan(d.MasterPort, 0, "master_port must be > 0"),
+		check.NotEmpty(d.JobStorageRoot, "job_storage_root must be set"),
+		check.In(d.ContainerRunType,
+			[]string{SingularityContainerRunType, PodmanContainerRunType},
+			"container_run_type must be singularity or podman"),
+		check.True(d.PollInterval > 0, "poll_interval must be > 0"),
+		checkSlotType,
+	}
+}
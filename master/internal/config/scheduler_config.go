@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/determined-ai/determined/master/pkg/check"
 	"github.com/determined-ai/determined/master/pkg/model"
@@ -18,6 +19,13 @@ const (
 	PriorityScheduling = "priority"
 	// RoundRobinScheduling schedules tasks based on the order in which they arrive.
 	RoundRobinScheduling = "round_robin"
+	// BackfillScheduling schedules tasks in the order in which they arrive, letting later tasks
+	// run early only if they do not delay the first task in the queue.
+	BackfillScheduling = "backfill"
+
+	// DefaultBackfillRuntime is the default expected runtime of allocations for the backfill
+	// scheduler.
+	DefaultBackfillRuntime = model.Duration(time.Hour)
 
 	best             = "best"
 	worst            = "worst"
@@ -37,6 +45,7 @@ type SchedulerConfig struct {
 	FairShare     *FairShareSchedulerConfig  `union:"type,fair_share" json:"-"`
 	Priority      *PrioritySchedulerConfig   `union:"type,priority" json:"-"`
 	RoundRobin    *RoundRobinSchedulerConfig `union:"type,round_robin" json:"-"`
+	Backfill      *BackfillSchedulerConfig   `union:"type,backfill" json:"-"`
 	FittingPolicy string                     `json:"fitting_policy"`
 }
 
@@ -57,13 +66,16 @@ func (s *SchedulerConfig) UnmarshalJSON(data []byte) error {
 	}
 
 	// Fill in the default
-	if s.FairShare == nil && s.Priority == nil && s.RoundRobin == nil {
+	if s.FairShare == nil && s.Priority == nil && s.RoundRobin == nil && s.Backfill == nil {
 		s.FairShare = &FairShareSchedulerConfig{}
 	}
 	if s.Priority != nil && s.Priority.DefaultPriority == nil {
 		defaultPriority := DefaultSchedulingPriority
 		s.Priority.DefaultPriority = &defaultPriority
 	}
+	if s.Backfill != nil && s.Backfill.DefaultRuntime == 0 {
+		s.Backfill.DefaultRuntime = DefaultBackfillRuntime
+	}
 	if s.FittingPolicy == "" {
 		s.FittingPolicy = best
 	}
@@ -89,6 +101,8 @@ func (s *SchedulerConfig) GetType() string {
 		return PriorityScheduling
 	case s.RoundRobin != nil:
 		return RoundRobinScheduling
+	case s.Backfill != nil:
+		return BackfillScheduling
 	default:
 		panic("neither scheduler type configured")
 	}
@@ -102,7 +116,7 @@ func (s *SchedulerConfig) GetPreemption() bool {
 		preemptionEnabled = true
 	case s.Priority != nil:
 		preemptionEnabled = s.Priority.Preemption
-	case s.RoundRobin != nil:
+	case s.RoundRobin != nil, s.Backfill != nil:
 		preemptionEnabled = false
 	}
 	return preemptionEnabled
@@ -124,3 +138,16 @@ type RoundRobinSchedulerConfig struct{}
 func (p PrioritySchedulerConfig) Validate() []error {
 	return model.ValidatePrioritySetting(p.DefaultPriority)
 }
+
+// BackfillSchedulerConfig holds the configurations for the backfill scheduler.
+type BackfillSchedulerConfig struct {
+	// DefaultRuntime is how long allocations are expected to run when they do not say.
+	DefaultRuntime model.Duration `json:"default_runtime"`
+}
+
+// Validate implements the check.Validatable interface.
+func (b BackfillSchedulerConfig) Validate() []error {
+	return []error{
+		check.GreaterThan(int64(b.DefaultRuntime), int64(0), "default_runtime must be positive"),
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TrustedProxiesConfig is the addresses, or ranges of addresses in CIDR notation, of the proxies in
+// front of the master whose X-Forwarded-For headers are trusted to give the address of the client.
+type TrustedProxiesConfig []string
+
+// Validate implements the check.Validatable interface.
+func (t TrustedProxiesConfig) Validate() []error {
+	var errs []error
+	for _, proxy := range t {
+		if parseIPRange(proxy) == nil {
+			errs = append(errs, errors.Errorf(
+				"trusted_proxies entry %q is neither an IP address nor a CIDR range", proxy))
+		}
+	}
+	return errs
+}
+
+// Ranges returns the ranges of addresses of the trusted proxies, skipping invalid entries.
+func (t TrustedProxiesConfig) Ranges() []*net.IPNet {
+	var ranges []*net.IPNet
+	for _, proxy := range t {
+		if r := parseIPRange(proxy); r != nil {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// Trusts returns whether the X-Forwarded-For header of a request from the address is trusted. The
+// master itself always is, since its REST API forwards requests to its gRPC API over loopback.
+func (t TrustedProxiesConfig) Trusts(ip net.IP) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, r := range t.Ranges() {
+		if r.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseIPRange(s string) *net.IPNet {
+	if strings.Contains(s, "/") {
+		_, r, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil
+		}
+		return r
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
@@ -36,6 +36,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/internal/audit"
 	"github.com/determined-ai/determined/master/internal/cluster"
 	"github.com/determined-ai/determined/master/internal/command"
 	"github.com/determined-ai/determined/master/internal/config"
@@ -49,6 +50,7 @@ import (
 	"github.com/determined-ai/determined/master/internal/plugin/sso"
 	"github.com/determined-ai/determined/master/internal/prom"
 	"github.com/determined-ai/determined/master/internal/proxy"
+	"github.com/determined-ai/determined/master/internal/rbac"
 	"github.com/determined-ai/determined/master/internal/rm"
 	"github.com/determined-ai/determined/master/internal/rm/allocationmap"
 	"github.com/determined-ai/determined/master/internal/sproto"
@@ -57,6 +59,8 @@ import (
 	"github.com/determined-ai/determined/master/internal/telemetry"
 	"github.com/determined-ai/determined/master/internal/template"
 	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/internal/usergroup"
+	"github.com/determined-ai/determined/master/internal/webhooks"
 	"github.com/determined-ai/determined/master/pkg/actor"
 	"github.com/determined-ai/determined/master/pkg/actor/actors"
 	"github.com/determined-ai/determined/master/pkg/aproto"
@@ -528,7 +532,7 @@ func (m *Master) startServers(ctx context.Context, cert *tls.Certificate) error
 	// This must be before grpcutil.RegisterHTTPProxy is called since it may use stuff set up by the
 	// gRPC server (logger initialization, maybe more). Found by --race.
 	gRPCServer := grpcutil.NewGRPCServer(m.db, &apiServer{m: m},
-		m.config.Observability.EnablePrometheus,
+		m.config.Observability.EnablePrometheus, m.config.Telemetry.OtelEnabled,
 		&m.config.InternalConfig.ExternalSessions)
 
 	err = grpcutil.RegisterHTTPProxy(ctx, m.echo, m.config.Port, cert)
@@ -814,8 +818,17 @@ func (m *Master) Run(ctx context.Context) error {
 	m.taskLogger = task.NewLogger(m.system, m.taskLogBackend)
 
 	user.InitService(m.db, m.system, &m.config.InternalConfig.ExternalSessions)
+	user.SetGroupSync(usergroup.SyncUserGroups)
 	userService := user.GetService()
 
+	if m.config.Webhooks.SigningKey == "" {
+		if m.config.Webhooks.SigningKey, err = webhooks.GetOrCreateSigningKey(ctx); err != nil {
+			return errors.Wrap(err, "could not initialize webhook signing key")
+		}
+	}
+	webhooks.Init(ctx, m.config.Webhooks)
+	audit.Init(ctx, m.config.AuditLog)
+
 	m.proxy, _ = m.system.ActorOf(actor.Addr("proxy"), &proxy.Proxy{
 		HTTPAuth: userService.ProcessProxyAuthentication,
 	})
@@ -831,6 +844,15 @@ func (m *Master) Run(ctx context.Context) error {
 
 	// Initialize the HTTP server and listen for incoming requests.
 	m.echo = echo.New()
+	// Client addresses, used for audit logs and login lockouts, are only taken from X-Forwarded-For
+	// headers set by the master itself or by proxies it is configured to trust.
+	trustOptions := []echo.TrustOption{
+		echo.TrustLoopback(true), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false),
+	}
+	for _, r := range m.config.Security.TrustedProxies.Ranges() {
+		trustOptions = append(trustOptions, echo.TrustIPRange(r))
+	}
+	m.echo.IPExtractor = echo.ExtractIPFromXFFHeader(trustOptions...)
 	m.echo.Use(middleware.Recover())
 
 	gzipConfig := middleware.GzipConfig{
@@ -871,6 +893,10 @@ func (m *Master) Run(ctx context.Context) error {
 		}
 	})
 
+	if m.config.AuditLog.Enabled {
+		m.echo.Use(auditEventMiddleware())
+	}
+
 	m.echo.Use(convertDBErrorsToNotFound)
 
 	if m.config.InternalConfig.AuditLoggingEnabled {
@@ -908,6 +934,12 @@ func (m *Master) Run(ctx context.Context) error {
 		},
 		cert,
 	)
+	if err = m.loadResourceQuotas(); err != nil {
+		return err
+	}
+	if err = m.loadResourceReservations(); err != nil {
+		return err
+	}
 	tasksGroup := m.echo.Group("/tasks")
 	tasksGroup.GET("", api.Route(m.getTasks))
 
@@ -930,6 +962,14 @@ func (m *Master) Run(ctx context.Context) error {
 		return err
 	}
 
+	m.system.MustActorOf(actor.Addr("checkpoint-retention"), &checkpointRetentionSweeper{
+		db:         m.db,
+		rm:         m.rm,
+		taskLogger: m.taskLogger,
+		taskSpec:   m.taskSpec,
+		interval:   time.Duration(m.config.CheckpointRetention.SweepInterval),
+	})
+
 	command.RegisterAPIHandler(
 		m.system,
 		m.echo,
@@ -1022,11 +1062,14 @@ func (m *Master) Run(ctx context.Context) error {
 	experimentsGroup.GET("/:experiment_id/model_def", m.getExperimentModelDefinition)
 	experimentsGroup.GET("/:experiment_id/file/download", m.getExperimentModelFile)
 	experimentsGroup.GET("/:experiment_id/preview_gc", api.Route(m.getExperimentCheckpointsToGC))
+	experimentsGroup.GET("/:experiment_id/pareto_front", api.Route(m.getExperimentParetoFront))
+	experimentsGroup.POST("/:experiment_id/continue", api.Route(m.postExperimentContinue))
 	experimentsGroup.PATCH("/:experiment_id", api.Route(m.patchExperiment))
 	experimentsGroup.POST("", api.Route(m.postExperiment))
 
 	checkpointsGroup := m.echo.Group("/checkpoints")
 	checkpointsGroup.GET("/:checkpoint_uuid", m.getCheckpoint)
+	checkpointsGroup.POST("", m.postCheckpoint)
 
 	searcherGroup := m.echo.Group("/searcher")
 	searcherGroup.POST("/preview", api.Route(m.getSearcherPreview))
@@ -1035,9 +1078,42 @@ func (m *Master) Run(ctx context.Context) error {
 	trialsGroup.GET("/:trial_id", api.Route(m.getTrial))
 	trialsGroup.GET("/:trial_id/metrics", api.Route(m.getTrialMetrics))
 
+	resourcePoolsGroup := m.echo.Group("/resource-pools")
+	resourcePoolsGroup.POST("/:resource_pool/simulate", api.Route(m.postResourcePoolSimulation))
+	resourcePoolsGroup.GET("/:resource_pool/quotas", api.Route(m.getResourcePoolQuotas))
+	resourcePoolsGroup.PUT("/:resource_pool/quotas", api.Route(m.putResourcePoolQuota))
+	resourcePoolsGroup.DELETE("/:resource_pool/quotas/:quota_id",
+		api.Route(m.deleteResourcePoolQuota))
+	resourcePoolsGroup.GET("/:resource_pool/reservations",
+		api.Route(m.getResourcePoolReservations))
+	resourcePoolsGroup.POST("/:resource_pool/reservations",
+		api.Route(m.postResourcePoolReservation))
+	resourcePoolsGroup.PUT("/:resource_pool/reservations/:reservation_id",
+		api.Route(m.putResourcePoolReservation))
+	resourcePoolsGroup.DELETE("/:resource_pool/reservations/:reservation_id",
+		api.Route(m.deleteResourcePoolReservation))
+
+	workspacesGroup := m.echo.Group("/workspaces")
+	workspacesGroup.GET("/:workspace_id/checkpoint-retention",
+		api.Route(m.getWorkspaceCheckpointRetention))
+	workspacesGroup.PUT("/:workspace_id/checkpoint-retention",
+		api.Route(m.putWorkspaceCheckpointRetention))
+	workspacesGroup.DELETE("/:workspace_id/checkpoint-retention",
+		api.Route(m.deleteWorkspaceCheckpointRetention))
+
+	projectsGroup := m.echo.Group("/projects")
+	projectsGroup.GET("/:project_id/checkpoint-retention",
+		api.Route(m.getProjectCheckpointRetention))
+	projectsGroup.PUT("/:project_id/checkpoint-retention",
+		api.Route(m.putProjectCheckpointRetention))
+	projectsGroup.DELETE("/:project_id/checkpoint-retention",
+		api.Route(m.deleteProjectCheckpointRetention))
+	projectsGroup.GET("/:project_id/preview_gc", api.Route(m.getProjectCheckpointsToGC))
+
 	resourcesGroup := m.echo.Group("/resources")
 	resourcesGroup.GET("/allocation/raw", m.getRawResourceAllocation)
 	resourcesGroup.GET("/allocation/aggregated", m.getAggregatedResourceAllocation)
+	resourcesGroup.GET("/storage", api.Route(m.getStorageReport))
 
 	m.echo.POST("/task-logs", api.Route(m.postTaskLogs))
 
@@ -1076,6 +1152,9 @@ func (m *Master) Run(ctx context.Context) error {
 
 	user.RegisterAPIHandler(m.echo, userService)
 	template.RegisterAPIHandler(m.echo, m.db)
+	webhooks.RegisterAPIHandler(m.echo)
+	audit.RegisterAPIHandler(m.echo)
+	rbac.RegisterAPIHandler(m.echo)
 
 	telemetry.Setup(
 		m.system,
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -19,7 +21,9 @@ import (
 	"github.com/determined-ai/determined/master/internal/api"
 	detContext "github.com/determined-ai/determined/master/internal/context"
 	expauth "github.com/determined-ai/determined/master/internal/experiment"
+	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
 )
 
@@ -71,6 +75,9 @@ func (m *Master) getCheckpointStorageConfig(id uuid.UUID) (
 	if err != nil || checkpoint == nil {
 		return nil, err
 	}
+	if checkpoint.CheckpointTrainingMetadata.ExperimentID == 0 {
+		return m.db.ImportedCheckpointStorageConfig(id)
+	}
 
 	bytes, err := json.Marshal(checkpoint.CheckpointTrainingMetadata.ExperimentConfig)
 	if err != nil {
@@ -178,3 +185,85 @@ func (m *Master) getCheckpoint(c echo.Context) error {
 	c.Response().Header().Set(echo.HeaderContentType, mimeType)
 	return m.getCheckpointImpl(c.Request().Context(), id, mimeType, c.Response())
 }
+
+// @Summary Upload a checkpoint's contents in a tgz or zip file.
+// @Tags Checkpoints
+// @ID post-checkpoint
+// @Accept  application/gzip,application/zip
+// @Produce  json
+// @Param   metadata query string  false  "Checkpoint metadata, as a JSON object"
+// @Success 200 {} string ""
+//nolint:godot
+// @Router /checkpoints [post]
+func (m *Master) postCheckpoint(c echo.Context) error {
+	mimeType := c.Request().Header.Get(echo.HeaderContentType)
+	if mimeType != MIMEApplicationGZip &&
+		mimeType != MIMEApplicationZip {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType,
+			fmt.Sprintf("unsupported media type to upload a checkpoint: '%s'", mimeType))
+	}
+
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	if err := expauth.AuthZProvider.Get().CanImportCheckpoint(curUser); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	args := struct {
+		Metadata *string `query:"metadata"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	metadata := model.JSONObj{}
+	if args.Metadata != nil {
+		if err := json.Unmarshal([]byte(*args.Metadata), &metadata); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("metadata must be a JSON object: %s", err))
+		}
+	}
+
+	// Imported checkpoints are not tied to an experiment, so they go to the master's storage.
+	storageConfig := schemas.WithDefaults(
+		m.config.CheckpointStorage).(expconf.CheckpointStorageConfig)
+	id := uuid.New()
+	uploader, err := checkpoints.NewUploader(
+		c.Request().Body, id.String(), &storageConfig, mimeToArchiveType(mimeType))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	resources, err := uploader.Upload(c.Request().Context())
+	if cErr := uploader.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		cleanupCheckpointUpload(uploader, id)
+		return echo.NewHTTPError(http.StatusInternalServerError,
+			fmt.Sprintf("unable to upload checkpoint: %s", err))
+	}
+
+	checkpoint := model.CheckpointV2{
+		UUID:       id,
+		ReportTime: time.Now().UTC(),
+		State:      model.CompletedState,
+		Resources:  resources,
+		Metadata:   metadata,
+	}
+	if err := m.db.AddImportedCheckpoint(
+		c.Request().Context(), &checkpoint, storageConfig); err != nil {
+		cleanupCheckpointUpload(uploader, id)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"uuid":      id.String(),
+		"resources": resources,
+	})
+}
+
+// cleanupCheckpointUpload deletes what a failed checkpoint import wrote to storage. The request's
+// context may already be canceled at this point, so it is not used.
+func cleanupCheckpointUpload(uploader checkpoints.CheckpointUploader, id uuid.UUID) {
+	if err := uploader.Cleanup(context.Background()); err != nil {
+		log.WithError(err).Errorf("failed to clean up checkpoint %s after a failed import", id)
+	}
+}
@@ -6,8 +6,10 @@ package internal
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,6 +30,7 @@ import (
 	detContext "github.com/determined-ai/determined/master/internal/context"
 	"github.com/determined-ai/determined/master/internal/db"
 	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
 	dets3 "github.com/determined-ai/determined/master/pkg/checkpoints/s3"
 	"github.com/determined-ai/determined/master/pkg/etc"
 	"github.com/determined-ai/determined/master/pkg/model"
@@ -249,6 +252,78 @@ func TestGetCheckpointEchoExpErr(t *testing.T) {
 	}
 }
 
+func TestPostCheckpointEcho(t *testing.T) {
+	api, ctx, rec := setupCheckpointTestEcho(t)
+	hostPath := t.TempDir()
+	api.m.config.CheckpointStorage = expconf.CheckpointStorageConfig{
+		RawSharedFSConfig: &expconf.SharedFSConfig{RawHostPath: ptrs.Ptr(hostPath)},
+	}
+
+	var body bytes.Buffer
+	aw, err := archive.NewArchiveWriter(&body, archive.ArchiveTgz)
+	require.NoError(t, err)
+	for name, content := range mockCheckpointContent {
+		require.NoError(t, aw.WriteHeader(name, int64(len(content))))
+		_, err = aw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, aw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/?metadata=%7B%22source%22%3A%22hf%22%7D", &body)
+	req.Header.Set(echo.HeaderContentType, MIMEApplicationGZip)
+	ctx.SetRequest(req)
+	require.NoError(t, api.m.postCheckpoint(ctx))
+
+	var resp struct {
+		UUID string `json:"uuid"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	id, err := uuid.Parse(resp.UUID)
+	require.NoError(t, err)
+
+	checkpoint, err := api.m.db.CheckpointByUUID(id)
+	require.NoError(t, err)
+	require.Nil(t, checkpoint.TaskID)
+	require.Equal(t, model.CompletedState, checkpoint.State)
+	require.Equal(t, "hf", checkpoint.Metadata["source"])
+
+	// The imported checkpoint can be downloaded back from the storage it was uploaded to.
+	_, ctx, rec = setupCheckpointTestEcho(t)
+	ctx.SetParamNames("checkpoint_uuid")
+	ctx.SetParamValues(resp.UUID)
+	ctx.SetRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+	ctx.Request().Header.Set("Accept", MIMEApplicationGZip)
+	require.NoError(t, api.m.getCheckpoint(ctx))
+	checkTgz(t, rec.Body, resp.UUID)
+
+	// Checkpoints must be uploaded as a tgz or zip file.
+	ctx.SetRequest(httptest.NewRequest(http.MethodPost, "/", nil))
+	ctx.Request().Header.Set(echo.HeaderContentType, "application/json")
+	require.Equal(t, echo.NewHTTPError(http.StatusUnsupportedMediaType,
+		"unsupported media type to upload a checkpoint: 'application/json'"),
+		api.m.postCheckpoint(ctx))
+
+	// Nothing is left in storage when an upload fails partway through.
+	body.Reset()
+	aw, err = archive.NewArchiveWriter(&body, archive.ArchiveTgz)
+	require.NoError(t, err)
+	// The second file can't be written, since its parent directory is a file.
+	for _, name := range []string{"a", "a/b"} {
+		require.NoError(t, aw.WriteHeader(name, 1))
+		_, err = aw.Write([]byte("x"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, aw.Close())
+	ctx.SetRequest(httptest.NewRequest(http.MethodPost, "/", &body))
+	ctx.Request().Header.Set(echo.HeaderContentType, MIMEApplicationGZip)
+	err = api.m.postCheckpoint(ctx)
+	require.Error(t, err)
+	require.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+	entries, err := os.ReadDir(hostPath)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "only the checkpoint imported above should remain")
+}
+
 func TestAuthZCheckpointsEcho(t *testing.T) {
 	api, authZExp, _, curUser, ctx := setupExpAuthTestEcho(t)
 
@@ -279,6 +354,14 @@ func TestAuthZCheckpointsEcho(t *testing.T) {
 	authZExp.On("CanGetExperimentArtifacts", curUser, mock.Anything).
 		Return(fmt.Errorf("canGetArtifactsError")).Once()
 	require.Equal(t, expectedErr, api.m.getCheckpoint(ctx))
+
+	// Importing a checkpoint is checked before anything is written to storage.
+	ctx.SetRequest(httptest.NewRequest(http.MethodPost, "/", nil))
+	ctx.Request().Header.Set(echo.HeaderContentType, MIMEApplicationGZip)
+	authZExp.On("CanImportCheckpoint", curUser).
+		Return(fmt.Errorf("canImportCheckpointError")).Once()
+	require.Equal(t, echo.NewHTTPError(http.StatusForbidden, "canImportCheckpointError"),
+		api.m.postCheckpoint(ctx))
 }
 
 //nolint: exhaustivestruct
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"regexp"
+	"sort"
 	"time"
 
 	"github.com/determined-ai/determined/proto/pkg/apiv1"
@@ -25,9 +26,11 @@ import (
 	"github.com/determined-ai/determined/master/internal/user"
 	"github.com/determined-ai/determined/master/pkg/actor"
 	"github.com/determined-ai/determined/master/pkg/archive"
+	"github.com/determined-ai/determined/master/pkg/check"
 	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/master/pkg/schemas"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+	"github.com/determined-ai/determined/master/pkg/searcher"
 	"github.com/determined-ai/determined/master/pkg/tasks"
 )
 
@@ -148,6 +151,33 @@ func (m *Master) getExperimentCheckpointsToGC(c echo.Context) (interface{}, erro
 	return checkpointsWithMetric, nil
 }
 
+// getExperimentParetoFront returns the metrics of an experiment that ranks trials by several
+// metrics and the trials on its Pareto front, with the metrics of their latest validations.
+func (m *Master) getExperimentParetoFront(c echo.Context) (interface{}, error) {
+	args := struct {
+		ExperimentID int `path:"experiment_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	exp, _, err := echoGetExperimentAndCheckCanDoActions(c, m, args.ExperimentID, true,
+		expauth.AuthZProvider.Get().CanGetExperimentArtifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := exp.Config.Searcher().Metrics()
+	if metrics == nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"experiment %d does not rank trials by several metrics", args.ExperimentID))
+	}
+	trials, err := db.ExperimentParetoFront(c.Request().Context(), args.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"metrics": metrics, "trials": trials}, nil
+}
+
 // @Summary Get individual file from modal definitions for download.
 // @Tags Experiments
 // @ID get-experiment-model-file
@@ -441,6 +471,16 @@ func (m *Master) parseCreateExperiment(params *CreateExperimentParams, user *mod
 		return nil, nil, false, nil, errors.Wrap(err, "invalid experiment configuration")
 	}
 
+	// Make sure the conditions and constraints of the hyperparameters are consistent.
+	if err = check.Validate(config.Hyperparameters()); err != nil {
+		return nil, nil, false, nil, errors.Wrap(err, "invalid hyperparameters configuration")
+	}
+
+	// Make sure searches that rank trials by several metrics list at least two distinct ones.
+	if err = check.Validate(config.Searcher()); err != nil {
+		return nil, nil, false, nil, errors.Wrap(err, "invalid searcher configuration")
+	}
+
 	// Disallow EOL searchers.
 	if err = config.Searcher().AssertCurrent(); err != nil {
 		return nil, nil, false, nil, errors.Wrap(err, "invalid experiment configuration")
@@ -596,3 +636,118 @@ func (m *Master) postExperiment(c echo.Context) (interface{}, error) {
 	}
 	return response, nil
 }
+
+// postExperimentContinue continues the search of a completed experiment with a larger max_trials
+// or max_length, in the same experiment and with the same trials.
+func (m *Master) postExperimentContinue(c echo.Context) (interface{}, error) {
+	args := struct {
+		ExperimentID int `path:"experiment_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	dbExp, _, err := echoGetExperimentAndCheckCanDoActions(c, m, args.ExperimentID, true,
+		expauth.AuthZProvider.Get().CanEditExperiment)
+	if err != nil {
+		return nil, err
+	}
+
+	params := struct {
+		MaxTrials *int            `json:"max_trials"`
+		MaxLength *expconf.Length `json:"max_length"`
+	}{}
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &params); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"invalid continue params: %s", err))
+	}
+
+	switch {
+	case dbExp.State != model.CompletedState:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"only completed experiments can be continued, experiment %d is %s",
+			args.ExperimentID, dbExp.State))
+	case dbExp.Archived:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"experiment %d is archived", args.ExperimentID))
+	}
+	searcherConfig, err := searcher.ContinueConfig(
+		dbExp.Config.Searcher(), params.MaxTrials, params.MaxLength)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	snapshot, err := m.retrieveExperimentSnapshot(dbExp)
+	if err != nil {
+		return nil, err
+	} else if snapshot == nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"the search of experiment %d was not saved when it completed", args.ExperimentID))
+	}
+	dbExp.Config.SetSearcher(searcherConfig)
+
+	poolName, err := m.rm.ResolveResourcePool(
+		m.system,
+		dbExp.Config.Resources().ResourcePool(),
+		dbExp.Config.Resources().SlotsPerTrial(),
+		false,
+	)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"invalid resource configuration: %s", err))
+	}
+	taskSpec := *m.taskSpec
+	taskSpec.TaskContainerDefaults = m.getTaskContainerDefaults(poolName)
+	owner, err := user.UserByUsername(dbExp.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving the owner of the experiment")
+	}
+	taskSpec.Owner = owner
+	token, err := m.db.StartUserSession(owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create user session inside task")
+	}
+	taskSpec.UserSessionToken = token
+
+	dbExp.State = model.ActiveState
+	dbExp.EndTime = nil
+	e, err := newExperiment(m, dbExp, &taskSpec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create experiment %d from model", dbExp.ID)
+	}
+	if err = e.Restore(snapshot); err != nil {
+		return nil, errors.Wrap(err, "failed to restore experiment")
+	}
+	e.restored = true
+	e.continued = true
+	config, ok := schemas.Copy(e.Config).(expconf.ExperimentConfig)
+	if !ok {
+		return nil, errors.Errorf("could not copy experiment's config to return")
+	}
+
+	switch err = db.ContinueExperiment(c.Request().Context(), dbExp.ID, e.Config); {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf(
+			"experiment %d is no longer completed", args.ExperimentID))
+	case err != nil:
+		return nil, err
+	}
+
+	experimentActor, _ := m.system.ActorOf(actor.Addr("experiments", e.ID), e)
+	// Wait for experiment to run PreStart, which restores its searcher and continues it.
+	m.system.Ask(experimentActor, actor.Ping{}).Get()
+
+	labels := make([]string, 0, len(config.Labels()))
+	for label := range config.Labels() {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return model.ExperimentDescriptor{
+		ID:       e.ID,
+		Archived: false,
+		Config:   config,
+		Labels:   labels,
+	}, nil
+}
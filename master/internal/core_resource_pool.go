@@ -0,0 +1,334 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/rm"
+	"github.com/determined-ai/determined/master/pkg/check"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// postResourcePoolSimulation runs a scheduler simulation against a snapshot of a resource pool.
+// Any of the scheduler, agents and requests of the snapshot can be replaced by the request body,
+// e.g., to see how the pool's current queue would fare under a different scheduler.
+func (m *Master) postResourcePoolSimulation(c echo.Context) (interface{}, error) {
+	args := struct {
+		ResourcePool string `path:"resource_pool"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+
+	agentRM, err := m.agentResourceManager("scheduler simulation")
+	if err != nil {
+		return nil, err
+	}
+	sim, err := agentRM.GetSimulationSnapshot(m.system, args.ResourcePool)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > 0 {
+		var overrides rm.Simulation
+		if err := json.Unmarshal(body, &overrides); err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if overrides.Scheduler != nil {
+			sim.Scheduler = overrides.Scheduler
+		}
+		if overrides.Agents != nil {
+			sim.Agents = overrides.Agents
+		}
+		if overrides.Requests != nil {
+			sim.Requests = overrides.Requests
+		}
+		sim.DefaultDurationSeconds = overrides.DefaultDurationSeconds
+		sim.HorizonSeconds = overrides.HorizonSeconds
+	}
+
+	result, err := rm.Simulate(sim)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return result, nil
+}
+
+// agentResourceManager returns the agent resource manager, which is the only one that manages
+// resource pools itself.
+func (m *Master) agentResourceManager(feature string) (rm.AgentResourceManager, error) {
+	agentRM, ok := m.rm.(rm.AgentResourceManager)
+	if !ok {
+		return agentRM, echo.NewHTTPError(http.StatusBadRequest,
+			feature+" is only supported by the agent resource manager")
+	}
+	return agentRM, nil
+}
+
+// loadResourceQuotas hands the quotas stored in the database to the resource pools that enforce
+// them.
+func (m *Master) loadResourceQuotas() error {
+	agentRM, ok := m.rm.(rm.AgentResourceManager)
+	if !ok {
+		return nil
+	}
+	quotas, err := db.ResourceQuotas(context.TODO())
+	if err != nil {
+		return errors.Wrap(err, "loading resource quotas")
+	}
+	byPool := make(map[string][]model.ResourceQuota)
+	for _, q := range quotas {
+		byPool[q.ResourcePool] = append(byPool[q.ResourcePool], q)
+	}
+	for pool, poolQuotas := range byPool {
+		if err := agentRM.SetResourceQuotas(m.system, pool, poolQuotas); err != nil {
+			log.WithError(err).Warnf("ignoring quotas of resource pool %s", pool)
+		}
+	}
+	return nil
+}
+
+// syncResourceQuotas reloads the quotas of a resource pool after they change.
+func (m *Master) syncResourceQuotas(
+	ctx context.Context, agentRM rm.AgentResourceManager, pool string,
+) error {
+	quotas, err := db.ResourceQuotasByPool(ctx, pool)
+	if err != nil {
+		return err
+	}
+	return agentRM.SetResourceQuotas(m.system, pool, quotas)
+}
+
+// getResourcePoolQuotas returns the quotas of a resource pool along with their usage.
+func (m *Master) getResourcePoolQuotas(c echo.Context) (interface{}, error) {
+	args := struct {
+		ResourcePool string `path:"resource_pool"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	agentRM, err := m.agentResourceManager("resource quotas")
+	if err != nil {
+		return nil, err
+	}
+	status, err := agentRM.GetResourceQuotaStatus(m.system, args.ResourcePool)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return status, nil
+}
+
+// putResourcePoolQuota sets the quota of a workspace or user in a resource pool.
+func (m *Master) putResourcePoolQuota(c echo.Context) (interface{}, error) {
+	args := struct {
+		ResourcePool string `path:"resource_pool"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	agentRM, err := m.agentResourceManager("resource quotas")
+	if err != nil {
+		return nil, err
+	}
+	if err := agentRM.ValidateResourcePool(m.system, args.ResourcePool); err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	var quota model.ResourceQuota
+	if err := json.NewDecoder(c.Request().Body).Decode(&quota); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	quota.ID = 0
+	quota.ResourcePool = args.ResourcePool
+	if err := check.Validate(quota); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	switch err := db.SetResourceQuota(ctx, &quota); {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound, "workspace or user not found")
+	case err != nil:
+		return nil, err
+	}
+	if err := m.syncResourceQuotas(ctx, agentRM, args.ResourcePool); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// deleteResourcePoolQuota deletes a quota of a resource pool.
+func (m *Master) deleteResourcePoolQuota(c echo.Context) (interface{}, error) {
+	args := struct {
+		ResourcePool string `path:"resource_pool"`
+		QuotaID      int    `path:"quota_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	agentRM, err := m.agentResourceManager("resource quotas")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := c.Request().Context()
+	switch err := db.DeleteResourceQuota(ctx, args.ResourcePool, args.QuotaID); {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound, "quota not found")
+	case err != nil:
+		return nil, err
+	}
+	if err := m.syncResourceQuotas(ctx, agentRM, args.ResourcePool); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// loadResourceReservations hands the reservations stored in the database to the resource pools
+// that hold them.
+func (m *Master) loadResourceReservations() error {
+	agentRM, ok := m.rm.(rm.AgentResourceManager)
+	if !ok {
+		return nil
+	}
+	reservations, err := db.ResourceReservations(context.TODO())
+	if err != nil {
+		return errors.Wrap(err, "loading resource reservations")
+	}
+	byPool := make(map[string][]model.ResourceReservation)
+	for _, r := range reservations {
+		byPool[r.ResourcePool] = append(byPool[r.ResourcePool], r)
+	}
+	for pool, poolReservations := range byPool {
+		if err := agentRM.SetResourceReservations(m.system, pool, poolReservations); err != nil {
+			log.WithError(err).Warnf("ignoring reservations of resource pool %s", pool)
+		}
+	}
+	return nil
+}
+
+// syncResourceReservations reloads the reservations of a resource pool after they change.
+func (m *Master) syncResourceReservations(
+	ctx context.Context, agentRM rm.AgentResourceManager, pool string,
+) error {
+	reservations, err := db.ResourceReservationsByPool(ctx, pool)
+	if err != nil {
+		return err
+	}
+	return agentRM.SetResourceReservations(m.system, pool, reservations)
+}
+
+// getResourcePoolReservations returns the reservations of a resource pool along with their usage.
+func (m *Master) getResourcePoolReservations(c echo.Context) (interface{}, error) {
+	args := struct {
+		ResourcePool string `path:"resource_pool"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	agentRM, err := m.agentResourceManager("resource reservations")
+	if err != nil {
+		return nil, err
+	}
+	status, err := agentRM.GetResourceReservationStatus(m.system, args.ResourcePool)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return status, nil
+}
+
+// postResourcePoolReservation adds a reservation to a resource pool.
+func (m *Master) postResourcePoolReservation(c echo.Context) (interface{}, error) {
+	return m.saveResourcePoolReservation(c, false)
+}
+
+// putResourcePoolReservation replaces a reservation of a resource pool.
+func (m *Master) putResourcePoolReservation(c echo.Context) (interface{}, error) {
+	return m.saveResourcePoolReservation(c, true)
+}
+
+func (m *Master) saveResourcePoolReservation(c echo.Context, update bool) (interface{}, error) {
+	args := struct {
+		ResourcePool  string `path:"resource_pool"`
+		ReservationID *int   `path:"reservation_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	agentRM, err := m.agentResourceManager("resource reservations")
+	if err != nil {
+		return nil, err
+	}
+	if err := agentRM.ValidateResourcePool(m.system, args.ResourcePool); err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	var reservation model.ResourceReservation
+	if err := json.NewDecoder(c.Request().Body).Decode(&reservation); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	reservation.ID = 0
+	if update {
+		reservation.ID = *args.ReservationID
+	}
+	reservation.ResourcePool = args.ResourcePool
+	if err := check.Validate(reservation); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if update {
+		err = db.UpdateResourceReservation(ctx, &reservation)
+	} else {
+		err = db.AddResourceReservation(ctx, &reservation)
+	}
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound, "reservation, workspace or user not found")
+	case err != nil:
+		return nil, err
+	}
+	if err := m.syncResourceReservations(ctx, agentRM, args.ResourcePool); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// deleteResourcePoolReservation deletes a reservation of a resource pool.
+func (m *Master) deleteResourcePoolReservation(c echo.Context) (interface{}, error) {
+	args := struct {
+		ResourcePool  string `path:"resource_pool"`
+		ReservationID int    `path:"reservation_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	agentRM, err := m.agentResourceManager("resource reservations")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := c.Request().Context()
+	switch err := db.DeleteResourceReservation(ctx, args.ResourcePool, args.ReservationID); {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound, "reservation not found")
+	case err != nil:
+		return nil, err
+	}
+	if err := m.syncResourceReservations(ctx, agentRM, args.ResourcePool); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
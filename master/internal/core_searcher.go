@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/determined-ai/determined/master/pkg/check"
 	"github.com/determined-ai/determined/master/pkg/schemas"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
 	"github.com/determined-ai/determined/master/pkg/searcher"
@@ -42,6 +43,9 @@ func (m *Master) getSearcherPreview(c echo.Context) (interface{}, error) {
 	if err = schemas.IsComplete(hc); err != nil {
 		return nil, errors.Wrapf(err, "invalid hyperparameters configuration")
 	}
+	if err = check.Validate(hc); err != nil {
+		return nil, errors.Wrapf(err, "invalid hyperparameters configuration")
+	}
 
 	// Disallow EOL searchers.
 	if err = sc.AssertCurrent(); err != nil {
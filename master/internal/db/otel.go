@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	opentelemetry "github.com/determined-ai/determined/master/pkg/opentelemetry"
+)
+
+// maxTracedStatementLength is how much of a query its span records.
+const maxTracedStatementLength = 4096
+
+// connectTraced connects to Postgres through a driver that traces every statement it runs, as
+// children of the spans in their contexts. Both sqlx and Bun use the connection, so queries are
+// traced whichever of them runs them, including within transactions.
+func connectTraced(url string) (*sqlx.DB, error) {
+	connector, err := stdlib.GetDefaultDriver().(driver.DriverContext).OpenConnector(url)
+	if err != nil {
+		return nil, err
+	}
+	db := sqlx.NewDb(sql.OpenDB(otelConnector{connector}), "pgx")
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// startQuerySpan starts the span of a statement, which the returned function ends.
+func startQuerySpan(ctx context.Context, query string) (context.Context, func(error)) {
+	operation := queryOperation(query)
+	ctx, span := opentelemetry.Tracer().Start(ctx, "db "+operation,
+		trace.WithSpanKind(trace.SpanKindClient))
+	if !span.IsRecording() {
+		return ctx, func(error) { span.End() }
+	}
+	statement := query
+	if len(statement) > maxTracedStatementLength {
+		statement = statement[:maxTracedStatementLength]
+	}
+	span.SetAttributes(
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperationKey.String(operation),
+		semconv.DBStatementKey.String(statement),
+	)
+	return ctx, func(err error) {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// queryOperation returns the first keyword of the query, such as SELECT, skipping comments.
+func queryOperation(query string) string {
+	for _, line := range strings.Split(query, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		return strings.ToUpper(strings.Fields(line)[0])
+	}
+	return ""
+}
+
+// otelConnector opens connections that trace the statements they run.
+type otelConnector struct {
+	driver.Connector
+}
+
+// Connect implements driver.Connector.
+func (c otelConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &otelConn{conn}, nil
+}
+
+// otelConn is a connection that traces the statements it runs. Statements the driver doesn't
+// support running directly are skipped, so that database/sql prepares them instead.
+type otelConn struct {
+	driver.Conn
+}
+
+// ExecContext implements driver.ExecerContext.
+func (c *otelConn) ExecContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, end := startQuerySpan(ctx, query)
+	res, err := execer.ExecContext(ctx, query, args)
+	end(err)
+	return res, err
+}
+
+// QueryContext implements driver.QueryerContext.
+func (c *otelConn) QueryContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, end := startQuerySpan(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	end(err)
+	return rows, err
+}
+
+// PrepareContext implements driver.ConnPrepareContext.
+func (c *otelConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := stmt.(driver.StmtExecContext); !ok {
+		return stmt, nil
+	}
+	if _, ok := stmt.(driver.StmtQueryContext); !ok {
+		return stmt, nil
+	}
+	return &otelStmt{Stmt: stmt, query: query}, nil
+}
+
+// BeginTx implements driver.ConnBeginTx.
+func (c *otelConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // The driver doesn't support BeginTx.
+}
+
+// Ping implements driver.Pinger.
+func (c *otelConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// CheckNamedValue implements driver.NamedValueChecker, which pgx relies on to accept arguments of
+// any type.
+func (c *otelConn) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+	return driver.ErrSkip
+}
+
+// ResetSession implements driver.SessionResetter.
+func (c *otelConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// otelStmt is a prepared statement that traces its runs. Only statements that support contexts
+// are wrapped, so that database/sql can fall back to the others' methods without them.
+type otelStmt struct {
+	driver.Stmt
+	query string
+}
+
+// ExecContext implements driver.StmtExecContext.
+func (s *otelStmt) ExecContext(
+	ctx context.Context, args []driver.NamedValue,
+) (driver.Result, error) {
+	ctx, end := startQuerySpan(ctx, s.query)
+	res, err := s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+	end(err)
+	return res, err
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s *otelStmt) QueryContext(
+	ctx context.Context, args []driver.NamedValue,
+) (driver.Rows, error) {
+	ctx, end := startQuerySpan(ctx, s.query)
+	rows, err := s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+	end(err)
+	return rows, err
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var errFakeQuery = errors.New("fake query failed")
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+
+func (fakeConnector) Driver() driver.Driver { return nil }
+
+// fakeConn is a connection whose statements succeed unless they're queries.
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+
+func (fakeConn) Close() error { return nil }
+
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (fakeConn) ExecContext(
+	context.Context, string, []driver.NamedValue,
+) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeConn) QueryContext(
+	context.Context, string, []driver.NamedValue,
+) (driver.Rows, error) {
+	return nil, errFakeQuery
+}
+
+func TestTracedConnection(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	db := sql.OpenDB(otelConnector{fakeConnector{}})
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, err := db.ExecContext(ctx, "-- Mark the trial done.\n  update trials SET state = $1", 1)
+	require.NoError(t, err)
+	_, err = db.QueryContext(ctx, "SELECT 1") //nolint:rowserrcheck,sqlclosecheck
+	require.ErrorIs(t, err, errFakeQuery)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	update, query := spans[0], spans[1]
+	require.Equal(t, "db UPDATE", update.Name())
+	require.Equal(t, parent.SpanContext().SpanID(), update.Parent().SpanID())
+	require.Equal(t, codes.Unset, update.Status().Code)
+	require.Equal(t, "db SELECT", query.Name())
+	require.Equal(t, codes.Error, query.Status().Code)
+}
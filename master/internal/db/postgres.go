@@ -169,7 +169,7 @@ type PgDB struct {
 func ConnectPostgres(url string) (*PgDB, error) {
 	numTries := 0
 	for {
-		sql, err := sqlx.Connect("pgx", url)
+		sql, err := connectTraced(url)
 		if err == nil {
 			initTheOneBun(sql.DB)
 			return &PgDB{sql: sql, queries: &staticQueryMap{queries: make(map[string]string)}, url: url}, err
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// RetainedCheckpoint is a completed checkpoint of an experiment, as seen by retention policies.
+type RetainedCheckpoint struct {
+	UUID            uuid.UUID   `bun:"uuid" json:"uuid"`
+	ExperimentID    int         `bun:"experiment_id" json:"experiment_id"`
+	ExperimentState model.State `bun:"experiment_state" json:"experiment_state"`
+	ReportTime      time.Time   `bun:"report_time" json:"report_time"`
+	SizeBytes       int64       `bun:"size_bytes" json:"size_bytes"`
+	// Registered is whether the checkpoint is registered as a model version.
+	Registered bool `bun:"registered" json:"registered"`
+	// WarmStarted is whether a trial started from the checkpoint.
+	WarmStarted bool `bun:"warm_started" json:"warm_started"`
+}
+
+// CheckpointRetentionPolicies returns the retention policies of every workspace and project.
+func CheckpointRetentionPolicies(ctx context.Context) ([]model.CheckpointRetentionPolicy, error) {
+	var policies []model.CheckpointRetentionPolicy
+	if err := Bun().NewSelect().Model(&policies).Order("id").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// CheckpointRetentionPoliciesByProject returns the retention policy that applies to each project
+// that has one, either its own or its workspace's.
+func CheckpointRetentionPoliciesByProject(
+	ctx context.Context,
+) (map[int]model.CheckpointRetentionPolicy, error) {
+	policies, err := CheckpointRetentionPolicies(ctx)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	var projects []struct {
+		ID          int `bun:"id"`
+		WorkspaceID int `bun:"workspace_id"`
+	}
+	if err := Bun().NewSelect().Table("projects").
+		Column("id", "workspace_id").
+		Scan(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	byWorkspace := make(map[int]model.CheckpointRetentionPolicy)
+	byProject := make(map[int]model.CheckpointRetentionPolicy)
+	for _, p := range policies {
+		if p.WorkspaceID != nil {
+			byWorkspace[*p.WorkspaceID] = p
+		} else {
+			byProject[*p.ProjectID] = p
+		}
+	}
+	for _, p := range projects {
+		if _, ok := byProject[p.ID]; ok {
+			continue
+		}
+		if policy, ok := byWorkspace[p.WorkspaceID]; ok {
+			byProject[p.ID] = policy
+		}
+	}
+	return byProject, nil
+}
+
+// WorkspaceCheckpointRetentionPolicy returns the retention policy set on a workspace. Returns
+// ErrNotFound if it has none.
+func WorkspaceCheckpointRetentionPolicy(
+	ctx context.Context, workspaceID int,
+) (*model.CheckpointRetentionPolicy, error) {
+	var policy model.CheckpointRetentionPolicy
+	if err := Bun().NewSelect().Model(&policy).
+		Where("workspace_id = ?", workspaceID).
+		Scan(ctx); err != nil {
+		return nil, MatchSentinelError(err)
+	}
+	return &policy, nil
+}
+
+// ProjectCheckpointRetentionPolicy returns the retention policy that applies to a project, either
+// its own or else its workspace's. Returns ErrNotFound if neither has one.
+func ProjectCheckpointRetentionPolicy(
+	ctx context.Context, projectID int,
+) (*model.CheckpointRetentionPolicy, error) {
+	var policy model.CheckpointRetentionPolicy
+	if err := Bun().NewSelect().Model(&policy).
+		Where("project_id = ?", projectID).
+		WhereOr("workspace_id = (SELECT workspace_id FROM projects WHERE id = ?)", projectID).
+		OrderExpr("project_id IS NULL").
+		Limit(1).
+		Scan(ctx); err != nil {
+		return nil, MatchSentinelError(err)
+	}
+	return &policy, nil
+}
+
+// SetCheckpointRetentionPolicy sets the retention policy of a workspace or project, replacing the
+// one it had, if any. Returns ErrNotFound if the workspace or project does not exist.
+func SetCheckpointRetentionPolicy(
+	ctx context.Context, policy *model.CheckpointRetentionPolicy,
+) error {
+	return Bun().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		q := tx.NewDelete().Model((*model.CheckpointRetentionPolicy)(nil))
+		if policy.WorkspaceID != nil {
+			q = q.Where("workspace_id = ?", *policy.WorkspaceID)
+		} else {
+			q = q.Where("project_id = ?", *policy.ProjectID)
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return err
+		}
+		_, err := tx.NewInsert().Model(policy).Exec(ctx)
+		return MatchSentinelError(err)
+	})
+}
+
+// DeleteWorkspaceCheckpointRetentionPolicy deletes the retention policy of a workspace. Returns
+// ErrNotFound if it has none.
+func DeleteWorkspaceCheckpointRetentionPolicy(ctx context.Context, workspaceID int) error {
+	return MustHaveAffectedRows(Bun().NewDelete().Model((*model.CheckpointRetentionPolicy)(nil)).
+		Where("workspace_id = ?", workspaceID).
+		Exec(ctx))
+}
+
+// DeleteProjectCheckpointRetentionPolicy deletes the retention policy of a project. Returns
+// ErrNotFound if it has none.
+func DeleteProjectCheckpointRetentionPolicy(ctx context.Context, projectID int) error {
+	return MustHaveAffectedRows(Bun().NewDelete().Model((*model.CheckpointRetentionPolicy)(nil)).
+		Where("project_id = ?", projectID).
+		Exec(ctx))
+}
+
+// ProjectRetainedCheckpoints returns the completed checkpoints of the experiments in a project,
+// oldest first.
+func ProjectRetainedCheckpoints(
+	ctx context.Context, projectID int,
+) ([]RetainedCheckpoint, error) {
+	var checkpoints []RetainedCheckpoint
+	if err := Bun().NewRaw(`
+SELECT c.uuid, e.id AS experiment_id, e.state AS experiment_state, c.report_time,
+    (SELECT coalesce(sum(r.value::bigint), 0) FROM jsonb_each_text(c.resources) r) AS size_bytes,
+    EXISTS (
+        SELECT 1 FROM model_versions mv WHERE mv.checkpoint_uuid = c.uuid
+    ) AS registered,
+    EXISTS (
+        SELECT 1 FROM trials wt WHERE wt.warm_start_checkpoint_id = c.id
+    ) AS warm_started
+FROM checkpoints_view c
+JOIN trials t ON t.id = c.trial_id
+JOIN experiments e ON e.id = t.experiment_id
+WHERE e.project_id = ? AND c.state = 'COMPLETED'
+ORDER BY c.report_time, c.id`, projectID).Scan(ctx, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
@@ -0,0 +1,113 @@
+//go:build integration
+// +build integration
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/etc"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func TestCheckpointRetentionPolicies(t *testing.T) {
+	require.NoError(t, etc.SetRootPath(RootFromDB))
+	db := MustResolveTestPostgres(t)
+	MustMigrateTestPostgres(t, db, MigrationsFromDB)
+	user := RequireMockUser(t, db)
+	exp := RequireMockExperiment(t, db, user)
+	ctx := context.TODO()
+
+	workspaceID, err := ProjectWorkspaceID(ctx, exp.ProjectID)
+	require.NoError(t, err)
+	defer func() {
+		_ = DeleteWorkspaceCheckpointRetentionPolicy(ctx, workspaceID)
+		_ = DeleteProjectCheckpointRetentionPolicy(ctx, exp.ProjectID)
+	}()
+
+	byWorkspace := &model.CheckpointRetentionPolicy{
+		WorkspaceID: &workspaceID, MaxAgeSeconds: ptrs.Ptr(int64(3600)), KeepRegistered: true,
+	}
+	require.NoError(t, SetCheckpointRetentionPolicy(ctx, byWorkspace))
+
+	// Without its own policy, a project has its workspace's.
+	policy, err := ProjectCheckpointRetentionPolicy(ctx, exp.ProjectID)
+	require.NoError(t, err)
+	require.Equal(t, byWorkspace.ID, policy.ID)
+	byProject, err := CheckpointRetentionPoliciesByProject(ctx)
+	require.NoError(t, err)
+	require.Equal(t, byWorkspace.ID, byProject[exp.ProjectID].ID)
+
+	// The policy of a project replaces its workspace's.
+	own := &model.CheckpointRetentionPolicy{
+		ProjectID: &exp.ProjectID, MaxTotalBytes: ptrs.Ptr(int64(1 << 30)),
+	}
+	require.NoError(t, SetCheckpointRetentionPolicy(ctx, own))
+	replaced := &model.CheckpointRetentionPolicy{
+		ProjectID: &exp.ProjectID, MaxTotalBytes: ptrs.Ptr(int64(1 << 20)),
+	}
+	require.NoError(t, SetCheckpointRetentionPolicy(ctx, replaced))
+	policy, err = ProjectCheckpointRetentionPolicy(ctx, exp.ProjectID)
+	require.NoError(t, err)
+	require.Equal(t, replaced.ID, policy.ID)
+	require.Equal(t, int64(1<<20), *policy.MaxTotalBytes)
+	require.False(t, policy.KeepRegistered)
+	byProject, err = CheckpointRetentionPoliciesByProject(ctx)
+	require.NoError(t, err)
+	require.Equal(t, replaced.ID, byProject[exp.ProjectID].ID)
+
+	missing := -1
+	require.ErrorIs(t, SetCheckpointRetentionPolicy(ctx, &model.CheckpointRetentionPolicy{
+		ProjectID: &missing,
+	}), ErrNotFound)
+
+	require.NoError(t, DeleteProjectCheckpointRetentionPolicy(ctx, exp.ProjectID))
+	require.ErrorIs(t, DeleteProjectCheckpointRetentionPolicy(ctx, exp.ProjectID), ErrNotFound)
+	policy, err = ProjectCheckpointRetentionPolicy(ctx, exp.ProjectID)
+	require.NoError(t, err)
+	require.Equal(t, byWorkspace.ID, policy.ID)
+
+	require.NoError(t, DeleteWorkspaceCheckpointRetentionPolicy(ctx, workspaceID))
+	_, err = WorkspaceCheckpointRetentionPolicy(ctx, workspaceID)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = ProjectCheckpointRetentionPolicy(ctx, exp.ProjectID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestProjectRetainedCheckpoints(t *testing.T) {
+	require.NoError(t, etc.SetRootPath(RootFromDB))
+	db := MustResolveTestPostgres(t)
+	MustMigrateTestPostgres(t, db, MigrationsFromDB)
+	user := RequireMockUser(t, db)
+	exp := RequireMockExperiment(t, db, user)
+	tr := RequireMockTrial(t, db, exp)
+	allocation := RequireMockAllocation(t, db, tr.TaskID)
+	ctx := context.TODO()
+
+	checkpoint := MockModelCheckpoint(uuid.New(), tr, allocation)
+	checkpoint.Resources = map[string]int64{"a": 10, "b": 32}
+	require.NoError(t, db.AddCheckpointMetadata(ctx, &checkpoint))
+	deleted := MockModelCheckpoint(uuid.New(), tr, allocation)
+	require.NoError(t, db.AddCheckpointMetadata(ctx, &deleted))
+	require.NoError(t, db.MarkCheckpointsDeleted([]uuid.UUID{deleted.UUID}))
+
+	checkpoints, err := ProjectRetainedCheckpoints(ctx, exp.ProjectID)
+	require.NoError(t, err)
+	var found []RetainedCheckpoint
+	for _, c := range checkpoints {
+		if c.ExperimentID == exp.ID {
+			found = append(found, c)
+		}
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, checkpoint.UUID, found[0].UUID)
+	require.Equal(t, int64(42), found[0].SizeBytes)
+	require.Equal(t, exp.State, found[0].ExperimentState)
+	require.False(t, found[0].Registered)
+	require.False(t, found[0].WarmStarted)
+}
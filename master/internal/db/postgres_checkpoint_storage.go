@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// Trials and experiments keep running totals of the size and number of their checkpoints that
+// aren't deleted. The totals of projects, workspaces and users are the sums of the totals of their
+// experiments, so they follow experiments that are moved or deleted.
+
+// StorageGroupBy is what a storage report totals checkpoint storage by.
+type StorageGroupBy string
+
+const (
+	// StorageByTrial totals checkpoint storage by trial.
+	StorageByTrial StorageGroupBy = "trial"
+	// StorageByExperiment totals checkpoint storage by experiment.
+	StorageByExperiment StorageGroupBy = "experiment"
+	// StorageByProject totals checkpoint storage by project.
+	StorageByProject StorageGroupBy = "project"
+	// StorageByWorkspace totals checkpoint storage by workspace.
+	StorageByWorkspace StorageGroupBy = "workspace"
+	// StorageByUser totals checkpoint storage by the user that owns the experiments.
+	StorageByUser StorageGroupBy = "user"
+)
+
+var storageReportQueries = map[StorageGroupBy]string{
+	StorageByTrial: `
+SELECT t.id, e.config->>'name' AS name, t.checkpoint_size, t.checkpoint_count
+FROM trials t
+JOIN experiments e ON e.id = t.experiment_id`,
+	StorageByExperiment: `
+SELECT e.id, e.config->>'name' AS name, e.checkpoint_size, e.checkpoint_count
+FROM experiments e`,
+	StorageByProject: `
+SELECT p.id, p.name,
+    coalesce(sum(e.checkpoint_size), 0) AS checkpoint_size,
+    coalesce(sum(e.checkpoint_count), 0) AS checkpoint_count
+FROM projects p
+LEFT JOIN experiments e ON e.project_id = p.id
+GROUP BY p.id`,
+	StorageByWorkspace: `
+SELECT w.id, w.name,
+    coalesce(sum(e.checkpoint_size), 0) AS checkpoint_size,
+    coalesce(sum(e.checkpoint_count), 0) AS checkpoint_count
+FROM workspaces w
+LEFT JOIN projects p ON p.workspace_id = w.id
+LEFT JOIN experiments e ON e.project_id = p.id
+GROUP BY w.id`,
+	StorageByUser: `
+SELECT u.id, u.username AS name,
+    coalesce(sum(e.checkpoint_size), 0) AS checkpoint_size,
+    coalesce(sum(e.checkpoint_count), 0) AS checkpoint_count
+FROM users u
+LEFT JOIN experiments e ON e.owner_id = u.id
+GROUP BY u.id`,
+}
+
+// StorageUsage is the size and number of the checkpoints of a trial, experiment, project,
+// workspace or user that aren't deleted.
+type StorageUsage struct {
+	ID int `bun:"id" json:"id"`
+	// Name is the name of the experiment, project, workspace or user; trials have the name of
+	// their experiment.
+	Name            string `bun:"name" json:"name"`
+	CheckpointSize  int64  `bun:"checkpoint_size" json:"checkpoint_size"`
+	CheckpointCount int    `bun:"checkpoint_count" json:"checkpoint_count"`
+}
+
+// StorageReport returns the checkpoint storage of every trial, experiment, project, workspace or
+// user, largest first. Returns at most limit entries if limit is positive.
+func StorageReport(
+	ctx context.Context, groupBy StorageGroupBy, limit int,
+) ([]StorageUsage, error) {
+	query, ok := storageReportQueries[groupBy]
+	if !ok {
+		return nil, errors.Errorf("invalid storage grouping %q", groupBy)
+	}
+	query = fmt.Sprintf("SELECT * FROM (%s) s ORDER BY checkpoint_size DESC, id", query)
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	usage := []StorageUsage{}
+	if err := Bun().NewRaw(query).Scan(ctx, &usage); err != nil {
+		return nil, errors.Wrapf(err, "querying storage by %s", groupBy)
+	}
+	return usage, nil
+}
+
+// addCheckpointStorage adds a checkpoint reported by a task to the storage totals of its trial and
+// experiment, if the task is a trial.
+func addCheckpointStorage(ctx context.Context, tx *sqlx.Tx, c *model.CheckpointV2) error {
+	if c.State == model.DeletedState {
+		return nil
+	}
+	var size int64
+	for _, s := range c.Resources {
+		size += s
+	}
+	if _, err := tx.ExecContext(ctx, `
+WITH t AS (
+    UPDATE trials
+    SET checkpoint_size = checkpoint_size + $2, checkpoint_count = checkpoint_count + 1
+    WHERE task_id = $1
+    RETURNING experiment_id
+)
+UPDATE experiments e
+SET checkpoint_size = e.checkpoint_size + $2, checkpoint_count = e.checkpoint_count + 1
+FROM t
+WHERE e.id = t.experiment_id`, c.TaskID, size); err != nil {
+		return errors.Wrap(err, "adding checkpoint storage")
+	}
+	return nil
+}
+
+// releaseCheckpointStorageQuery wraps a statement that deletes checkpoints, returning the trial_id
+// and resources of each one, so that it also subtracts them from the storage totals of their trials
+// and experiments.
+func releaseCheckpointStorageQuery(deleteCheckpoints string) string {
+	return fmt.Sprintf(`
+WITH deleted AS (%s
+), usage AS (
+    SELECT t.id AS trial_id, t.experiment_id, count(*) AS count, coalesce(sum(s.size), 0) AS size
+    FROM deleted d
+    JOIN trials t ON t.id = d.trial_id,
+        LATERAL (
+            SELECT coalesce(sum(r.value::bigint), 0) AS size
+            FROM jsonb_each_text(d.resources) r
+        ) s
+    GROUP BY t.id, t.experiment_id
+), trial_usage AS (
+    UPDATE trials t
+    SET checkpoint_size = t.checkpoint_size - u.size,
+        checkpoint_count = t.checkpoint_count - u.count
+    FROM usage u
+    WHERE t.id = u.trial_id
+)
+UPDATE experiments e
+SET checkpoint_size = e.checkpoint_size - u.size, checkpoint_count = e.checkpoint_count - u.count
+FROM (
+    SELECT experiment_id, sum(size) AS size, sum(count) AS count
+    FROM usage
+    GROUP BY experiment_id
+) u
+WHERE e.id = u.experiment_id`, deleteCheckpoints)
+}
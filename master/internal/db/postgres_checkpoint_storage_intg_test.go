@@ -0,0 +1,87 @@
+//go:build integration
+// +build integration
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/etc"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestCheckpointStorage(t *testing.T) {
+	require.NoError(t, etc.SetRootPath(RootFromDB))
+	db := MustResolveTestPostgres(t)
+	MustMigrateTestPostgres(t, db, MigrationsFromDB)
+	user := RequireMockUser(t, db)
+	exp := RequireMockExperiment(t, db, user)
+	tr := RequireMockTrial(t, db, exp)
+	allocation := RequireMockAllocation(t, db, tr.TaskID)
+	ctx := context.TODO()
+
+	requireStorage := func(size int64, count int) {
+		var trialUsage, expUsage StorageUsage
+		require.NoError(t, Bun().NewRaw(
+			"SELECT checkpoint_size, checkpoint_count FROM trials WHERE id = ?", tr.ID,
+		).Scan(ctx, &trialUsage))
+		require.NoError(t, Bun().NewRaw(
+			"SELECT checkpoint_size, checkpoint_count FROM experiments WHERE id = ?", exp.ID,
+		).Scan(ctx, &expUsage))
+		require.Equal(t, size, trialUsage.CheckpointSize)
+		require.Equal(t, count, trialUsage.CheckpointCount)
+		require.Equal(t, size, expUsage.CheckpointSize)
+		require.Equal(t, count, expUsage.CheckpointCount)
+	}
+	requireStorage(0, 0)
+
+	first := MockModelCheckpoint(uuid.New(), tr, allocation)
+	first.Resources = map[string]int64{"a": 10, "b": 32}
+	require.NoError(t, db.AddCheckpointMetadata(ctx, &first))
+	second := MockModelCheckpoint(uuid.New(), tr, allocation)
+	second.Resources = map[string]int64{"c": 100}
+	require.NoError(t, db.AddCheckpointMetadata(ctx, &second))
+	deleted := MockModelCheckpoint(uuid.New(), tr, allocation)
+	deleted.State = model.DeletedState
+	require.NoError(t, db.AddCheckpointMetadata(ctx, &deleted))
+	requireStorage(142, 2)
+
+	// Deleting a checkpoint twice only releases its storage once.
+	require.NoError(t, db.MarkCheckpointsDeleted([]uuid.UUID{first.UUID}))
+	require.NoError(t, db.MarkCheckpointsDeleted([]uuid.UUID{first.UUID, deleted.UUID}))
+	requireStorage(100, 1)
+
+	for _, groupBy := range []StorageGroupBy{
+		StorageByTrial, StorageByExperiment, StorageByProject, StorageByWorkspace, StorageByUser,
+	} {
+		report, err := StorageReport(ctx, groupBy, 0)
+		require.NoError(t, err)
+		for i := 1; i < len(report); i++ {
+			require.GreaterOrEqual(t, report[i-1].CheckpointSize, report[i].CheckpointSize)
+		}
+	}
+
+	report, err := StorageReport(ctx, StorageByUser, 0)
+	require.NoError(t, err)
+	var found bool
+	for _, u := range report {
+		if u.ID == int(user.ID) {
+			found = true
+			require.Equal(t, user.Username, u.Name)
+			require.Equal(t, int64(100), u.CheckpointSize)
+			require.Equal(t, 1, u.CheckpointCount)
+		}
+	}
+	require.True(t, found)
+
+	report, err = StorageReport(ctx, StorageByExperiment, 1)
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+
+	_, err = StorageReport(ctx, "model", 0)
+	require.Error(t, err)
+}
@@ -1,19 +1,30 @@
 package db
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
 	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
 )
 
 // CheckpointByUUID looks up a checkpoint by UUID, returning nil if none exists.
 func (db *PgDB) CheckpointByUUID(id uuid.UUID) (*model.Checkpoint, error) {
 	var checkpoint model.Checkpoint
+	// Imported checkpoints have no trial, so their training columns are NULL.
 	if err := db.query(`
-	SELECT * FROM checkpoints_view c
+	SELECT c.id, c.uuid, c.task_id, c.allocation_id, c.report_time, c.state, c.resources,
+		c.metadata, COALESCE(c.trial_id, 0) AS trial_id,
+		COALESCE(c.experiment_id, 0) AS experiment_id, c.experiment_config, c.hparams,
+		c.training_metrics, c.validation_metrics, c.searcher_metric,
+		COALESCE(c.steps_completed, 0) AS steps_completed, c.checkpoint_version
+	FROM checkpoints_view c
 	WHERE c.uuid = $1`, &checkpoint, id.String()); errors.Cause(err) == ErrNotFound {
 		return nil, nil
 	} else if err != nil {
@@ -22,6 +33,50 @@ func (db *PgDB) CheckpointByUUID(id uuid.UUID) (*model.Checkpoint, error) {
 	return &checkpoint, nil
 }
 
+// AddImportedCheckpoint inserts a checkpoint that was uploaded rather than reported by a trial,
+// along with the storage it was uploaded to.
+func (db *PgDB) AddImportedCheckpoint(
+	ctx context.Context, c *model.CheckpointV2, storage expconf.CheckpointStorageConfig,
+) error {
+	storageJSON, err := json.Marshal(storage)
+	if err != nil {
+		return errors.Wrap(err, "marshaling checkpoint storage config")
+	}
+	if _, err := db.sql.ExecContext(ctx, `
+INSERT INTO checkpoints_v2
+	(uuid, task_id, allocation_id, report_time, state, resources, metadata, storage_config)
+VALUES
+	($1, NULL, NULL, $2, $3, $4, $5, $6)`,
+		c.UUID, c.ReportTime, c.State, c.Resources, c.Metadata, storageJSON,
+	); err != nil {
+		return errors.Wrap(err, "inserting imported checkpoint")
+	}
+	return nil
+}
+
+// ImportedCheckpointStorageConfig returns the storage an imported checkpoint was uploaded to, or
+// nil if the checkpoint was not imported.
+func (db *PgDB) ImportedCheckpointStorageConfig(
+	id uuid.UUID,
+) (*expconf.CheckpointStorageConfig, error) {
+	var raw []byte
+	switch err := db.sql.QueryRowx(
+		`SELECT storage_config FROM checkpoints_v2 WHERE uuid = $1`, id,
+	).Scan(&raw); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "error querying for checkpoint storage (%v)", id)
+	case raw == nil:
+		return nil, nil
+	}
+	var storage expconf.CheckpointStorageConfig
+	if err := json.Unmarshal(raw, &storage); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling checkpoint storage config")
+	}
+	return &storage, nil
+}
+
 // CheckpointByUUIDs looks up a checkpoint by list of UUIDS, returning nil if error.
 func (db *PgDB) CheckpointByUUIDs(ckptUUIDs []uuid.UUID) ([]model.Checkpoint, error) {
 	var checkpoints []model.Checkpoint
@@ -57,23 +112,31 @@ func (db *PgDB) GetRegisteredCheckpoints(checkpoints []uuid.UUID) (map[uuid.UUID
 	return checkpointIDs, nil
 }
 
-// MarkCheckpointsDeleted updates the provided delete checkpoints to DELETED state.
+// MarkCheckpointsDeleted updates the provided delete checkpoints to DELETED state and releases
+// their storage.
 func (db *PgDB) MarkCheckpointsDeleted(deleteCheckpoints []uuid.UUID) error {
-	_, err := db.sql.Exec(`UPDATE raw_checkpoints c
+	return db.withTransaction("mark checkpoints deleted", func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(releaseCheckpointStorageQuery(`
+    UPDATE raw_checkpoints c
     SET state = 'DELETED'
-    WHERE c.uuid IN (SELECT UNNEST($1::uuid[]))`, deleteCheckpoints)
-	if err != nil {
-		return fmt.Errorf("deleting checkpoints from raw_checkpoints: %w", err)
-	}
+    WHERE c.uuid IN (SELECT UNNEST($1::uuid[])) AND c.state != 'DELETED'
+    RETURNING c.trial_id, c.resources`), deleteCheckpoints)
+		if err != nil {
+			return fmt.Errorf("deleting checkpoints from raw_checkpoints: %w", err)
+		}
 
-	_, err = db.sql.Exec(`UPDATE checkpoints_v2 c
+		_, err = tx.Exec(releaseCheckpointStorageQuery(`
+    UPDATE checkpoints_v2 c
     SET state = 'DELETED'
-    WHERE c.uuid IN (SELECT UNNEST($1::uuid[]))`, deleteCheckpoints)
-	if err != nil {
-		return fmt.Errorf("deleting checkpoints from checkpoints_v2: %w", err)
-	}
+    WHERE c.uuid IN (SELECT UNNEST($1::uuid[])) AND c.state != 'DELETED'
+    RETURNING (SELECT t.id FROM trials t WHERE t.task_id = c.task_id) AS trial_id, c.resources`),
+			deleteCheckpoints)
+		if err != nil {
+			return fmt.Errorf("deleting checkpoints from checkpoints_v2: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // ExperimentCheckpointGrouping represents a mapping of checkpoint uuids to experiment id.
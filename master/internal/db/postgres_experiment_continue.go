@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+// ContinueExperiment reactivates a completed experiment with the configuration its search is
+// continued with. It returns ErrNotFound if the experiment is not completed.
+func ContinueExperiment(
+	ctx context.Context, experimentID int, config expconf.ExperimentConfig,
+) error {
+	res, err := Bun().ExecContext(ctx, `
+UPDATE experiments
+SET state = ?, end_time = NULL, config = ?
+WHERE id = ? AND state = ?`,
+		model.ActiveState, config, experimentID, model.CompletedState,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "continuing experiment %d", experimentID)
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return errors.Wrapf(err, "continuing experiment %d", experimentID)
+	} else if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ReopenTrial reactivates a completed trial of a continued experiment, so that it can be trained
+// further.
+func ReopenTrial(ctx context.Context, trialID int) error {
+	return Bun().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+UPDATE trials
+SET state = ?, end_time = NULL
+WHERE id = ? AND state = ?`,
+			model.ActiveState, trialID, model.CompletedState,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "reopening trial %d", trialID)
+		}
+		if rows, err := res.RowsAffected(); err != nil {
+			return errors.Wrapf(err, "reopening trial %d", trialID)
+		} else if rows == 0 {
+			return errors.Errorf("trial %d is not completed", trialID)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+UPDATE tasks
+SET end_time = NULL
+FROM trials
+WHERE trials.id = ? AND tasks.task_id = trials.task_id`, trialID,
+		); err != nil {
+			return errors.Wrapf(err, "reopening the task of trial %d", trialID)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// ParetoFrontTrial is a trial on the Pareto front of an experiment that ranks trials by several
+// metrics.
+type ParetoFrontTrial struct {
+	ID      int                    `bun:"id" json:"id"`
+	HParams map[string]interface{} `bun:"hparams" json:"hparams"`
+	// ValidationMetrics are the metrics of the latest validation of the trial.
+	ValidationMetrics map[string]interface{} `bun:"validation_metrics" json:"validation_metrics"`
+}
+
+// LatestValidationMetrics returns the metrics of the latest validation of a trial of an experiment.
+func LatestValidationMetrics(
+	ctx context.Context, experimentID int, requestID model.RequestID,
+) (map[string]interface{}, error) {
+	var validation struct {
+		Metrics map[string]interface{} `bun:"validation_metrics"`
+	}
+	switch err := Bun().NewRaw(`
+SELECT v.metrics->'validation_metrics' AS validation_metrics
+FROM validations v
+JOIN trials t ON t.id = v.trial_id
+WHERE t.experiment_id = ? AND t.request_id = ?
+ORDER BY v.total_batches DESC
+LIMIT 1`, experimentID, requestID).Scan(ctx, &validation); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrNotFound
+	case err != nil:
+		return nil, errors.Wrapf(err, "querying latest validation of trial %s", requestID)
+	}
+	return validation.Metrics, nil
+}
+
+// SetExperimentParetoFront replaces the trials on the Pareto front of an experiment.
+func SetExperimentParetoFront(
+	ctx context.Context, experimentID int, requestIDs []model.RequestID,
+) error {
+	return Bun().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+DELETE FROM experiment_pareto_fronts WHERE experiment_id = ?`, experimentID,
+		); err != nil {
+			return errors.Wrap(err, "deleting Pareto front")
+		}
+		if len(requestIDs) == 0 {
+			return nil
+		}
+		ids := make([]string, 0, len(requestIDs))
+		for _, requestID := range requestIDs {
+			ids = append(ids, requestID.String())
+		}
+		if _, err := tx.ExecContext(ctx, `
+INSERT INTO experiment_pareto_fronts (experiment_id, trial_id)
+SELECT experiment_id, id
+FROM trials
+WHERE experiment_id = ? AND request_id IN (?)`, experimentID, bun.In(ids),
+		); err != nil {
+			return errors.Wrap(err, "saving Pareto front")
+		}
+		return nil
+	})
+}
+
+// ExperimentParetoFront returns the trials on the Pareto front of an experiment.
+func ExperimentParetoFront(ctx context.Context, experimentID int) ([]ParetoFrontTrial, error) {
+	trials := []ParetoFrontTrial{}
+	if err := Bun().NewRaw(`
+SELECT t.id, t.hparams, v.metrics->'validation_metrics' AS validation_metrics
+FROM experiment_pareto_fronts f
+JOIN trials t ON t.id = f.trial_id
+LEFT JOIN LATERAL (
+    SELECT metrics
+    FROM validations
+    WHERE trial_id = t.id
+    ORDER BY total_batches DESC
+    LIMIT 1
+) v ON true
+WHERE f.experiment_id = ?
+ORDER BY t.id`, experimentID).Scan(ctx, &trials); err != nil {
+		return nil, errors.Wrap(err, "querying Pareto front")
+	}
+	return trials, nil
+}
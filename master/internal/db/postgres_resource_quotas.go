@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// ResourceQuotas returns the quotas of every resource pool.
+func ResourceQuotas(ctx context.Context) ([]model.ResourceQuota, error) {
+	var quotas []model.ResourceQuota
+	if err := Bun().NewSelect().Model(&quotas).Order("id").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
+
+// ResourceQuotasByPool returns the quotas of a resource pool.
+func ResourceQuotasByPool(ctx context.Context, pool string) ([]model.ResourceQuota, error) {
+	var quotas []model.ResourceQuota
+	if err := Bun().NewSelect().Model(&quotas).
+		Where("resource_pool = ?", pool).
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
+
+// SetResourceQuota sets the quota of a workspace or user in a resource pool, replacing the one it
+// had, if any. Returns ErrNotFound if the workspace or user does not exist.
+func SetResourceQuota(ctx context.Context, quota *model.ResourceQuota) error {
+	return Bun().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		q := tx.NewDelete().Model((*model.ResourceQuota)(nil)).
+			Where("resource_pool = ?", quota.ResourcePool)
+		if quota.WorkspaceID != nil {
+			q = q.Where("workspace_id = ?", *quota.WorkspaceID)
+		} else {
+			q = q.Where("user_id = ?", *quota.UserID)
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return err
+		}
+		_, err := tx.NewInsert().Model(quota).Exec(ctx)
+		return MatchSentinelError(err)
+	})
+}
+
+// DeleteResourceQuota deletes a quota of a resource pool. Returns ErrNotFound if it does not
+// exist.
+func DeleteResourceQuota(ctx context.Context, pool string, id int) error {
+	return MustHaveAffectedRows(Bun().NewDelete().Model((*model.ResourceQuota)(nil)).
+		Where("resource_pool = ?", pool).
+		Where("id = ?", id).
+		Exec(ctx))
+}
+
+// ProjectWorkspaceID returns the ID of the workspace that a project belongs to.
+func ProjectWorkspaceID(ctx context.Context, projectID int) (int, error) {
+	var workspaceID int
+	if err := Bun().NewSelect().Table("projects").
+		Column("workspace_id").
+		Where("id = ?", projectID).
+		Scan(ctx, &workspaceID); err != nil {
+		return 0, MatchSentinelError(err)
+	}
+	return workspaceID, nil
+}
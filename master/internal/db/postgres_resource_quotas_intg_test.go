@@ -0,0 +1,59 @@
+//go:build integration
+// +build integration
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/etc"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestResourceQuotas(t *testing.T) {
+	require.NoError(t, etc.SetRootPath(RootFromDB))
+	db := MustResolveTestPostgres(t)
+	MustMigrateTestPostgres(t, db, MigrationsFromDB)
+	user := RequireMockUser(t, db)
+	exp := RequireMockExperiment(t, db, user)
+	ctx := context.TODO()
+	pool := uuid.NewString()
+
+	workspaceID, err := ProjectWorkspaceID(ctx, exp.ProjectID)
+	require.NoError(t, err)
+
+	byUser := &model.ResourceQuota{ResourcePool: pool, UserID: &user.ID, MaxSlots: 4}
+	require.NoError(t, SetResourceQuota(ctx, byUser))
+	byWorkspace := &model.ResourceQuota{ResourcePool: pool, WorkspaceID: &workspaceID, MaxSlots: 8}
+	require.NoError(t, SetResourceQuota(ctx, byWorkspace))
+
+	// Setting the quota of a user again replaces it.
+	maxQueued := 2
+	replaced := &model.ResourceQuota{
+		ResourcePool: pool, UserID: &user.ID, MaxSlots: 2, MaxQueuedJobs: &maxQueued,
+	}
+	require.NoError(t, SetResourceQuota(ctx, replaced))
+
+	quotas, err := ResourceQuotasByPool(ctx, pool)
+	require.NoError(t, err)
+	require.Len(t, quotas, 2)
+	require.Equal(t, byWorkspace.ID, quotas[0].ID)
+	require.Equal(t, replaced.ID, quotas[1].ID)
+	require.Equal(t, 2, quotas[1].MaxSlots)
+	require.Equal(t, &maxQueued, quotas[1].MaxQueuedJobs)
+
+	missing := model.UserID(-1)
+	require.ErrorIs(t, SetResourceQuota(ctx, &model.ResourceQuota{
+		ResourcePool: pool, UserID: &missing, MaxSlots: 1,
+	}), ErrNotFound)
+
+	require.NoError(t, DeleteResourceQuota(ctx, pool, byWorkspace.ID))
+	require.ErrorIs(t, DeleteResourceQuota(ctx, pool, byWorkspace.ID), ErrNotFound)
+	quotas, err = ResourceQuotasByPool(ctx, pool)
+	require.NoError(t, err)
+	require.Len(t, quotas, 1)
+}
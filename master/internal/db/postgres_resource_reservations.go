@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// ResourceReservations returns the reservations of every resource pool.
+func ResourceReservations(ctx context.Context) ([]model.ResourceReservation, error) {
+	var reservations []model.ResourceReservation
+	if err := Bun().NewSelect().Model(&reservations).Order("id").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// ResourceReservationsByPool returns the reservations of a resource pool.
+func ResourceReservationsByPool(
+	ctx context.Context, pool string,
+) ([]model.ResourceReservation, error) {
+	var reservations []model.ResourceReservation
+	if err := Bun().NewSelect().Model(&reservations).
+		Where("resource_pool = ?", pool).
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// AddResourceReservation adds a reservation to a resource pool. Returns ErrNotFound if its
+// workspace or user does not exist.
+func AddResourceReservation(ctx context.Context, reservation *model.ResourceReservation) error {
+	_, err := Bun().NewInsert().Model(reservation).Exec(ctx)
+	return MatchSentinelError(err)
+}
+
+// UpdateResourceReservation replaces a reservation of a resource pool. Returns ErrNotFound if the
+// reservation, or its workspace or user, does not exist.
+func UpdateResourceReservation(ctx context.Context, reservation *model.ResourceReservation) error {
+	res, err := Bun().NewUpdate().Model(reservation).
+		ExcludeColumn("id").
+		Where("id = ?", reservation.ID).
+		Where("resource_pool = ?", reservation.ResourcePool).
+		Exec(ctx)
+	if err != nil {
+		return MatchSentinelError(err)
+	}
+	return MustHaveAffectedRows(res, nil)
+}
+
+// DeleteResourceReservation deletes a reservation of a resource pool. Returns ErrNotFound if it
+// does not exist.
+func DeleteResourceReservation(ctx context.Context, pool string, id int) error {
+	return MustHaveAffectedRows(Bun().NewDelete().Model((*model.ResourceReservation)(nil)).
+		Where("resource_pool = ?", pool).
+		Where("id = ?", id).
+		Exec(ctx))
+}
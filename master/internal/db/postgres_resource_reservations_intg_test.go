@@ -0,0 +1,64 @@
+//go:build integration
+// +build integration
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/etc"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func TestResourceReservations(t *testing.T) {
+	require.NoError(t, etc.SetRootPath(RootFromDB))
+	db := MustResolveTestPostgres(t)
+	MustMigrateTestPostgres(t, db, MigrationsFromDB)
+	user := RequireMockUser(t, db)
+	ctx := context.TODO()
+	pool := uuid.NewString()
+
+	weekly := &model.ResourceReservation{
+		ResourcePool: pool,
+		UserID:       &user.ID,
+		Slots:        16,
+		Weekdays:     []string{"monday", "friday"},
+		DailyStart:   ptrs.Ptr("09:00"),
+		DailyEnd:     ptrs.Ptr("18:00"),
+	}
+	require.NoError(t, AddResourceReservation(ctx, weekly))
+
+	reservations, err := ResourceReservationsByPool(ctx, pool)
+	require.NoError(t, err)
+	require.Len(t, reservations, 1)
+	require.Equal(t, []string{"monday", "friday"}, reservations[0].Weekdays)
+	require.Equal(t, "18:00", *reservations[0].DailyEnd)
+
+	// Updating a reservation replaces its window.
+	start := time.Now().UTC().Truncate(time.Second)
+	weekly.Weekdays, weekly.DailyStart, weekly.DailyEnd = nil, nil, nil
+	weekly.StartTime, weekly.EndTime = &start, ptrs.Ptr(start.Add(time.Hour))
+	require.NoError(t, UpdateResourceReservation(ctx, weekly))
+	reservations, err = ResourceReservationsByPool(ctx, pool)
+	require.NoError(t, err)
+	require.Nil(t, reservations[0].DailyStart)
+	require.True(t, start.Equal(*reservations[0].StartTime))
+
+	missing := model.UserID(-1)
+	require.ErrorIs(t, AddResourceReservation(ctx, &model.ResourceReservation{
+		ResourcePool: pool, UserID: &missing, Slots: 1,
+		StartTime: &start, EndTime: ptrs.Ptr(start.Add(time.Hour)),
+	}), ErrNotFound)
+	require.ErrorIs(t, UpdateResourceReservation(ctx, &model.ResourceReservation{
+		ID: weekly.ID, ResourcePool: uuid.NewString(), UserID: &user.ID, Slots: 1,
+	}), ErrNotFound)
+
+	require.NoError(t, DeleteResourceReservation(ctx, pool, weekly.ID))
+	require.ErrorIs(t, DeleteResourceReservation(ctx, pool, weekly.ID), ErrNotFound)
+}
@@ -59,14 +59,15 @@ WHERE experiment_id = $1`, experimentID); err != nil {
 }
 
 // DeleteSnapshotsForTerminalExperiments deletes all snapshots for
-// terminal state experiments from the database.
+// canceled and errored experiments from the database. Completed experiments
+// keep their snapshots so that their searches can be continued.
 func (db *PgDB) DeleteSnapshotsForTerminalExperiments() error {
 	if _, err := db.sql.Exec(`
 DELETE FROM experiment_snapshots
 WHERE experiment_id IN (
 	SELECT id
 	FROM experiments
-	WHERE state IN ('COMPLETED', 'CANCELED', 'ERROR'))`); err != nil {
+	WHERE state IN ('CANCELED', 'ERROR'))`); err != nil {
 		return errors.Wrap(err, "failed to delete experiment snapshots")
 	}
 	return nil
@@ -306,11 +306,12 @@ INSERT INTO checkpoints_v2
 VALUES
 	(:uuid, :task_id, :allocation_id, :report_time, :state, :resources, :metadata)`
 
-	if _, err := db.sql.NamedExecContext(ctx, query, m); err != nil {
-		return errors.Wrap(err, "inserting checkpoint")
-	}
-
-	return nil
+	return db.withTransaction("add checkpoint metadata", func(tx *sqlx.Tx) error {
+		if _, err := tx.NamedExecContext(ctx, query, m); err != nil {
+			return errors.Wrap(err, "inserting checkpoint")
+		}
+		return addCheckpointStorage(ctx, tx, m)
+	})
 }
 
 func checkTrialRunID(ctx context.Context, tx *sqlx.Tx, trialID, runID int32) error {
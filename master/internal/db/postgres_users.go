@@ -60,8 +60,9 @@ func (db *PgDB) DeleteUserSessionByID(sessionID model.SessionID) error {
 func addUser(tx *sqlx.Tx, user *model.User) (model.UserID, error) {
 	stmt, err := tx.PrepareNamed(`
 INSERT INTO users
-(username, admin, active, password_hash, display_name)
-VALUES (:username, :admin, :active, :password_hash, :display_name)
+(username, admin, active, password_hash, display_name, service_account, password_change_required)
+VALUES (:username, :admin, :active, :password_hash, :display_name, :service_account,
+	:password_change_required)
 RETURNING id`)
 	if err != nil {
 		return 0, errors.WithStack(err)
@@ -187,6 +188,24 @@ func (db *PgDB) UpdateUser(updated *model.User, toUpdate []string, ug *model.Age
 		}
 	}()
 
+	var updatePassword bool
+	for _, e := range toUpdate {
+		if e == "password_hash" {
+			updatePassword = true
+			break
+		}
+	}
+
+	if updatePassword {
+		// Keep the password being replaced so that password policies can forbid reusing it.
+		query := `
+INSERT INTO user_password_history (user_id, password_hash)
+SELECT id, password_hash FROM users WHERE id = $1 AND password_hash IS NOT NULL`
+		if _, err = tx.Exec(query, updated.ID); err != nil {
+			return errors.Wrap(err, "error saving password history")
+		}
+	}
+
 	if len(toUpdate) > 0 {
 		query := fmt.Sprintf(
 			"UPDATE users %v WHERE id = :id",
@@ -197,14 +216,6 @@ func (db *PgDB) UpdateUser(updated *model.User, toUpdate []string, ug *model.Age
 		}
 	}
 
-	var updatePassword bool
-	for _, e := range toUpdate {
-		if e == "password_hash" {
-			updatePassword = true
-			break
-		}
-	}
-
 	if updatePassword {
 		query := "DELETE FROM user_sessions WHERE user_id = $1"
 		if _, err = tx.Exec(query, updated.ID); err != nil {
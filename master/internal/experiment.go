@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -17,6 +19,7 @@ import (
 	"github.com/determined-ai/determined/master/internal/rm"
 	"github.com/determined-ai/determined/master/internal/task"
 	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/internal/webhooks"
 
 	"github.com/determined-ai/determined/master/internal/db"
 	"github.com/determined-ai/determined/master/internal/hpimportance"
@@ -25,6 +28,7 @@ import (
 	"github.com/determined-ai/determined/master/pkg/actor"
 	"github.com/determined-ai/determined/master/pkg/logger"
 	"github.com/determined-ai/determined/master/pkg/model"
+	opentelemetry "github.com/determined-ai/determined/master/pkg/opentelemetry"
 	"github.com/determined-ai/determined/master/pkg/ptrs"
 	"github.com/determined-ai/determined/master/pkg/schemas"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
@@ -76,6 +80,12 @@ type (
 	UnwatchEvents struct {
 		id uuid.UUID
 	}
+
+	// experimentMoved is sent when the experiment is moved to another project, which may be in
+	// another workspace.
+	experimentMoved struct {
+		projectID int
+	}
 )
 
 type (
@@ -107,6 +117,13 @@ type (
 
 		faultToleranceEnabled bool
 		restored              bool
+		// continued is set when a completed experiment is restored to continue its search.
+		continued bool
+
+		// span traces the experiment from when its actor starts until it reaches a terminal state.
+		span trace.Span
+		// spanCtx carries span, for work the experiment does outside of its actor.
+		spanCtx context.Context
 
 		logCtx logger.Context
 	}
@@ -186,10 +203,16 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 	// Searcher-related messages.
 	case actor.PreStart:
 		ctx.AddLabels(e.logCtx)
+		e.spanCtx, e.span = opentelemetry.Tracer().Start(context.Background(), "experiment",
+			trace.WithAttributes(
+				opentelemetry.ExperimentIDKey.Int(e.ID),
+				opentelemetry.JobIDKey.String(string(e.JobID)),
+			))
 		e.rm.SetGroupMaxSlots(ctx, sproto.SetGroupMaxSlots{
 			MaxSlots: e.Config.Resources().MaxSlots(),
 			Handler:  ctx.Self(),
 		})
+		e.setOwner(ctx)
 		if err := e.setWeight(ctx, e.Config.Resources().Weight()); err != nil {
 			e.updateState(ctx, model.StateWithReason{
 				State:               model.StoppingErrorState,
@@ -229,6 +252,10 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 			}
 
 			e.restoreTrials(ctx)
+			if e.continued {
+				ops, err := e.searcher.Continue()
+				e.processOperations(ctx, ops, err)
+			}
 			return nil
 		}
 
@@ -261,11 +288,29 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 			return nil
 		}
 
+		var objectives []float64
+		if metrics := e.Config.Searcher().Metrics(); metrics != nil {
+			validationMetrics, err := db.LatestValidationMetrics(
+				context.TODO(), e.ID, msg.requestID,
+			)
+			if err != nil {
+				ctx.Respond(errors.Wrap(err, "looking up searcher metrics"))
+				return nil
+			}
+			if objectives, err = searcher.Objectives(metrics, validationMetrics); err != nil {
+				ctx.Respond(api.AsValidationError("%v", err))
+				return nil
+			}
+		}
+
 		state.Complete = true
 		e.TrialSearcherState[msg.op.RequestID] = state
 		ctx.Tell(ctx.Child(msg.op.RequestID), state)
-		ops, err := e.searcher.ValidationCompleted(msg.requestID, msg.metric, msg.op)
+		ops, err := e.searcher.ValidationCompleted(msg.requestID, msg.metric, objectives, msg.op)
 		e.processOperations(ctx, ops, err)
+		if objectives != nil {
+			e.saveParetoFront(ctx)
+		}
 	case trialReportEarlyExit:
 		state, ok := e.TrialSearcherState[msg.requestID]
 		if !ok {
@@ -346,6 +391,10 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 			ctx.Respond(err)
 		}
 
+	case experimentMoved:
+		e.ProjectID = msg.projectID
+		e.setOwner(ctx)
+
 	case sproto.RegisterJobPosition:
 		err := e.db.UpdateJobPosition(msg.JobID, msg.JobPosition)
 		if err != nil {
@@ -354,6 +403,11 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 
 	// Experiment shutdown logic.
 	case actor.PostStop:
+		defer func() {
+			e.span.SetAttributes(opentelemetry.StateKey.String(string(e.State)))
+			e.span.End()
+		}()
+
 		if e.State == model.CompletedState || e.State == model.StoppingCompletedState {
 			if err := e.db.SaveExperimentProgress(e.ID, ptrs.Ptr(1.0)); err != nil {
 				ctx.Log().Error(err)
@@ -371,6 +425,7 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 			return errors.New("experiment is already in a terminal state")
 		}
 		telemetry.ReportExperimentStateChanged(ctx.Self().System(), e.db, *e.Experiment)
+		e.reportWebhookStateChanged()
 
 		if err := e.db.SaveExperimentState(e.Experiment); err != nil {
 			return err
@@ -405,9 +460,12 @@ func (e *experiment) Receive(ctx *actor.Context) error {
 			ctx.Tell(e.hpImportance, hpimportance.ExperimentCompleted{ID: e.ID})
 		}
 
-		if err := e.db.DeleteSnapshotsForExperiment(e.Experiment.ID); err != nil {
-			ctx.Log().WithError(err).Errorf(
-				"failure to delete snapshots for experiment: %d", e.Experiment.ID)
+		// Completed searches keep their snapshot so that they can be continued.
+		if e.State != model.CompletedState {
+			if err := e.db.DeleteSnapshotsForExperiment(e.Experiment.ID); err != nil {
+				ctx.Log().WithError(err).Errorf(
+					"failure to delete snapshots for experiment: %d", e.Experiment.ID)
+			}
 		}
 
 		if err := e.db.DeleteUserSessionByToken(taskSpec.UserSessionToken); err != nil {
@@ -614,14 +672,27 @@ func (e *experiment) processOperations(
 			config := schemas.Copy(e.Config).(expconf.ExperimentConfig)
 			state := trialSearcherState{Create: op, Complete: true}
 			e.TrialSearcherState[op.RequestID] = state
-			ctx.ActorOf(op.RequestID, newTrial(
+			t := newTrial(
 				e.logCtx, trialTaskID(e.ID, op.RequestID), e.JobID, e.StartTime, e.ID, e.State,
 				state, e.taskLogger, e.rm, e.db, config, checkpoint, e.taskSpec, false,
-			))
+			)
+			t.traceParent = e.span.SpanContext()
+			ctx.ActorOf(op.RequestID, t)
 		case searcher.ValidateAfter:
 			state := e.TrialSearcherState[op.RequestID]
 			state.Op = op
 			state.Complete = false
+			if state.Closed && e.searcher.Continued && ctx.Child(op.RequestID) == nil {
+				state.Closed = false
+				if err := e.reopenTrial(ctx, state); err != nil {
+					e.updateState(ctx, model.StateWithReason{
+						State:               model.StoppingErrorState,
+						InformationalReason: fmt.Sprintf("failed to reopen trial with error %v", err),
+					})
+					ctx.Log().Error(err)
+					return
+				}
+			}
 			e.TrialSearcherState[op.RequestID] = state
 			updatedTrials[op.RequestID] = true
 		case searcher.SetSearcherProgress:
@@ -662,6 +733,38 @@ func (e *experiment) processOperations(
 	}
 }
 
+// reopenTrial restarts a trial that was closed before the search was continued, so that it trains
+// further from its latest checkpoint.
+func (e *experiment) reopenTrial(ctx *actor.Context, state trialSearcherState) error {
+	trial, err := e.db.TrialByExperimentAndRequestID(e.ID, state.Create.RequestID)
+	if err != nil {
+		return errors.Wrapf(err, "retrieving trial %s", state.Create.RequestID)
+	}
+	if err := db.ReopenTrial(context.TODO(), trial.ID); err != nil {
+		return err
+	}
+
+	config := schemas.Copy(e.Config).(expconf.ExperimentConfig)
+	t := newTrial(
+		e.logCtx, trialTaskID(e.ID, state.Create.RequestID), e.JobID, e.StartTime, e.ID, e.State,
+		state, e.taskLogger, e.rm, e.db, config, nil, e.taskSpec, false,
+	)
+	t.id = trial.ID
+	t.idSet = true
+	t.traceParent = e.span.SpanContext()
+	ctx.ActorOf(state.Create.RequestID, t)
+	return nil
+}
+
+// saveParetoFront persists the Pareto front of a search that ranks trials by several metrics.
+func (e *experiment) saveParetoFront(ctx *actor.Context) {
+	if err := db.SetExperimentParetoFront(
+		context.TODO(), e.ID, e.searcher.ParetoFront(),
+	); err != nil {
+		ctx.Log().WithError(err).Error("failed to save Pareto front")
+	}
+}
+
 func trialTaskID(eID int, rID model.RequestID) model.TaskID {
 	return model.TaskID(fmt.Sprintf("%d.%s", eID, rID))
 }
@@ -684,6 +787,16 @@ func (e *experiment) checkpointForCreate(op searcher.Create) (*model.Checkpoint,
 	return checkpoint, nil
 }
 
+// reportWebhookStateChanged reports the experiment's current state to webhooks. Matching
+// triggers are looked up in the database, so this happens outside of the actor's message loop.
+func (e *experiment) reportWebhookStateChanged() {
+	ctx := e.spanCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go webhooks.ReportExperimentStateChanged(ctx, *e.Experiment)
+}
+
 func (e *experiment) updateState(ctx *actor.Context, state model.StateWithReason) bool {
 	if wasPatched, err := e.Transition(state.State); err != nil {
 		ctx.Log().Errorf("error transitioning experiment state: %s", err)
@@ -692,6 +805,7 @@ func (e *experiment) updateState(ctx *actor.Context, state model.StateWithReason
 		return true
 	}
 	telemetry.ReportExperimentStateChanged(ctx.Self().System(), e.db, *e.Experiment)
+	e.reportWebhookStateChanged()
 
 	ctx.Log().Infof("experiment state changed to %s", state.State)
 	ctx.TellAll(state, ctx.Children()...)
@@ -805,6 +919,23 @@ func (e *experiment) setPriority(ctx *actor.Context, priority *int, forward bool
 	return nil
 }
 
+// setOwner tells the resource manager who owns the experiment, so that the quotas of its owner and
+// workspace apply to it.
+func (e *experiment) setOwner(ctx *actor.Context) {
+	msg := sproto.SetGroupOwner{
+		UserID:       e.OwnerID,
+		ResourcePool: e.Config.Resources().ResourcePool(),
+		Handler:      ctx.Self(),
+	}
+	workspaceID, err := db.ProjectWorkspaceID(context.TODO(), e.ProjectID)
+	if err != nil {
+		ctx.Log().WithError(err).Warn("failed to get the workspace of the experiment")
+	} else {
+		msg.WorkspaceID = &workspaceID
+	}
+	e.rm.SetGroupOwner(ctx, msg)
+}
+
 func (e *experiment) setWeight(ctx *actor.Context, weight float64) error {
 	resources := e.Config.Resources()
 	oldWeight := resources.Weight()
@@ -857,6 +988,9 @@ func (e *experiment) setRP(ctx *actor.Context, msg sproto.SetResourcePool) error
 	// also change to ask all?
 	ctx.TellAll(sproto.ChangeRP{ResourcePool: rp}, ctx.Children()...)
 
+	// The group is created afresh in the new pool, so it has to be told the owner again.
+	e.setOwner(ctx)
+
 	return nil
 }
 
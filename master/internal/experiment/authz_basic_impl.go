@@ -81,6 +81,11 @@ func (a *ExperimentAuthZBasic) CanForkFromExperiment(
 	return nil
 }
 
+// CanImportCheckpoint always returns a nil error.
+func (a *ExperimentAuthZBasic) CanImportCheckpoint(curUser model.User) error {
+	return nil
+}
+
 // CanSetExperimentsMaxSlots always returns a nil error.
 func (a *ExperimentAuthZBasic) CanSetExperimentsMaxSlots(
 	curUser model.User, e *model.Experiment, slots int,
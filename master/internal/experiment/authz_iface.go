@@ -89,6 +89,9 @@ type ExperimentAuthZ interface {
 	CanCreateExperiment(curUser model.User, proj *projectv1.Project, e *model.Experiment) error
 	CanForkFromExperiment(curUser model.User, e *model.Experiment) error
 
+	// POST /checkpoints
+	CanImportCheckpoint(curUser model.User) error
+
 	// PATCH /experiments/:exp_id
 	CanSetExperimentsMaxSlots(curUser model.User, e *model.Experiment, slots int) error
 	CanSetExperimentsWeight(curUser model.User, e *model.Experiment, weight float64) error
@@ -0,0 +1,196 @@
+package experiment
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/rbac"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/projectv1"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// ExperimentAuthZRBAC is role-based access control for experiments, which are governed by the
+// roles assigned within their project and workspace.
+type ExperimentAuthZRBAC struct{}
+
+// CanGetExperiment returns whether the user can view the metadata of the experiment.
+func (a *ExperimentAuthZRBAC) CanGetExperiment(
+	curUser model.User, e *model.Experiment,
+) (canGetExp bool, serverError error) {
+	scope, err := experimentScope(e)
+	if err != nil {
+		return false, err
+	}
+	return rbac.HasPermission(context.TODO(), curUser, scope,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_EXPERIMENT_METADATA)
+}
+
+// CanGetExperimentArtifacts requires the permission to view the artifacts of the experiment.
+func (a *ExperimentAuthZRBAC) CanGetExperimentArtifacts(
+	curUser model.User, e *model.Experiment,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_EXPERIMENT_ARTIFACTS)
+}
+
+// CanDeleteExperiment requires the permission to delete the experiment.
+func (a *ExperimentAuthZRBAC) CanDeleteExperiment(curUser model.User, e *model.Experiment) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_DELETE_EXPERIMENT)
+}
+
+// FilterExperimentsQuery restricts the query to the experiments whose metadata the user can view.
+func (a *ExperimentAuthZRBAC) FilterExperimentsQuery(
+	curUser model.User, proj *projectv1.Project, query *bun.SelectQuery,
+) (*bun.SelectQuery, error) {
+	return filterExperimentsQuery(curUser, proj, query)
+}
+
+// FilterExperimentLabelsQuery restricts the query to the experiments whose metadata the user can
+// view.
+func (a *ExperimentAuthZRBAC) FilterExperimentLabelsQuery(
+	curUser model.User, proj *projectv1.Project, query *bun.SelectQuery,
+) (*bun.SelectQuery, error) {
+	return filterExperimentsQuery(curUser, proj, query)
+}
+
+// CanPreviewHPSearch always returns a nil error.
+func (a *ExperimentAuthZRBAC) CanPreviewHPSearch(curUser model.User) error {
+	return nil
+}
+
+// CanEditExperiment requires the permission to update the experiment.
+func (a *ExperimentAuthZRBAC) CanEditExperiment(curUser model.User, e *model.Experiment) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+}
+
+// CanEditExperimentsMetadata requires the permission to update the metadata of the experiment.
+func (a *ExperimentAuthZRBAC) CanEditExperimentsMetadata(
+	curUser model.User, e *model.Experiment,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT_METADATA)
+}
+
+// CanCreateExperiment requires the permission to create experiments in the project.
+func (a *ExperimentAuthZRBAC) CanCreateExperiment(
+	curUser model.User, proj *projectv1.Project, e *model.Experiment,
+) error {
+	return rbac.CheckForPermission(context.TODO(), curUser,
+		rbac.ProjectScope(int(proj.WorkspaceId), int(proj.Id)),
+		rbacv1.PermissionType_PERMISSION_TYPE_CREATE_EXPERIMENT)
+}
+
+// CanForkFromExperiment requires the permission to view the artifacts of the experiment.
+func (a *ExperimentAuthZRBAC) CanForkFromExperiment(
+	curUser model.User, e *model.Experiment,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_EXPERIMENT_ARTIFACTS)
+}
+
+// CanImportCheckpoint requires the permission to create experiments globally, since imported
+// checkpoints are not tied to a workspace.
+func (a *ExperimentAuthZRBAC) CanImportCheckpoint(curUser model.User) error {
+	return rbac.CheckForPermission(context.TODO(), curUser, rbac.GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_CREATE_EXPERIMENT)
+}
+
+// CanSetExperimentsMaxSlots requires the permission to update the experiment.
+func (a *ExperimentAuthZRBAC) CanSetExperimentsMaxSlots(
+	curUser model.User, e *model.Experiment, slots int,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+}
+
+// CanSetExperimentsWeight requires the permission to update the experiment.
+func (a *ExperimentAuthZRBAC) CanSetExperimentsWeight(
+	curUser model.User, e *model.Experiment, weight float64,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+}
+
+// CanSetExperimentsPriority requires the permission to update the experiment.
+func (a *ExperimentAuthZRBAC) CanSetExperimentsPriority(
+	curUser model.User, e *model.Experiment, priority int,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+}
+
+// CanSetExperimentsCheckpointGCPolicy requires the permission to update the experiment.
+func (a *ExperimentAuthZRBAC) CanSetExperimentsCheckpointGCPolicy(
+	curUser model.User, e *model.Experiment,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+}
+
+// CanRunCustomSearch requires the permission to update the experiment.
+func (a *ExperimentAuthZRBAC) CanRunCustomSearch(
+	curUser model.User, e *model.Experiment,
+) error {
+	return checkExperimentPermission(curUser, e,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+}
+
+// experimentScope returns the scope of the project the experiment is in.
+func experimentScope(e *model.Experiment) (rbac.Scope, error) {
+	workspaceID, err := rbac.ProjectWorkspaceID(context.TODO(), e.ProjectID)
+	if err != nil {
+		return rbac.Scope{}, err
+	}
+	return rbac.ProjectScope(workspaceID, e.ProjectID), nil
+}
+
+func checkExperimentPermission(
+	curUser model.User, e *model.Experiment, permission rbacv1.PermissionType,
+) error {
+	scope, err := experimentScope(e)
+	if err != nil {
+		return err
+	}
+	return rbac.CheckForPermission(context.TODO(), curUser, scope, permission)
+}
+
+// filterExperimentsQuery restricts a query of experiments, within a project or across all of
+// them, to those whose metadata the user can view.
+func filterExperimentsQuery(
+	curUser model.User, proj *projectv1.Project, query *bun.SelectQuery,
+) (*bun.SelectQuery, error) {
+	scopes, err := rbac.PermittedScopesFor(context.TODO(), curUser,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_EXPERIMENT_METADATA)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case proj != nil && scopes.ContainsProject(int(proj.WorkspaceId), int(proj.Id)):
+		return query, nil
+	case proj != nil:
+		return query.Where("false"), nil
+	case scopes.Global:
+		return query, nil
+	case len(scopes.WorkspaceIDs) == 0 && len(scopes.ProjectIDs) == 0:
+		return query.Where("false"), nil
+	}
+	return query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		if len(scopes.WorkspaceIDs) > 0 {
+			q = q.WhereOr("project_id IN (SELECT id FROM projects WHERE workspace_id IN (?))",
+				bun.In(scopes.WorkspaceIDs))
+		}
+		if len(scopes.ProjectIDs) > 0 {
+			q = q.WhereOr("project_id IN (?)", bun.In(scopes.ProjectIDs))
+		}
+		return q
+	}), nil
+}
+
+func init() {
+	AuthZProvider.Register("rbac", &ExperimentAuthZRBAC{})
+}
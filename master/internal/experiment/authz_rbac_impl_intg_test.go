@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/rbac"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/proto/pkg/projectv1"
+)
+
+func TestFilterExperimentsQuery(t *testing.T) {
+	ctx := context.Background()
+	pgDB := db.MustSetupTestPostgres(t)
+	owner := db.RequireMockUser(t, pgDB)
+	user := db.RequireMockUser(t, pgDB)
+
+	// Two workspaces with a project each, and an experiment in each project.
+	var projects []*projectv1.Project
+	var expIDs []int
+	for i := 0; i < 2; i++ {
+		var workspaceID, projectID int
+		require.NoError(t, db.Bun().NewRaw(
+			"INSERT INTO workspaces (name, user_id) VALUES (?, ?) RETURNING id",
+			uuid.NewString(), owner.ID).Scan(ctx, &workspaceID))
+		require.NoError(t, db.Bun().NewRaw(
+			"INSERT INTO projects (name, workspace_id, user_id) VALUES (?, ?, ?) RETURNING id",
+			uuid.NewString(), workspaceID, owner.ID).Scan(ctx, &projectID))
+		projects = append(projects, &projectv1.Project{
+			Id: int32(projectID), WorkspaceId: int32(workspaceID),
+		})
+		exp := db.RequireMockExperiment(t, pgDB, owner)
+		_, err := db.Bun().NewUpdate().Table("experiments").Set("project_id = ?", projectID).
+			Where("id = ?", exp.ID).Exec(ctx)
+		require.NoError(t, err)
+		expIDs = append(expIDs, exp.ID)
+	}
+
+	filtered := func(proj *projectv1.Project) []int {
+		t.Helper()
+		query := db.Bun().NewSelect().Table("experiments").Column("id").
+			Where("id IN (?)", bun.In(expIDs)).Order("id")
+		query, err := filterExperimentsQuery(user, proj, query)
+		require.NoError(t, err)
+		ids := []int{}
+		require.NoError(t, query.Scan(ctx, &ids))
+		return ids
+	}
+	assign := func(role string, workspaceID, projectID *int) {
+		t.Helper()
+		roleID, err := rbac.RoleIDByName(ctx, nil, role)
+		require.NoError(t, err)
+		groupID, err := rbac.PersonalGroupID(ctx, nil, user.ID)
+		require.NoError(t, err)
+		require.NoError(t, rbac.AddRoleAssignmentsTx(ctx, nil, rbac.RoleAssignment{
+			GroupID: groupID, RoleID: roleID,
+			ScopeWorkspaceID: workspaceID, ScopeProjectID: projectID,
+		}))
+	}
+
+	require.Empty(t, filtered(nil))
+	require.Empty(t, filtered(projects[0]))
+
+	assign("Viewer", ptrs.Ptr(int(projects[0].WorkspaceId)), nil)
+	require.Equal(t, []int{expIDs[0]}, filtered(nil))
+	require.Equal(t, expIDs, filtered(projects[0]), "a permitted project is not filtered")
+	require.Empty(t, filtered(projects[1]))
+
+	assign("Viewer", nil, ptrs.Ptr(int(projects[1].Id)))
+	require.Equal(t, expIDs, filtered(nil))
+	require.Equal(t, expIDs, filtered(projects[1]))
+
+	// Admins are not subject to role assignments.
+	admin := model.User{ID: owner.ID, Admin: true}
+	query := db.Bun().NewSelect().Table("experiments").Column("id").
+		Where("id IN (?)", bun.In(expIDs))
+	query, err := filterExperimentsQuery(admin, nil, query)
+	require.NoError(t, err)
+	count, err := query.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(expIDs), count)
+}
@@ -0,0 +1,299 @@
+package grpcutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// readOnlyMethodPrefixes are prefixes of the names of the API methods that don't change anything,
+// which are the only ones read-only access tokens may call.
+var readOnlyMethodPrefixes = []string{
+	"Get", "List", "Search", "Compare", "Query", "Summarize", "Preview",
+	"CurrentUser", "Logout", "MasterLogs", "TaskLogs", "TrialLogs", "TrialsSnapshot",
+	"TrialsSample", "MetricNames", "MetricBatches", "ExpCompare", "ResourceAllocation",
+}
+
+// unscopedMethods are the API methods that workspace-limited access tokens may call although their
+// requests aren't about any workspace.
+var unscopedMethods = map[string]bool{
+	"CurrentUser": true,
+	"Logout":      true,
+}
+
+// Kinds of entities that requests can refer to by ID.
+const (
+	workspaceEntity        = "workspace"
+	projectEntity          = "project"
+	experimentEntity       = "experiment"
+	trialEntity            = "trial"
+	trialsCollectionEntity = "trialscollection"
+)
+
+// entitiesOfID are the kinds of entities that the "id" field of a request may refer to, in the
+// order they are matched against the name of the method.
+var entitiesOfID = []string{
+	workspaceEntity, projectEntity, experimentEntity, trialsCollectionEntity, trialEntity,
+}
+
+// scopedIDFields maps the names of request fields that refer to entities within a workspace to
+// the kind of entity.
+var scopedIDFields = map[protoreflect.Name]string{
+	"workspace_id":             workspaceEntity,
+	"destination_workspace_id": workspaceEntity,
+	"project_id":               projectEntity,
+	"destination_project_id":   projectEntity,
+	"experiment_id":            experimentEntity,
+	"experiment_ids":           experimentEntity,
+	"parent_id":                experimentEntity,
+	"trial_id":                 trialEntity,
+	"trial_ids":                trialEntity,
+	"workspace_ids":            workspaceEntity,
+	"project_ids":              projectEntity,
+}
+
+// filterIDFields are the names of request fields that look like they refer to entities but only
+// narrow down what is returned about the entities named by other fields, e.g. the logs of a trial,
+// or identify something within them, e.g. a searcher operation of an experiment.
+var filterIDFields = map[protoreflect.FullName]bool{
+	"rank_ids":       true,
+	"agent_ids":      true,
+	"container_ids":  true,
+	"allocation_ids": true,
+	"user_ids":       true,
+	"request_id":     true,
+	"trial_run_id":   true,
+
+	"determined.trial.v1.TrialProfilerMetricLabels.agent_id": true,
+	"determined.trial.v1.TrialProfilerMetricLabels.gpu_uuid": true,
+}
+
+// namedEntityFields are the names of request fields that refer to entities by name.
+var namedEntityFields = map[protoreflect.Name]bool{
+	"model_name":    true,
+	"template_name": true,
+	"username":      true,
+}
+
+// isEntityField returns whether a request field refers to some entity, judging by its name.
+func isEntityField(fd protoreflect.FieldDescriptor) bool {
+	name := fd.Name()
+	if filterIDFields[protoreflect.FullName(name)] || filterIDFields[fd.FullName()] {
+		return false
+	}
+	for _, suffix := range []string{"_id", "_ids", "_uuid", "_uuids"} {
+		if strings.HasSuffix(string(name), suffix) {
+			return true
+		}
+	}
+	return name == "id" || name == "uuid" || namedEntityFields[name]
+}
+
+// workspaceQueries look up the workspace an entity is in.
+var workspaceQueries = map[string]string{
+	workspaceEntity: `SELECT id FROM workspaces WHERE id = ?`,
+	projectEntity:   `SELECT workspace_id FROM projects WHERE id = ?`,
+	experimentEntity: `
+SELECT p.workspace_id FROM experiments e
+JOIN projects p ON e.project_id = p.id
+WHERE e.id = ?`,
+	trialEntity: `
+SELECT p.workspace_id FROM trials t
+JOIN experiments e ON t.experiment_id = e.id
+JOIN projects p ON e.project_id = p.id
+WHERE t.id = ?`,
+	trialsCollectionEntity: `
+SELECT p.workspace_id FROM trials_collections c
+JOIN projects p ON c.project_id = p.id
+WHERE c.id = ?`,
+}
+
+// methodName returns the name of the method without its service.
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func isReadOnlyMethod(fullMethod string) bool {
+	name := methodName(fullMethod)
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReadOnly returns an error if the session is a read-only access token and the method may
+// change something.
+func checkReadOnly(fullMethod string, session *model.UserSession) error {
+	if session != nil && session.ReadOnly && !isReadOnlyMethod(fullMethod) {
+		return status.Error(codes.PermissionDenied, "access token is read-only")
+	}
+	return nil
+}
+
+// entityOfID returns the kind of entity that the "id" field of a request to the method refers to,
+// which is named right after the verb of the method, e.g. GetWorkspaceProjects refers to a
+// workspace, KillTrial to a trial and PatchTrialsCollection to a trials collection.
+func entityOfID(fullMethod string) string {
+	name := methodName(fullMethod)
+	if name == "" {
+		return ""
+	}
+	i := strings.IndexFunc(name[1:], unicode.IsUpper)
+	if i < 0 {
+		return ""
+	}
+	noun := name[i+1:]
+	for _, entity := range entitiesOfID {
+		if strings.HasPrefix(strings.ToLower(noun), entity) {
+			return entity
+		}
+	}
+	return ""
+}
+
+// scopedIDs returns the IDs of the entities within workspaces that the request refers to, by kind
+// of entity, including those in nested messages. IDs left at zero are treated as unset. It also
+// returns whether the request refers to any entity whose workspace can't be looked up, such as a
+// model or a user.
+func scopedIDs(fullMethod string, req interface{}) (map[string][]int, bool) {
+	m, ok := req.(protoreflect.ProtoMessage)
+	if !ok {
+		return nil, false
+	}
+	ids := make(map[string][]int)
+	unresolved := collectScopedIDs(fullMethod, m.ProtoReflect(), ids)
+	return ids, unresolved
+}
+
+func collectScopedIDs(fullMethod string, msg protoreflect.Message, ids map[string][]int) bool {
+	unresolved := false
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		entity, ok := scopedIDFields[fd.Name()]
+		if fd.Name() == "id" {
+			entity, ok = entityOfID(fullMethod), true
+		}
+		switch {
+		case fd.IsMap():
+		case fd.Kind() == protoreflect.MessageKind && isWrapper(fd.Message()):
+			// Optional IDs, e.g. google.protobuf.Int32Value, are resolved like plain ones.
+			inner := v.Message().Get(fd.Message().Fields().ByName("value"))
+			if ok && entity != "" && isIntegerKind(fd.Message().Fields().ByName("value").Kind()) {
+				if id := int(inner.Int()); id != 0 {
+					ids[entity] = append(ids[entity], id)
+				}
+			} else if isEntityField(fd) {
+				unresolved = true
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			for j := 0; j < v.List().Len(); j++ {
+				unresolved = collectScopedIDs(fullMethod, v.List().Get(j).Message(), ids) ||
+					unresolved
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			unresolved = collectScopedIDs(fullMethod, v.Message(), ids) || unresolved
+		case !ok || entity == "" || !isIntegerKind(fd.Kind()):
+			if isEntityField(fd) {
+				unresolved = true
+			}
+		case fd.IsList():
+			for j := 0; j < v.List().Len(); j++ {
+				if id := int(v.List().Get(j).Int()); id != 0 {
+					ids[entity] = append(ids[entity], id)
+				}
+			}
+		default:
+			if id := int(v.Int()); id != 0 {
+				ids[entity] = append(ids[entity], id)
+			}
+		}
+		return true
+	})
+	return unresolved
+}
+
+// isWrapper returns whether the message is one of the well-known wrappers of scalar values.
+func isWrapper(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf" &&
+		strings.HasSuffix(string(md.Name()), "Value") && md.Fields().ByName("value") != nil
+}
+
+func isIntegerKind(k protoreflect.Kind) bool {
+	switch k {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkWorkspaceScope returns an error if the session is a workspace-limited access token and the
+// request isn't only about entities in its workspace. Requests that refer to entities whose
+// workspace can't be looked up are denied.
+func checkWorkspaceScope(
+	ctx context.Context, fullMethod string, session *model.UserSession, req interface{},
+) error {
+	if session == nil || session.ScopeWorkspaceID == nil {
+		return nil
+	}
+	scope := *session.ScopeWorkspaceID
+	denied := status.Error(codes.PermissionDenied,
+		fmt.Sprintf("access token is limited to workspace %d", scope))
+
+	ids, unresolved := scopedIDs(fullMethod, req)
+	if unresolved {
+		return denied
+	}
+	if len(ids) == 0 {
+		if unscopedMethods[methodName(fullMethod)] {
+			return nil
+		}
+		return denied
+	}
+	for entity, entityIDs := range ids {
+		for _, id := range entityIDs {
+			var workspaceID int
+			err := db.Bun().NewRaw(workspaceQueries[entity], id).Scan(ctx, &workspaceID)
+			switch {
+			case db.MatchSentinelError(err) == db.ErrNotFound:
+				return denied
+			case err != nil:
+				return err
+			case workspaceID != scope:
+				return denied
+			}
+		}
+	}
+	return nil
+}
+
+// scopedServerStream checks the scope of an access token against the request of a stream once
+// it's received.
+type scopedServerStream struct {
+	grpc.ServerStream
+	fullMethod string
+	session    *model.UserSession
+	checked    bool
+}
+
+func (s *scopedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.checked {
+		return nil
+	}
+	s.checked = true
+	return checkWorkspaceScope(s.Context(), s.fullMethod, s.session, m)
+}
@@ -0,0 +1,142 @@
+package grpcutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/proto/pkg/apiv1"
+)
+
+const servicePrefix = "/determined.api.v1.Determined/"
+
+func TestCheckReadOnly(t *testing.T) {
+	readOnly := &model.UserSession{AccessToken: true, ReadOnly: true}
+	for _, method := range []string{"GetExperiment", "TrialLogs", "CurrentUser", "MetricBatches"} {
+		require.NoError(t, checkReadOnly(servicePrefix+method, readOnly), method)
+	}
+	for _, method := range []string{"PostProject", "KillTrial", "ComputeHPImportance"} {
+		require.Error(t, checkReadOnly(servicePrefix+method, readOnly), method)
+		require.NoError(t, checkReadOnly(servicePrefix+method, &model.UserSession{}), method)
+	}
+	require.NoError(t, checkReadOnly(servicePrefix+"PostProject", nil))
+}
+
+func TestEntityOfID(t *testing.T) {
+	for method, entity := range map[string]string{
+		"GetWorkspaceProjects": workspaceEntity,
+		"PatchProject":         projectEntity,
+		"ActivateExperiment":   experimentEntity,
+		"KillTrial":            trialEntity,
+		"GetModel":             "",
+		"Logout":               "",
+	} {
+		require.Equal(t, entity, entityOfID(servicePrefix+method), method)
+	}
+}
+
+func TestScopedIDs(t *testing.T) {
+	for _, tc := range []struct {
+		method     string
+		req        interface{}
+		ids        map[string][]int
+		unresolved bool
+	}{
+		{
+			method: "MoveExperiment",
+			req:    &apiv1.MoveExperimentRequest{ExperimentId: 3, DestinationProjectId: 5},
+			ids:    map[string][]int{experimentEntity: {3}, projectEntity: {5}},
+		},
+		{
+			method: "GetWorkspaceProjects",
+			req:    &apiv1.GetWorkspaceProjectsRequest{Id: 2},
+			ids:    map[string][]int{workspaceEntity: {2}},
+		},
+		{
+			method: "ExpCompareTrialsSample",
+			req:    &apiv1.ExpCompareTrialsSampleRequest{ExperimentIds: []int32{1, 4}},
+			ids:    map[string][]int{experimentEntity: {1, 4}},
+		},
+		{
+			method: "PatchTrialsCollection",
+			req:    &apiv1.PatchTrialsCollectionRequest{Id: 6},
+			ids:    map[string][]int{trialsCollectionEntity: {6}},
+		},
+		{
+			method: "SearchRolesAssignableToScope",
+			req: &apiv1.SearchRolesAssignableToScopeRequest{
+				WorkspaceId: wrapperspb.Int32(7),
+			},
+			ids: map[string][]int{workspaceEntity: {7}},
+		},
+		{
+			// Filters of the logs of a trial don't name other entities.
+			method: "TrialLogs",
+			req:    &apiv1.TrialLogsRequest{TrialId: 8, RankIds: []int32{0, 1}},
+			ids:    map[string][]int{trialEntity: {8}},
+		},
+		{
+			method: "TrialsSample",
+			req:    &apiv1.TrialsSampleRequest{ExperimentId: 9, MetricName: "loss"},
+			ids:    map[string][]int{experimentEntity: {9}},
+		},
+		{
+			method: "QueryTrials",
+			req: &apiv1.QueryTrialsRequest{Filters: &apiv1.TrialFilters{
+				WorkspaceIds: []int32{1}, ProjectIds: []int32{2},
+			}},
+			ids: map[string][]int{workspaceEntity: {1}, projectEntity: {2}},
+		},
+		{
+			method: "GetExperiments",
+			req:    &apiv1.GetExperimentsRequest{},
+			ids:    map[string][]int{},
+		},
+		{
+			// Models aren't within workspaces, so the request can't be checked.
+			method:     "GetModel",
+			req:        &apiv1.GetModelRequest{ModelName: "mnist"},
+			ids:        map[string][]int{},
+			unresolved: true,
+		},
+		{
+			method: "LaunchTensorboard",
+			req: &apiv1.LaunchTensorboardRequest{
+				ExperimentIds: []int32{1}, TemplateName: "elsewhere",
+			},
+			ids:        map[string][]int{experimentEntity: {1}},
+			unresolved: true,
+		},
+		{
+			method:     "GetRolesAssignedToUser",
+			req:        &apiv1.GetRolesAssignedToUserRequest{UserId: 2},
+			ids:        map[string][]int{},
+			unresolved: true,
+		},
+	} {
+		ids, unresolved := scopedIDs(servicePrefix+tc.method, tc.req)
+		require.Equal(t, tc.ids, ids, tc.method)
+		require.Equal(t, tc.unresolved, unresolved, tc.method)
+	}
+}
+
+func TestCheckWorkspaceScopeUnresolved(t *testing.T) {
+	session := &model.UserSession{AccessToken: true, ScopeWorkspaceID: ptrs.Ptr(1)}
+	// Naming an entity in the workspace of the token doesn't allow also naming one whose workspace
+	// can't be looked up. This is denied before anything is looked up.
+	err := checkWorkspaceScope(context.Background(), servicePrefix+"LaunchTensorboard", session,
+		&apiv1.LaunchTensorboardRequest{ExperimentIds: []int32{1}, TemplateName: "elsewhere"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = checkWorkspaceScope(context.Background(), servicePrefix+"GetModel", session,
+		&apiv1.GetModelRequest{ModelName: "mnist"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	require.NoError(t, checkWorkspaceScope(context.Background(), servicePrefix+"GetModel",
+		&model.UserSession{AccessToken: true}, &apiv1.GetModelRequest{ModelName: "mnist"}))
+}
@@ -28,7 +28,7 @@ const jsonPretty = "application/json+pretty"
 
 // NewGRPCServer creates a Determined gRPC service.
 func NewGRPCServer(db *db.PgDB, srv proto.DeterminedServer, enablePrometheus bool,
-	extConfig *model.ExternalSessions,
+	enableOtel bool, extConfig *model.ExternalSessions,
 ) *grpc.Server {
 	// In go-grpc, the INFO log level is used primarily for debugging
 	// purposes, so omit INFO messages from the master log.
@@ -65,6 +65,14 @@ func NewGRPCServer(db *db.PgDB, srv proto.DeterminedServer, enablePrometheus boo
 		grpc_prometheus.EnableHandlingTimeHistogram()
 	}
 
+	if enableOtel {
+		// Trace calls from the start, so that their spans include the other interceptors.
+		streamInterceptors = append(
+			[]grpc.StreamServerInterceptor{streamOtelInterceptor}, streamInterceptors...)
+		unaryInterceptors = append(
+			[]grpc.UnaryServerInterceptor{unaryOtelInterceptor}, unaryInterceptors...)
+	}
+
 	grpcS := grpc.NewServer(
 		grpc.StreamInterceptor(grpcmiddleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpcmiddleware.ChainUnaryServer(unaryInterceptors...)),
@@ -0,0 +1,121 @@
+package grpcutil
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/determined-ai/determined/master/internal/audit"
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/apiv1"
+)
+
+// unauditedMethods are API methods that change something but are called so often by tasks while
+// they run that they're left out of the audit log.
+var unauditedMethods = map[string]bool{
+	"AckAllocationPreemptionSignal":     true,
+	"AllocationAllGather":               true,
+	"AllocationPendingPreemptionSignal": true,
+	"AllocationPreemptionSignal":        true,
+	"AllocationReady":                   true,
+	"AllocationRendezvousInfo":          true,
+	"AllocationWaiting":                 true,
+	"CompleteTrialSearcherValidation":   true,
+	"IdleNotebook":                      true,
+	"MarkAllocationResourcesDaemon":     true,
+	"PostAllocationProxyAddress":        true,
+	"PostTrialProfilerMetricsBatch":     true,
+	"PostTrialRunnerMetadata":           true,
+	"ReportCheckpoint":                  true,
+	"ReportTrialProgress":               true,
+	"ReportTrialSearcherEarlyExit":      true,
+	"ReportTrialTrainingMetrics":        true,
+	"ReportTrialValidationMetrics":      true,
+}
+
+func isAuditedMethod(fullMethod string) bool {
+	return !isReadOnlyMethod(fullMethod) && !unauditedMethods[methodName(fullMethod)]
+}
+
+// auditEvent describes a call to the method for the audit log.
+func auditEvent(
+	ctx context.Context, fullMethod string, req interface{},
+	user *model.User, session *model.UserSession, err error,
+) *audit.Event {
+	targets, _ := scopedIDs(fullMethod, req)
+	e := &audit.Event{
+		Protocol:   audit.ProtocolGRPC,
+		Method:     fullMethod,
+		Targets:    targets,
+		RemoteAddr: RemoteAddr(ctx),
+	}
+	e.SetSession(user, session)
+	if r, ok := req.(*apiv1.LoginRequest); ok && e.Username == "" {
+		e.Username = r.Username
+	}
+	if len(e.Targets) == 0 {
+		e.Targets = nil
+	}
+
+	code := status.Code(err)
+	e.Status = code.String()
+	switch code {
+	case codes.OK:
+		e.Outcome = audit.OutcomeSuccess
+	case codes.Unauthenticated, codes.PermissionDenied:
+		e.Outcome = audit.OutcomeDenied
+	default:
+		e.Outcome = audit.OutcomeFailed
+	}
+	return e
+}
+
+// recordAuditEvent records a call to the method in the audit log if it may have changed something.
+func recordAuditEvent(
+	ctx context.Context, fullMethod string, req interface{},
+	user *model.User, session *model.UserSession, err error,
+) {
+	if !audit.Enabled() || !isAuditedMethod(fullMethod) {
+		return
+	}
+	audit.Record(auditEvent(ctx, fullMethod, req, user, session, err))
+}
+
+// RemoteAddr returns the address of the client. That's the address the connection came from,
+// unless it's a trusted proxy such as the REST gateway, in which case the address the proxy got
+// the request from is taken from x-forwarded-for, repeatedly for a chain of trusted proxies.
+func RemoteAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	var forwarded []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, f := range md.Get("x-forwarded-for") {
+			forwarded = append(forwarded, strings.Split(f, ",")...)
+		}
+	}
+	trusted := config.GetMasterConfig().Security.TrustedProxies
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		if ip := net.ParseIP(addr); ip == nil || !trusted.Trusts(ip) {
+			break
+		}
+		next := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(next) == nil {
+			break
+		}
+		addr = next
+	}
+	return addr
+}
@@ -0,0 +1,109 @@
+package grpcutil
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/determined-ai/determined/master/internal/audit"
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/apiv1"
+)
+
+func TestIsAuditedMethod(t *testing.T) {
+	for method, audited := range map[string]bool{
+		"KillExperiment":             true,
+		"PostUser":                   true,
+		"Login":                      true,
+		"GetExperiment":              false,
+		"ReportTrialTrainingMetrics": false,
+		"AllocationReady":            false,
+	} {
+		require.Equal(t, audited, isAuditedMethod(servicePrefix+method), method)
+	}
+}
+
+func TestAuditEvent(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr("127.0.0.1:40000")})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "10.0.0.1"))
+	user := &model.User{ID: 3, Username: "alice"}
+	session := &model.UserSession{ID: 7, AccessToken: true}
+
+	e := auditEvent(ctx, servicePrefix+"KillExperiment", &apiv1.KillExperimentRequest{Id: 5},
+		user, session, nil)
+	require.Equal(t, audit.ProtocolGRPC, e.Protocol)
+	require.Equal(t, servicePrefix+"KillExperiment", e.Method)
+	require.Equal(t, model.UserID(3), *e.UserID)
+	require.Equal(t, "alice", e.Username)
+	require.Equal(t, model.SessionID(7), *e.SessionID)
+	require.True(t, e.AccessToken)
+	require.Equal(t, map[string][]int{experimentEntity: {5}}, e.Targets)
+	require.Equal(t, audit.OutcomeSuccess, e.Outcome)
+	require.Equal(t, "OK", e.Status)
+	require.Equal(t, "10.0.0.1", e.RemoteAddr)
+
+	e = auditEvent(ctx, servicePrefix+"KillExperiment", &apiv1.KillExperimentRequest{Id: 5},
+		user, session, status.Error(codes.PermissionDenied, "no"))
+	require.Equal(t, audit.OutcomeDenied, e.Outcome)
+	require.Equal(t, "PermissionDenied", e.Status)
+
+	e = auditEvent(ctx, servicePrefix+"Login", &apiv1.LoginRequest{Username: "bob"},
+		nil, nil, ErrInvalidCredentials)
+	require.Nil(t, e.UserID)
+	require.Equal(t, "bob", e.Username, "failed logins should say who tried to log in")
+	require.Nil(t, e.Targets)
+	require.Equal(t, audit.OutcomeDenied, e.Outcome)
+}
+
+func tcpAddr(addr string) net.Addr {
+	a, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestRemoteAddr(t *testing.T) {
+	security := &config.GetMasterConfig().Security
+	defer func(proxies config.TrustedProxiesConfig) { security.TrustedProxies = proxies }(
+		security.TrustedProxies)
+	security.TrustedProxies = config.TrustedProxiesConfig{"10.1.0.0/16", "10.2.0.1"}
+
+	for _, tc := range []struct {
+		name      string
+		peer      string
+		forwarded []string
+		expected  string
+	}{
+		{"direct", "192.0.2.1:5000", nil, "192.0.2.1"},
+		{"direct ignores forwarded", "192.0.2.1:5000", []string{"10.0.0.1"}, "192.0.2.1"},
+		{"REST gateway", "127.0.0.1:5000", []string{"192.0.2.1"}, "192.0.2.1"},
+		{
+			"REST gateway ignores client's forwarded",
+			"127.0.0.1:5000", []string{"10.0.0.1, 192.0.2.1"}, "192.0.2.1",
+		},
+		{
+			"trusted proxies",
+			"127.0.0.1:5000", []string{"10.0.0.1, 192.0.2.1, 10.2.0.1", "10.1.5.5"}, "192.0.2.1",
+		},
+		{"invalid forwarded", "127.0.0.1:5000", []string{"bogus, 10.1.5.5"}, "10.1.5.5"},
+		{"all trusted", "127.0.0.1:5000", []string{"10.1.5.5"}, "10.1.5.5"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr(tc.peer)})
+			if tc.forwarded != nil {
+				ctx = metadata.NewIncomingContext(ctx,
+					metadata.MD{"x-forwarded-for": tc.forwarded})
+			}
+			require.Equal(t, tc.expected, RemoteAddr(ctx))
+		})
+	}
+	require.Empty(t, RemoteAddr(context.Background()))
+}
@@ -43,6 +43,13 @@ var unauthenticatedMethods = map[string]bool{
 	"/determined.api.v1.Determined/GetTelemetry": true,
 }
 
+// passwordChangeMethods are the API methods that users who must change their password may call.
+var passwordChangeMethods = map[string]bool{
+	"CurrentUser":     true,
+	"Logout":          true,
+	"SetUserPassword": true,
+}
+
 var (
 	// ErrInvalidCredentials notifies that the provided credentials are invalid or missing.
 	ErrInvalidCredentials = status.Error(codes.Unauthenticated, "invalid credentials")
@@ -159,6 +166,18 @@ func GetUser(ctx context.Context) (*model.User, *model.UserSession, error) {
 	}
 }
 
+// checkPasswordChangeRequired returns an error if the user logged in with a session must change
+// their password and the method isn't one that lets them do so.
+func checkPasswordChangeRequired(
+	fullMethod string, u *model.User, session *model.UserSession,
+) error {
+	if u == nil || session == nil || !u.PasswordChangeRequired ||
+		passwordChangeMethods[methodName(fullMethod)] {
+		return nil
+	}
+	return status.Error(codes.FailedPrecondition, user.ErrPasswordChangeRequired.Error())
+}
+
 // Return error if user cannot be authenticated or lacks authorization.
 func auth(ctx context.Context, db *db.PgDB, fullMethod string,
 	extConfig *model.ExternalSessions,
@@ -175,14 +194,26 @@ func streamAuthInterceptor(db *db.PgDB,
 ) grpc.StreamServerInterceptor {
 	return func(
 		srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
-	) error {
+	) (err error) {
 		// Don't cache the result of the stream auth interceptor because
 		// we can't easily modify ss's context and
 		// we would have to worry about the user session expiring in the context.
-		_, _, err := auth(ss.Context(), db, info.FullMethod, extConfig)
+		user, session, err := auth(ss.Context(), db, info.FullMethod, extConfig)
+		defer func() {
+			recordAuditEvent(ss.Context(), info.FullMethod, nil, user, session, err)
+		}()
 		if err != nil {
 			return err
 		}
+		if err := checkReadOnly(info.FullMethod, session); err != nil {
+			return err
+		}
+		if err := checkPasswordChangeRequired(info.FullMethod, user, session); err != nil {
+			return err
+		}
+		if session != nil && session.IsLimited() {
+			ss = &scopedServerStream{ServerStream: ss, fullMethod: info.FullMethod, session: session}
+		}
 
 		return handler(srv, ss)
 	}
@@ -195,9 +226,21 @@ func unaryAuthInterceptor(db *db.PgDB,
 		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 	) (resp interface{}, err error) {
 		user, session, err := auth(ctx, db, info.FullMethod, extConfig)
+		defer func() {
+			recordAuditEvent(ctx, info.FullMethod, req, user, session, err)
+		}()
 		if err != nil {
 			return nil, err
 		}
+		if err := checkReadOnly(info.FullMethod, session); err != nil {
+			return nil, err
+		}
+		if err := checkPasswordChangeRequired(info.FullMethod, user, session); err != nil {
+			return nil, err
+		}
+		if err := checkWorkspaceScope(ctx, info.FullMethod, session, req); err != nil {
+			return nil, err
+		}
 		if user != nil {
 			ctx = context.WithValue(ctx, userContextKey{}, user)
 		}
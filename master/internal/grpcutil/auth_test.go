@@ -0,0 +1,27 @@
+package grpcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestCheckPasswordChangeRequired(t *testing.T) {
+	mustChange := &model.User{PasswordChangeRequired: true}
+	session := &model.UserSession{}
+	for _, method := range []string{"CurrentUser", "Logout", "SetUserPassword"} {
+		require.NoError(t,
+			checkPasswordChangeRequired(servicePrefix+method, mustChange, session), method)
+	}
+
+	err := checkPasswordChangeRequired(servicePrefix+"GetExperiments", mustChange, session)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.NoError(t,
+		checkPasswordChangeRequired(servicePrefix+"GetExperiments", &model.User{}, session))
+	// Tasks authenticate with allocation sessions and aren't held up.
+	require.NoError(t, checkPasswordChangeRequired(servicePrefix+"GetExperiments", mustChange, nil))
+}
@@ -0,0 +1,108 @@
+package grpcutil
+
+import (
+	"context"
+	"strings"
+
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	opentelemetry "github.com/determined-ai/determined/master/pkg/opentelemetry"
+)
+
+// metadataCarrier lets the OpenTelemetry propagators read the trace context of a caller from the
+// metadata of its call.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// requestAttributes returns the IDs of the Determined objects that a request is about.
+func requestAttributes(req interface{}) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if r, ok := req.(interface{ GetExperimentId() int32 }); ok && r.GetExperimentId() != 0 {
+		attrs = append(attrs, opentelemetry.ExperimentIDKey.Int(int(r.GetExperimentId())))
+	}
+	if r, ok := req.(interface{ GetTrialId() int32 }); ok && r.GetTrialId() != 0 {
+		attrs = append(attrs, opentelemetry.TrialIDKey.Int(int(r.GetTrialId())))
+	}
+	if r, ok := req.(interface{ GetTaskId() string }); ok && r.GetTaskId() != "" {
+		attrs = append(attrs, opentelemetry.TaskIDKey.String(r.GetTaskId()))
+	}
+	if r, ok := req.(interface{ GetAllocationId() string }); ok && r.GetAllocationId() != "" {
+		attrs = append(attrs, opentelemetry.AllocationIDKey.String(r.GetAllocationId()))
+	}
+	return attrs
+}
+
+// startServerSpan starts the span of a call, continuing the trace of the caller if it sent one.
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	attrs := []attribute.KeyValue{semconv.RPCSystemKey.String("grpc")}
+	if service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/"); ok {
+		attrs = append(attrs, semconv.RPCServiceKey.String(service),
+			semconv.RPCMethodKey.String(method))
+	}
+	return opentelemetry.Tracer().Start(ctx, fullMethod,
+		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// endServerSpan records the outcome of a call on its span and ends it.
+func endServerSpan(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(s.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+	span.End()
+}
+
+// unaryOtelInterceptor traces unary calls.
+func unaryOtelInterceptor(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, span := startServerSpan(ctx, info.FullMethod)
+	span.SetAttributes(requestAttributes(req)...)
+	resp, err := handler(ctx, req)
+	endServerSpan(span, err)
+	return resp, err
+}
+
+// streamOtelInterceptor traces streaming calls.
+func streamOtelInterceptor(
+	srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+	wrapped := grpcmiddleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	err := handler(srv, wrapped)
+	endServerSpan(span, err)
+	return err
+}
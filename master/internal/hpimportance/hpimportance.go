@@ -124,7 +124,12 @@ func createDataFile(data map[int][]model.HPImportanceTrialData,
 			st += fmt.Sprintf("%v", trial.Metric)
 			hparamsVals := trial.Hparams
 			for _, hp := range hpsOrder {
-				st += fmt.Sprintf(",%v", hparamsVals[hp])
+				// Hyperparameters that were inactive for the trial are missing values.
+				if val, ok := hparamsVals[hp]; ok {
+					st += fmt.Sprintf(",%v", val)
+				} else {
+					st += ",?"
+				}
 			}
 			st += fmt.Sprintf(",%s\n", batchIDStr)
 			_, err = arff.WriteString(st)
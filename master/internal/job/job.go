@@ -8,6 +8,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/determined-ai/determined/master/internal/rm"
 	"github.com/determined-ai/determined/master/internal/sproto"
@@ -251,11 +252,18 @@ func UpdateJobQInfo(job *jobv1.Job, rmInfo *sproto.RMJobInfo) {
 		job.Summary = nil
 		job.RequestedSlots = 0
 		job.AllocatedSlots = 0
+		job.ReservedStart = nil
+		job.BlockedByQuota = false
 		return
 	}
 
 	job.RequestedSlots = int32(rmInfo.RequestedSlots)
 	job.AllocatedSlots = int32(rmInfo.AllocatedSlots)
+	job.ReservedStart = nil
+	if rmInfo.ReservedStart != nil {
+		job.ReservedStart = timestamppb.New(*rmInfo.ReservedStart)
+	}
+	job.BlockedByQuota = rmInfo.BlockedByQuota
 	if job.Summary == nil {
 		job.Summary = &jobv1.JobSummary{}
 	}
@@ -0,0 +1,65 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/rm"
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/actor/actors"
+	"github.com/determined-ai/determined/proto/pkg/apiv1"
+	"github.com/determined-ai/determined/proto/pkg/jobv1"
+)
+
+func TestUpdateJobQInfo(t *testing.T) {
+	reserved := time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+	job := &jobv1.Job{}
+	UpdateJobQInfo(job, &sproto.RMJobInfo{
+		JobsAhead:      2,
+		State:          sproto.SchedulingStateQueued,
+		RequestedSlots: 4,
+		ReservedStart:  &reserved,
+		BlockedByQuota: true,
+	})
+	require.Equal(t, int32(4), job.RequestedSlots)
+	require.Equal(t, int32(2), job.Summary.JobsAhead)
+	require.Equal(t, jobv1.State_STATE_QUEUED, job.Summary.State)
+	require.Equal(t, reserved, job.ReservedStart.AsTime())
+	require.True(t, job.BlockedByQuota)
+
+	// The reservation goes away once the scheduler no longer holds one.
+	UpdateJobQInfo(job, &sproto.RMJobInfo{State: sproto.SchedulingStateScheduled})
+	require.Nil(t, job.ReservedStart)
+	require.False(t, job.BlockedByQuota)
+
+	UpdateJobQInfo(job, nil)
+	require.Nil(t, job.Summary)
+	require.Nil(t, job.ReservedStart)
+}
+
+func TestGetJobsBlockedByQuota(t *testing.T) {
+	system := actor.NewSystem(t.Name())
+	rmRef := system.MustActorOf(actor.Addr("rm"), &actors.MockActor{
+		Responses: map[string]*actors.MockResponse{
+			"sproto.GetJobQ": {Msg: sproto.AQueue{
+				"blocked": {State: sproto.SchedulingStateQueued, BlockedByQuota: true},
+			}},
+		},
+	})
+	jobRef := system.MustActorOf(actor.Addr("blocked"), &actors.MockActor{
+		Responses: map[string]*actors.MockResponse{
+			"sproto.GetJob": {Msg: &jobv1.Job{JobId: "blocked"}},
+		},
+	})
+	jobs := system.MustActorOf(actor.Addr("jobs"), NewJobs(rm.WrapRMActor(rmRef)))
+	system.Tell(jobs, sproto.RegisterJob{JobID: "blocked", JobActor: jobRef})
+
+	resp := system.Ask(jobs, &apiv1.GetJobsRequest{})
+	require.NoError(t, resp.Error())
+	got := resp.Get().([]*jobv1.Job)
+	require.Len(t, got, 1)
+	require.True(t, got[0].BlockedByQuota)
+}
@@ -122,6 +122,20 @@ func (_m *ExperimentAuthZ) CanGetExperimentArtifacts(curUser model.User, e *mode
 	return r0
 }
 
+// CanImportCheckpoint provides a mock function with given fields: curUser
+func (_m *ExperimentAuthZ) CanImportCheckpoint(curUser model.User) error {
+	ret := _m.Called(curUser)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.User) error); ok {
+		r0 = rf(curUser)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CanPreviewHPSearch provides a mock function with given fields: curUser
 func (_m *ExperimentAuthZ) CanPreviewHPSearch(curUser model.User) error {
 	ret := _m.Called(curUser)
@@ -108,6 +108,20 @@ func (_m *ProjectAuthZ) CanMoveProjectExperiments(curUser model.User, exp *model
 	return r0
 }
 
+// CanSetProjectCheckpointRetention provides a mock function with given fields: curUser, _a1
+func (_m *ProjectAuthZ) CanSetProjectCheckpointRetention(curUser model.User, _a1 *projectv1.Project) error {
+	ret := _m.Called(curUser, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.User, *projectv1.Project) error); ok {
+		r0 = rf(curUser, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CanSetProjectDescription provides a mock function with given fields: curUser, _a1
 func (_m *ProjectAuthZ) CanSetProjectDescription(curUser model.User, _a1 *projectv1.Project) error {
 	ret := _m.Called(curUser, _a1)
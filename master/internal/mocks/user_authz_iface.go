@@ -125,6 +125,20 @@ func (_m *UserAuthZ) CanGetUsersOwnSettings(curUser model.User) error {
 	return r0
 }
 
+// CanManageUsersAccessTokens provides a mock function with given fields: curUser, targetUser
+func (_m *UserAuthZ) CanManageUsersAccessTokens(curUser model.User, targetUser model.User) error {
+	ret := _m.Called(curUser, targetUser)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.User, model.User) error); ok {
+		r0 = rf(curUser, targetUser)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CanResetUsersOwnSettings provides a mock function with given fields: curUser
 func (_m *UserAuthZ) CanResetUsersOwnSettings(curUser model.User) error {
 	ret := _m.Called(curUser)
@@ -209,6 +223,20 @@ func (_m *UserAuthZ) CanSetUsersPassword(curUser model.User, targetUser model.Us
 	return r0
 }
 
+// CanSetUsersPasswordChangeRequired provides a mock function with given fields: curUser, targetUser
+func (_m *UserAuthZ) CanSetUsersPasswordChangeRequired(curUser model.User, targetUser model.User) error {
+	ret := _m.Called(curUser, targetUser)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.User, model.User) error); ok {
+		r0 = rf(curUser, targetUser)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CanSetUsersUsername provides a mock function with given fields: curUser, targetUser
 func (_m *UserAuthZ) CanSetUsersUsername(curUser model.User, targetUser model.User) error {
 	ret := _m.Called(curUser, targetUser)
@@ -121,6 +121,20 @@ func (_m *WorkspaceAuthZ) CanSetWorkspacesAgentUserGroup(curUser model.User, _a1
 	return r0
 }
 
+// CanSetWorkspacesCheckpointRetention provides a mock function with given fields: curUser, _a1
+func (_m *WorkspaceAuthZ) CanSetWorkspacesCheckpointRetention(curUser model.User, _a1 *workspacev1.Workspace) error {
+	ret := _m.Called(curUser, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.User, *workspacev1.Workspace) error); ok {
+		r0 = rf(curUser, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CanSetWorkspacesName provides a mock function with given fields: curUser, _a1
 func (_m *WorkspaceAuthZ) CanSetWorkspacesName(curUser model.User, _a1 *workspacev1.Workspace) error {
 	ret := _m.Called(curUser, _a1)
@@ -0,0 +1,451 @@
+// Package oidc signs users in with an OpenID Connect provider, using the authorization code flow
+// with PKCE.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/internal/usergroup"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+const (
+	// SSOPath is where users start signing in with the provider.
+	SSOPath = "/oidc/sso"
+	// CallbackPath is where the provider sends users back to after they sign in.
+	CallbackPath = "/oidc/callback"
+	// LogoutPath ends the session of the user both with Determined and the provider.
+	LogoutPath = "/oidc/logout"
+
+	// loginCookie holds the state of a sign-in attempt between the redirect to the provider and
+	// the callback.
+	loginCookie  = "oidc_login"
+	loginTimeout = 10 * time.Minute
+	// relayStateParam is the query parameter with the path to send users to once they're signed in.
+	relayStateParam   = "relayState"
+	defaultLandingURL = "/det/"
+	loginPageURL      = "/det/login"
+)
+
+// providerMetadata is the part of the discovery document of the provider that sign-in relies on.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+// Service signs users in with an OpenID Connect provider.
+type Service struct {
+	config config.OIDCConfig
+	db     *db.PgDB
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *providerMetadata
+	keys     map[string]*rsa.PublicKey
+}
+
+// New returns a service for the configured provider. The provider isn't contacted until the first
+// user signs in, so the master can start while it's unavailable.
+func New(pgDB *db.PgDB, conf config.OIDCConfig) *Service {
+	return &Service{
+		config: conf,
+		db:     pgDB,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// RegisterAPIHandler registers the sign-in and logout handlers.
+func RegisterAPIHandler(e *echo.Echo, s *Service) {
+	e.GET(SSOPath, s.getSSO)
+	e.GET(CallbackPath, s.getCallback)
+	e.GET(LogoutPath, s.getLogout)
+}
+
+// SSOURL returns the URL at which users start signing in with the provider.
+func SSOURL(conf config.OIDCConfig) string {
+	return strings.TrimSuffix(conf.IDPRecipientURL, "/") + SSOPath
+}
+
+func (s *Service) redirectURL() string {
+	return strings.TrimSuffix(s.config.IDPRecipientURL, "/") + CallbackPath
+}
+
+// providerMetadata returns the discovery document of the provider, fetching it the first time.
+func (s *Service) providerMetadata(ctx context.Context) (*providerMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.metadata != nil {
+		return s.metadata, nil
+	}
+
+	wellKnown := strings.TrimSuffix(s.config.IDPSSOURL, "/") + "/.well-known/openid-configuration"
+	var md providerMetadata
+	if err := s.getJSON(ctx, wellKnown, &md); err != nil {
+		return nil, errors.Wrap(err, "fetching OIDC discovery document")
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != strings.TrimSuffix(s.config.IDPSSOURL, "/") {
+		return nil, errors.Errorf("OIDC provider reports issuer %q instead of %q",
+			md.Issuer, s.config.IDPSSOURL)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing required endpoints")
+	}
+	s.metadata = &md
+	return s.metadata, nil
+}
+
+func (s *Service) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("failed to close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("GET %s returned %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (s *Service) oauth2Config(md *providerMetadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  md.AuthorizationEndpoint,
+			TokenURL: md.TokenEndpoint,
+		},
+		RedirectURL: s.redirectURL(),
+		Scopes:      s.config.Scopes,
+	}
+}
+
+// loginState is what the master remembers about a sign-in attempt, in a cookie, until the provider
+// sends the user back.
+type loginState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	RelayState   string `json:"relay_state"`
+}
+
+func (s *Service) getSSO(c echo.Context) error {
+	md, err := s.providerMetadata(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	ls := loginState{RelayState: safeRelayState(c.QueryParam(relayStateParam))}
+	for _, v := range []*string{&ls.State, &ls.Nonce, &ls.CodeVerifier} {
+		if *v, err = randomString(); err != nil {
+			return err
+		}
+	}
+	value, err := json.Marshal(ls)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(s.cookie(base64.RawURLEncoding.EncodeToString(value), loginTimeout))
+
+	return c.Redirect(http.StatusSeeOther, s.oauth2Config(md).AuthCodeURL(ls.State,
+		oauth2.SetAuthURLParam("nonce", ls.Nonce),
+		oauth2.SetAuthURLParam("code_challenge", pkceChallenge(ls.CodeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	))
+}
+
+func (s *Service) getCallback(c echo.Context) error {
+	ctx := c.Request().Context()
+	if e := c.QueryParam("error"); e != "" {
+		return echo.NewHTTPError(http.StatusUnauthorized,
+			fmt.Sprintf("OIDC provider refused sign-in: %s %s", e, c.QueryParam("error_description")))
+	}
+
+	ls, err := s.loginState(c)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(s.cookie("", -1))
+	if c.QueryParam("state") != ls.State {
+		return echo.NewHTTPError(http.StatusBadRequest, "OIDC state does not match")
+	}
+
+	md, err := s.providerMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	token, err := s.oauth2Config(md).Exchange(
+		context.WithValue(ctx, oauth2.HTTPClient, s.client),
+		c.QueryParam("code"),
+		oauth2.SetAuthURLParam("code_verifier", ls.CodeVerifier),
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized,
+			fmt.Sprintf("exchanging OIDC authorization code: %s", err))
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "OIDC provider returned no ID token")
+	}
+	claims, err := s.verifyIDToken(ctx, md, rawIDToken, ls.Nonce)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	username, ok := claims[s.config.AuthenticationClaim].(string)
+	if !ok || username == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized,
+			fmt.Sprintf("ID token has no %q claim", s.config.AuthenticationClaim))
+	}
+	u, err := s.userForLogin(username)
+	if err != nil {
+		return err
+	}
+	if s.config.GroupsClaim != "" {
+		groups := user.GroupsFromClaim(claims, s.config.GroupsClaim)
+		if err := usergroup.SyncUserGroups(ctx, u.ID, groups, user.GroupSourceOIDC); err != nil {
+			return err
+		}
+	}
+
+	sessionToken, err := s.db.StartUserSession(u)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(user.NewCookieFromToken(sessionToken))
+	return c.Redirect(http.StatusSeeOther, ls.RelayState)
+}
+
+func (s *Service) getLogout(c echo.Context) error {
+	if cookie, err := c.Cookie("auth"); err == nil {
+		if err := s.db.DeleteUserSessionByToken(cookie.Value); err != nil {
+			return err
+		}
+		cookie.Value = ""
+		cookie.Path = "/"
+		cookie.Expires = time.Unix(0, 0)
+		c.SetCookie(cookie)
+	}
+
+	md, err := s.providerMetadata(c.Request().Context())
+	if err != nil || md.EndSessionEndpoint == "" {
+		return c.Redirect(http.StatusSeeOther, loginPageURL)
+	}
+	q := url.Values{}
+	q.Set("client_id", s.config.ClientID)
+	q.Set("post_logout_redirect_uri", strings.TrimSuffix(s.config.IDPRecipientURL, "/")+loginPageURL)
+	return c.Redirect(http.StatusSeeOther, md.EndSessionEndpoint+"?"+q.Encode())
+}
+
+func (s *Service) loginState(c echo.Context) (*loginState, error) {
+	expired := echo.NewHTTPError(http.StatusBadRequest,
+		"OIDC sign-in expired or was started in another browser, please try again")
+	cookie, err := c.Cookie(loginCookie)
+	if err != nil {
+		return nil, expired
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, expired
+	}
+	var ls loginState
+	if err := json.Unmarshal(value, &ls); err != nil || ls.State == "" {
+		return nil, expired
+	}
+	return &ls, nil
+}
+
+func (s *Service) cookie(value string, maxAge time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:     loginCookie,
+		Value:    value,
+		Path:     "/oidc",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.config.IDPRecipientURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// verifyIDToken checks the signature and claims of an ID token and returns its claims.
+func (s *Service) verifyIDToken(
+	ctx context.Context, md *providerMetadata, raw, nonce string,
+) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, errors.Errorf("unsupported ID token signing method %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return s.publicKey(ctx, md, kid)
+	}); err != nil {
+		return nil, errors.Wrap(err, "invalid ID token")
+	}
+
+	switch {
+	case !claims.VerifyIssuer(md.Issuer, true):
+		return nil, errors.New("ID token has the wrong issuer")
+	case !claims.VerifyAudience(s.config.ClientID, true):
+		return nil, errors.New("ID token has the wrong audience")
+	case !claims.VerifyExpiresAt(time.Now().Unix(), true):
+		return nil, errors.New("ID token has expired")
+	case claims["nonce"] != nonce:
+		return nil, errors.New("ID token has the wrong nonce")
+	}
+	return claims, nil
+}
+
+// publicKey returns the key of the provider with the given ID, refetching the keys of the provider
+// if it's unknown in case they were rotated.
+func (s *Service) publicKey(
+	ctx context.Context, md *providerMetadata, kid string,
+) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := s.getJSON(ctx, md.JWKSURI, &jwks); err != nil {
+		return nil, errors.Wrap(err, "fetching OIDC provider keys")
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	s.keys = keys
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, errors.Errorf("OIDC provider has no key %q", kid)
+	}
+	return key, nil
+}
+
+// userForLogin returns the user signing in, creating them if they don't exist yet and users are
+// provisioned automatically.
+func (s *Service) userForLogin(username string) (*model.User, error) {
+	u, err := user.UserByUsername(username)
+	switch {
+	case errors.Is(err, db.ErrNotFound) && s.config.AutoProvisionUsers:
+		u = &model.User{
+			Username: strings.ToLower(username),
+			Active:   true,
+		}
+		if err := setRandomPassword(u); err != nil {
+			return nil, errors.Wrapf(err, "provisioning user %s", username)
+		}
+		if _, err := s.db.AddUser(u, nil); err != nil {
+			return nil, errors.Wrapf(err, "provisioning user %s", username)
+		}
+		log.Infof("provisioned user %s signing in with OIDC", u.Username)
+		return u, nil
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusForbidden,
+			fmt.Sprintf("user %s does not exist", username))
+	case err != nil:
+		return nil, err
+	case !u.Active:
+		return nil, echo.NewHTTPError(http.StatusForbidden, "user not active")
+	case u.ServiceAccount:
+		return nil, echo.NewHTTPError(http.StatusForbidden, user.ErrServiceAccountLogin.Error())
+	case !u.PasswordHash.Valid:
+		// Users signing in with OIDC, such as those provisioned before they got random passwords,
+		// must not be able to log in with an empty password instead.
+		if err := setRandomPassword(u); err != nil {
+			return nil, err
+		}
+		if err := s.db.UpdateUser(u, []string{"password_hash"}, nil); err != nil {
+			return nil, errors.Wrapf(err, "setting a password for user %s", u.Username)
+		}
+	}
+	return u, nil
+}
+
+// setRandomPassword gives a user who signs in with OIDC a random password that nobody knows, since
+// a user without a password can log in with an empty one.
+func setRandomPassword(u *model.User) error {
+	password, err := randomString()
+	if err != nil {
+		return err
+	}
+	return u.UpdatePasswordHash(password)
+}
+
+// safeRelayState returns the path to send users to once they're signed in, which must be on the
+// master so that sign-in can't be used to redirect users elsewhere.
+func safeRelayState(relayState string) string {
+	if !strings.HasPrefix(relayState, "/") || strings.HasPrefix(relayState, "//") ||
+		strings.HasPrefix(relayState, "/\\") {
+		return defaultLandingURL
+	}
+	return relayState
+}
+
+// pkceChallenge returns the S256 code challenge for a PKCE code verifier.
+func pkceChallenge(verifier string) string {
+	challenge := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(challenge[:])
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generating random value")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
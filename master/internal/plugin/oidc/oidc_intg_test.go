@@ -0,0 +1,87 @@
+//go:build integration
+// +build integration
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/pkg/etc"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestLoginFlow(t *testing.T) {
+	pgDB := db.MustResolveTestPostgres(t)
+	db.MustMigrateTestPostgres(t, pgDB, "file://../../../static/migrations")
+	require.NoError(t, etc.SetRootPath("../../../static/srv"))
+
+	username := "oidc-" + model.NewTaskID().String()[:8] + "@example.com"
+	issuer := newMockIssuer(t, jwt.MapClaims{"email": username})
+	conf := issuer.config()
+	e := echo.New()
+
+	login := func(s *Service) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, SSOPath+"?relayState=/det/models", nil)
+		require.NoError(t, s.getSSO(e.NewContext(req, rec)))
+
+		callback := issuer.authorize(rec.Header().Get("Location"))
+		req = httptest.NewRequest(http.MethodGet, callback.String(), nil)
+		for _, cookie := range rec.Result().Cookies() {
+			req.AddCookie(cookie)
+		}
+		rec = httptest.NewRecorder()
+		if err := s.getCallback(e.NewContext(req, rec)); err != nil {
+			e.HTTPErrorHandler(err, e.NewContext(req, rec))
+		}
+		return rec
+	}
+
+	// Users who don't exist yet can't sign in unless they're provisioned automatically.
+	rec := login(New(pgDB, conf))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	conf.AutoProvisionUsers = true
+	rec = login(New(pgDB, conf))
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	require.Equal(t, "/det/models", rec.Header().Get("Location"))
+
+	var token string
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "auth" {
+			token = cookie.Value
+		}
+	}
+	u, _, err := user.UserByToken(token, &model.ExternalSessions{})
+	require.NoError(t, err)
+	require.Equal(t, username, u.Username)
+	require.True(t, u.Active)
+
+	// Provisioned users can't log in with a password, including an empty one.
+	require.True(t, u.PasswordHash.Valid)
+	_, err = user.Authenticate(context.Background(), username, "", "127.0.0.1")
+	require.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+	// Users provisioned without a password get one the next time they sign in.
+	_, err = db.Bun().NewUpdate().Table("users").
+		Set("password_hash = NULL").
+		Where("id = ?", u.ID).
+		Exec(context.Background())
+	require.NoError(t, err)
+	rec = login(New(pgDB, conf))
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	u, err = user.UserByUsername(username)
+	require.NoError(t, err)
+	require.True(t, u.PasswordHash.Valid)
+	_, err = user.Authenticate(context.Background(), username, "", "127.0.0.1")
+	require.ErrorIs(t, err, user.ErrInvalidCredentials)
+}
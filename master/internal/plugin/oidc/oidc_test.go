@@ -0,0 +1,243 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/determined-ai/determined/master/internal/config"
+)
+
+const (
+	testClientID     = "determined"
+	testClientSecret = "secret"
+	testKeyID        = "key-1"
+)
+
+// mockIssuer is an in-process OpenID Connect provider that issues ID tokens for a fixed set of
+// claims to whoever presents the authorization code it handed out.
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu        sync.Mutex
+	claims    jwt.MapClaims
+	code      string
+	challenge string
+	nonce     string
+}
+
+func newMockIssuer(t *testing.T, claims jwt.MapClaims) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	m := &mockIssuer{t: t, key: key, claims: claims}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		m.writeJSON(w, map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/keys",
+			"end_session_endpoint":   m.server.URL + "/logout",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		m.writeJSON(w, map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKeyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", m.token)
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockIssuer) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(m.t, json.NewEncoder(w).Encode(v))
+}
+
+// authorize does what the provider does when the user signs in, given the URL the master sent the
+// user to, and returns the URL the provider sends the user back to.
+func (m *mockIssuer) authorize(authURL string) *url.URL {
+	u, err := url.Parse(authURL)
+	require.NoError(m.t, err)
+	q := u.Query()
+	require.Equal(m.t, "S256", q.Get("code_challenge_method"))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.code = "code-" + q.Get("state")
+	m.challenge = q.Get("code_challenge")
+	m.nonce = q.Get("nonce")
+
+	callback, err := url.Parse(q.Get("redirect_uri"))
+	require.NoError(m.t, err)
+	callback.RawQuery = url.Values{"code": {m.code}, "state": {q.Get("state")}}.Encode()
+	return callback
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(m.t, r.ParseForm())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.PostForm.Get("code") != m.code ||
+		pkceChallenge(r.PostForm.Get("code_verifier")) != m.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		m.writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	m.writeJSON(w, map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     m.idToken(jwt.MapClaims{"nonce": m.nonce}),
+	})
+}
+
+// idToken signs an ID token with the standard claims, the claims of the issuer and the overrides.
+func (m *mockIssuer) idToken(overrides jwt.MapClaims) string {
+	claims := jwt.MapClaims{
+		"iss": m.server.URL,
+		"aud": testClientID,
+		"sub": "1234",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range m.claims {
+		claims[k] = v
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(m.key)
+	require.NoError(m.t, err)
+	return signed
+}
+
+func (m *mockIssuer) config() config.OIDCConfig {
+	conf := config.DefaultOIDCConfig()
+	conf.Enabled = true
+	conf.IDPSSOURL = m.server.URL
+	conf.ClientID = testClientID
+	conf.ClientSecret = testClientSecret
+	conf.IDPRecipientURL = "http://master.example.com"
+	return conf
+}
+
+func TestSSORedirect(t *testing.T) {
+	issuer := newMockIssuer(t, nil)
+	s := New(nil, issuer.config())
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, SSOPath+"?relayState=/det/experiments",
+		nil), rec)
+	require.NoError(t, s.getSSO(c))
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+
+	loc, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, issuer.server.URL+"/authorize", loc.Scheme+"://"+loc.Host+loc.Path)
+	q := loc.Query()
+	require.Equal(t, testClientID, q.Get("client_id"))
+	require.Equal(t, "http://master.example.com"+CallbackPath, q.Get("redirect_uri"))
+	require.Equal(t, "code", q.Get("response_type"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+
+	// The login state is kept in a cookie and matches what was sent to the provider.
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	req := httptest.NewRequest(http.MethodGet, CallbackPath, nil)
+	req.AddCookie(cookies[0])
+	ls, err := s.loginState(e.NewContext(req, httptest.NewRecorder()))
+	require.NoError(t, err)
+	require.Equal(t, q.Get("state"), ls.State)
+	require.Equal(t, q.Get("nonce"), ls.Nonce)
+	require.Equal(t, "/det/experiments", ls.RelayState)
+	require.Equal(t, pkceChallenge(ls.CodeVerifier), q.Get("code_challenge"))
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	issuer := newMockIssuer(t, jwt.MapClaims{"email": "alice@example.com"})
+	s := New(nil, issuer.config())
+	md, err := s.providerMetadata(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	require.NoError(t, err)
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	claims, err := s.verifyIDToken(ctx, md, issuer.idToken(jwt.MapClaims{"nonce": "n"}), "n")
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", claims["email"])
+
+	for name, overrides := range map[string]jwt.MapClaims{
+		"wrong nonce":    {"nonce": "other"},
+		"wrong audience": {"nonce": "n", "aud": "someone-else"},
+		"wrong issuer":   {"nonce": "n", "iss": "https://evil.example.com"},
+		"expired":        {"nonce": "n", "exp": time.Now().Add(-time.Minute).Unix()},
+	} {
+		_, err := s.verifyIDToken(ctx, md, issuer.idToken(overrides), "n")
+		require.Error(t, err, name)
+	}
+
+	// Tokens signed by anyone but the provider are rejected.
+	other := newMockIssuer(t, nil)
+	other.server.URL = issuer.server.URL
+	_, err = s.verifyIDToken(ctx, md, other.idToken(jwt.MapClaims{"nonce": "n"}), "n")
+	require.Error(t, err)
+}
+
+func TestCodeExchange(t *testing.T) {
+	issuer := newMockIssuer(t, nil)
+	s := New(nil, issuer.config())
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+	md, err := s.providerMetadata(ctx)
+	require.NoError(t, err)
+
+	callback := issuer.authorize(s.oauth2Config(md).AuthCodeURL("state",
+		oauth2.SetAuthURLParam("nonce", "n"),
+		oauth2.SetAuthURLParam("code_challenge", pkceChallenge("verifier")),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256")))
+	require.Equal(t, CallbackPath, callback.Path)
+
+	_, err = s.oauth2Config(md).Exchange(ctx, callback.Query().Get("code"),
+		oauth2.SetAuthURLParam("code_verifier", "wrong"))
+	require.Error(t, err, "the code must not be redeemable without the PKCE verifier")
+	token, err := s.oauth2Config(md).Exchange(ctx, callback.Query().Get("code"),
+		oauth2.SetAuthURLParam("code_verifier", "verifier"))
+	require.NoError(t, err)
+	_, err = s.verifyIDToken(ctx, md, token.Extra("id_token").(string), "n")
+	require.NoError(t, err)
+}
+
+func TestSafeRelayState(t *testing.T) {
+	for relayState, expected := range map[string]string{
+		"":                         defaultLandingURL,
+		"/det/models":              "/det/models",
+		"https://evil.example.com": defaultLandingURL,
+		"//evil.example.com":       defaultLandingURL,
+		"/\\evil.example.com":      defaultLandingURL,
+	} {
+		require.Equal(t, expected, safeRelayState(relayState), relayState)
+	}
+}
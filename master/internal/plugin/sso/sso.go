@@ -5,22 +5,40 @@ import (
 
 	"github.com/determined-ai/determined/master/internal/config"
 	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/plugin/oidc"
 	"github.com/determined-ai/determined/master/pkg/aproto"
 	"github.com/determined-ai/determined/proto/pkg/apiv1"
 )
 
 // AddProviderInfoToMasterResponse modifies passed in master response adds sso
-// provider information. In OSS this is a no-op. While having two functions that
-// just set a field is somewhat awkward it avoids having to have OSS have any
-// requirements that masterResp/masterInfo has a field defined for provider info.
-func AddProviderInfoToMasterResponse(config *config.Config, masterResp *apiv1.GetMasterResponse) {}
+// provider information. While having two functions that just set a field is
+// somewhat awkward it avoids having to have any requirements that
+// masterResp/masterInfo has a field defined for provider info.
+func AddProviderInfoToMasterResponse(config *config.Config, masterResp *apiv1.GetMasterResponse) {
+	if config.OIDC.Enabled {
+		masterResp.SsoProviders = append(masterResp.SsoProviders, &apiv1.SSOProvider{
+			Name:   config.OIDC.Provider,
+			SsoUrl: oidc.SSOURL(config.OIDC),
+		})
+	}
+}
 
 // AddProviderInfoToMasterInfo modifies passed in master info adds sso
-// provider information. In OSS this is a no-op.
-func AddProviderInfoToMasterInfo(config *config.Config, masterInfo *aproto.MasterInfo) {}
+// provider information.
+func AddProviderInfoToMasterInfo(config *config.Config, masterInfo *aproto.MasterInfo) {
+	if config.OIDC.Enabled {
+		masterInfo.SSOProviders = append(masterInfo.SSOProviders, aproto.SSOProviderInfo{
+			Name:   config.OIDC.Provider,
+			SSOURL: oidc.SSOURL(config.OIDC),
+		})
+	}
+}
 
 // RegisterAPIHandlers registers needed API handlers
-// determined by master config. In OSS this is just a no-op.
+// determined by master config.
 func RegisterAPIHandlers(config *config.Config, db *db.PgDB, echo *echo.Echo) error {
+	if config.OIDC.Enabled {
+		oidc.RegisterAPIHandler(echo, oidc.New(db, config.OIDC))
+	}
 	return nil
 }
@@ -78,6 +78,17 @@ func (a *ProjectAuthZBasic) CanSetProjectDescription(
 	return nil
 }
 
+// CanSetProjectCheckpointRetention returns an error if a non admin isn't the owner of the
+// project or workspace.
+func (a *ProjectAuthZBasic) CanSetProjectCheckpointRetention(
+	curUser model.User, project *projectv1.Project,
+) error {
+	if err := shouldBeAdminOrOwnWorkspaceOrProject(curUser, project); err != nil {
+		return fmt.Errorf("can't set project checkpoint retention: %w", err)
+	}
+	return nil
+}
+
 // CanDeleteProject returns an error if if a non admin isn't the owner of the project or workspace.
 func (a *ProjectAuthZBasic) CanDeleteProject(curUser model.User, project *projectv1.Project) error {
 	if err := shouldBeAdminOrOwnWorkspaceOrProject(curUser, project); err != nil {
@@ -38,6 +38,10 @@ type ProjectAuthZ interface {
 		curUser model.User, exp *model.Experiment, from, to *projectv1.Project,
 	) error
 
+	// PUT /projects/:project_id/checkpoint-retention
+	// DELETE /projects/:project_id/checkpoint-retention
+	CanSetProjectCheckpointRetention(curUser model.User, project *projectv1.Project) error
+
 	// POST /api/v1/projects/:project_id/archive
 	CanArchiveProject(curUser model.User, project *projectv1.Project) error
 	// POST /api/v1/projects/:project_id/unarchive
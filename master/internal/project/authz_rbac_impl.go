@@ -0,0 +1,127 @@
+package project
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/internal/rbac"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/projectv1"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+	"github.com/determined-ai/determined/proto/pkg/workspacev1"
+)
+
+// ProjectAuthZRBAC is role-based access control for projects.
+type ProjectAuthZRBAC struct{}
+
+// CanGetProject returns whether the user can view the project.
+func (a *ProjectAuthZRBAC) CanGetProject(
+	curUser model.User, project *projectv1.Project,
+) (canGetProject bool, serverError error) {
+	return rbac.HasPermission(context.TODO(), curUser, projectScope(project),
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_PROJECT)
+}
+
+// CanCreateProject requires the permission to create projects in the workspace.
+func (a *ProjectAuthZRBAC) CanCreateProject(
+	curUser model.User, targetWorkspace *workspacev1.Workspace,
+) error {
+	return rbac.CheckForPermission(context.TODO(), curUser,
+		rbac.WorkspaceScope(int(targetWorkspace.Id)),
+		rbacv1.PermissionType_PERMISSION_TYPE_CREATE_PROJECT)
+}
+
+// CanSetProjectNotes requires the permission to update the project.
+func (a *ProjectAuthZRBAC) CanSetProjectNotes(
+	curUser model.User, project *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT)
+}
+
+// CanSetProjectName requires the permission to update the project.
+func (a *ProjectAuthZRBAC) CanSetProjectName(
+	curUser model.User, project *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT)
+}
+
+// CanSetProjectDescription requires the permission to update the project.
+func (a *ProjectAuthZRBAC) CanSetProjectDescription(
+	curUser model.User, project *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT)
+}
+
+// CanSetProjectCheckpointRetention requires the permission to update the project.
+func (a *ProjectAuthZRBAC) CanSetProjectCheckpointRetention(
+	curUser model.User, project *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT)
+}
+
+// CanDeleteProject requires the permission to delete the project.
+func (a *ProjectAuthZRBAC) CanDeleteProject(
+	curUser model.User, targetProject *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, targetProject,
+		rbacv1.PermissionType_PERMISSION_TYPE_DELETE_PROJECT)
+}
+
+// CanMoveProject requires the permissions to delete the project from the workspace it's in and
+// to create projects in the workspace it's moved to.
+func (a *ProjectAuthZRBAC) CanMoveProject(
+	curUser model.User, project *projectv1.Project, from, to *workspacev1.Workspace,
+) error {
+	if err := checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_DELETE_PROJECT); err != nil {
+		return err
+	}
+	return rbac.CheckForPermission(context.TODO(), curUser, rbac.WorkspaceScope(int(to.Id)),
+		rbacv1.PermissionType_PERMISSION_TYPE_CREATE_PROJECT)
+}
+
+// CanMoveProjectExperiments requires the permissions to delete the experiment from the project
+// it's in and to create experiments in the project it's moved to.
+func (a *ProjectAuthZRBAC) CanMoveProjectExperiments(
+	curUser model.User, exp *model.Experiment, from, to *projectv1.Project,
+) error {
+	if err := checkProjectPermission(curUser, from,
+		rbacv1.PermissionType_PERMISSION_TYPE_DELETE_EXPERIMENT); err != nil {
+		return err
+	}
+	return checkProjectPermission(curUser, to,
+		rbacv1.PermissionType_PERMISSION_TYPE_CREATE_EXPERIMENT)
+}
+
+// CanArchiveProject requires the permission to update the project.
+func (a *ProjectAuthZRBAC) CanArchiveProject(
+	curUser model.User, project *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT)
+}
+
+// CanUnarchiveProject requires the permission to update the project.
+func (a *ProjectAuthZRBAC) CanUnarchiveProject(
+	curUser model.User, project *projectv1.Project,
+) error {
+	return checkProjectPermission(curUser, project,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT)
+}
+
+func projectScope(project *projectv1.Project) rbac.Scope {
+	return rbac.ProjectScope(int(project.WorkspaceId), int(project.Id))
+}
+
+func checkProjectPermission(
+	curUser model.User, project *projectv1.Project, permission rbacv1.PermissionType,
+) error {
+	return rbac.CheckForPermission(context.TODO(), curUser, projectScope(project), permission)
+}
+
+func init() {
+	AuthZProvider.Register("rbac", &ProjectAuthZRBAC{})
+}
@@ -0,0 +1,220 @@
+package prom
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	opentelemetry "github.com/determined-ai/determined/master/pkg/opentelemetry"
+)
+
+// Label names of the scheduler metrics.
+const (
+	// ResourcePoolLabel is the name of the resource pool.
+	ResourcePoolLabel = "resource_pool"
+	// ReasonLabel is why an allocation was preempted.
+	ReasonLabel = "reason"
+	// StateLabel is the state of an agent or of a slot.
+	StateLabel = "state"
+	// AgentLabelLabel is the label of an agent.
+	AgentLabelLabel = "agent_label"
+)
+
+// States of agents and slots.
+const (
+	// AgentStateEnabled is the state of agents that are enabled.
+	AgentStateEnabled = "enabled"
+	// AgentStateDraining is the state of agents that are draining.
+	AgentStateDraining = "draining"
+	// AgentStateDisabled is the state of agents that are disabled.
+	AgentStateDisabled = "disabled"
+	// SlotStateUsed is the state of slots that are allocated to containers.
+	SlotStateUsed = "used"
+	// SlotStateTotal counts all the slots that the scheduler can allocate, used or not.
+	SlotStateTotal = "total"
+)
+
+var (
+	allocationQueueBuckets = prometheus.ExponentialBuckets(0.5, 2, 16)
+	schedulingPassBuckets  = prometheus.ExponentialBuckets(0.001, 2, 14)
+)
+
+var (
+	pendingJobs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "det",
+		Name:      "scheduler_pending_jobs",
+		Help:      "the number of jobs with allocations waiting for resources",
+	}, []string{ResourcePoolLabel})
+
+	allocationQueueSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: "det",
+		Name:      "allocation_queue_seconds",
+		Help:      "how long allocations waited for resources before they were allocated",
+		Buckets:   allocationQueueBuckets,
+	}, []string{ResourcePoolLabel})
+
+	allocationPreemptions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "det",
+		Name:      "allocation_preemptions_total",
+		Help:      "the number of allocations the scheduler asked to release their resources",
+	}, []string{ResourcePoolLabel, ReasonLabel})
+
+	schedulingPassSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: "det",
+		Name:      "scheduler_pass_seconds",
+		Help:      "how long scheduling passes took, including allocating and releasing resources",
+		Buckets:   schedulingPassBuckets,
+	}, []string{ResourcePoolLabel})
+
+	agents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "det",
+		Name:      "agents",
+		Help:      "the number of agents by state",
+	}, []string{ResourcePoolLabel, StateLabel})
+
+	slots = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "det",
+		Name:      "slots",
+		Help:      "the number of used slots and of total slots by agent label",
+	}, []string{ResourcePoolLabel, AgentLabelLabel, StateLabel})
+
+	// slotLabels tracks the agent labels that have slot gauges, by resource pool, so that the gauges
+	// of labels whose agents are all gone are removed.
+	slotLabels   = map[string]map[string]bool{}
+	slotLabelsMu sync.Mutex
+)
+
+// The same measurements are recorded for OpenTelemetry, which pushes them when it is enabled.
+var (
+	otelPendingJobs = otelInstrument(opentelemetry.Meter().Int64Gauge(
+		"det.scheduler.pending_jobs",
+		metric.WithDescription("the number of jobs with allocations waiting for resources"),
+	))
+
+	otelAllocationQueueSeconds = otelInstrument(opentelemetry.Meter().Float64Histogram(
+		"det.allocation.queue_duration",
+		metric.WithDescription("how long allocations waited for resources before they were allocated"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(allocationQueueBuckets...),
+	))
+
+	otelAllocationPreemptions = otelInstrument(opentelemetry.Meter().Int64Counter(
+		"det.allocation.preemptions",
+		metric.WithDescription(
+			"the number of allocations the scheduler asked to release their resources"),
+	))
+
+	otelSchedulingPassSeconds = otelInstrument(opentelemetry.Meter().Float64Histogram(
+		"det.scheduler.pass_duration",
+		metric.WithDescription(
+			"how long scheduling passes took, including allocating and releasing resources"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(schedulingPassBuckets...),
+	))
+
+	otelAgents = otelInstrument(opentelemetry.Meter().Int64Gauge(
+		"det.agents",
+		metric.WithDescription("the number of agents by state"),
+	))
+
+	otelSlots = otelInstrument(opentelemetry.Meter().Int64Gauge(
+		"det.slots",
+		metric.WithDescription("the number of used slots and of total slots by agent label"),
+	))
+)
+
+// otelInstrument logs the error of creating an instrument, which still records nothing if it
+// can't be created.
+func otelInstrument[T any](instrument T, err error) T {
+	if err != nil {
+		log.WithError(err).Error("failed to create OpenTelemetry instrument")
+	}
+	return instrument
+}
+
+func poolAttributes(pool string, kvs ...attribute.KeyValue) metric.MeasurementOption {
+	return metric.WithAttributes(append(kvs, attribute.String(ResourcePoolLabel, pool))...)
+}
+
+// SlotCounts are the used and total slots of the agents of a resource pool with a label.
+type SlotCounts struct {
+	Used  int
+	Total int
+}
+
+// SetPendingJobs sets the number of jobs with allocations waiting for resources in a pool.
+func SetPendingJobs(pool string, n int) {
+	pendingJobs.WithLabelValues(pool).Set(float64(n))
+	otelPendingJobs.Record(context.Background(), int64(n), poolAttributes(pool))
+}
+
+// ObserveAllocationQueueTime records how long an allocation waited for resources.
+func ObserveAllocationQueueTime(pool string, d time.Duration) {
+	allocationQueueSeconds.WithLabelValues(pool).Observe(d.Seconds())
+	otelAllocationQueueSeconds.Record(context.Background(), d.Seconds(), poolAttributes(pool))
+}
+
+// IncAllocationPreemptions counts an allocation that was asked to release its resources.
+func IncAllocationPreemptions(pool string, reason string) {
+	allocationPreemptions.WithLabelValues(pool, reason).Inc()
+	otelAllocationPreemptions.Add(context.Background(), 1,
+		poolAttributes(pool, attribute.String(ReasonLabel, reason)))
+}
+
+// ObserveSchedulingPass records how long a scheduling pass of a pool took.
+func ObserveSchedulingPass(pool string, d time.Duration) {
+	schedulingPassSeconds.WithLabelValues(pool).Observe(d.Seconds())
+	otelSchedulingPassSeconds.Record(context.Background(), d.Seconds(), poolAttributes(pool))
+}
+
+// SetAgents sets the number of agents of a pool in each state.
+func SetAgents(pool string, enabled, draining, disabled int) {
+	agents.WithLabelValues(pool, AgentStateEnabled).Set(float64(enabled))
+	agents.WithLabelValues(pool, AgentStateDraining).Set(float64(draining))
+	agents.WithLabelValues(pool, AgentStateDisabled).Set(float64(disabled))
+	for state, n := range map[string]int{
+		AgentStateEnabled:  enabled,
+		AgentStateDraining: draining,
+		AgentStateDisabled: disabled,
+	} {
+		otelAgents.Record(context.Background(), int64(n),
+			poolAttributes(pool, attribute.String(StateLabel, state)))
+	}
+}
+
+// SetSlots sets the used and total slots of a pool by agent label.
+func SetSlots(pool string, counts map[string]SlotCounts) {
+	slotLabelsMu.Lock()
+	defer slotLabelsMu.Unlock()
+
+	for label := range slotLabels[pool] {
+		if _, ok := counts[label]; !ok {
+			slots.DeleteLabelValues(pool, label, SlotStateUsed)
+			slots.DeleteLabelValues(pool, label, SlotStateTotal)
+			// OpenTelemetry gauges can't be deleted, so those of labels that are gone drop to zero.
+			recordOtelSlots(pool, label, SlotCounts{})
+		}
+	}
+	labels := make(map[string]bool, len(counts))
+	for label, c := range counts {
+		slots.WithLabelValues(pool, label, SlotStateUsed).Set(float64(c.Used))
+		slots.WithLabelValues(pool, label, SlotStateTotal).Set(float64(c.Total))
+		recordOtelSlots(pool, label, c)
+		labels[label] = true
+	}
+	slotLabels[pool] = labels
+}
+
+func recordOtelSlots(pool, label string, c SlotCounts) {
+	agentLabel := attribute.String(AgentLabelLabel, label)
+	otelSlots.Record(context.Background(), int64(c.Used),
+		poolAttributes(pool, agentLabel, attribute.String(StateLabel, SlotStateUsed)))
+	otelSlots.Record(context.Background(), int64(c.Total),
+		poolAttributes(pool, agentLabel, attribute.String(StateLabel, SlotStateTotal)))
+}
@@ -0,0 +1,156 @@
+package rbac
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/internal/authz"
+	"github.com/determined-ai/determined/master/internal/config"
+	detContext "github.com/determined-ai/determined/master/internal/context"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// RegisterAPIHandler registers the REST handlers for role assignments scoped to projects, which
+// the protobuf API has no way to express.
+func RegisterAPIHandler(echo *echo.Echo) {
+	echo.GET("/projects/:project_id/role-assignments", api.Route(getProjectRoleAssignments))
+	echo.POST("/projects/:project_id/role-assignments", api.Route(postProjectRoleAssignment))
+	echo.DELETE("/projects/:project_id/role-assignments/:assignment_id",
+		api.Route(deleteProjectRoleAssignment))
+}
+
+// projectRoleAssignmentRequest assigns a role within a project to either a group or a user.
+type projectRoleAssignmentRequest struct {
+	RoleID  int           `json:"role_id"`
+	GroupID *int          `json:"group_id"`
+	UserID  *model.UserID `json:"user_id"`
+}
+
+func getProjectRoleAssignments(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID int `path:"project_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	ctx := c.Request().Context()
+	scope, err := projectScopeOf(c, args.ProjectID,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_PROJECT)
+	if err != nil {
+		return nil, err
+	}
+	return RoleAssignments(ctx, RoleAssignmentFilter{ProjectID: *scope.ProjectID})
+}
+
+func postProjectRoleAssignment(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID int `path:"project_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	var req projectRoleAssignmentRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if (req.GroupID == nil) == (req.UserID == nil) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest,
+			"exactly one of group_id and user_id must be set")
+	}
+
+	ctx := c.Request().Context()
+	if _, err := projectScopeOf(c, args.ProjectID,
+		rbacv1.PermissionType_PERMISSION_TYPE_ASSIGN_ROLES); err != nil {
+		return nil, err
+	}
+
+	roles, err := RolesByID(ctx, nil, req.RoleID)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case err != nil:
+		return nil, err
+	case roles[0].GlobalOnly():
+		return nil, echo.NewHTTPError(http.StatusBadRequest,
+			"role "+roles[0].Name+" can only be assigned globally")
+	}
+
+	a := RoleAssignment{RoleID: req.RoleID, ScopeProjectID: &args.ProjectID}
+	if req.GroupID != nil {
+		a.GroupID = *req.GroupID
+	} else {
+		groupID, err := PersonalGroupID(ctx, nil, *req.UserID)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "user not found")
+		} else if err != nil {
+			return nil, err
+		}
+		a.GroupID = groupID
+	}
+	switch err := AddRoleAssignmentsTx(ctx, nil, a); {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound, "group not found")
+	case err != nil:
+		return nil, err
+	}
+	return a, nil
+}
+
+func deleteProjectRoleAssignment(c echo.Context) (interface{}, error) {
+	args := struct {
+		ProjectID    int `path:"project_id"`
+		AssignmentID int `path:"assignment_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	ctx := c.Request().Context()
+	if _, err := projectScopeOf(c, args.ProjectID,
+		rbacv1.PermissionType_PERMISSION_TYPE_ASSIGN_ROLES); err != nil {
+		return nil, err
+	}
+
+	assignments, err := RoleAssignments(ctx, RoleAssignmentFilter{ProjectID: args.ProjectID})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignments {
+		if a.ID == args.AssignmentID {
+			return nil, RemoveRoleAssignmentsTx(ctx, nil, a)
+		}
+	}
+	return nil, echo.NewHTTPError(http.StatusNotFound,
+		"role assignment not found in project")
+}
+
+// projectScopeOf returns the scope of a project after checking that role-based access control is
+// enabled and that the current user has the permission within the project.
+func projectScopeOf(
+	c echo.Context, projectID int, permission rbacv1.PermissionType,
+) (Scope, error) {
+	if config.GetAuthZConfig().Type != config.RBACAuthZType {
+		return Scope{}, echo.NewHTTPError(http.StatusNotImplemented,
+			"role-based access control is not enabled")
+	}
+	ctx := c.Request().Context()
+	workspaceID, err := ProjectWorkspaceID(ctx, projectID)
+	if errors.Is(err, db.ErrNotFound) {
+		return Scope{}, echo.NewHTTPError(http.StatusNotFound, "project not found")
+	} else if err != nil {
+		return Scope{}, err
+	}
+	scope := ProjectScope(workspaceID, projectID)
+
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	err = CheckForPermission(ctx, curUser, scope, permission)
+	if _, ok := err.(authz.PermissionDeniedError); ok {
+		return Scope{}, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+	return scope, err
+}
@@ -5,12 +5,11 @@ import (
 
 	"github.com/uptrace/bun"
 
+	"github.com/determined-ai/determined/master/internal/config"
 	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/proto/pkg/apiv1"
 )
 
-var rbacAPIServer RBACAPIServer = &rbacAPIServerStub{}
-
 // RBACAPIServer is the interface for all functions in RBAC.
 type RBACAPIServer interface {
 	GetPermissionsSummary(context.Context, *apiv1.GetPermissionsSummaryRequest) (
@@ -36,3 +35,12 @@ type RBACAPIServer interface {
 		ctx context.Context, idb bun.IDB, workspaceID int, userID model.UserID,
 	) error
 }
+
+// rbacAPIServer returns the RBAC implementation if role-based access control is the configured
+// authz type, and a stub that reports it as unimplemented otherwise.
+func rbacAPIServer() RBACAPIServer {
+	if config.GetAuthZConfig().Type == config.RBACAuthZType {
+		return &rbacAPIServerImpl{}
+	}
+	return &rbacAPIServerStub{}
+}
@@ -0,0 +1,509 @@
+package rbac
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/api/apiutils"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/grpcutil"
+	"github.com/determined-ai/determined/master/internal/user"
+	"github.com/determined-ai/determined/master/internal/usergroup"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/proto/pkg/apiv1"
+	"github.com/determined-ai/determined/proto/pkg/groupv1"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+	"github.com/determined-ai/determined/proto/pkg/userv1"
+)
+
+// rbacAPIServerImpl implements RBACAPIServer with the roles and role assignments in the
+// database. Roles may be listed by any user, while reading the assignments of others goes through
+// the RBACAuthZ interface and assigning roles requires the ASSIGN_ROLES permission within the
+// scope of the assignment.
+type rbacAPIServerImpl struct{}
+
+func (s *rbacAPIServerImpl) GetPermissionsSummary(
+	ctx context.Context, req *apiv1.GetPermissionsSummaryRequest,
+) (resp *apiv1.GetPermissionsSummaryResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	curUser, _, err := grpcutil.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := RoleAssignments(ctx, RoleAssignmentFilter{
+		UserID: curUser.ID, IncludeUserGroups: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if curUser.Admin {
+		// Admins have every permission, as if they were assigned the cluster admin role.
+		roleID, err := RoleIDByName(ctx, nil, ClusterAdminRole)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, RoleAssignment{RoleID: roleID})
+	}
+	roles, err := rolesOf(ctx, assignments)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetPermissionsSummaryResponse{
+		Roles:       roles.Proto(),
+		Assignments: summarizeAssignments(assignments),
+	}, nil
+}
+
+func (s *rbacAPIServerImpl) GetGroupsAndUsersAssignedToWorkspace(
+	ctx context.Context, req *apiv1.GetGroupsAndUsersAssignedToWorkspaceRequest,
+) (resp *apiv1.GetGroupsAndUsersAssignedToWorkspaceResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	curUser, _, err := grpcutil.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = CheckForPermission(ctx, *curUser, WorkspaceScope(int(req.WorkspaceId)),
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_WORKSPACE); err != nil {
+		return nil, err
+	}
+
+	assignments, err := RoleAssignments(ctx, RoleAssignmentFilter{
+		WorkspaceID: int(req.WorkspaceId),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &apiv1.GetGroupsAndUsersAssignedToWorkspaceResponse{
+		Groups:                []*groupv1.GroupDetails{},
+		UsersAssignedDirectly: []*userv1.User{},
+	}
+	var matched []RoleAssignment
+	seenGroups := make(map[int]bool)
+	for _, a := range assignments {
+		if a.UserID != nil {
+			u, err := user.UserByID(model.UserID(*a.UserID))
+			if err != nil {
+				return nil, err
+			}
+			if !matchesName(req.Name, u.Username, u.DisplayName.ValueOrZero()) {
+				continue
+			}
+			matched = append(matched, a)
+			if !seenGroups[a.GroupID] {
+				seenGroups[a.GroupID] = true
+				resp.UsersAssignedDirectly = append(resp.UsersAssignedDirectly,
+					ptrs.Ptr(u.ToUser()).Proto())
+			}
+			continue
+		}
+
+		g, err := usergroup.GroupByIDTx(ctx, nil, a.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if !matchesName(req.Name, g.Name) {
+			continue
+		}
+		matched = append(matched, a)
+		if !seenGroups[a.GroupID] {
+			seenGroups[a.GroupID] = true
+			users, err := usergroup.UsersInGroupTx(ctx, nil, g.ID)
+			if err != nil {
+				return nil, err
+			}
+			resp.Groups = append(resp.Groups, &groupv1.GroupDetails{
+				GroupId: int32(g.ID),
+				Name:    g.Name,
+				Users:   model.Users(users).Proto(),
+			})
+		}
+	}
+
+	roles, err := rolesOf(ctx, matched)
+	if err != nil {
+		return nil, err
+	}
+	resp.Assignments = withAssignments(roles, matched)
+	return resp, nil
+}
+
+func (s *rbacAPIServerImpl) GetRolesByID(
+	ctx context.Context, req *apiv1.GetRolesByIDRequest,
+) (resp *apiv1.GetRolesByIDResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	curUser, _, err := grpcutil.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = AuthZProvider.Get().CanGetRoles(ctx, *curUser); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(req.RoleIds))
+	for i, id := range req.RoleIds {
+		ids[i] = int(id)
+	}
+	roles, err := RolesByID(ctx, nil, ids...)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := RoleAssignments(ctx, RoleAssignmentFilter{RoleIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetRolesByIDResponse{Roles: withAssignments(roles, assignments)}, nil
+}
+
+func (s *rbacAPIServerImpl) GetRolesAssignedToUser(
+	ctx context.Context, req *apiv1.GetRolesAssignedToUserRequest,
+) (resp *apiv1.GetRolesAssignedToUserResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	curUser, _, err := grpcutil.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = AuthZProvider.Get().CanGetUserRoles(
+		ctx, *curUser, model.UserID(req.UserId)); err != nil {
+		return nil, err
+	}
+
+	assignments, err := RoleAssignments(ctx, RoleAssignmentFilter{
+		UserID: model.UserID(req.UserId), IncludeUserGroups: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	roles, err := rolesOf(ctx, assignments)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetRolesAssignedToUserResponse{
+		Roles: withAssignments(roles, assignments),
+	}, nil
+}
+
+func (s *rbacAPIServerImpl) GetRolesAssignedToGroup(
+	ctx context.Context, req *apiv1.GetRolesAssignedToGroupRequest,
+) (resp *apiv1.GetRolesAssignedToGroupResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	curUser, _, err := grpcutil.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = AuthZProvider.Get().CanGetGroupRoles(ctx, *curUser, int(req.GroupId)); err != nil {
+		return nil, err
+	}
+
+	assignments, err := RoleAssignments(ctx, RoleAssignmentFilter{GroupID: int(req.GroupId)})
+	if err != nil {
+		return nil, err
+	}
+	roles, err := rolesOf(ctx, assignments)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetRolesAssignedToGroupResponse{
+		Roles:       roles.Proto(),
+		Assignments: summarizeAssignments(assignments),
+	}, nil
+}
+
+func (s *rbacAPIServerImpl) SearchRolesAssignableToScope(
+	ctx context.Context, req *apiv1.SearchRolesAssignableToScopeRequest,
+) (resp *apiv1.SearchRolesAssignableToScopeResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	if req.Limit > apiutils.MaxLimit || req.Limit == 0 {
+		return nil, apiutils.ErrInvalidLimit
+	}
+	roles, count, err := ListRoles(ctx, int(req.Offset), int(req.Limit), req.WorkspaceId != nil)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.SearchRolesAssignableToScopeResponse{
+		Roles:      roles.Proto(),
+		Pagination: pagination(req.Offset, req.Limit, len(roles), count),
+	}, nil
+}
+
+func (s *rbacAPIServerImpl) ListRoles(
+	ctx context.Context, req *apiv1.ListRolesRequest,
+) (resp *apiv1.ListRolesResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	if req.Limit > apiutils.MaxLimit || req.Limit == 0 {
+		return nil, apiutils.ErrInvalidLimit
+	}
+	roles, count, err := ListRoles(ctx, int(req.Offset), int(req.Limit), false)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.ListRolesResponse{
+		Roles:      roles.Proto(),
+		Pagination: pagination(req.Offset, req.Limit, len(roles), count),
+	}, nil
+}
+
+func (s *rbacAPIServerImpl) AssignRoles(
+	ctx context.Context, req *apiv1.AssignRolesRequest,
+) (resp *apiv1.AssignRolesResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	assignments, err := authorizeAssignments(ctx,
+		req.GroupRoleAssignments, req.UserRoleAssignments)
+	if err != nil {
+		return nil, err
+	}
+	if err = AddRoleAssignmentsTx(ctx, nil, assignments...); err != nil {
+		return nil, err
+	}
+	return &apiv1.AssignRolesResponse{}, nil
+}
+
+func (s *rbacAPIServerImpl) RemoveAssignments(
+	ctx context.Context, req *apiv1.RemoveAssignmentsRequest,
+) (resp *apiv1.RemoveAssignmentsResponse, err error) {
+	defer func() {
+		err = apiutils.MapAndFilterErrors(err, nil, nil)
+	}()
+
+	assignments, err := authorizeAssignments(ctx,
+		req.GroupRoleAssignments, req.UserRoleAssignments)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Bun().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return RemoveRoleAssignmentsTx(ctx, tx, assignments...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.RemoveAssignmentsResponse{}, nil
+}
+
+func (s *rbacAPIServerImpl) AssignWorkspaceAdminToUserTx(
+	ctx context.Context, idb bun.IDB, workspaceID int, userID model.UserID,
+) error {
+	roleID, err := RoleIDByName(ctx, idb, WorkspaceAdminRole)
+	if err != nil {
+		return err
+	}
+	groupID, err := PersonalGroupID(ctx, idb, userID)
+	if err != nil {
+		return err
+	}
+	return AddRoleAssignmentsTx(ctx, idb, RoleAssignment{
+		GroupID:          groupID,
+		RoleID:           roleID,
+		ScopeWorkspaceID: &workspaceID,
+	})
+}
+
+// authorizeAssignments converts role assignments to their database representation, assigning
+// roles of users to their personal groups, and checks that the current user may make them.
+func authorizeAssignments(
+	ctx context.Context,
+	groupAssignments []*rbacv1.GroupRoleAssignment,
+	userAssignments []*rbacv1.UserRoleAssignment,
+) ([]RoleAssignment, error) {
+	curUser, _, err := grpcutil.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []RoleAssignment
+	add := func(groupID int, ra *rbacv1.RoleAssignment) error {
+		if ra.GetRole() == nil {
+			return errors.Wrap(db.ErrInvalidInput, "role assignments must name a role")
+		}
+		a := RoleAssignment{GroupID: groupID, RoleID: int(ra.Role.RoleId)}
+		if ra.ScopeWorkspaceId != nil {
+			a.ScopeWorkspaceID = ptrs.Ptr(int(ra.ScopeWorkspaceId.Value))
+		}
+		assignments = append(assignments, a)
+		return nil
+	}
+	for _, ga := range groupAssignments {
+		if err := add(int(ga.GroupId), ga.RoleAssignment); err != nil {
+			return nil, err
+		}
+	}
+	for _, ua := range userAssignments {
+		groupID, err := PersonalGroupID(ctx, nil, model.UserID(ua.UserId))
+		if err != nil {
+			return nil, err
+		}
+		if err := add(groupID, ua.RoleAssignment); err != nil {
+			return nil, err
+		}
+	}
+
+	return assignments, checkAssignments(ctx, *curUser, assignments)
+}
+
+// checkAssignments checks that the roles of the assignments exist and may be assigned within
+// their scopes, and that the current user may assign roles within them.
+func checkAssignments(ctx context.Context, curUser model.User, assignments []RoleAssignment) error {
+	var roleIDs []int
+	for _, a := range assignments {
+		roleIDs = append(roleIDs, a.RoleID)
+	}
+	roles, err := RolesByID(ctx, nil, uniqueInts(roleIDs)...)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*Role, len(roles))
+	for i := range roles {
+		byID[roles[i].ID] = &roles[i]
+	}
+
+	for _, a := range assignments {
+		scope := a.Scope()
+		if !scope.IsGlobal() && byID[a.RoleID].GlobalOnly() {
+			return errors.Wrapf(db.ErrInvalidInput,
+				"role %s can only be assigned globally", byID[a.RoleID].Name)
+		}
+		if scope.ProjectID != nil {
+			workspaceID, err := ProjectWorkspaceID(ctx, *scope.ProjectID)
+			if err != nil {
+				return err
+			}
+			scope.WorkspaceID = &workspaceID
+		}
+		if err := CheckForPermission(ctx, curUser, scope,
+			rbacv1.PermissionType_PERMISSION_TYPE_ASSIGN_ROLES); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rolesOf returns the roles of the given assignments.
+func rolesOf(ctx context.Context, assignments []RoleAssignment) (Roles, error) {
+	var ids []int
+	for _, a := range assignments {
+		ids = append(ids, a.RoleID)
+	}
+	return RolesByID(ctx, nil, uniqueInts(ids)...)
+}
+
+// summarizeAssignments summarizes role assignments by role. Assignments scoped to projects are
+// left out, since they cannot be represented.
+func summarizeAssignments(assignments []RoleAssignment) []*rbacv1.RoleAssignmentSummary {
+	byRole := make(map[int]*rbacv1.RoleAssignmentSummary)
+	var summaries []*rbacv1.RoleAssignmentSummary
+	for _, a := range assignments {
+		if a.ScopeProjectID != nil {
+			continue
+		}
+		summary, ok := byRole[a.RoleID]
+		if !ok {
+			summary = &rbacv1.RoleAssignmentSummary{RoleId: int32(a.RoleID)}
+			byRole[a.RoleID] = summary
+			summaries = append(summaries, summary)
+		}
+		if a.ScopeWorkspaceID == nil {
+			summary.IsGlobal = true
+		} else {
+			summary.ScopeWorkspaceIds = append(summary.ScopeWorkspaceIds,
+				int32(*a.ScopeWorkspaceID))
+		}
+	}
+	return summaries
+}
+
+// withAssignments returns the roles along with their assignments to groups and users.
+// Assignments scoped to projects are left out, since they cannot be represented.
+func withAssignments(roles Roles, assignments []RoleAssignment) []*rbacv1.RoleWithAssignments {
+	out := make([]*rbacv1.RoleWithAssignments, len(roles))
+	byID := make(map[int]*rbacv1.RoleWithAssignments, len(roles))
+	for i := range roles {
+		out[i] = &rbacv1.RoleWithAssignments{Role: roles[i].Proto()}
+		byID[roles[i].ID] = out[i]
+	}
+	rolesByID := make(map[int]*Role, len(roles))
+	for i := range roles {
+		rolesByID[roles[i].ID] = &roles[i]
+	}
+
+	for _, a := range assignments {
+		r, ok := byID[a.RoleID]
+		if !ok || a.ScopeProjectID != nil {
+			continue
+		}
+		ra := a.Proto(rolesByID[a.RoleID])
+		if a.UserID != nil {
+			r.UserRoleAssignments = append(r.UserRoleAssignments, &rbacv1.UserRoleAssignment{
+				UserId:         int32(*a.UserID),
+				RoleAssignment: ra,
+			})
+		} else {
+			r.GroupRoleAssignments = append(r.GroupRoleAssignments, &rbacv1.GroupRoleAssignment{
+				GroupId:        int32(a.GroupID),
+				RoleAssignment: ra,
+			})
+		}
+	}
+	return out
+}
+
+func pagination(offset, limit int32, n, total int) *apiv1.Pagination {
+	return &apiv1.Pagination{
+		Offset:     offset,
+		Limit:      limit,
+		StartIndex: offset,
+		EndIndex:   offset + int32(n),
+		Total:      int32(total),
+	}
+}
+
+// matchesName returns whether any of the names contains the filter, ignoring case.
+func matchesName(filter string, names ...string) bool {
+	filter = strings.ToLower(filter)
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueInts(ints []int) []int {
+	seen := make(map[int]bool, len(ints))
+	var out []int
+	for _, i := range ints {
+		if !seen[i] {
+			seen[i] = true
+			out = append(out, i)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
@@ -0,0 +1,50 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/internal/grpcutil"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// RBACAuthZBasic is basic OSS controls.
+type RBACAuthZBasic struct{}
+
+// CanGetRoles requires the user to be an admin.
+func (a *RBACAuthZBasic) CanGetRoles(ctx context.Context, curUser model.User) error {
+	if curUser.Admin {
+		return nil
+	}
+	return grpcutil.ErrPermissionDenied
+}
+
+// CanGetUserRoles allows admins and the user themself.
+func (a *RBACAuthZBasic) CanGetUserRoles(
+	ctx context.Context, curUser model.User, userID model.UserID,
+) error {
+	if curUser.Admin || curUser.ID == userID {
+		return nil
+	}
+	return grpcutil.ErrPermissionDenied
+}
+
+// CanGetGroupRoles allows admins and the members of the group.
+func (a *RBACAuthZBasic) CanGetGroupRoles(
+	ctx context.Context, curUser model.User, groupID int,
+) error {
+	if curUser.Admin {
+		return nil
+	}
+	member, err := isGroupMember(ctx, curUser.ID, groupID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return grpcutil.ErrPermissionDenied
+	}
+	return nil
+}
+
+func init() {
+	AuthZProvider.Register("basic", &RBACAuthZBasic{})
+}
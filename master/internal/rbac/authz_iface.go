@@ -0,0 +1,26 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/internal/authz"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// RBACAuthZ describes authz methods for reading role assignments.
+type RBACAuthZ interface {
+	// CanGetRoles checks whether a user can get roles along with all of their assignments.
+	// POST /api/v1/roles/search/by-ids
+	CanGetRoles(ctx context.Context, curUser model.User) error
+
+	// CanGetUserRoles checks whether a user can get the roles assigned to a user.
+	// GET /api/v1/roles/search/by-user/{user_id}
+	CanGetUserRoles(ctx context.Context, curUser model.User, userID model.UserID) error
+
+	// CanGetGroupRoles checks whether a user can get the roles assigned to a group.
+	// GET /api/v1/roles/search/by-group/{group_id}
+	CanGetGroupRoles(ctx context.Context, curUser model.User, groupID int) error
+}
+
+// AuthZProvider is the authz registry for `rbac` package.
+var AuthZProvider authz.AuthZProviderType[RBACAuthZ]
@@ -0,0 +1,48 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// RBACAuthZRBAC is role-based access control for reading role assignments. Users may see the
+// roles assigned to themselves and to their groups; everything else requires the permission to
+// assign roles to the whole cluster.
+type RBACAuthZRBAC struct{}
+
+// CanGetRoles requires the permission to assign roles, since the assignments of every user are
+// returned.
+func (a *RBACAuthZRBAC) CanGetRoles(ctx context.Context, curUser model.User) error {
+	return CheckForPermission(ctx, curUser, GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_ASSIGN_ROLES)
+}
+
+// CanGetUserRoles allows the user themself and otherwise requires the permission to assign roles.
+func (a *RBACAuthZRBAC) CanGetUserRoles(
+	ctx context.Context, curUser model.User, userID model.UserID,
+) error {
+	if curUser.ID == userID {
+		return nil
+	}
+	return CheckForPermission(ctx, curUser, GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_ASSIGN_ROLES)
+}
+
+// CanGetGroupRoles allows the members of the group and otherwise requires the permission to
+// assign roles.
+func (a *RBACAuthZRBAC) CanGetGroupRoles(
+	ctx context.Context, curUser model.User, groupID int,
+) error {
+	member, err := isGroupMember(ctx, curUser.ID, groupID)
+	if err != nil || member {
+		return err
+	}
+	return CheckForPermission(ctx, curUser, GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_ASSIGN_ROLES)
+}
+
+func init() {
+	AuthZProvider.Register("rbac", &RBACAuthZRBAC{})
+}
@@ -0,0 +1,39 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/usergroup"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// UserGroupAuthZRBAC is role-based access control for user groups. It lives here rather than in
+// package usergroup, which this package depends on.
+type UserGroupAuthZRBAC struct{}
+
+// CanGetGroup always returns true and a nil error.
+func (a *UserGroupAuthZRBAC) CanGetGroup(ctx context.Context, curUser model.User, gid int) (
+	bool, error,
+) {
+	return true, nil
+}
+
+// FilterGroupsList returns the query it was given and a nil error.
+func (a *UserGroupAuthZRBAC) FilterGroupsList(ctx context.Context, curUser model.User,
+	query *bun.SelectQuery,
+) (*bun.SelectQuery, error) {
+	return query, nil
+}
+
+// CanUpdateGroups requires the permission to update groups.
+func (a *UserGroupAuthZRBAC) CanUpdateGroups(ctx context.Context, curUser model.User) error {
+	return CheckForPermission(ctx, curUser, GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_GROUP)
+}
+
+func init() {
+	usergroup.AuthZProvider.Register("rbac", &UserGroupAuthZRBAC{})
+}
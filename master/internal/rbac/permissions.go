@@ -0,0 +1,74 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/internal/authz"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// CheckForPermission returns an authz.PermissionDeniedError if the user does not have the
+// permission within the scope. Admins have every permission.
+func CheckForPermission(
+	ctx context.Context, curUser model.User, scope Scope, permission rbacv1.PermissionType,
+) error {
+	ok, err := HasPermission(ctx, curUser, scope, permission)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return authz.PermissionDeniedError{
+			RequiredPermissions: []rbacv1.PermissionType{permission},
+		}
+	}
+	return nil
+}
+
+// HasPermission returns whether the user has the permission within the scope. Admins have every
+// permission.
+func HasPermission(
+	ctx context.Context, curUser model.User, scope Scope, permission rbacv1.PermissionType,
+) (bool, error) {
+	if curUser.Admin {
+		return true, nil
+	}
+	return UserHasPermission(ctx, curUser.ID, scope, permission)
+}
+
+// PermittedScopesFor returns the scopes in which the user has the permission. Admins have every
+// permission globally.
+func PermittedScopesFor(
+	ctx context.Context, curUser model.User, permission rbacv1.PermissionType,
+) (PermittedScopes, error) {
+	if curUser.Admin {
+		return PermittedScopes{Global: true}, nil
+	}
+	return UserPermittedScopes(ctx, curUser.ID, permission)
+}
+
+// ContainsWorkspace returns whether the scopes include all of a workspace.
+func (s PermittedScopes) ContainsWorkspace(workspaceID int) bool {
+	if s.Global {
+		return true
+	}
+	for _, id := range s.WorkspaceIDs {
+		if id == workspaceID {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsProject returns whether the scopes include a project.
+func (s PermittedScopes) ContainsProject(workspaceID, projectID int) bool {
+	if s.ContainsWorkspace(workspaceID) {
+		return true
+	}
+	for _, id := range s.ProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// ListRoles returns a page of roles with their permissions, ordered by ID, along with the total
+// number of roles. Only roles that may be assigned to a workspace are returned if
+// workspaceAssignable is set.
+func ListRoles(
+	ctx context.Context, offset, limit int, workspaceAssignable bool,
+) (Roles, int, error) {
+	var roles Roles
+	q := db.Bun().NewSelect().Model(&roles).Order("id")
+	if workspaceAssignable {
+		q = q.Where(`NOT EXISTS (
+SELECT 1 FROM permission_assignments pa JOIN permissions p ON pa.permission_id = p.id
+WHERE pa.role_id = roles.id AND p.global_only)`)
+	}
+	count, err := q.Offset(offset).Limit(limit).ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errors.Wrap(db.MatchSentinelError(err), "error listing roles")
+	}
+	if err := loadPermissions(ctx, db.Bun(), roles); err != nil {
+		return nil, 0, err
+	}
+	return roles, count, nil
+}
+
+// RolesByID returns the roles with the given IDs along with their permissions. Returns
+// ErrNotFound if any of them does not exist.
+func RolesByID(ctx context.Context, idb bun.IDB, ids ...int) (Roles, error) {
+	if idb == nil {
+		idb = db.Bun()
+	}
+	roles := Roles{}
+	if len(ids) == 0 {
+		return roles, nil
+	}
+	if err := idb.NewSelect().Model(&roles).Where("id IN (?)", bun.In(ids)).Order("id").
+		Scan(ctx); err != nil {
+		return nil, errors.Wrap(db.MatchSentinelError(err), "error getting roles")
+	}
+	found := make(map[int]bool, len(roles))
+	for _, r := range roles {
+		found[r.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, errors.Wrapf(db.ErrNotFound, "role %d", id)
+		}
+	}
+	if err := loadPermissions(ctx, idb, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// loadPermissions fills in the permissions of the given roles.
+func loadPermissions(ctx context.Context, idb bun.IDB, roles Roles) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	ids := make([]int, len(roles))
+	for i, r := range roles {
+		ids[i] = r.ID
+	}
+	var rows []struct {
+		Permission
+		RoleID int `bun:"role_id"`
+	}
+	if err := idb.NewSelect().
+		TableExpr("permission_assignments pa").
+		Join("JOIN permissions ON pa.permission_id = permissions.id").
+		ColumnExpr("permissions.*, pa.role_id").
+		Where("pa.role_id IN (?)", bun.In(ids)).
+		Order("permissions.id").
+		Scan(ctx, &rows); err != nil {
+		return errors.Wrap(db.MatchSentinelError(err), "error getting permissions of roles")
+	}
+	byRole := make(map[int][]Permission)
+	for _, row := range rows {
+		byRole[row.RoleID] = append(byRole[row.RoleID], row.Permission)
+	}
+	for i := range roles {
+		roles[i].Permissions = byRole[roles[i].ID]
+	}
+	return nil
+}
+
+// RoleIDByName returns the ID of the role with the given name. Will use db.Bun() if passed nil for
+// idb.
+func RoleIDByName(ctx context.Context, idb bun.IDB, name string) (int, error) {
+	if idb == nil {
+		idb = db.Bun()
+	}
+	var id int
+	err := idb.NewSelect().Table("roles").Column("id").Where("role_name = ?", name).
+		Scan(ctx, &id)
+	return id, errors.Wrapf(db.MatchSentinelError(err), "error getting role %s", name)
+}
+
+// PersonalGroupID returns the ID of the personal group of a user.
+func PersonalGroupID(ctx context.Context, idb bun.IDB, userID model.UserID) (int, error) {
+	if idb == nil {
+		idb = db.Bun()
+	}
+	var id int
+	err := idb.NewSelect().Table("groups").Column("id").Where("user_id = ?", userID).
+		Scan(ctx, &id)
+	return id, errors.Wrapf(db.MatchSentinelError(err),
+		"error getting personal group of user %d", userID)
+}
+
+// isGroupMember returns whether a user belongs to a group.
+func isGroupMember(ctx context.Context, userID model.UserID, groupID int) (bool, error) {
+	member, err := db.Bun().NewSelect().Table("user_group_membership").
+		Where("user_id = ?", userID).Where("group_id = ?", groupID).Exists(ctx)
+	return member, errors.Wrapf(db.MatchSentinelError(err),
+		"error checking membership of user %d in group %d", userID, groupID)
+}
+
+// RoleAssignmentFilter selects role assignments. Zero values match any.
+type RoleAssignmentFilter struct {
+	RoleIDs []int
+	GroupID int
+	// UserID matches the assignments to the personal group of the user and, if
+	// IncludeUserGroups is set, to the other groups the user belongs to.
+	UserID            model.UserID
+	IncludeUserGroups bool
+	WorkspaceID       int
+	ProjectID         int
+}
+
+// RoleAssignments returns the role assignments that match the filter, with UserID set for
+// assignments to personal groups.
+func RoleAssignments(
+	ctx context.Context, filter RoleAssignmentFilter,
+) ([]RoleAssignment, error) {
+	var assignments []RoleAssignment
+	q := db.Bun().NewSelect().Model(&assignments).
+		ColumnExpr("role_assignments.*").
+		ColumnExpr("g.user_id").
+		Join("JOIN groups g ON role_assignments.group_id = g.id").
+		Order("role_assignments.id")
+	if len(filter.RoleIDs) > 0 {
+		q = q.Where("role_assignments.role_id IN (?)", bun.In(filter.RoleIDs))
+	}
+	if filter.GroupID != 0 {
+		q = q.Where("role_assignments.group_id = ?", filter.GroupID)
+	}
+	switch {
+	case filter.UserID != 0 && filter.IncludeUserGroups:
+		q = q.Where(`role_assignments.group_id IN (
+SELECT group_id FROM user_group_membership WHERE user_id = ?)`, filter.UserID)
+	case filter.UserID != 0:
+		q = q.Where("g.user_id = ?", filter.UserID)
+	}
+	if filter.WorkspaceID != 0 {
+		q = q.Where("role_assignments.scope_workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.ProjectID != 0 {
+		q = q.Where("role_assignments.scope_project_id = ?", filter.ProjectID)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, errors.Wrap(db.MatchSentinelError(err), "error getting role assignments")
+	}
+	return assignments, nil
+}
+
+// AddRoleAssignmentsTx assigns roles to groups. Assignments that already exist are left as is.
+// Will use db.Bun() if passed nil for idb.
+func AddRoleAssignmentsTx(
+	ctx context.Context, idb bun.IDB, assignments ...RoleAssignment,
+) error {
+	if idb == nil {
+		idb = db.Bun()
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+	_, err := idb.NewInsert().Model(&assignments).
+		ExcludeColumn("id").
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	return errors.Wrap(db.MatchSentinelError(err), "error adding role assignments")
+}
+
+// RemoveRoleAssignmentsTx removes role assignments by their group, role and scope. Returns
+// ErrNotFound if any of them does not exist. Will use db.Bun() if passed nil for idb.
+func RemoveRoleAssignmentsTx(
+	ctx context.Context, idb bun.IDB, assignments ...RoleAssignment,
+) error {
+	if idb == nil {
+		idb = db.Bun()
+	}
+	for _, a := range assignments {
+		res, err := idb.NewDelete().Table("role_assignments").
+			Where("group_id = ?", a.GroupID).
+			Where("role_id = ?", a.RoleID).
+			Where("scope_workspace_id IS NOT DISTINCT FROM ?", a.ScopeWorkspaceID).
+			Where("scope_project_id IS NOT DISTINCT FROM ?", a.ScopeProjectID).
+			Exec(ctx)
+		if err := db.MustHaveAffectedRows(res, err); err != nil {
+			return errors.Wrapf(db.MatchSentinelError(err),
+				"error removing role %d from group %d", a.RoleID, a.GroupID)
+		}
+	}
+	return nil
+}
+
+// permittedAssignments returns a query for the role assignments through which a user has any of
+// the given permissions.
+func permittedAssignments(
+	userID model.UserID, permissions ...rbacv1.PermissionType,
+) *bun.SelectQuery {
+	return db.Bun().NewSelect().
+		TableExpr("role_assignments ra").
+		Join("JOIN user_group_membership m ON ra.group_id = m.group_id").
+		Join("JOIN permission_assignments pa ON ra.role_id = pa.role_id").
+		Where("m.user_id = ?", userID).
+		Where("pa.permission_id IN (?)", bun.In(permissions))
+}
+
+// UserHasPermission returns whether a user has a permission within a scope, through an assignment
+// to the whole cluster or to the workspace or project of the scope.
+func UserHasPermission(
+	ctx context.Context, userID model.UserID, scope Scope, permission rbacv1.PermissionType,
+) (bool, error) {
+	q := permittedAssignments(userID, permission).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.Where("ra.scope_workspace_id IS NULL AND ra.scope_project_id IS NULL")
+			if scope.WorkspaceID != nil {
+				q = q.WhereOr("ra.scope_workspace_id = ?", *scope.WorkspaceID)
+			}
+			if scope.ProjectID != nil {
+				q = q.WhereOr("ra.scope_project_id = ?", *scope.ProjectID)
+			}
+			return q
+		})
+	exists, err := q.Exists(ctx)
+	return exists, errors.Wrapf(db.MatchSentinelError(err),
+		"error checking permission %s of user %d", permission, userID)
+}
+
+// UserPermittedScopes returns the scopes in which a user has a permission.
+func UserPermittedScopes(
+	ctx context.Context, userID model.UserID, permission rbacv1.PermissionType,
+) (PermittedScopes, error) {
+	var rows []struct {
+		ScopeWorkspaceID *int `bun:"scope_workspace_id"`
+		ScopeProjectID   *int `bun:"scope_project_id"`
+	}
+	if err := permittedAssignments(userID, permission).
+		Distinct().
+		ColumnExpr("ra.scope_workspace_id, ra.scope_project_id").
+		Scan(ctx, &rows); err != nil {
+		return PermittedScopes{}, errors.Wrapf(db.MatchSentinelError(err),
+			"error getting scopes of permission %s of user %d", permission, userID)
+	}
+	var scopes PermittedScopes
+	for _, row := range rows {
+		switch {
+		case row.ScopeWorkspaceID != nil:
+			scopes.WorkspaceIDs = append(scopes.WorkspaceIDs, *row.ScopeWorkspaceID)
+		case row.ScopeProjectID != nil:
+			scopes.ProjectIDs = append(scopes.ProjectIDs, *row.ScopeProjectID)
+		default:
+			scopes.Global = true
+		}
+	}
+	return scopes, nil
+}
+
+// ProjectWorkspaceID returns the ID of the workspace a project is in.
+func ProjectWorkspaceID(ctx context.Context, projectID int) (int, error) {
+	var id int
+	err := db.Bun().NewSelect().Table("projects").Column("workspace_id").
+		Where("id = ?", projectID).Scan(ctx, &id)
+	return id, errors.Wrapf(db.MatchSentinelError(err),
+		"error getting workspace of project %d", projectID)
+}
@@ -0,0 +1,157 @@
+//go:build integration
+// +build integration
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/usergroup"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// requireWorkspaceAndProject returns the IDs of a new workspace and a project within it.
+func requireWorkspaceAndProject(ctx context.Context, t *testing.T, user model.User) (int, int) {
+	var workspaceID, projectID int
+	require.NoError(t, db.Bun().NewRaw(
+		"INSERT INTO workspaces (name, user_id) VALUES (?, ?) RETURNING id",
+		uuid.NewString(), user.ID).Scan(ctx, &workspaceID))
+	require.NoError(t, db.Bun().NewRaw(
+		"INSERT INTO projects (name, workspace_id, user_id) VALUES (?, ?, ?) RETURNING id",
+		uuid.NewString(), workspaceID, user.ID).Scan(ctx, &projectID))
+	return workspaceID, projectID
+}
+
+// requireRoleAssignment assigns a role to the personal group of a user.
+func requireRoleAssignment(
+	ctx context.Context, t *testing.T, user model.User, role string, scope Scope,
+) {
+	roleID, err := RoleIDByName(ctx, nil, role)
+	require.NoError(t, err)
+	groupID, err := PersonalGroupID(ctx, nil, user.ID)
+	require.NoError(t, err)
+	require.NoError(t, AddRoleAssignmentsTx(ctx, nil, RoleAssignment{
+		GroupID:          groupID,
+		RoleID:           roleID,
+		ScopeWorkspaceID: scope.WorkspaceID,
+		ScopeProjectID:   scope.ProjectID,
+	}))
+}
+
+func TestUserHasPermission(t *testing.T) {
+	ctx := context.Background()
+	pgDB := db.MustSetupTestPostgres(t)
+	user := db.RequireMockUser(t, pgDB)
+	workspaceID, projectID := requireWorkspaceAndProject(ctx, t, user)
+	otherWorkspaceID, otherProjectID := requireWorkspaceAndProject(ctx, t, user)
+	view := rbacv1.PermissionType_PERMISSION_TYPE_VIEW_PROJECT
+	update := rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_PROJECT
+
+	check := func(scope Scope, permission rbacv1.PermissionType, expected bool) {
+		t.Helper()
+		ok, err := UserHasPermission(ctx, user.ID, scope, permission)
+		require.NoError(t, err)
+		require.Equal(t, expected, ok, "permission %s in %+v", permission, scope)
+	}
+
+	check(GlobalScope(), view, false)
+	check(WorkspaceScope(workspaceID), view, false)
+
+	// A project assignment only grants permissions within the project.
+	requireRoleAssignment(ctx, t, user, "Viewer", Scope{ProjectID: ptrs.Ptr(projectID)})
+	check(ProjectScope(workspaceID, projectID), view, true)
+	check(ProjectScope(workspaceID, projectID), update, false)
+	check(WorkspaceScope(workspaceID), view, false)
+	check(ProjectScope(otherWorkspaceID, otherProjectID), view, false)
+
+	// A workspace assignment grants them within every project of the workspace.
+	requireRoleAssignment(ctx, t, user, "Editor", WorkspaceScope(otherWorkspaceID))
+	check(WorkspaceScope(otherWorkspaceID), update, true)
+	check(ProjectScope(otherWorkspaceID, otherProjectID), update, true)
+	check(ProjectScope(workspaceID, projectID), update, false)
+	check(GlobalScope(), update, false)
+
+	// Roles assigned to the other groups of the user count too.
+	group, _, err := usergroup.AddGroupWithMembers(ctx, usergroup.Group{Name: uuid.NewString()},
+		user.ID)
+	require.NoError(t, err)
+	roleID, err := RoleIDByName(ctx, nil, "Viewer")
+	require.NoError(t, err)
+	require.NoError(t, AddRoleAssignmentsTx(ctx, nil, RoleAssignment{
+		GroupID: group.ID, RoleID: roleID,
+	}))
+	check(GlobalScope(), view, true)
+	check(ProjectScope(otherWorkspaceID, otherProjectID), view, true)
+	check(GlobalScope(), update, false)
+}
+
+func TestUserPermittedScopes(t *testing.T) {
+	ctx := context.Background()
+	pgDB := db.MustSetupTestPostgres(t)
+	user := db.RequireMockUser(t, pgDB)
+	workspaceID, _ := requireWorkspaceAndProject(ctx, t, user)
+	_, projectID := requireWorkspaceAndProject(ctx, t, user)
+	view := rbacv1.PermissionType_PERMISSION_TYPE_VIEW_EXPERIMENT_METADATA
+
+	scopes, err := UserPermittedScopes(ctx, user.ID, view)
+	require.NoError(t, err)
+	require.Equal(t, PermittedScopes{}, scopes)
+
+	requireRoleAssignment(ctx, t, user, "Viewer", WorkspaceScope(workspaceID))
+	requireRoleAssignment(ctx, t, user, "Editor", Scope{ProjectID: ptrs.Ptr(projectID)})
+	// Both roles grant the permission; other permissions of the roles are not included.
+	scopes, err = UserPermittedScopes(ctx, user.ID, view)
+	require.NoError(t, err)
+	require.Equal(t, PermittedScopes{
+		WorkspaceIDs: []int{workspaceID},
+		ProjectIDs:   []int{projectID},
+	}, scopes)
+	scopes, err = UserPermittedScopes(ctx, user.ID,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_EXPERIMENT)
+	require.NoError(t, err)
+	require.Equal(t, PermittedScopes{ProjectIDs: []int{projectID}}, scopes)
+
+	requireRoleAssignment(ctx, t, user, "Viewer", GlobalScope())
+	scopes, err = UserPermittedScopes(ctx, user.ID, view)
+	require.NoError(t, err)
+	require.True(t, scopes.Global)
+}
+
+func TestRBACAuthZRBACRoleGetters(t *testing.T) {
+	ctx := context.Background()
+	pgDB := db.MustSetupTestPostgres(t)
+	user := db.RequireMockUser(t, pgDB)
+	other := db.RequireMockUser(t, pgDB)
+	group, _, err := usergroup.AddGroupWithMembers(ctx, usergroup.Group{Name: uuid.NewString()},
+		user.ID)
+	require.NoError(t, err)
+	otherGroup, _, err := usergroup.AddGroupWithMembers(ctx,
+		usergroup.Group{Name: uuid.NewString()}, other.ID)
+	require.NoError(t, err)
+	authz := &RBACAuthZRBAC{}
+
+	// Users may see their own roles and those of their groups, but nobody else's.
+	require.NoError(t, authz.CanGetUserRoles(ctx, user, user.ID))
+	require.NoError(t, authz.CanGetGroupRoles(ctx, user, group.ID))
+	require.Error(t, authz.CanGetUserRoles(ctx, user, other.ID))
+	require.Error(t, authz.CanGetGroupRoles(ctx, user, otherGroup.ID))
+	require.Error(t, authz.CanGetRoles(ctx, user))
+
+	// Assigning roles within a workspace is not enough to see every assignment.
+	workspaceID, _ := requireWorkspaceAndProject(ctx, t, user)
+	requireRoleAssignment(ctx, t, user, "WorkspaceAdmin", WorkspaceScope(workspaceID))
+	require.Error(t, authz.CanGetUserRoles(ctx, user, other.ID))
+	require.Error(t, authz.CanGetRoles(ctx, user))
+
+	requireRoleAssignment(ctx, t, user, "ClusterAdmin", GlobalScope())
+	require.NoError(t, authz.CanGetUserRoles(ctx, user, other.ID))
+	require.NoError(t, authz.CanGetGroupRoles(ctx, user, otherGroup.ID))
+	require.NoError(t, authz.CanGetRoles(ctx, user))
+}
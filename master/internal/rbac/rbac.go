@@ -0,0 +1,154 @@
+package rbac
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+const (
+	// ClusterAdminRole is the role with every permission.
+	ClusterAdminRole = "ClusterAdmin"
+	// WorkspaceAdminRole is the role given to users on the workspaces they create.
+	WorkspaceAdminRole = "WorkspaceAdmin"
+)
+
+// Permission represents a permission as it's stored in the database.
+type Permission struct {
+	bun.BaseModel `bun:"table:permissions,alias:permissions"`
+
+	ID         rbacv1.PermissionType `bun:"id,pk"              json:"id"`
+	Name       string                `bun:"name,notnull"       json:"name"`
+	GlobalOnly bool                  `bun:"global_only,notnull" json:"global_only"`
+}
+
+// Proto converts a permission to its protobuf representation.
+func (p *Permission) Proto() *rbacv1.Permission {
+	return &rbacv1.Permission{
+		Id:       p.ID,
+		Name:     p.Name,
+		IsGlobal: p.GlobalOnly,
+	}
+}
+
+// Role represents a role as it's stored in the database.
+type Role struct {
+	bun.BaseModel `bun:"table:roles,alias:roles"`
+
+	ID          int          `bun:"id,pk,autoincrement" json:"id"`
+	Name        string       `bun:"role_name,notnull"   json:"name"`
+	Created     time.Time    `bun:"created_at,notnull"  json:"created_at"`
+	Permissions []Permission `bun:"-"                   json:"permissions"`
+}
+
+// GlobalOnly returns whether the role may only be assigned to the whole cluster, which is the
+// case if any of its permissions is global-only.
+func (r *Role) GlobalOnly() bool {
+	for _, p := range r.Permissions {
+		if p.GlobalOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// Proto converts a role to its protobuf representation.
+func (r *Role) Proto() *rbacv1.Role {
+	permissions := make([]*rbacv1.Permission, len(r.Permissions))
+	for i := range r.Permissions {
+		permissions[i] = r.Permissions[i].Proto()
+	}
+	return &rbacv1.Role{
+		RoleId:      int32(r.ID),
+		Name:        r.Name,
+		Permissions: permissions,
+	}
+}
+
+// Roles is a slice of Role objects—primarily useful for its methods.
+type Roles []Role
+
+// Proto converts Roles into its protobuf representation.
+func (rs Roles) Proto() []*rbacv1.Role {
+	out := make([]*rbacv1.Role, len(rs))
+	for i := range rs {
+		out[i] = rs[i].Proto()
+	}
+	return out
+}
+
+// PermissionAssignment represents a permission of a role as it's stored in the database.
+type PermissionAssignment struct {
+	bun.BaseModel `bun:"table:permission_assignments"`
+
+	PermissionID rbacv1.PermissionType `bun:"permission_id,pk"`
+	RoleID       int                   `bun:"role_id,pk"`
+}
+
+// Scope is what a role assignment applies to: the whole cluster, a workspace or a project.
+type Scope struct {
+	WorkspaceID *int `json:"workspace_id,omitempty"`
+	ProjectID   *int `json:"project_id,omitempty"`
+}
+
+// GlobalScope returns the scope of the whole cluster.
+func GlobalScope() Scope {
+	return Scope{}
+}
+
+// WorkspaceScope returns the scope of a workspace.
+func WorkspaceScope(workspaceID int) Scope {
+	return Scope{WorkspaceID: &workspaceID}
+}
+
+// ProjectScope returns the scope of a project. Permissions are checked against assignments to the
+// project as well as to the workspace it's in.
+func ProjectScope(workspaceID, projectID int) Scope {
+	return Scope{WorkspaceID: &workspaceID, ProjectID: &projectID}
+}
+
+// IsGlobal returns whether the scope is the whole cluster.
+func (s Scope) IsGlobal() bool {
+	return s.WorkspaceID == nil && s.ProjectID == nil
+}
+
+// RoleAssignment represents the assignment of a role to a group as it's stored in the database.
+// Users are assigned roles through their personal groups.
+type RoleAssignment struct {
+	bun.BaseModel `bun:"table:role_assignments,alias:role_assignments"`
+
+	ID               int  `bun:"id,pk,autoincrement" json:"id"`
+	GroupID          int  `bun:"group_id,notnull"    json:"group_id"`
+	RoleID           int  `bun:"role_id,notnull"     json:"role_id"`
+	ScopeWorkspaceID *int `bun:"scope_workspace_id"  json:"scope_workspace_id,omitempty"`
+	ScopeProjectID   *int `bun:"scope_project_id"    json:"scope_project_id,omitempty"`
+
+	// UserID is set if the group is the personal group of a user.
+	UserID *int `bun:"user_id,scanonly" json:"user_id,omitempty"`
+}
+
+// Scope returns the scope of the role assignment.
+func (a *RoleAssignment) Scope() Scope {
+	return Scope{WorkspaceID: a.ScopeWorkspaceID, ProjectID: a.ScopeProjectID}
+}
+
+// Proto converts the role assignment to its protobuf representation. Assignments scoped to a
+// project have no protobuf representation and are reported as global ones, so callers should
+// leave them out.
+func (a *RoleAssignment) Proto(role *Role) *rbacv1.RoleAssignment {
+	out := &rbacv1.RoleAssignment{Role: role.Proto()}
+	if a.ScopeWorkspaceID != nil {
+		out.ScopeWorkspaceId = &wrappers.Int32Value{Value: int32(*a.ScopeWorkspaceID)}
+	}
+	return out
+}
+
+// PermittedScopes are the scopes in which a user has a permission.
+type PermittedScopes struct {
+	Global       bool
+	WorkspaceIDs []int
+	ProjectIDs   []int
+}
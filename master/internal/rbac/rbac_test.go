@@ -0,0 +1,74 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+func TestScope(t *testing.T) {
+	require.True(t, GlobalScope().IsGlobal())
+	require.False(t, WorkspaceScope(1).IsGlobal())
+	require.False(t, ProjectScope(1, 2).IsGlobal())
+
+	a := RoleAssignment{ScopeWorkspaceID: ptrs.Ptr(3)}
+	require.Equal(t, WorkspaceScope(3), a.Scope())
+}
+
+func TestRoleGlobalOnly(t *testing.T) {
+	r := Role{Permissions: []Permission{
+		{ID: rbacv1.PermissionType_PERMISSION_TYPE_VIEW_PROJECT},
+	}}
+	require.False(t, r.GlobalOnly())
+
+	r.Permissions = append(r.Permissions, Permission{
+		ID:         rbacv1.PermissionType_PERMISSION_TYPE_CREATE_WORKSPACE,
+		GlobalOnly: true,
+	})
+	require.True(t, r.GlobalOnly())
+}
+
+func TestPermittedScopes(t *testing.T) {
+	require.True(t, PermittedScopes{Global: true}.ContainsProject(1, 2))
+
+	s := PermittedScopes{WorkspaceIDs: []int{1}, ProjectIDs: []int{5}}
+	require.True(t, s.ContainsWorkspace(1))
+	require.False(t, s.ContainsWorkspace(2))
+	require.True(t, s.ContainsProject(1, 3))
+	require.True(t, s.ContainsProject(2, 5))
+	require.False(t, s.ContainsProject(2, 6))
+}
+
+func TestSummarizeAssignments(t *testing.T) {
+	summaries := summarizeAssignments([]RoleAssignment{
+		{RoleID: 1},
+		{RoleID: 2, ScopeWorkspaceID: ptrs.Ptr(7)},
+		{RoleID: 2, ScopeWorkspaceID: ptrs.Ptr(8)},
+		{RoleID: 3, ScopeWorkspaceID: ptrs.Ptr(7), ScopeProjectID: ptrs.Ptr(9)},
+	})
+	require.Len(t, summaries, 2)
+	require.Equal(t, int32(1), summaries[0].RoleId)
+	require.True(t, summaries[0].IsGlobal)
+	require.Equal(t, int32(2), summaries[1].RoleId)
+	require.False(t, summaries[1].IsGlobal)
+	require.Equal(t, []int32{7, 8}, summaries[1].ScopeWorkspaceIds)
+}
+
+func TestWithAssignments(t *testing.T) {
+	roles := Roles{{ID: 1, Name: "Viewer"}}
+	out := withAssignments(roles, []RoleAssignment{
+		{RoleID: 1, GroupID: 10, UserID: ptrs.Ptr(4), ScopeWorkspaceID: ptrs.Ptr(7)},
+		{RoleID: 1, GroupID: 11},
+		{RoleID: 1, GroupID: 12, ScopeWorkspaceID: ptrs.Ptr(7), ScopeProjectID: ptrs.Ptr(9)},
+	})
+	require.Len(t, out, 1)
+	require.Len(t, out[0].UserRoleAssignments, 1)
+	require.Equal(t, int32(4), out[0].UserRoleAssignments[0].UserId)
+	require.Equal(t, int32(7),
+		out[0].UserRoleAssignments[0].RoleAssignment.ScopeWorkspaceId.Value)
+	require.Len(t, out[0].GroupRoleAssignments, 1)
+	require.Equal(t, int32(11), out[0].GroupRoleAssignments[0].GroupId)
+}
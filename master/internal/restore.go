@@ -156,6 +156,7 @@ func (e *experiment) restoreTrial(
 		e.logCtx, trialTaskID(e.ID, searcher.Create.RequestID), e.JobID, e.StartTime, e.ID, e.State,
 		searcher, e.taskLogger, e.rm, e.db, config, ckpt, e.taskSpec, true,
 	)
+	t.traceParent = e.span.SpanContext()
 	if trialID != nil {
 		t.id = *trialID
 		t.idSet = true
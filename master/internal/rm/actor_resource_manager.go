@@ -189,6 +189,11 @@ func (r *ActorResourceManager) SetGroupMaxSlots(ctx actor.Messenger, msg sproto.
 	r.tell(ctx, msg)
 }
 
+// SetGroupOwner sets the user and workspace that own a group.
+func (r *ActorResourceManager) SetGroupOwner(ctx actor.Messenger, msg sproto.SetGroupOwner) {
+	r.tell(ctx, msg)
+}
+
 // DeleteJob requests we clean up our state related to a given job.
 func (r *ActorResourceManager) DeleteJob(
 	ctx actor.Messenger,
@@ -65,6 +65,69 @@ func (a AgentResourceManager) GetResourcePoolRef(
 	return rp, nil
 }
 
+// GetSimulationSnapshot returns the agents and requests of a resource pool as a Simulation.
+func (a AgentResourceManager) GetSimulationSnapshot(
+	ctx actor.Messenger,
+	name string,
+) (resp Simulation, err error) {
+	rp, err := a.GetResourcePoolRef(ctx, name)
+	if err != nil {
+		return resp, err
+	}
+	return resp, askAt(a.ref.System(), rp.Address(), GetSimulationSnapshot{}, &resp)
+}
+
+// SetResourceQuotas replaces the quotas enforced by a resource pool.
+func (a AgentResourceManager) SetResourceQuotas(
+	ctx actor.Messenger,
+	name string,
+	quotas []model.ResourceQuota,
+) error {
+	rp, err := a.GetResourcePoolRef(ctx, name)
+	if err != nil {
+		return err
+	}
+	return askAt(a.ref.System(), rp.Address(), SetResourceQuotas{Quotas: quotas}, nil)
+}
+
+// GetResourceQuotaStatus returns the quotas of a resource pool along with their usage.
+func (a AgentResourceManager) GetResourceQuotaStatus(
+	ctx actor.Messenger,
+	name string,
+) (resp []ResourceQuotaStatus, err error) {
+	rp, err := a.GetResourcePoolRef(ctx, name)
+	if err != nil {
+		return resp, err
+	}
+	return resp, askAt(a.ref.System(), rp.Address(), GetResourceQuotaStatus{}, &resp)
+}
+
+// SetResourceReservations replaces the reservations of a resource pool.
+func (a AgentResourceManager) SetResourceReservations(
+	ctx actor.Messenger,
+	name string,
+	reservations []model.ResourceReservation,
+) error {
+	rp, err := a.GetResourcePoolRef(ctx, name)
+	if err != nil {
+		return err
+	}
+	return askAt(a.ref.System(), rp.Address(),
+		SetResourceReservations{Reservations: reservations}, nil)
+}
+
+// GetResourceReservationStatus returns the reservations of a resource pool along with their usage.
+func (a AgentResourceManager) GetResourceReservationStatus(
+	ctx actor.Messenger,
+	name string,
+) (resp []ResourceReservationStatus, err error) {
+	rp, err := a.GetResourcePoolRef(ctx, name)
+	if err != nil {
+		return resp, err
+	}
+	return resp, askAt(a.ref.System(), rp.Address(), GetResourceReservationStatus{}, &resp)
+}
+
 // ValidateResourcePool validates existence of a resource pool.
 func (a AgentResourceManager) ValidateResourcePool(ctx actor.Messenger, name string) error {
 	_, err := a.GetResourcePoolRef(ctx, name)
@@ -196,7 +259,7 @@ func (a *agentResourceManager) Receive(ctx *actor.Context) error {
 		a.forwardToAllPools(ctx, msg)
 
 	case sproto.SetGroupMaxSlots, sproto.SetGroupWeight, sproto.SetGroupPriority,
-		sproto.MoveJob:
+		sproto.SetGroupOwner, sproto.MoveJob:
 		a.forwardToAllPools(ctx, msg)
 
 	case sproto.PendingPreemption:
@@ -514,6 +577,9 @@ func (a *agentResourceManager) createResourcePoolSummary(
 	if pool.Scheduler.RoundRobin != nil {
 		schedulerType = resourcepoolv1.SchedulerType_SCHEDULER_TYPE_ROUND_ROBIN
 	}
+	if pool.Scheduler.Backfill != nil {
+		schedulerType = resourcepoolv1.SchedulerType_SCHEDULER_TYPE_BACKFILL
+	}
 
 	resp := &resourcepoolv1.ResourcePool{
 		Name:                         pool.PoolName,
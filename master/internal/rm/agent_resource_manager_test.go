@@ -0,0 +1,46 @@
+package rm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/actor/actors"
+	"github.com/determined-ai/determined/proto/pkg/resourcepoolv1"
+)
+
+func TestResourcePoolSummarySchedulerType(t *testing.T) {
+	system := actor.NewSystem(t.Name())
+	pool := system.MustActorOf(actor.Addr("backfill"), &actors.MockActor{
+		Responses: map[string]*actors.MockResponse{
+			"rm.GetResourceSummary": {Msg: ResourceSummary{}},
+		},
+	})
+	a := &agentResourceManager{
+		config: &config.AgentResourceManagerConfig{},
+		poolsConfig: []config.ResourcePoolConfig{{
+			PoolName: "backfill",
+			Scheduler: &config.SchedulerConfig{
+				Backfill: &config.BackfillSchedulerConfig{
+					DefaultRuntime: config.DefaultBackfillRuntime,
+				},
+				FittingPolicy: best,
+			},
+		}},
+		pools: map[string]*actor.Ref{"backfill": pool},
+	}
+
+	// The summary asks the pool for its resources, so it must be made from inside an actor.
+	ref := system.MustActorOf(actor.Addr("summary"), actor.ActorFunc(func(ctx *actor.Context) error {
+		if _, ok := ctx.Message().(string); ok {
+			summary, err := a.createResourcePoolSummary(ctx, "backfill")
+			assert.NilError(t, err)
+			ctx.Respond(summary)
+		}
+		return nil
+	}))
+	summary := system.Ask(ref, "summary").Get().(*resourcepoolv1.ResourcePool)
+	assert.Equal(t, summary.SchedulerType, resourcepoolv1.SchedulerType_SCHEDULER_TYPE_BACKFILL)
+}
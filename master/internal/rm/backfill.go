@@ -0,0 +1,272 @@
+package rm
+
+import (
+	"sort"
+	"time"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/cproto"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// backfillScheduler schedules tasks in queue order. When the task at the head of the queue does
+// not fit, it reserves the resources it needs at the earliest time they are expected to be free,
+// based on the expected runtimes of running allocations. Tasks further back in the queue may run
+// in the meantime if doing so does not push back that reservation.
+type backfillScheduler struct {
+	defaultRuntime time.Duration
+	now            func() time.Time
+
+	// startTimes records when the scheduler first saw each allocation running.
+	startTimes map[*actor.Ref]time.Time
+	// reservations holds when the head of the queue of each agent label is expected to start, as
+	// of the last scheduling pass.
+	reservations map[model.JobID]time.Time
+}
+
+// NewBackfillScheduler creates a new scheduler that schedules tasks in queue order with
+// backfilling.
+func NewBackfillScheduler(conf *config.SchedulerConfig) Scheduler {
+	return &backfillScheduler{
+		defaultRuntime: time.Duration(conf.Backfill.DefaultRuntime),
+		now:            time.Now,
+		startTimes:     make(map[*actor.Ref]time.Time),
+		reservations:   make(map[model.JobID]time.Time),
+	}
+}
+
+func (b *backfillScheduler) Schedule(rp *ResourcePool) ([]*sproto.AllocateRequest, []*actor.Ref) {
+	return b.backfillSchedule(rp.schedulableTasks(), rp.groups, rp.queuePositions,
+		rp.agentStatesCache, rp.fittingMethod)
+}
+
+func (b *backfillScheduler) JobQInfo(rp *ResourcePool) map[model.JobID]*sproto.RMJobInfo {
+	jobQInfo := reduceToJobQInfo(sortTasksByPosition(rp.taskList, rp.queuePositions))
+	for jobID, start := range b.reservations {
+		if info, ok := jobQInfo[jobID]; ok {
+			reserved := start
+			info.ReservedStart = &reserved
+		}
+	}
+	return jobQInfo
+}
+
+// agentContainer identifies a container on an agent.
+type agentContainer struct {
+	agent *actor.Ref
+	id    cproto.ID
+}
+
+// expectedRelease is when a set of containers is expected to free their resources.
+type expectedRelease struct {
+	at         time.Time
+	containers []agentContainer
+}
+
+func (b *backfillScheduler) backfillSchedule(
+	taskList *taskList,
+	groups map[*actor.Ref]*group,
+	jobPositions jobSortState,
+	agents map[*actor.Ref]*AgentState,
+	fittingMethod SoftConstraint,
+) ([]*sproto.AllocateRequest, []*actor.Ref) {
+	now := b.now()
+	toAllocate := make([]*sproto.AllocateRequest, 0)
+	b.reservations = make(map[model.JobID]time.Time)
+
+	reqs := sortTasksByPosition(taskList, jobPositions)
+	b.updateStartTimes(taskList, reqs, now)
+
+	groupSlots := make(map[*actor.Ref]int)
+	for _, req := range reqs {
+		if taskList.GetAllocations(req.AllocationRef) != nil {
+			groupSlots[req.Group] += req.SlotsNeeded
+		}
+	}
+
+	// Since labels are a hard scheduling constraint, process every label independently.
+	for label, agentsWithLabel := range splitAgentsByLabel(agents) {
+		localAgents := deepCopyAgents(agentsWithLabel)
+		releases := b.expectedReleases(taskList, reqs, label, now)
+
+		var head *sproto.AllocateRequest
+		var reservedAt time.Time
+		for _, req := range reqs {
+			if req.AgentLabel != label || taskList.GetAllocations(req.AllocationRef) != nil {
+				continue
+			}
+			if g := groups[req.Group]; g != nil && g.maxSlots != nil &&
+				groupSlots[req.Group]+req.SlotsNeeded > *g.maxSlots {
+				continue
+			}
+			fits := findFits(req, localAgents, fittingMethod)
+
+			// Zero-slot tasks do not compete for slots, so they start whenever they fit.
+			if req.SlotsNeeded == 0 || head == nil {
+				if len(fits) == 0 {
+					if req.SlotsNeeded > 0 {
+						head, reservedAt = b.reserve(req, localAgents, releases, fittingMethod)
+					}
+					continue
+				}
+				allocateFits(fits)
+				toAllocate = append(toAllocate, req)
+				groupSlots[req.Group] += req.SlotsNeeded
+				continue
+			}
+
+			if len(fits) == 0 {
+				continue
+			}
+			started := allocateFits(fits)
+			releases = append(releases, expectedRelease{
+				at:         now.Add(b.expectedRuntime(req)),
+				containers: started,
+			})
+			if len(findFits(head, freeAt(reservedAt, localAgents, releases), fittingMethod)) == 0 {
+				// Starting this task would delay the reservation.
+				for _, c := range started {
+					localAgents[c.agent].DeallocateContainer(c.id)
+				}
+				releases = releases[:len(releases)-1]
+				continue
+			}
+			toAllocate = append(toAllocate, req)
+			groupSlots[req.Group] += req.SlotsNeeded
+		}
+		if head != nil {
+			b.reservations[head.JobID] = reservedAt
+		}
+	}
+
+	for _, req := range toAllocate {
+		b.startTimes[req.AllocationRef] = now
+	}
+	return toAllocate, make([]*actor.Ref, 0)
+}
+
+// reserve returns the earliest time at which req is expected to fit. It returns a nil request if
+// req cannot fit even once all running allocations finish, so it should not hold up the queue.
+func (b *backfillScheduler) reserve(
+	req *sproto.AllocateRequest,
+	agents map[*actor.Ref]*AgentState,
+	releases []expectedRelease,
+	fittingMethod SoftConstraint,
+) (*sproto.AllocateRequest, time.Time) {
+	times := make([]time.Time, 0, len(releases))
+	for _, r := range releases {
+		times = append(times, r.at)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	for _, at := range times {
+		if len(findFits(req, freeAt(at, agents, releases), fittingMethod)) > 0 {
+			return req, at
+		}
+	}
+	return nil, time.Time{}
+}
+
+// expectedReleases returns when the running allocations with the label are expected to finish.
+func (b *backfillScheduler) expectedReleases(
+	taskList *taskList, reqs []*sproto.AllocateRequest, label string, now time.Time,
+) []expectedRelease {
+	var releases []expectedRelease
+	for _, req := range reqs {
+		allocated := taskList.GetAllocations(req.AllocationRef)
+		if req.AgentLabel != label || allocated == nil {
+			continue
+		}
+		release := expectedRelease{
+			at: b.startTimes[req.AllocationRef].Add(b.expectedRuntime(req)),
+		}
+		if release.at.Before(now) {
+			// Overdue allocations could finish at any moment.
+			release.at = now
+		}
+		for _, r := range allocated.Resources {
+			if cr, ok := r.(*containerResources); ok {
+				release.containers = append(release.containers,
+					agentContainer{agent: cr.agent.Handler, id: cr.containerID})
+			}
+		}
+		releases = append(releases, release)
+	}
+	return releases
+}
+
+// updateStartTimes tracks when allocations started. Allocations that were running when the
+// master started are treated as if they had just started.
+func (b *backfillScheduler) updateStartTimes(
+	taskList *taskList, reqs []*sproto.AllocateRequest, now time.Time,
+) {
+	running := make(map[*actor.Ref]bool, len(reqs))
+	for _, req := range reqs {
+		if taskList.GetAllocations(req.AllocationRef) == nil {
+			continue
+		}
+		running[req.AllocationRef] = true
+		if _, ok := b.startTimes[req.AllocationRef]; !ok {
+			b.startTimes[req.AllocationRef] = now
+		}
+	}
+	for ref := range b.startTimes {
+		if !running[ref] {
+			delete(b.startTimes, ref)
+		}
+	}
+}
+
+func (b *backfillScheduler) expectedRuntime(req *sproto.AllocateRequest) time.Duration {
+	if req.ExpectedRuntime != nil {
+		return *req.ExpectedRuntime
+	}
+	return b.defaultRuntime
+}
+
+// freeAt returns a copy of the agents with the containers expected to finish by the given time
+// removed.
+func freeAt(
+	at time.Time, agents map[*actor.Ref]*AgentState, releases []expectedRelease,
+) map[*actor.Ref]*AgentState {
+	copied := deepCopyAgents(agents)
+	for _, r := range releases {
+		if r.at.After(at) {
+			continue
+		}
+		for _, c := range r.containers {
+			if agent, ok := copied[c.agent]; ok {
+				agent.DeallocateContainer(c.id)
+			}
+		}
+	}
+	return copied
+}
+
+// allocateFits allocates the fits on their agents, returning the containers it created.
+func allocateFits(fits []*fittingState) []agentContainer {
+	containers := make([]agentContainer, 0, len(fits))
+	for _, fit := range fits {
+		id := cproto.NewID()
+		if _, err := fit.Agent.AllocateFreeDevices(fit.Slots, id); err != nil {
+			panic(err)
+		}
+		containers = append(containers, agentContainer{agent: fit.Agent.Handler, id: id})
+	}
+	return containers
+}
+
+// sortTasksByPosition returns the tasks in the order of the job queue.
+func sortTasksByPosition(
+	taskList *taskList, jobPositions jobSortState,
+) []*sproto.AllocateRequest {
+	var reqs []*sproto.AllocateRequest
+	for it := taskList.iterator(); it.next(); {
+		reqs = append(reqs, it.value())
+	}
+	sort.SliceStable(reqs, func(i, j int) bool {
+		return comparePositions(reqs[i], reqs[j], jobPositions) > 0
+	})
+	return reqs
+}
@@ -0,0 +1,121 @@
+package rm
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func setExpectedRuntime(t *testing.T, taskList *taskList, id string, runtime time.Duration) {
+	req, ok := taskList.GetTaskByID(model.AllocationID(id))
+	assert.Assert(t, ok)
+	req.ExpectedRuntime = &runtime
+}
+
+func newTestBackfillScheduler(now time.Time) *backfillScheduler {
+	b := NewBackfillScheduler(&config.SchedulerConfig{
+		Backfill: &config.BackfillSchedulerConfig{DefaultRuntime: config.DefaultBackfillRuntime},
+	}).(*backfillScheduler)
+	b.now = func() time.Time { return now }
+	return b
+}
+
+func TestBackfillSchedulingReservesHeadOfLine(t *testing.T) {
+	now := time.Now()
+	agents := []*mockAgent{
+		{id: "agent1", slots: 4, maxZeroSlotContainers: 100},
+	}
+	tasks := []*mockTask{
+		{
+			id: "running", slotsNeeded: 2, allocatedAgent: agents[0], containerStarted: true,
+			jobSubmissionTime: now.Add(-4 * time.Minute),
+		},
+		{id: "big", slotsNeeded: 4, jobSubmissionTime: now.Add(-3 * time.Minute)},
+		{id: "long", slotsNeeded: 2, jobSubmissionTime: now.Add(-2 * time.Minute)},
+		{id: "short", slotsNeeded: 2, jobSubmissionTime: now.Add(-time.Minute)},
+		{id: "aux", slotsNeeded: 0, jobSubmissionTime: now},
+	}
+
+	system := actor.NewSystem(t.Name())
+	rp := NewResourcePool(&config.ResourcePoolConfig{PoolName: "pool"}, nil, nil, nil, BestFit)
+	rp.taskList, rp.groups, rp.agentStatesCache = setupSchedulerStates(
+		t, system, tasks, nil, agents)
+	setExpectedRuntime(t, rp.taskList, "running", time.Hour)
+	setExpectedRuntime(t, rp.taskList, "long", 2*time.Hour)
+	setExpectedRuntime(t, rp.taskList, "short", 30*time.Minute)
+
+	// The big task must wait for the running task to finish in an hour. The long task would still
+	// be running then, but the short task can run in the meantime.
+	b := newTestBackfillScheduler(now)
+	toAllocate, toRelease := b.Schedule(rp)
+	assertEqualToAllocate(t, toAllocate, []*mockTask{tasks[3], tasks[4]})
+	assert.Equal(t, len(toRelease), 0)
+
+	jobQInfo := b.JobQInfo(rp)
+	assert.Equal(t, *jobQInfo["big"].ReservedStart, now.Add(time.Hour))
+	assert.Assert(t, jobQInfo["long"].ReservedStart == nil)
+	assert.Equal(t, jobQInfo["long"].JobsAhead, 2)
+}
+
+func TestBackfillSchedulingSkipsUnfittableHead(t *testing.T) {
+	now := time.Now()
+	agents := []*mockAgent{
+		{id: "agent1", slots: 4, maxZeroSlotContainers: 100},
+	}
+	tasks := []*mockTask{
+		{id: "huge", slotsNeeded: 8, jobSubmissionTime: now.Add(-time.Minute)},
+		{id: "small", slotsNeeded: 4, jobSubmissionTime: now},
+	}
+
+	system := actor.NewSystem(t.Name())
+	taskList, groupMap, agentMap := setupSchedulerStates(t, system, tasks, nil, agents)
+	b := newTestBackfillScheduler(now)
+	toAllocate, _ := b.backfillSchedule(
+		taskList, groupMap, make(jobSortState), agentMap, BestFit)
+	assertEqualToAllocate(t, toAllocate, []*mockTask{tasks[1]})
+	assert.Equal(t, len(b.reservations), 0)
+}
+
+func TestBackfillSchedulerSimulation(t *testing.T) {
+	conf := &config.SchedulerConfig{
+		Backfill:      &config.BackfillSchedulerConfig{DefaultRuntime: config.DefaultBackfillRuntime},
+		FittingPolicy: best,
+	}
+	assert.Equal(t, conf.GetType(), config.BackfillScheduling)
+
+	sim := Simulation{
+		Scheduler: conf,
+		Agents:    []SimulatedAgent{{ID: "agent1", Slots: 4}, {ID: "agent2", Slots: 4}},
+		Requests: []SimulatedRequest{
+			{ID: "first", SlotsNeeded: 4, DurationSeconds: 100},
+			{ID: "gang", SlotsNeeded: 8, SubmitSeconds: 1, DurationSeconds: 100},
+			{ID: "filler", SlotsNeeded: 4, SubmitSeconds: 2, DurationSeconds: 50},
+			{ID: "late", SlotsNeeded: 4, SubmitSeconds: 3, DurationSeconds: 500},
+		},
+	}
+	res, err := Simulate(sim)
+	assert.NilError(t, err)
+	byID := simulationRequestsByID(res)
+	assert.Equal(t, *byID["filler"].StartSeconds, 2.0)
+	assert.Equal(t, *byID["gang"].StartSeconds, 100.0)
+	assert.Equal(t, *byID["late"].StartSeconds, 200.0)
+
+	// Without a reservation, the later requests keep taking the free agent and the gang waits until
+	// all of them are done.
+	sim.Scheduler = &config.SchedulerConfig{
+		Priority: &config.PrioritySchedulerConfig{
+			DefaultPriority: ptrs.Ptr(config.DefaultSchedulingPriority),
+		},
+		FittingPolicy: best,
+	}
+	res, err = Simulate(sim)
+	assert.NilError(t, err)
+	byID = simulationRequestsByID(res)
+	assert.Equal(t, *byID["gang"].StartSeconds, 552.0)
+}
@@ -0,0 +1,162 @@
+package dispatcher
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/device"
+)
+
+// fakeCLI puts executable scripts with the given names and bodies first on the PATH.
+func fakeCLI(t *testing.T, scripts map[string]string) string {
+	dir := t.TempDir()
+	for name, body := range scripts {
+		err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), 0o700)
+		assert.NilError(t, err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+func TestSlurm(t *testing.T) {
+	dir := fakeCLI(t, map[string]string{
+		"sbatch":  `echo "$@" > "$(dirname "$0")/sbatch.args"; echo "42;cluster"`,
+		"squeue":  `echo "42 RUNNING"; echo "43 PENDING"; echo "44 CANCELLED"`,
+		"scancel": `echo "$@" > "$(dirname "$0")/scancel.args"`,
+	})
+	wlm, err := New("slurm")
+	assert.NilError(t, err)
+	ctx := context.Background()
+
+	id, err := wlm.Submit(ctx, "/jobs/job.sh")
+	assert.NilError(t, err)
+	assert.Equal(t, id, "42")
+	args, err := os.ReadFile(filepath.Join(dir, "sbatch.args"))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(args)), "--parsable /jobs/job.sh")
+
+	statuses, err := wlm.Status(ctx, []string{"42", "43", "44"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, statuses, map[string]JobStatus{
+		"42": {State: JobRunning, NativeState: "RUNNING"},
+		"43": {State: JobPending, NativeState: "PENDING"},
+		"44": {State: JobDone, NativeState: "CANCELLED"},
+	})
+
+	assert.NilError(t, wlm.Cancel(ctx, "42"))
+	args, err = os.ReadFile(filepath.Join(dir, "scancel.args"))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(args)), "42")
+}
+
+func TestSlurmErrors(t *testing.T) {
+	fakeCLI(t, map[string]string{
+		"sbatch": `echo "sbatch: error: invalid partition specified" >&2; exit 1`,
+		"squeue": `echo "slurm_load_jobs error: Invalid job id specified" >&2; exit 1`,
+	})
+	wlm, err := New("slurm")
+	assert.NilError(t, err)
+	ctx := context.Background()
+
+	_, err = wlm.Submit(ctx, "/jobs/job.sh")
+	assert.ErrorContains(t, err, "invalid partition specified")
+
+	statuses, err := wlm.Status(ctx, []string{"42"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(statuses), 0)
+}
+
+func TestPBS(t *testing.T) {
+	dir := fakeCLI(t, map[string]string{
+		"qsub": `echo "7.pbs-server"`,
+		"qstat": `cat <<EOF
+Job id            Name             User              Time Use S Queue
+----------------  ---------------- ----------------  -------- - -----
+7.pbs-server      det-a            det               00:00:01 R workq
+8.pbs-server      det-b            det                      0 Q workq
+EOF
+echo "qstat: Unknown Job Id 9.pbs-server" >&2
+exit 153`,
+		"qdel": `echo "$@" > "$(dirname "$0")/qdel.args"`,
+	})
+	wlm, err := New("pbs")
+	assert.NilError(t, err)
+	ctx := context.Background()
+
+	id, err := wlm.Submit(ctx, "/jobs/job.sh")
+	assert.NilError(t, err)
+	assert.Equal(t, id, "7.pbs-server")
+
+	statuses, err := wlm.Status(ctx, []string{"7.pbs-server", "8.pbs-server", "9.pbs-server"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, statuses, map[string]JobStatus{
+		"7.pbs-server": {State: JobRunning, NativeState: "R"},
+		"8.pbs-server": {State: JobPending, NativeState: "Q"},
+	})
+
+	assert.NilError(t, wlm.Cancel(ctx, "7.pbs-server"))
+	args, err := os.ReadFile(filepath.Join(dir, "qdel.args"))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(args)), "7.pbs-server")
+}
+
+func TestJobScript(t *testing.T) {
+	dir := fakeCLI(t, map[string]string{
+		"singularity": `echo "$@" > "$(dirname "$0")/singularity.args"; echo "$DET_TASK_ID"; exit 3`,
+	})
+	wlm, err := New("slurm")
+	assert.NilError(t, err)
+
+	job := &Job{
+		Name:       "det-1",
+		Dir:        filepath.Join(t.TempDir(), "det-1"),
+		Queue:      "gpus",
+		Slots:      2,
+		SlotType:   device.CUDA,
+		ExtraArgs:  []string{"--time=1:00:00"},
+		Image:      "determinedai/environments:py-3.8",
+		Env:        []string{"DET_TASK_ID=it's a task"},
+		Entrypoint: []string{"/run/determined/train/entrypoint.sh"},
+	}
+	script, err := job.Write(wlm)
+	assert.NilError(t, err)
+
+	b, err := os.ReadFile(script) // #nosec G304
+	assert.NilError(t, err)
+	for _, directive := range []string{
+		"#SBATCH --job-name=det-1", "#SBATCH --partition=gpus", "#SBATCH --gpus=2",
+		"#SBATCH --time=1:00:00",
+	} {
+		assert.Assert(t, strings.Contains(string(b), directive+"\n"), directive)
+	}
+
+	_, ok := job.Host()
+	assert.Assert(t, !ok)
+	_, ok = job.ExitCode()
+	assert.Assert(t, !ok)
+
+	// Run the batch script the way the compute node would.
+	out, err := exec.Command("/bin/bash", script).CombinedOutput() // #nosec G204
+	exitErr, ok := err.(*exec.ExitError)
+	assert.Assert(t, ok, "%v: %s", err, out)
+	assert.Equal(t, exitErr.ExitCode(), 3)
+	assert.Equal(t, strings.TrimSpace(string(out)), "it's a task")
+
+	args, err := os.ReadFile(filepath.Join(dir, "singularity.args"))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(args)),
+		"exec --nv docker://determinedai/environments:py-3.8 /run/determined/train/entrypoint.sh")
+
+	host, ok := job.Host()
+	assert.Assert(t, ok)
+	assert.Assert(t, host != "")
+	code, ok := job.ExitCode()
+	assert.Assert(t, ok)
+	assert.Equal(t, code, 3)
+}
@@ -0,0 +1,259 @@
+package dispatcher
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/cproto"
+	"github.com/determined-ai/determined/master/pkg/device"
+)
+
+const (
+	scriptFile   = "job.sh"
+	outputFile   = "output.log"
+	hostFile     = "host"
+	exitCodeFile = "exit_code"
+	rootDir      = "root"
+
+	singularity = "singularity"
+	podman      = "podman"
+)
+
+// BindMount mounts a path of the compute node into the task container.
+type BindMount struct {
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// Job is a task container to be run as a batch job.
+type Job struct {
+	// Name is the name of the job, which is also the name of its directory.
+	Name string
+	// Dir is the directory, shared by the master and the compute nodes, that holds the batch
+	// script, files and output of the job.
+	Dir string
+	// Queue is the partition or queue to submit the job to, if not the default one.
+	Queue     string
+	Slots     int
+	SlotType  device.Type
+	ExtraArgs []string
+
+	// ContainerRunType is the container runtime used on the compute nodes: singularity or podman.
+	ContainerRunType string
+	Image            string
+	Env              []string
+	Entrypoint       []string
+	WorkDir          string
+	Archives         []cproto.RunArchive
+	Mounts           []BindMount
+}
+
+// ScriptPath is the path of the batch script of the job.
+func (j *Job) ScriptPath() string {
+	return filepath.Join(j.Dir, scriptFile)
+}
+
+// OutputPath is the path of the file that the output of the job is written to.
+func (j *Job) OutputPath() string {
+	return filepath.Join(j.Dir, outputFile)
+}
+
+// Write creates the directory of the job with the files of its archives and its batch script, and
+// returns the path of the batch script.
+func (j *Job) Write(wlm WorkloadManager) (string, error) {
+	if err := os.MkdirAll(j.Dir, 0o700); err != nil {
+		return "", errors.Wrap(err, "creating job directory")
+	}
+	for _, a := range j.Archives {
+		if err := writeArchive(filepath.Join(j.Dir, rootDir), a); err != nil {
+			return "", err
+		}
+	}
+	if err := os.WriteFile(j.ScriptPath(), []byte(j.script(wlm)), 0o700); err != nil {
+		return "", errors.Wrap(err, "writing batch script")
+	}
+	return j.ScriptPath(), nil
+}
+
+// Host returns the compute node that the job runs on, once it has started.
+func (j *Job) Host() (string, bool) {
+	b, err := os.ReadFile(filepath.Join(j.Dir, hostFile)) // #nosec G304
+	if err != nil || len(strings.TrimSpace(string(b))) == 0 {
+		return "", false
+	}
+	return strings.TrimSpace(string(b)), true
+}
+
+// ExitCode returns the exit code of the task container, once the job has finished. Jobs that are
+// canceled or killed by the workload manager do not report one.
+func (j *Job) ExitCode() (int, bool) {
+	b, err := os.ReadFile(filepath.Join(j.Dir, exitCodeFile)) // #nosec G304
+	if err != nil {
+		return 0, false
+	}
+	code, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, false
+	}
+	return code, true
+}
+
+// script returns the batch script of the job. It records the compute node that the job runs on
+// and the exit code of the task container in the directory of the job.
+func (j *Job) script(wlm WorkloadManager) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/bash\n")
+	for _, d := range wlm.Directives(j) {
+		b.WriteString(d + "\n")
+	}
+	b.WriteString("\n")
+
+	env := append([]string{}, j.Env...)
+	sort.Strings(env)
+	for _, e := range env {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		fmt.Fprintf(&b, "export %s=%s\n", kv[0], quote(kv[1]))
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "hostname > %s\n", quote(filepath.Join(j.Dir, hostFile)))
+	b.WriteString(strings.Join(quoteAll(j.containerCommand(env)), " ") + "\n")
+	b.WriteString("exit_code=$?\n")
+	fmt.Fprintf(&b, "echo \"$exit_code\" > %s\n", quote(filepath.Join(j.Dir, exitCodeFile)))
+	b.WriteString("exit \"$exit_code\"\n")
+	return b.String()
+}
+
+// containerCommand returns the command that runs the task container. The environment is exported
+// by the batch script, which singularity passes on as is and podman is told to pass on.
+func (j *Job) containerCommand(env []string) []string {
+	var cmd []string
+	binds := j.binds()
+	switch j.ContainerRunType {
+	case podman:
+		cmd = []string{podman, "run", "--rm", "--network=host"}
+		switch j.SlotType {
+		case device.CUDA:
+			cmd = append(cmd, "--device=nvidia.com/gpu=all")
+		case device.ROCM:
+			cmd = append(cmd, "--device=/dev/kfd", "--device=/dev/dri")
+		}
+		for _, e := range env {
+			cmd = append(cmd, "--env="+strings.SplitN(e, "=", 2)[0])
+		}
+		for _, m := range binds {
+			cmd = append(cmd, "--volume="+m)
+		}
+		if j.WorkDir != "" {
+			cmd = append(cmd, "--workdir="+j.WorkDir)
+		}
+		cmd = append(cmd, j.Image)
+	default:
+		cmd = []string{singularity, "exec"}
+		switch j.SlotType {
+		case device.CUDA:
+			cmd = append(cmd, "--nv")
+		case device.ROCM:
+			cmd = append(cmd, "--rocm")
+		}
+		for _, m := range binds {
+			cmd = append(cmd, "--bind="+m)
+		}
+		if j.WorkDir != "" {
+			cmd = append(cmd, "--pwd="+j.WorkDir)
+		}
+		cmd = append(cmd, singularityImage(j.Image))
+	}
+	return append(cmd, j.Entrypoint...)
+}
+
+// binds returns the bind mounts of the task container as source:target[:ro]. The files of the
+// archives are bound from the directory of the job by their top two levels, e.g.,
+// /run/determined, since container images do not provide the directories they go into.
+func (j *Job) binds() []string {
+	seen := make(map[string]bool)
+	var binds []string
+	for _, a := range j.Archives {
+		for _, item := range a.Archive {
+			parts := strings.SplitN(
+				strings.TrimPrefix(filepath.Join("/", a.Path, item.Path), "/"), "/", 3)
+			if len(parts) < 2 && item.IsDir() {
+				continue
+			}
+			if len(parts) > 2 {
+				parts = parts[:2]
+			}
+			target := "/" + strings.Join(parts, "/")
+			if seen[target] {
+				continue
+			}
+			seen[target] = true
+			binds = append(binds, filepath.Join(j.Dir, rootDir, target)+":"+target)
+		}
+	}
+	for _, m := range j.Mounts {
+		bind := m.Source + ":" + m.Target
+		if m.ReadOnly {
+			bind += ":ro"
+		}
+		binds = append(binds, bind)
+	}
+	return binds
+}
+
+// writeArchive writes the files of an archive under the given root directory.
+func writeArchive(root string, a cproto.RunArchive) error {
+	for _, item := range a.Archive {
+		path := filepath.Join(root, a.Path, item.Path)
+		var err error
+		switch item.Type {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, item.FileMode.Perm()|0o700)
+		case tar.TypeSymlink:
+			if err = os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+				err = os.Symlink(string(item.Content), path)
+			}
+		default:
+			if err = os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+				err = os.WriteFile(path, []byte(item.Content), item.FileMode.Perm())
+			}
+		}
+		if err != nil && !os.IsExist(err) {
+			return errors.Wrapf(err, "writing %s", item.Path)
+		}
+	}
+	return nil
+}
+
+// singularityImage returns the image reference for singularity. Docker images are pulled from
+// their registry, while other references, e.g., local .sif files, are used as is.
+func singularityImage(image string) string {
+	if strings.Contains(image, "://") || strings.HasPrefix(image, "/") {
+		return image
+	}
+	return "docker://" + image
+}
+
+// quote quotes a string for the shell.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func quoteAll(ss []string) []string {
+	quoted := make([]string, 0, len(ss))
+	for _, s := range ss {
+		quoted = append(quoted, quote(s))
+	}
+	return quoted
+}
@@ -0,0 +1,107 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/device"
+)
+
+// pbs submits jobs with qsub, polls them with qstat and cancels them with qdel.
+type pbs struct{}
+
+func (pbs) Name() string {
+	return "pbs"
+}
+
+func (pbs) Directives(job *Job) []string {
+	d := []string{
+		"-N " + job.Name,
+		"-o " + job.OutputPath(),
+		"-j oe",
+	}
+	if job.Queue != "" {
+		d = append(d, "-q "+job.Queue)
+	}
+	switch {
+	case job.Slots == 0:
+		d = append(d, "-l select=1")
+	case job.SlotType == device.CPU:
+		d = append(d, fmt.Sprintf("-l select=1:ncpus=%d", job.Slots))
+	default:
+		d = append(d, fmt.Sprintf("-l select=1:ngpus=%d", job.Slots))
+	}
+	d = append(d, job.ExtraArgs...)
+
+	lines := make([]string, 0, len(d))
+	for _, directive := range d {
+		lines = append(lines, "#PBS "+directive)
+	}
+	return lines
+}
+
+func (pbs) Submit(ctx context.Context, script string) (string, error) {
+	out, err := run(ctx, "qsub", script)
+	if err != nil {
+		return "", err
+	}
+	id := strings.TrimSpace(out)
+	if id == "" {
+		return "", errors.Errorf("qsub did not report a job ID: %q", out)
+	}
+	return id, nil
+}
+
+func (pbs) Status(ctx context.Context, ids []string) (map[string]JobStatus, error) {
+	statuses := make(map[string]JobStatus)
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+	out, err := run(ctx, "qstat", ids...)
+	if err != nil && !strings.Contains(err.Error(), "Unknown Job Id") {
+		return nil, err
+	}
+
+	// qstat prints a table with the job ID first and the state in the fifth column, truncating
+	// long job IDs, so they are matched by their sequence number.
+	bySequence := make(map[string]string, len(ids))
+	for _, id := range ids {
+		bySequence[pbsSequenceNumber(id)] = id
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		id, ok := bySequence[pbsSequenceNumber(fields[0])]
+		if !ok {
+			continue
+		}
+		statuses[id] = JobStatus{State: pbsJobState(fields[4]), NativeState: fields[4]}
+	}
+	return statuses, nil
+}
+
+func (pbs) Cancel(ctx context.Context, id string) error {
+	_, err := run(ctx, "qdel", id)
+	return err
+}
+
+func pbsSequenceNumber(id string) string {
+	return strings.SplitN(id, ".", 2)[0]
+}
+
+// pbsJobState maps the job state codes of PBS to job states.
+func pbsJobState(state string) JobState {
+	switch state {
+	case "Q", "H", "W", "T", "S", "U":
+		return JobPending
+	case "R", "E", "B":
+		return JobRunning
+	default:
+		return JobDone
+	}
+}
@@ -0,0 +1,99 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/device"
+)
+
+// slurm submits jobs with sbatch, polls them with squeue and cancels them with scancel.
+type slurm struct{}
+
+func (slurm) Name() string {
+	return "slurm"
+}
+
+func (slurm) Directives(job *Job) []string {
+	d := []string{
+		"--job-name=" + job.Name,
+		"--output=" + job.OutputPath(),
+		"--ntasks=1",
+	}
+	if job.Queue != "" {
+		d = append(d, "--partition="+job.Queue)
+	}
+	if job.Slots > 0 {
+		switch job.SlotType {
+		case device.CPU:
+			d = append(d, fmt.Sprintf("--cpus-per-task=%d", job.Slots))
+		default:
+			d = append(d, fmt.Sprintf("--gpus=%d", job.Slots))
+		}
+	}
+	d = append(d, job.ExtraArgs...)
+
+	lines := make([]string, 0, len(d))
+	for _, directive := range d {
+		lines = append(lines, "#SBATCH "+directive)
+	}
+	return lines
+}
+
+func (slurm) Submit(ctx context.Context, script string) (string, error) {
+	out, err := run(ctx, "sbatch", "--parsable", script)
+	if err != nil {
+		return "", err
+	}
+	// The output is either "<id>" or "<id>;<cluster>".
+	id := strings.TrimSpace(strings.SplitN(out, ";", 2)[0])
+	if id == "" {
+		return "", errors.Errorf("sbatch did not report a job ID: %q", out)
+	}
+	return id, nil
+}
+
+func (slurm) Status(ctx context.Context, ids []string) (map[string]JobStatus, error) {
+	statuses := make(map[string]JobStatus)
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+	out, err := run(ctx, "squeue",
+		"--noheader", "--format=%i %T", "--jobs="+strings.Join(ids, ","))
+	switch {
+	case err != nil && strings.Contains(err.Error(), "Invalid job id"):
+		// squeue fails rather than printing nothing when it no longer knows about the job.
+		return statuses, nil
+	case err != nil:
+		return nil, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		statuses[fields[0]] = JobStatus{State: slurmJobState(fields[1]), NativeState: fields[1]}
+	}
+	return statuses, nil
+}
+
+func (slurm) Cancel(ctx context.Context, id string) error {
+	_, err := run(ctx, "scancel", id)
+	return err
+}
+
+// slurmJobState maps the job state codes of Slurm to job states.
+func slurmJobState(state string) JobState {
+	switch state {
+	case "PENDING", "CONFIGURING", "REQUEUED", "REQUEUE_FED", "REQUEUE_HOLD", "RESIZING",
+		"SUSPENDED":
+		return JobPending
+	case "RUNNING", "COMPLETING", "STAGE_OUT", "SIGNALING":
+		return JobRunning
+	default:
+		return JobDone
+	}
+}
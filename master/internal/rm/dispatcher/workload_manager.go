@@ -0,0 +1,72 @@
+// Package dispatcher runs tasks as batch jobs of an HPC workload manager, such as Slurm or PBS,
+// through its command line tools.
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JobState is the state of a batch job, as far as the master is concerned.
+type JobState string
+
+const (
+	// JobPending means the job is waiting in the queue of the workload manager.
+	JobPending JobState = "PENDING"
+	// JobRunning means the job is running on a compute node.
+	JobRunning JobState = "RUNNING"
+	// JobDone means the job has finished, successfully or not.
+	JobDone JobState = "DONE"
+)
+
+// JobStatus is the status of a batch job as reported by the workload manager.
+type JobStatus struct {
+	State JobState
+	// NativeState is the state as reported by the workload manager, e.g., CANCELLED or Q.
+	NativeState string
+}
+
+// WorkloadManager submits, monitors and cancels batch jobs.
+type WorkloadManager interface {
+	// Name is the name of the workload manager, for informational purposes.
+	Name() string
+	// Directives returns the lines of the batch script header that request resources for the job.
+	Directives(job *Job) []string
+	// Submit submits the batch script of a job and returns the ID of the job.
+	Submit(ctx context.Context, script string) (string, error)
+	// Status returns the status of the given jobs. Jobs that the workload manager no longer knows
+	// about are omitted.
+	Status(ctx context.Context, ids []string) (map[string]JobStatus, error)
+	// Cancel cancels a job.
+	Cancel(ctx context.Context, id string) error
+}
+
+// New returns the workload manager of the given name, i.e., slurm or pbs.
+func New(name string) (WorkloadManager, error) {
+	switch name {
+	case "slurm":
+		return slurm{}, nil
+	case "pbs":
+		return pbs{}, nil
+	default:
+		return nil, errors.Errorf("unknown workload manager: %s", name)
+	}
+}
+
+// run runs a command line tool, found through PATH, and returns its output. The error includes
+// whatever the tool wrote to stderr.
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), errors.Wrapf(err, "running %s: %s",
+			name, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
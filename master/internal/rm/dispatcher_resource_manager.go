@@ -0,0 +1,846 @@
+package rm
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/rm/dispatcher"
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/actor/actors"
+	"github.com/determined-ai/determined/master/pkg/aproto"
+	"github.com/determined-ai/determined/master/pkg/cproto"
+	"github.com/determined-ai/determined/master/pkg/logger"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/tasks"
+	"github.com/determined-ai/determined/proto/pkg/apiv1"
+	"github.com/determined-ai/determined/proto/pkg/resourcepoolv1"
+)
+
+// dispatcherCommandTimeout bounds how long the command line tools of the workload manager may take.
+const dispatcherCommandTimeout = time.Minute
+
+// DispatcherResourceManager is a resource manager that runs each allocation as a batch job of an
+// HPC workload manager, i.e., Slurm or PBS. Resource pools are the partitions or queues of the
+// workload manager, which does all of the scheduling.
+type DispatcherResourceManager struct {
+	*ActorResourceManager
+	config    *config.DispatcherResourceManagerConfig
+	poolNames []string
+}
+
+// NewDispatcherResourceManager returns a new DispatcherResourceManager, which submits work to
+// Slurm or PBS through their command line tools.
+func NewDispatcherResourceManager(
+	system *actor.System,
+	db *db.PgDB,
+	echo *echo.Echo,
+	config *config.ResourceConfig,
+	opts *aproto.MasterSetAgentOptions,
+	cert *tls.Certificate,
+) DispatcherResourceManager {
+	tlsConfig, err := makeTLSConfig(cert)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to set up TLS config"))
+	}
+	wlmName := "slurm"
+	if config.ResourceManager.PbsRM != nil {
+		wlmName = "pbs"
+	}
+	wlm, err := dispatcher.New(wlmName)
+	if err != nil {
+		panic(err)
+	}
+
+	var poolNames []string
+	for _, pool := range config.ResourcePools {
+		poolNames = append(poolNames, pool.PoolName)
+	}
+	rmConfig := config.ResourceManager.DispatcherRM()
+	if settings := rmConfig.LauncherSettings(); len(settings) > 0 {
+		log.Warnf("ignoring HPC Launcher settings of the %s resource manager: %s",
+			wlmName, strings.Join(settings, ", "))
+	}
+	ref, _ := system.ActorOf(
+		sproto.DispatcherRMAddr,
+		newDispatcherResourceManager(rmConfig, wlm, poolNames, tlsConfig),
+	)
+	system.Ask(ref, actor.Ping{}).Get()
+	return DispatcherResourceManager{
+		ActorResourceManager: WrapRMActor(ref),
+		config:               rmConfig,
+		poolNames:            poolNames,
+	}
+}
+
+// GetResourcePoolRef just returns the RM actor, since the workload manager owns the partitions.
+func (d DispatcherResourceManager) GetResourcePoolRef(
+	ctx actor.Messenger,
+	name string,
+) (*actor.Ref, error) {
+	return d.Ref(), nil
+}
+
+// ResolveResourcePool resolves the resource pool completely.
+func (d DispatcherResourceManager) ResolveResourcePool(
+	ctx actor.Messenger,
+	name string,
+	slots int,
+	command bool,
+) (string, error) {
+	if name == "" {
+		if slots == 0 {
+			name = d.config.DefaultAuxResourcePool
+		} else {
+			name = d.config.DefaultComputeResourcePool
+		}
+	}
+	return name, d.ValidateResourcePool(ctx, name)
+}
+
+// ValidateResourcePool validates that a resource pool is one of the configured ones. If none are
+// configured, any partition or queue of the workload manager may be used.
+func (d DispatcherResourceManager) ValidateResourcePool(ctx actor.Messenger, name string) error {
+	if name == "" || len(d.poolNames) == 0 {
+		return nil
+	}
+	for _, pool := range d.poolNames {
+		if pool == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot find resource pool %s", name)
+}
+
+// GetDefaultComputeResourcePool requests the default compute resource pool.
+func (d DispatcherResourceManager) GetDefaultComputeResourcePool(
+	ctx actor.Messenger,
+	msg sproto.GetDefaultComputeResourcePoolRequest,
+) (sproto.GetDefaultComputeResourcePoolResponse, error) {
+	return sproto.GetDefaultComputeResourcePoolResponse{
+		PoolName: d.config.DefaultComputeResourcePool,
+	}, nil
+}
+
+// GetDefaultAuxResourcePool requests the default aux resource pool.
+func (d DispatcherResourceManager) GetDefaultAuxResourcePool(
+	ctx actor.Messenger,
+	msg sproto.GetDefaultAuxResourcePoolRequest,
+) (sproto.GetDefaultAuxResourcePoolResponse, error) {
+	return sproto.GetDefaultAuxResourcePoolResponse{
+		PoolName: d.config.DefaultAuxResourcePool,
+	}, nil
+}
+
+type (
+	// dispatcherPoll polls the workload manager for the state of the submitted jobs.
+	dispatcherPoll struct{}
+	// dispatcherPollResult carries the status of the polled jobs back to the RM.
+	dispatcherPollResult struct {
+		IDs      []string
+		Statuses map[string]dispatcher.JobStatus
+		Err      error
+	}
+	// dispatcherJobCanceled carries the outcome of canceling a batch job back to the RM.
+	dispatcherJobCanceled struct {
+		ID  string
+		Err error
+	}
+	// dispatcherJobSubmitted notifies the RM that the batch job of some resources was submitted.
+	dispatcherJobSubmitted struct {
+		ResourcesID sproto.ResourcesID
+		ID          string
+		Job         *dispatcher.Job
+		Ports       []int
+	}
+	// killDispatcherJob asks the RM to cancel the batch job of some resources.
+	killDispatcherJob struct {
+		ResourcesID   sproto.ResourcesID
+		AllocationRef *actor.Ref
+	}
+)
+
+// dispatcherJob is the batch job that runs some resources of an allocation.
+type dispatcherJob struct {
+	resourcesID   sproto.ResourcesID
+	allocationRef *actor.Ref
+	req           *sproto.AllocateRequest
+	id            string
+	job           *dispatcher.Job
+	ports         []int
+
+	state   dispatcher.JobState
+	started bool
+	killed  bool
+}
+
+// update returns the changes to the state of the resources given the status of the job, if the
+// workload manager still knows about it. A failure to poll the workload manager leaves the state
+// of the job as is unless the job already recorded its exit code.
+func (j *dispatcherJob) update(
+	status *dispatcher.JobStatus, pollErr error,
+) []sproto.ResourcesStateChanged {
+	if j.state == dispatcher.JobDone {
+		return nil
+	}
+	switch {
+	case pollErr != nil:
+		if _, ok := j.job.ExitCode(); !ok {
+			return nil
+		}
+		return j.finish("")
+	case status == nil:
+		return j.finish("")
+	case status.State == dispatcher.JobDone:
+		return j.finish(status.NativeState)
+	case status.State == dispatcher.JobRunning:
+		j.state = dispatcher.JobRunning
+		j.req.State = sproto.SchedulingStateScheduled
+		if j.started {
+			return nil
+		}
+		// The job records the node it runs on first thing; until it does, try again next time.
+		host, ok := j.job.Host()
+		if !ok {
+			return nil
+		}
+		j.started = true
+		var addresses []cproto.Address
+		for _, port := range j.ports {
+			addresses = append(addresses, cproto.Address{
+				ContainerIP:   host,
+				ContainerPort: port,
+				HostIP:        host,
+				HostPort:      port,
+			})
+		}
+		return []sproto.ResourcesStateChanged{
+			{ResourcesID: j.resourcesID, ResourcesState: sproto.Pulling},
+			{ResourcesID: j.resourcesID, ResourcesState: sproto.Starting},
+			{
+				ResourcesID:    j.resourcesID,
+				ResourcesState: sproto.Running,
+				ResourcesStarted: &sproto.ResourcesStarted{
+					Addresses:         addresses,
+					NativeResourcesID: j.id,
+				},
+			},
+		}
+	default:
+		j.state = dispatcher.JobPending
+		j.req.State = sproto.SchedulingStateQueued
+		return nil
+	}
+}
+
+// finish marks the job as done, reporting how the task container exited.
+func (j *dispatcherJob) finish(nativeState string) []sproto.ResourcesStateChanged {
+	j.state = dispatcher.JobDone
+	var stopped sproto.ResourcesStopped
+	switch code, ok := j.job.ExitCode(); {
+	case ok && code == sproto.SuccessExitCode:
+	case ok:
+		exitCode := sproto.ExitCode(code)
+		stopped.Failure = sproto.NewResourcesFailure(sproto.ResourcesFailed,
+			fmt.Sprintf("job %s failed", j.id), &exitCode)
+	case j.killed:
+		stopped = sproto.ResourcesError(sproto.ResourcesAborted,
+			errors.Errorf("job %s was canceled", j.id))
+	default:
+		if nativeState == "" {
+			nativeState = "unknown"
+		}
+		stopped = sproto.ResourcesError(sproto.TaskError, errors.Errorf(
+			"job %s ended without reporting an exit code (state: %s), see %s",
+			j.id, nativeState, j.job.OutputPath()))
+	}
+	return []sproto.ResourcesStateChanged{{
+		ResourcesID:      j.resourcesID,
+		ResourcesState:   sproto.Terminated,
+		ResourcesStopped: &stopped,
+	}}
+}
+
+// dispatcherResourceManager manages the batch jobs that run allocations.
+type dispatcherResourceManager struct {
+	config          *config.DispatcherResourceManagerConfig
+	wlm             dispatcher.WorkloadManager
+	poolNames       []string
+	masterTLSConfig model.TLSClientConfig
+
+	reqList        *taskList
+	groups         map[*actor.Ref]*group
+	queuePositions jobSortState
+	jobs           map[sproto.ResourcesID]*dispatcherJob
+
+	reschedule bool
+}
+
+func newDispatcherResourceManager(
+	config *config.DispatcherResourceManagerConfig,
+	wlm dispatcher.WorkloadManager,
+	poolNames []string,
+	masterTLSConfig model.TLSClientConfig,
+) actor.Actor {
+	return &dispatcherResourceManager{
+		config:          config,
+		wlm:             wlm,
+		poolNames:       poolNames,
+		masterTLSConfig: masterTLSConfig,
+
+		reqList:        newTaskList(),
+		groups:         make(map[*actor.Ref]*group),
+		queuePositions: initalizeJobSortState(false),
+		jobs:           make(map[sproto.ResourcesID]*dispatcherJob),
+	}
+}
+
+func (d *dispatcherResourceManager) Receive(ctx *actor.Context) error {
+	reschedule := true
+	defer func() {
+		d.reschedule = d.reschedule || reschedule
+	}()
+
+	switch msg := ctx.Message().(type) {
+	case actor.PreStart:
+		actors.NotifyAfter(ctx, actionCoolDown, schedulerTick{})
+		actors.NotifyAfter(ctx, time.Duration(d.config.PollInterval), dispatcherPoll{})
+
+	case groupActorStopped:
+		delete(d.groups, msg.Ref)
+
+	case sproto.SetGroupMaxSlots:
+		d.getOrCreateGroup(ctx, msg.Handler).maxSlots = msg.MaxSlots
+
+	case sproto.SetGroupOwner:
+		// Resource quotas are only enforced by the agent resource manager.
+
+	case sproto.SetAllocationName:
+		if req, ok := d.reqList.GetAllocationByHandler(msg.AllocationRef); ok {
+			req.Name = msg.Name
+		}
+
+	case sproto.AllocateRequest:
+		d.addTask(ctx, msg)
+
+	case sproto.ResourcesReleased:
+		d.resourcesReleased(ctx, msg)
+
+	case dispatcherJobSubmitted:
+		reschedule = false
+		d.jobSubmitted(ctx, msg)
+
+	case killDispatcherJob:
+		reschedule = false
+		d.killJob(ctx, msg)
+
+	case dispatcherPoll:
+		reschedule = false
+		d.poll(ctx)
+
+	case dispatcherPollResult:
+		reschedule = false
+		d.pollResult(ctx, msg)
+		actors.NotifyAfter(ctx, time.Duration(d.config.PollInterval), dispatcherPoll{})
+
+	case dispatcherJobCanceled:
+		reschedule = false
+		if msg.Err != nil {
+			ctx.Log().WithError(msg.Err).Warnf("failed to cancel %s job %s", d.wlm.Name(), msg.ID)
+		}
+
+	case sproto.PendingPreemption:
+		ctx.Respond(actor.ErrUnexpectedMessage(ctx))
+
+	case sproto.GetJobQ:
+		reschedule = false
+		ctx.Respond(reduceToJobQInfo(
+			sortTasksWithPosition(d.reqList, d.groups, d.queuePositions, false)))
+
+	case sproto.GetJobQStats:
+		reschedule = false
+		ctx.Respond(jobStats(d.reqList))
+
+	case *apiv1.GetJobQueueStatsRequest:
+		reschedule = false
+		resp := &apiv1.GetJobQueueStatsResponse{Results: make([]*apiv1.RPQueueStat, 0)}
+		for _, pool := range d.resourcePoolNames() {
+			resp.Results = append(resp.Results, &apiv1.RPQueueStat{
+				Stats:        jobStatsByPool(d.reqList, pool),
+				ResourcePool: pool,
+			})
+		}
+		ctx.Respond(resp)
+
+	case sproto.MoveJob:
+		if ctx.ExpectingResponse() {
+			ctx.Respond(ErrUnsupported(fmt.Sprintf(
+				"moving jobs is unsupported with %s, which orders its own queue", d.wlm.Name())))
+		}
+
+	case sproto.SetGroupWeight:
+		if ctx.ExpectingResponse() {
+			ctx.Respond(ErrUnsupported(fmt.Sprintf("set group weight is unsupported with %s",
+				d.wlm.Name())))
+		}
+
+	case sproto.SetGroupPriority:
+		// The priority is informational only; the workload manager prioritizes its own queue.
+		d.getOrCreateGroup(ctx, msg.Handler).priority = &msg.Priority
+
+	case sproto.RecoverJobPosition:
+		d.queuePositions.RecoverJobPosition(msg.JobID, msg.JobPosition)
+
+	case sproto.DeleteJob:
+		ctx.Respond(sproto.EmptyDeleteJobResponse())
+
+	case sproto.GetAllocationHandler:
+		reschedule = false
+		ctx.Respond(getTaskHandler(d.reqList, msg.ID))
+
+	case sproto.GetAllocationSummary:
+		reschedule = false
+		if resp := getTaskSummary(d.reqList, msg.ID, d.groups, d.wlm.Name()); resp != nil {
+			ctx.Respond(*resp)
+		}
+
+	case sproto.GetAllocationSummaries:
+		reschedule = false
+		ctx.Respond(getTaskSummaries(d.reqList, d.groups, d.wlm.Name()))
+
+	case *apiv1.GetResourcePoolsRequest:
+		reschedule = false
+		ctx.Respond(&apiv1.GetResourcePoolsResponse{ResourcePools: d.summarizeResourcePools()})
+
+	case *apiv1.GetAgentsRequest:
+		reschedule = false
+		// The compute nodes are managed by the workload manager rather than by agents.
+		ctx.Respond(&apiv1.GetAgentsResponse{})
+
+	case sproto.ValidateCommandResourcesRequest:
+		reschedule = false
+		ctx.Respond(sproto.ValidateCommandResourcesResponse{Fulfillable: true})
+
+	case schedulerTick:
+		if d.reschedule {
+			d.assignPendingTasks(ctx)
+		}
+		d.reschedule = false
+		reschedule = false
+		actors.NotifyAfter(ctx, actionCoolDown, schedulerTick{})
+
+	default:
+		reschedule = false
+		ctx.Log().Errorf("unexpected message %T", msg)
+		return actor.ErrUnexpectedMessage(ctx)
+	}
+	return nil
+}
+
+func (d *dispatcherResourceManager) addTask(ctx *actor.Context, msg sproto.AllocateRequest) {
+	actors.NotifyOnStop(ctx, msg.AllocationRef, sproto.ResourcesReleased{
+		AllocationRef: msg.AllocationRef,
+	})
+
+	if len(msg.AllocationID) == 0 {
+		msg.AllocationID = model.AllocationID(uuid.New().String())
+	}
+	if msg.Group == nil {
+		msg.Group = msg.AllocationRef
+	}
+	d.getOrCreateGroup(ctx, msg.Group)
+	if msg.IsUserVisible {
+		if _, ok := d.queuePositions[msg.JobID]; !ok {
+			d.queuePositions[msg.JobID] = initalizeQueuePosition(msg.JobSubmissionTime, false)
+		}
+	}
+
+	ctx.Log().Infof(
+		"resources are requested by %s (Allocation ID: %s)",
+		msg.AllocationRef.Address(), msg.AllocationID,
+	)
+	d.reqList.AddTask(&msg)
+}
+
+// assignPendingTasks hands resources to every request right away, since the workload manager
+// decides when their jobs run. Only the max slots of groups are enforced by the master.
+func (d *dispatcherResourceManager) assignPendingTasks(ctx *actor.Context) {
+	slotsUsed := make(map[*group]int)
+	for it := d.reqList.iterator(); it.next(); {
+		req := it.value()
+		if assignmentIsScheduled(d.reqList.GetAllocations(req.AllocationRef)) {
+			slotsUsed[d.groups[req.Group]] += req.SlotsNeeded
+		}
+	}
+
+	for it := d.reqList.iterator(); it.next(); {
+		req := it.value()
+		if assignmentIsScheduled(d.reqList.GetAllocations(req.AllocationRef)) {
+			continue
+		}
+		group := d.groups[req.Group]
+		if maxSlots := group.maxSlots; maxSlots != nil &&
+			slotsUsed[group]+req.SlotsNeeded > *maxSlots {
+			continue
+		}
+		slotsUsed[group] += req.SlotsNeeded
+		d.assignResources(ctx, req)
+	}
+}
+
+func (d *dispatcherResourceManager) assignResources(
+	ctx *actor.Context, req *sproto.AllocateRequest,
+) {
+	rs := &dispatcherResources{
+		req:             req,
+		rm:              ctx.Self(),
+		wlm:             d.wlm,
+		config:          d.config,
+		masterTLSConfig: d.masterTLSConfig,
+		resourcesID:     sproto.ResourcesID(cproto.NewID()),
+	}
+	assigned := sproto.ResourcesAllocated{
+		ID:                req.AllocationID,
+		ResourcePool:      req.ResourcePool,
+		Resources:         sproto.ResourceList{rs.resourcesID: rs},
+		JobSubmissionTime: req.JobSubmissionTime,
+	}
+	d.reqList.SetAllocationsRaw(req.AllocationRef, &assigned)
+	req.AllocationRef.System().Tell(req.AllocationRef, assigned.Clone())
+
+	ctx.Log().
+		WithField("allocation-id", req.AllocationID).
+		WithField("task-handler", req.AllocationRef.Address()).
+		Infof("resources assigned as a %s job", d.wlm.Name())
+}
+
+func (d *dispatcherResourceManager) jobSubmitted(
+	ctx *actor.Context, msg dispatcherJobSubmitted,
+) {
+	var req *sproto.AllocateRequest
+	for it := d.reqList.iterator(); it.next(); {
+		allocated := d.reqList.GetAllocations(it.value().AllocationRef)
+		if allocated == nil {
+			continue
+		}
+		if _, ok := allocated.Resources[msg.ResourcesID]; ok {
+			req = it.value()
+		}
+	}
+	if req == nil {
+		// The allocation went away while its job was being submitted.
+		d.cancel(ctx, msg.ID)
+		return
+	}
+
+	ctx.Log().WithField("allocation-id", req.AllocationID).
+		Infof("submitted %s job %s", d.wlm.Name(), msg.ID)
+	d.jobs[msg.ResourcesID] = &dispatcherJob{
+		resourcesID:   msg.ResourcesID,
+		allocationRef: req.AllocationRef,
+		req:           req,
+		id:            msg.ID,
+		job:           msg.Job,
+		ports:         msg.Ports,
+		state:         dispatcher.JobPending,
+	}
+}
+
+func (d *dispatcherResourceManager) killJob(ctx *actor.Context, msg killDispatcherJob) {
+	job, ok := d.jobs[msg.ResourcesID]
+	if !ok {
+		// The job was never submitted, so there is nothing to wait for.
+		stopped := sproto.ResourcesError(sproto.ResourcesAborted,
+			errors.New("resources were killed before their job was submitted"))
+		ctx.Tell(msg.AllocationRef, sproto.ResourcesStateChanged{
+			ResourcesID:      msg.ResourcesID,
+			ResourcesState:   sproto.Terminated,
+			ResourcesStopped: &stopped,
+		})
+		return
+	}
+	if job.state == dispatcher.JobDone {
+		return
+	}
+	job.killed = true
+	d.cancel(ctx, job.id)
+}
+
+// cancel cancels a batch job in the background, since the workload manager may be slow to
+// respond, and reports the outcome back to the RM.
+func (d *dispatcherResourceManager) cancel(ctx *actor.Context, id string) {
+	self, wlm := ctx.Self(), d.wlm
+	go func() {
+		cmdCtx, cancel := context.WithTimeout(context.Background(), dispatcherCommandTimeout)
+		defer cancel()
+		self.System().Tell(self, dispatcherJobCanceled{ID: id, Err: wlm.Cancel(cmdCtx, id)})
+	}()
+}
+
+// poll checks on the submitted jobs in the background and reports their status back to the RM.
+// The next poll is scheduled once the result arrives, so polls never overlap.
+func (d *dispatcherResourceManager) poll(ctx *actor.Context) {
+	var ids []string
+	for _, job := range d.jobs {
+		if job.state != dispatcher.JobDone {
+			ids = append(ids, job.id)
+		}
+	}
+	if len(ids) == 0 {
+		actors.NotifyAfter(ctx, time.Duration(d.config.PollInterval), dispatcherPoll{})
+		return
+	}
+
+	self, wlm := ctx.Self(), d.wlm
+	go func() {
+		cmdCtx, cancel := context.WithTimeout(context.Background(), dispatcherCommandTimeout)
+		defer cancel()
+		statuses, err := wlm.Status(cmdCtx, ids)
+		self.System().Tell(self, dispatcherPollResult{IDs: ids, Statuses: statuses, Err: err})
+	}()
+}
+
+// pollResult notifies the allocations of any changes to the polled jobs. Jobs submitted while
+// the poll was in flight are left for the next one.
+func (d *dispatcherResourceManager) pollResult(ctx *actor.Context, msg dispatcherPollResult) {
+	if msg.Err != nil {
+		ctx.Log().WithError(msg.Err).Warnf("failed to poll %s jobs", d.wlm.Name())
+	}
+	polled := make(map[string]bool, len(msg.IDs))
+	for _, id := range msg.IDs {
+		polled[id] = true
+	}
+	for _, job := range d.jobs {
+		if !polled[job.id] {
+			continue
+		}
+		var status *dispatcher.JobStatus
+		if s, ok := msg.Statuses[job.id]; ok {
+			status = &s
+		}
+		for _, change := range job.update(status, msg.Err) {
+			ctx.Tell(job.allocationRef, change)
+		}
+	}
+}
+
+func (d *dispatcherResourceManager) resourcesReleased(
+	ctx *actor.Context, msg sproto.ResourcesReleased,
+) {
+	if msg.ResourcesID != nil {
+		// Every allocation has a single job, which is done by the time it is released.
+		return
+	}
+
+	ctx.Log().Infof("resources are released for %s", msg.AllocationRef.Address())
+	for id, job := range d.jobs {
+		if job.allocationRef != msg.AllocationRef {
+			continue
+		}
+		if job.state != dispatcher.JobDone {
+			d.cancel(ctx, job.id)
+		}
+		delete(d.jobs, id)
+	}
+	d.reqList.RemoveTaskByHandler(msg.AllocationRef)
+}
+
+func (d *dispatcherResourceManager) getOrCreateGroup(
+	ctx *actor.Context,
+	handler *actor.Ref,
+) *group {
+	if g, ok := d.groups[handler]; ok {
+		return g
+	}
+	priority := config.DefaultSchedulingPriority
+	g := &group{handler: handler, weight: 1, priority: &priority}
+	d.groups[handler] = g
+	if ctx != nil && handler != nil { // ctx is nil only for testing purposes.
+		actors.NotifyOnStop(ctx, handler, groupActorStopped{})
+	}
+	return g
+}
+
+// resourcePoolNames returns the configured resource pools or, if there are none, the defaults.
+func (d *dispatcherResourceManager) resourcePoolNames() []string {
+	if len(d.poolNames) > 0 {
+		return d.poolNames
+	}
+	var names []string
+	for _, name := range []string{
+		d.config.DefaultComputeResourcePool, d.config.DefaultAuxResourcePool,
+	} {
+		if name != "" && (len(names) == 0 || names[0] != name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (d *dispatcherResourceManager) summarizeResourcePools() []*resourcepoolv1.ResourcePool {
+	schedulerType := resourcepoolv1.SchedulerType_SCHEDULER_TYPE_SLURM
+	fittingPolicy := resourcepoolv1.FittingPolicy_FITTING_POLICY_SLURM
+	if d.wlm.Name() == "pbs" {
+		schedulerType = resourcepoolv1.SchedulerType_SCHEDULER_TYPE_PBS
+		fittingPolicy = resourcepoolv1.FittingPolicy_FITTING_POLICY_PBS
+	}
+
+	var pools []*resourcepoolv1.ResourcePool
+	for _, name := range d.resourcePoolNames() {
+		slotsUsed := 0
+		for it := d.reqList.iterator(); it.next(); {
+			if req := it.value(); req.ResourcePool == name &&
+				req.State == sproto.SchedulingStateScheduled {
+				slotsUsed += req.SlotsNeeded
+			}
+		}
+		pools = append(pools, &resourcepoolv1.ResourcePool{
+			Name:                   name,
+			Description:            fmt.Sprintf("%s-managed pool of resources", d.wlm.Name()),
+			Type:                   resourcepoolv1.ResourcePoolType_RESOURCE_POOL_TYPE_STATIC,
+			SlotType:               d.config.SlotType.Proto(),
+			SlotsUsed:              int32(slotsUsed),
+			AuxContainerCapacity:   int32(1),
+			DefaultComputePool:     name == d.config.DefaultComputeResourcePool,
+			DefaultAuxPool:         name == d.config.DefaultAuxResourcePool,
+			SchedulerType:          schedulerType,
+			SchedulerFittingPolicy: fittingPolicy,
+			Location:               d.wlm.Name(),
+			Details:                &resourcepoolv1.ResourcePoolDetail{},
+		})
+	}
+	return pools
+}
+
+// dispatcherResources are the resources of an allocation that run as a batch job.
+type dispatcherResources struct {
+	req             *sproto.AllocateRequest
+	rm              *actor.Ref
+	wlm             dispatcher.WorkloadManager
+	config          *config.DispatcherResourceManagerConfig
+	masterTLSConfig model.TLSClientConfig
+	resourcesID     sproto.ResourcesID
+}
+
+// Summary summarizes the batch job.
+func (r dispatcherResources) Summary() sproto.ResourcesSummary {
+	return sproto.ResourcesSummary{
+		AllocationID:  r.req.AllocationID,
+		ResourcesID:   r.resourcesID,
+		ResourcesType: r.resourcesType(),
+		AgentDevices:  nil,
+	}
+}
+
+// resourcesType is the type of the resources, which depends on the workload manager in use.
+func (r dispatcherResources) resourcesType() sproto.ResourcesType {
+	if r.wlm.Name() == "pbs" {
+		return sproto.ResourcesTypePbsJob
+	}
+	return sproto.ResourcesTypeSlurmJob
+}
+
+// Start writes the batch script and files of the task container to the job storage root and
+// submits the batch job.
+func (r dispatcherResources) Start(
+	ctx *actor.Context, logCtx logger.Context, spec tasks.TaskSpec, rri sproto.ResourcesRuntimeInfo,
+) error {
+	spec.ContainerID = string(r.resourcesID)
+	spec.ResourcesID = string(r.resourcesID)
+	spec.AllocationID = string(r.req.AllocationID)
+	spec.AllocationSessionToken = rri.Token
+	spec.TaskID = string(r.req.TaskID)
+	if spec.LoggingFields == nil {
+		spec.LoggingFields = map[string]string{}
+	}
+	spec.LoggingFields["allocation_id"] = spec.AllocationID
+	spec.LoggingFields["task_id"] = spec.TaskID
+	if spec.ExtraEnvVars == nil {
+		spec.ExtraEnvVars = map[string]string{}
+	}
+	spec.ExtraEnvVars[sproto.ResourcesTypeEnvVar] = string(r.resourcesType())
+	spec.ExtraEnvVars["DET_MASTER"] = fmt.Sprintf("%s:%d", r.config.MasterHost, r.config.MasterPort)
+	spec.ExtraEnvVars["DET_MASTER_HOST"] = r.config.MasterHost
+	spec.ExtraEnvVars["DET_MASTER_ADDR"] = r.config.MasterHost
+	spec.ExtraEnvVars["DET_MASTER_PORT"] = fmt.Sprintf("%d", r.config.MasterPort)
+	spec.ExtraEnvVars["DET_AGENT_ID"] = r.wlm.Name()
+	if r.masterTLSConfig.CertificateName != "" {
+		spec.ExtraEnvVars["DET_MASTER_CERT_NAME"] = r.masterTLSConfig.CertificateName
+	}
+
+	extraArgs := append([]string{}, spec.TaskContainerDefaults.Slurm...)
+	extraArgs = append(extraArgs, spec.SlurmConfig.SbatchArgs()...)
+	if r.wlm.Name() == "pbs" {
+		extraArgs = append([]string{}, spec.TaskContainerDefaults.Pbs...)
+		extraArgs = append(extraArgs, spec.PbsConfig.SbatchArgs()...)
+	}
+
+	dockerSpec := spec.ToDockerSpec()
+	var mounts []dispatcher.BindMount
+	for _, m := range spec.Mounts {
+		mounts = append(mounts, dispatcher.BindMount{
+			Source: m.Source, Target: m.Target, ReadOnly: m.ReadOnly,
+		})
+	}
+	name := "det-" + string(r.resourcesID)
+	job := &dispatcher.Job{
+		Name:             name,
+		Dir:              filepath.Join(r.config.JobStorageRoot, name),
+		Queue:            r.req.ResourcePool,
+		Slots:            r.req.SlotsNeeded,
+		SlotType:         r.config.SlotType,
+		ExtraArgs:        extraArgs,
+		ContainerRunType: r.config.ContainerRunType,
+		Image:            dockerSpec.RunSpec.ContainerConfig.Image,
+		Env:              dockerSpec.RunSpec.ContainerConfig.Env,
+		Entrypoint:       spec.Entrypoint,
+		WorkDir:          spec.WorkDir,
+		Archives:         dockerSpec.RunSpec.Archives,
+		Mounts:           mounts,
+	}
+
+	var ports []int
+	for _, port := range spec.Environment.Ports() {
+		ports = append(ports, port)
+	}
+	if r.req.ProxyPort != nil {
+		ports = append(ports, r.req.ProxyPort.Port)
+	}
+
+	script, err := job.Write(r.wlm)
+	if err != nil {
+		return errors.Wrapf(err, "preparing %s job", r.wlm.Name())
+	}
+	cmdCtx, cancel := context.WithTimeout(context.Background(), dispatcherCommandTimeout)
+	defer cancel()
+	id, err := r.wlm.Submit(cmdCtx, script)
+	if err != nil {
+		return errors.Wrapf(err, "submitting %s job", r.wlm.Name())
+	}
+	ctx.Tell(r.rm, dispatcherJobSubmitted{
+		ResourcesID: r.resourcesID,
+		ID:          strings.TrimSpace(id),
+		Job:         job,
+		Ports:       ports,
+	})
+	return nil
+}
+
+// Kill cancels the batch job.
+func (r dispatcherResources) Kill(ctx *actor.Context, _ logger.Context) {
+	ctx.Tell(r.rm, killDispatcherJob{
+		ResourcesID:   r.resourcesID,
+		AllocationRef: r.req.AllocationRef,
+	})
+}
@@ -0,0 +1,218 @@
+package rm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/rm/dispatcher"
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/cproto"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// fakeWorkloadManager reports fixed statuses and blocks polls until it is released.
+type fakeWorkloadManager struct {
+	name     string
+	statuses map[string]dispatcher.JobStatus
+	release  chan struct{}
+	canceled chan string
+}
+
+func (f *fakeWorkloadManager) Name() string { return f.name }
+
+func (f *fakeWorkloadManager) Directives(job *dispatcher.Job) []string { return nil }
+
+func (f *fakeWorkloadManager) Submit(ctx context.Context, script string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (f *fakeWorkloadManager) Status(
+	ctx context.Context, ids []string,
+) (map[string]dispatcher.JobStatus, error) {
+	<-f.release
+	return f.statuses, nil
+}
+
+func (f *fakeWorkloadManager) Cancel(ctx context.Context, id string) error {
+	f.canceled <- id
+	return errors.New("qdel: unknown job id")
+}
+
+func newTestDispatcherJob(t *testing.T) *dispatcherJob {
+	return &dispatcherJob{
+		resourcesID: "resources",
+		req:         &sproto.AllocateRequest{},
+		id:          "42",
+		job:         &dispatcher.Job{Dir: t.TempDir()},
+		ports:       []int{2222},
+		state:       dispatcher.JobPending,
+	}
+}
+
+func writeDispatcherJobFile(t *testing.T, j *dispatcherJob, name, content string) {
+	assert.NilError(t, os.WriteFile(filepath.Join(j.job.Dir, name), []byte(content), 0o600))
+}
+
+func TestDispatcherJobUpdate(t *testing.T) {
+	j := newTestDispatcherJob(t)
+
+	changes := j.update(&dispatcher.JobStatus{State: dispatcher.JobPending}, nil)
+	assert.Equal(t, len(changes), 0)
+	assert.Equal(t, j.req.State, sproto.SchedulingStateQueued)
+
+	// Running jobs are only reported as such once they recorded their node.
+	changes = j.update(&dispatcher.JobStatus{State: dispatcher.JobRunning}, nil)
+	assert.Equal(t, len(changes), 0)
+	assert.Equal(t, j.req.State, sproto.SchedulingStateScheduled)
+
+	writeDispatcherJobFile(t, j, "host", "node-1\n")
+	changes = j.update(&dispatcher.JobStatus{State: dispatcher.JobRunning}, nil)
+	assert.Equal(t, len(changes), 3)
+	assert.Equal(t, changes[0].ResourcesState, sproto.Pulling)
+	assert.Equal(t, changes[1].ResourcesState, sproto.Starting)
+	assert.Equal(t, changes[2].ResourcesState, sproto.Running)
+	assert.DeepEqual(t, changes[2].ResourcesStarted, &sproto.ResourcesStarted{
+		Addresses: []cproto.Address{{
+			ContainerIP: "node-1", ContainerPort: 2222, HostIP: "node-1", HostPort: 2222,
+		}},
+		NativeResourcesID: "42",
+	})
+
+	changes = j.update(&dispatcher.JobStatus{State: dispatcher.JobRunning}, nil)
+	assert.Equal(t, len(changes), 0)
+
+	// Failing to poll does not end the job until it recorded its exit code.
+	changes = j.update(nil, errors.New("squeue: command not found"))
+	assert.Equal(t, len(changes), 0)
+
+	writeDispatcherJobFile(t, j, "exit_code", "0\n")
+	changes = j.update(nil, errors.New("squeue: command not found"))
+	assert.Equal(t, len(changes), 1)
+	assert.Equal(t, changes[0].ResourcesState, sproto.Terminated)
+	assert.Assert(t, changes[0].ResourcesStopped.Failure == nil)
+
+	changes = j.update(nil, nil)
+	assert.Equal(t, len(changes), 0)
+}
+
+func TestDispatcherJobFailures(t *testing.T) {
+	cases := []struct {
+		name        string
+		exitCode    string
+		killed      bool
+		failureType sproto.FailureType
+	}{
+		{name: "nonzero exit code", exitCode: "3", failureType: sproto.ResourcesFailed},
+		{name: "killed", killed: true, failureType: sproto.ResourcesAborted},
+		{name: "no exit code", failureType: sproto.TaskError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := newTestDispatcherJob(t)
+			j.killed = tc.killed
+			if tc.exitCode != "" {
+				writeDispatcherJobFile(t, j, "exit_code", tc.exitCode)
+			}
+
+			changes := j.update(&dispatcher.JobStatus{
+				State: dispatcher.JobDone, NativeState: "CANCELLED",
+			}, nil)
+			assert.Equal(t, len(changes), 1)
+			assert.Equal(t, changes[0].ResourcesState, sproto.Terminated)
+			failure := changes[0].ResourcesStopped.Failure
+			assert.Assert(t, failure != nil)
+			assert.Equal(t, failure.FailureType, tc.failureType)
+			assert.Equal(t, j.state, dispatcher.JobDone)
+		})
+	}
+}
+
+func TestDispatcherPollDoesNotBlock(t *testing.T) {
+	wlm := &fakeWorkloadManager{
+		name:     "slurm",
+		statuses: map[string]dispatcher.JobStatus{"42": {State: dispatcher.JobDone}},
+		release:  make(chan struct{}),
+	}
+	d := newDispatcherResourceManager(&config.DispatcherResourceManagerConfig{
+		PollInterval: model.Duration(time.Hour),
+	}, wlm, nil, model.TLSClientConfig{}).(*dispatcherResourceManager)
+
+	system := actor.NewSystem(t.Name())
+	changes := make(chan sproto.ResourcesStateChanged, 2)
+	allocation := system.MustActorOf(actor.Addr("allocation"), actor.ActorFunc(
+		func(ctx *actor.Context) error {
+			if msg, ok := ctx.Message().(sproto.ResourcesStateChanged); ok {
+				changes <- msg
+			}
+			return nil
+		}))
+	polled := newTestDispatcherJob(t)
+	polled.allocationRef = allocation
+	writeDispatcherJobFile(t, polled, "exit_code", "0")
+	// The job is submitted while the poll is in flight, so the poll knows nothing about it.
+	submitted := newTestDispatcherJob(t)
+	submitted.resourcesID, submitted.id, submitted.allocationRef = "late", "43", allocation
+	d.jobs[polled.resourcesID] = polled
+	ref := system.MustActorOf(actor.Addr("dispatcher"), d)
+
+	system.Tell(ref, dispatcherPoll{})
+	// The RM keeps answering while the workload manager has yet to respond.
+	assert.NilError(t, system.Ask(ref, sproto.GetJobQ{}).Error())
+	d.jobs[submitted.resourcesID] = submitted
+	close(wlm.release)
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, change.ResourcesID, polled.resourcesID)
+		assert.Equal(t, change.ResourcesState, sproto.Terminated)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the poll result never reached the allocation")
+	}
+	assert.NilError(t, system.Ask(ref, sproto.GetJobQ{}).Error())
+	assert.Equal(t, submitted.state, dispatcher.JobPending)
+	assert.Equal(t, len(changes), 0)
+}
+
+func TestDispatcherKillJobDoesNotBlock(t *testing.T) {
+	wlm := &fakeWorkloadManager{name: "pbs", canceled: make(chan string)}
+	d := newDispatcherResourceManager(&config.DispatcherResourceManagerConfig{
+		PollInterval: model.Duration(time.Hour),
+	}, wlm, nil, model.TLSClientConfig{}).(*dispatcherResourceManager)
+	j := newTestDispatcherJob(t)
+	d.jobs[j.resourcesID] = j
+
+	system := actor.NewSystem(t.Name())
+	ref := system.MustActorOf(actor.Addr("dispatcher"), d)
+	system.Tell(ref, killDispatcherJob{ResourcesID: j.resourcesID})
+	// The RM keeps answering while the workload manager has yet to cancel the job.
+	assert.NilError(t, system.Ask(ref, sproto.GetJobQ{}).Error())
+	assert.Assert(t, j.killed)
+
+	select {
+	case id := <-wlm.canceled:
+		assert.Equal(t, id, j.id)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the job was never canceled")
+	}
+}
+
+func TestDispatcherResourcesType(t *testing.T) {
+	for name, expected := range map[string]sproto.ResourcesType{
+		"slurm": sproto.ResourcesTypeSlurmJob,
+		"pbs":   sproto.ResourcesTypePbsJob,
+	} {
+		r := dispatcherResources{
+			req: &sproto.AllocateRequest{},
+			wlm: &fakeWorkloadManager{name: name},
+		}
+		assert.Equal(t, r.Summary().ResourcesType, expected, name)
+	}
+}
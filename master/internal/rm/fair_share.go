@@ -50,7 +50,7 @@ func (g groupState) String() string {
 }
 
 func (f *fairShare) Schedule(rp *ResourcePool) ([]*sproto.AllocateRequest, []*actor.Ref) {
-	return fairshareSchedule(rp.taskList, rp.groups, rp.agentStatesCache, rp.fittingMethod)
+	return fairshareSchedule(rp.schedulableTasks(), rp.groups, rp.agentStatesCache, rp.fittingMethod)
 }
 
 func (f *fairShare) createJobQInfo(
@@ -2,6 +2,7 @@ package rm
 
 import (
 	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
 )
 
 // groupActorStopped notifies that the group actor is stopped.
@@ -16,4 +17,8 @@ type group struct {
 	maxSlots *int
 	weight   float64
 	priority *int
+
+	// userID and workspaceID identify the owners of the group, whose quotas apply to it.
+	userID      *model.UserID
+	workspaceID *int
 }
@@ -55,7 +55,6 @@ func jobStats(taskList *taskList) *jobv1.QueueStats {
 	return stats
 }
 
-//nolint:deadcode,nolintlint // Method used by Slurm support in determined-ee
 func jobStatsByPool(taskList *taskList, resourcePool string) *jobv1.QueueStats {
 	reqs := make(AllocReqs, 0)
 	for it := taskList.iterator(); it.next(); {
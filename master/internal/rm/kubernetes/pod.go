@@ -180,15 +180,21 @@ func (p *pod) Receive(ctx *actor.Context) error {
 
 	case PreemptTaskPod:
 		ctx.Log().Info("received preemption command")
-		p.taskActor.System().Tell(p.taskActor, sproto.ReleaseResources{})
+		p.taskActor.System().Tell(p.taskActor, sproto.ReleaseResources{
+			Reason: sproto.ReleaseReasonScheduler,
+		})
 
 	case ChangePriority:
 		ctx.Log().Info("interrupting pod to change priorities")
-		p.taskActor.System().Tell(p.taskActor, sproto.ReleaseResources{})
+		p.taskActor.System().Tell(p.taskActor, sproto.ReleaseResources{
+			Reason: sproto.ReleaseReasonPriorityChange,
+		})
 
 	case ChangePosition:
 		ctx.Log().Info("interrupting pod to change positions")
-		p.taskActor.System().Tell(p.taskActor, sproto.ReleaseResources{})
+		p.taskActor.System().Tell(p.taskActor, sproto.ReleaseResources{
+			Reason: sproto.ReleaseReasonPositionChange,
+		})
 
 	case sproto.ContainerLog:
 		p.receiveContainerLog(ctx, msg)
@@ -205,6 +205,7 @@ func (k *kubernetesResourceManager) Receive(ctx *actor.Context) error {
 	case
 		groupActorStopped,
 		sproto.SetGroupMaxSlots,
+		sproto.SetGroupOwner,
 		sproto.SetAllocationName,
 		sproto.AllocateRequest,
 		sproto.ResourcesReleased,
@@ -342,6 +343,9 @@ func (k *kubernetesResourceManager) receiveRequestMsg(ctx *actor.Context) error
 	case sproto.SetGroupMaxSlots:
 		k.getOrCreateGroup(ctx, msg.Handler).maxSlots = msg.MaxSlots
 
+	case sproto.SetGroupOwner:
+		// Resource quotas are only enforced by the agent resource manager.
+
 	case sproto.SetAllocationName:
 		k.receiveSetAllocationName(ctx, msg)
 
@@ -30,7 +30,7 @@ func NewPriorityScheduler(config *config.SchedulerConfig) Scheduler {
 }
 
 func (p *priorityScheduler) Schedule(rp *ResourcePool) ([]*sproto.AllocateRequest, []*actor.Ref) {
-	return p.prioritySchedule(rp.taskList, rp.groups, rp.queuePositions,
+	return p.prioritySchedule(rp.schedulableTasks(), rp.groups, rp.queuePositions,
 		rp.agentStatesCache, rp.fittingMethod)
 }
 
@@ -0,0 +1,210 @@
+package rm
+
+import (
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// SetResourceQuotas replaces the quotas enforced by a resource pool.
+type SetResourceQuotas struct {
+	Quotas []model.ResourceQuota
+}
+
+// GetResourceQuotaStatus requests the quotas of a resource pool along with their usage.
+type GetResourceQuotaStatus struct{}
+
+// ResourceQuotaStatus is a quota of a resource pool along with its usage.
+type ResourceQuotaStatus struct {
+	model.ResourceQuota
+	// UsedSlots is the number of slots used by running tasks covered by the quota.
+	UsedSlots int `json:"used_slots"`
+	// QueuedJobs is the number of queued jobs covered by the quota that it does not hold back.
+	QueuedJobs int `json:"queued_jobs"`
+	// BlockedJobs are the jobs covered by the quota that are blocked by it.
+	BlockedJobs []model.JobID `json:"blocked_jobs"`
+}
+
+// quotaState is the result of checking the tasks of a resource pool against its quotas.
+type quotaState struct {
+	// blockedTasks are the pending tasks that may not be scheduled.
+	blockedTasks map[*actor.Ref]bool
+	// blockedJobs are the jobs with tasks that may not be scheduled.
+	blockedJobs map[model.JobID]bool
+	statuses    []ResourceQuotaStatus
+}
+
+// checkQuotas determines which pending tasks are blocked by the quotas of their owners. Running
+// tasks count against the quotas that cover them first, then pending tasks are admitted in queue
+// order as long as they fit within every quota that covers them. A job that has no running tasks
+// counts as queued; queued jobs past the max queued jobs of a quota are held back entirely.
+func checkQuotas(
+	quotas []model.ResourceQuota,
+	reqs []*sproto.AllocateRequest,
+	taskList *taskList,
+	groups map[*actor.Ref]*group,
+) *quotaState {
+	state := &quotaState{
+		blockedTasks: make(map[*actor.Ref]bool),
+		blockedJobs:  make(map[model.JobID]bool),
+		statuses:     make([]ResourceQuotaStatus, len(quotas)),
+	}
+	for i, q := range quotas {
+		state.statuses[i].ResourceQuota = q
+	}
+
+	covering := func(req *sproto.AllocateRequest) []*ResourceQuotaStatus {
+		var statuses []*ResourceQuotaStatus
+		g := groups[req.Group]
+		if g == nil {
+			return nil
+		}
+		for i := range state.statuses {
+			if state.statuses[i].AppliesTo(g.userID, g.workspaceID) {
+				statuses = append(statuses, &state.statuses[i])
+			}
+		}
+		return statuses
+	}
+
+	running := make(map[*actor.Ref]bool)
+	for _, req := range reqs {
+		if taskList.GetAllocations(req.AllocationRef) == nil {
+			continue
+		}
+		running[req.Group] = true
+		for _, s := range covering(req) {
+			s.UsedSlots += req.SlotsNeeded
+		}
+	}
+
+	heldBack := make(map[*actor.Ref]bool)
+	slots := make(map[*ResourceQuotaStatus]int, len(state.statuses))
+	for i := range state.statuses {
+		slots[&state.statuses[i]] = state.statuses[i].UsedSlots
+	}
+	for _, req := range reqs {
+		if taskList.GetAllocations(req.AllocationRef) != nil {
+			continue
+		}
+		statuses := covering(req)
+		if len(statuses) == 0 {
+			continue
+		}
+
+		blocked := false
+		if !running[req.Group] {
+			held, seen := heldBack[req.Group]
+			if !seen {
+				held = queueFull(statuses)
+				if !held {
+					for _, s := range statuses {
+						s.QueuedJobs++
+					}
+				}
+				heldBack[req.Group] = held
+			}
+			blocked = held
+		}
+		for _, s := range statuses {
+			if slots[s]+req.SlotsNeeded > s.MaxSlots {
+				blocked = true
+			}
+		}
+
+		if blocked {
+			state.blockedTasks[req.AllocationRef] = true
+			if !state.blockedJobs[req.JobID] {
+				state.blockedJobs[req.JobID] = true
+				for _, s := range statuses {
+					s.BlockedJobs = append(s.BlockedJobs, req.JobID)
+				}
+			}
+			continue
+		}
+		for _, s := range statuses {
+			slots[s] += req.SlotsNeeded
+		}
+	}
+	return state
+}
+
+func queueFull(statuses []*ResourceQuotaStatus) bool {
+	for _, s := range statuses {
+		if s.MaxQueuedJobs != nil && s.QueuedJobs >= *s.MaxQueuedJobs {
+			return true
+		}
+	}
+	return false
+}
+
+// checkQuotas checks the tasks of the resource pool against its quotas, in the order in which its
+// scheduler considers them. It returns nil if the resource pool has no quotas.
+func (rp *ResourcePool) checkQuotas() *quotaState {
+	if len(rp.quotas) == 0 {
+		return nil
+	}
+	return checkQuotas(rp.quotas, rp.sortedTasks(), rp.taskList, rp.groups)
+}
+
+// sortedTasks returns the tasks of the resource pool in the order in which its scheduler considers
+// them.
+func (rp *ResourcePool) sortedTasks() []*sproto.AllocateRequest {
+	if rp.config.Scheduler != nil && rp.config.Scheduler.Priority != nil {
+		return sortTasksWithPosition(rp.taskList, rp.groups, rp.queuePositions, false)
+	}
+	return sortTasksByPosition(rp.taskList, rp.queuePositions)
+}
+
+// schedulableTasks returns the tasks of the resource pool without the pending tasks that are
+// blocked by a quota or by a reservation of someone else.
+func (rp *ResourcePool) schedulableTasks() *taskList {
+	blocked := make(map[*actor.Ref]bool)
+	if state := rp.checkQuotas(); state != nil {
+		for ref := range state.blockedTasks {
+			blocked[ref] = true
+		}
+	}
+	if state := rp.checkReservations(); state != nil {
+		for ref := range state.blockedTasks {
+			blocked[ref] = true
+		}
+	}
+	if len(blocked) == 0 {
+		return rp.taskList
+	}
+	tasks := newTaskList()
+	for it := rp.taskList.iterator(); it.next(); {
+		req := it.value()
+		if blocked[req.AllocationRef] {
+			continue
+		}
+		tasks.AddTask(req)
+		if allocated := rp.taskList.GetAllocations(req.AllocationRef); allocated != nil {
+			tasks.SetAllocations(req.AllocationRef, allocated)
+		}
+	}
+	return tasks
+}
+
+// jobQInfo returns the job queue of the resource pool, marking the jobs blocked by a quota.
+func (rp *ResourcePool) jobQInfo() map[model.JobID]*sproto.RMJobInfo {
+	jobQInfo := rp.scheduler.JobQInfo(rp)
+	if state := rp.checkQuotas(); state != nil {
+		for jobID := range state.blockedJobs {
+			if info, ok := jobQInfo[jobID]; ok {
+				info.BlockedByQuota = true
+			}
+		}
+	}
+	return jobQInfo
+}
+
+// quotaStatus returns the quotas of the resource pool along with their usage.
+func (rp *ResourcePool) quotaStatus() []ResourceQuotaStatus {
+	state := rp.checkQuotas()
+	if state == nil {
+		return []ResourceQuotaStatus{}
+	}
+	return state.statuses
+}
@@ -0,0 +1,108 @@
+package rm
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func setGroupOwners(groups map[*actor.Ref]*group, owners map[string]model.UserID) {
+	for ref, g := range groups {
+		if userID, ok := owners[ref.Address().Local()]; ok {
+			g.userID = ptrs.Ptr(userID)
+		}
+	}
+}
+
+func TestQuotaMaxSlotsPriorityScheduler(t *testing.T) {
+	now := time.Now()
+	priority := 50
+	agents := []*mockAgent{{id: "agent1", slots: 8}}
+	groups := []*mockGroup{
+		{id: "group1", priority: &priority},
+		{id: "group2", priority: &priority},
+		{id: "group3", priority: &priority},
+	}
+	tasks := []*mockTask{
+		{id: "task1", slotsNeeded: 2, group: groups[0], jobSubmissionTime: now},
+		{id: "task2", slotsNeeded: 2, group: groups[1], jobSubmissionTime: now.Add(time.Second)},
+		{id: "task3", slotsNeeded: 2, group: groups[2], jobSubmissionTime: now.Add(2 * time.Second)},
+	}
+
+	conf := &config.SchedulerConfig{
+		Priority: &config.PrioritySchedulerConfig{DefaultPriority: &priority},
+	}
+	system := actor.NewSystem(t.Name())
+	rp := NewResourcePool(&config.ResourcePoolConfig{PoolName: "pool", Scheduler: conf},
+		nil, nil, NewPriorityScheduler(conf), BestFit)
+	rp.taskList, rp.groups, rp.agentStatesCache = setupSchedulerStates(
+		t, system, tasks, groups, agents)
+	setGroupOwners(rp.groups, map[string]model.UserID{"group1": 1, "group2": 1, "group3": 2})
+	rp.quotas = []model.ResourceQuota{
+		{ResourcePool: "pool", UserID: ptrs.Ptr(model.UserID(1)), MaxSlots: 2},
+	}
+
+	// The second job of the first user would exceed its quota, but the other user is unaffected.
+	toAllocate, _ := rp.scheduler.Schedule(rp)
+	assertEqualToAllocate(t, toAllocate, []*mockTask{tasks[0], tasks[2]})
+
+	jobQInfo := rp.jobQInfo()
+	assert.Assert(t, !jobQInfo["task1"].BlockedByQuota)
+	assert.Assert(t, jobQInfo["task2"].BlockedByQuota)
+	assert.Assert(t, !jobQInfo["task3"].BlockedByQuota)
+
+	status := rp.quotaStatus()
+	assert.Equal(t, len(status), 1)
+	assert.Equal(t, status[0].UsedSlots, 0)
+	assert.DeepEqual(t, status[0].BlockedJobs, []model.JobID{"task2"})
+}
+
+func TestQuotaMaxQueuedJobsFairShareScheduler(t *testing.T) {
+	now := time.Now()
+	agents := []*mockAgent{{id: "agent1", slots: 4}}
+	groups := []*mockGroup{
+		{id: "group1", weight: 1},
+		{id: "group2", weight: 1},
+		{id: "group3", weight: 1},
+		{id: "group4", weight: 1},
+	}
+	tasks := []*mockTask{
+		{
+			id: "running", slotsNeeded: 1, group: groups[0], jobSubmissionTime: now,
+			allocatedAgent: agents[0], containerStarted: true,
+		},
+		{id: "task1", slotsNeeded: 1, group: groups[1], jobSubmissionTime: now.Add(time.Second)},
+		{id: "task2", slotsNeeded: 1, group: groups[2], jobSubmissionTime: now.Add(2 * time.Second)},
+		{id: "task3", slotsNeeded: 1, group: groups[3], jobSubmissionTime: now.Add(3 * time.Second)},
+	}
+
+	system := actor.NewSystem(t.Name())
+	rp := NewResourcePool(&config.ResourcePoolConfig{PoolName: "pool"},
+		nil, nil, NewFairShareScheduler(), BestFit)
+	rp.taskList, rp.groups, rp.agentStatesCache = setupSchedulerStates(
+		t, system, tasks, groups, agents)
+	setGroupOwners(rp.groups, map[string]model.UserID{
+		"group1": 1, "group2": 1, "group3": 1, "group4": 1,
+	})
+	rp.quotas = []model.ResourceQuota{{
+		ResourcePool:  "pool",
+		UserID:        ptrs.Ptr(model.UserID(1)),
+		MaxSlots:      10,
+		MaxQueuedJobs: ptrs.Ptr(2),
+	}}
+
+	// The running job does not count as queued, and the third queued job is held back.
+	toAllocate, _ := rp.scheduler.Schedule(rp)
+	assertEqualToAllocate(t, toAllocate, []*mockTask{tasks[1], tasks[2]})
+
+	status := rp.quotaStatus()
+	assert.Equal(t, status[0].UsedSlots, 1)
+	assert.Equal(t, status[0].QueuedJobs, 2)
+	assert.DeepEqual(t, status[0].BlockedJobs, []model.JobID{"task3"})
+}
@@ -0,0 +1,208 @@
+package rm
+
+import (
+	"time"
+
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// SetResourceReservations replaces the reservations of a resource pool.
+type SetResourceReservations struct {
+	Reservations []model.ResourceReservation
+}
+
+// GetResourceReservationStatus requests the reservations of a resource pool along with their
+// usage.
+type GetResourceReservationStatus struct{}
+
+// ResourceReservationStatus is a reservation of a resource pool along with its usage.
+type ResourceReservationStatus struct {
+	model.ResourceReservation
+	// Active is whether the reservation is in effect.
+	Active bool `json:"active"`
+	// UsedSlots is the number of reserved slots used by running tasks of the owners, if active.
+	UsedSlots int `json:"used_slots"`
+}
+
+// reservationState is the result of checking the tasks of a resource pool against its active
+// reservations.
+type reservationState struct {
+	// blockedTasks are the pending tasks that would need reserved slots they do not own.
+	blockedTasks map[*actor.Ref]bool
+	// toRelease are the running tasks that must be preempted to free reserved slots.
+	toRelease []*actor.Ref
+	statuses  []ResourceReservationStatus
+}
+
+// checkReservations determines which tasks may not use the slots held by the active reservations.
+// The slots of a reservation that are not used by the running tasks of its owners are held for
+// them: pending tasks are admitted in queue order as long as they fit within the free slots that
+// are not held for someone else, and running preemptible tasks that are not covered by any active
+// reservation are preempted, newest first, until the held slots are free.
+func checkReservations(
+	reservations []model.ResourceReservation,
+	now time.Time,
+	freeSlots int,
+	reqs []*sproto.AllocateRequest,
+	taskList *taskList,
+	groups map[*actor.Ref]*group,
+) *reservationState {
+	state := &reservationState{
+		blockedTasks: make(map[*actor.Ref]bool),
+		statuses:     make([]ResourceReservationStatus, len(reservations)),
+	}
+	for i, r := range reservations {
+		state.statuses[i].ResourceReservation = r
+		state.statuses[i].Active = r.Active(now)
+	}
+
+	covering := func(req *sproto.AllocateRequest) []*ResourceReservationStatus {
+		var statuses []*ResourceReservationStatus
+		g := groups[req.Group]
+		if g == nil {
+			return nil
+		}
+		for i := range state.statuses {
+			s := &state.statuses[i]
+			if s.Active && s.AppliesTo(g.userID, g.workspaceID) {
+				statuses = append(statuses, s)
+			}
+		}
+		return statuses
+	}
+	// use charges slots to the reservations of the owner of a task while they have slots left.
+	use := func(statuses []*ResourceReservationStatus, slots int) {
+		for _, s := range statuses {
+			n := s.Slots - s.UsedSlots
+			if n > slots {
+				n = slots
+			}
+			s.UsedSlots += n
+			slots -= n
+		}
+	}
+	// held returns the number of reserved slots held for owners other than the given ones.
+	held := func(owned []*ResourceReservationStatus) (slots int) {
+		for i := range state.statuses {
+			s := &state.statuses[i]
+			if !s.Active {
+				continue
+			}
+			isOwner := false
+			for _, o := range owned {
+				isOwner = isOwner || o == s
+			}
+			if !isOwner {
+				slots += s.Slots - s.UsedSlots
+			}
+		}
+		return slots
+	}
+
+	var unreserved []*sproto.AllocateRequest
+	for _, req := range reqs {
+		if taskList.GetAllocations(req.AllocationRef) == nil {
+			continue
+		}
+		statuses := covering(req)
+		if len(statuses) == 0 {
+			unreserved = append(unreserved, req)
+		}
+		use(statuses, req.SlotsNeeded)
+	}
+
+	for i := len(unreserved) - 1; i >= 0 && freeSlots < held(nil); i-- {
+		if req := unreserved[i]; req.Preemptible && req.SlotsNeeded > 0 {
+			state.toRelease = append(state.toRelease, req.AllocationRef)
+			freeSlots += req.SlotsNeeded
+		}
+	}
+
+	for _, req := range reqs {
+		if taskList.GetAllocations(req.AllocationRef) != nil || req.SlotsNeeded == 0 {
+			continue
+		}
+		statuses := covering(req)
+		if req.SlotsNeeded > freeSlots-held(statuses) {
+			state.blockedTasks[req.AllocationRef] = true
+			continue
+		}
+		freeSlots -= req.SlotsNeeded
+		use(statuses, req.SlotsNeeded)
+	}
+	return state
+}
+
+// checkReservations checks the tasks of the resource pool against its reservations, in the order
+// in which its scheduler considers them. It returns nil if the resource pool has no active
+// reservations or if the state of its agents is unknown.
+func (rp *ResourcePool) checkReservations() *reservationState {
+	if len(rp.activeReservations(time.Now())) == 0 || rp.agentStatesCache == nil {
+		return nil
+	}
+	freeSlots := 0
+	for _, agent := range rp.agentStatesCache {
+		freeSlots += agent.NumEmptySlots()
+	}
+	return checkReservations(
+		rp.reservations, time.Now(), freeSlots, rp.sortedTasks(), rp.taskList, rp.groups)
+}
+
+// activeReservations returns the IDs of the reservations of the resource pool that are active.
+func (rp *ResourcePool) activeReservations(now time.Time) map[int]bool {
+	active := make(map[int]bool)
+	for _, r := range rp.reservations {
+		if r.Active(now) {
+			active[r.ID] = true
+		}
+	}
+	return active
+}
+
+// reservationWindowsChanged returns whether a reservation window opened or closed since the last
+// time it was called.
+func (rp *ResourcePool) reservationWindowsChanged() bool {
+	active := rp.activeReservations(time.Now())
+	changed := len(active) != len(rp.reservationsActive)
+	for id := range active {
+		changed = changed || !rp.reservationsActive[id]
+	}
+	rp.reservationsActive = active
+	return changed
+}
+
+// reservationPreemptions returns the running tasks that must be released to free the slots held by
+// the active reservations.
+func (rp *ResourcePool) reservationPreemptions() []*actor.Ref {
+	if state := rp.checkReservations(); state != nil {
+		return state.toRelease
+	}
+	return nil
+}
+
+func containsRef(refs []*actor.Ref, ref *actor.Ref) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// reservationStatus returns the reservations of the resource pool along with their usage.
+func (rp *ResourcePool) reservationStatus() []ResourceReservationStatus {
+	if state := rp.checkReservations(); state != nil {
+		return state.statuses
+	}
+	now := time.Now()
+	statuses := make([]ResourceReservationStatus, 0, len(rp.reservations))
+	for _, r := range rp.reservations {
+		statuses = append(statuses, ResourceReservationStatus{
+			ResourceReservation: r,
+			Active:              r.Active(now),
+		})
+	}
+	return statuses
+}
@@ -0,0 +1,106 @@
+package rm
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func newTestReservation(userID model.UserID, slots int, now time.Time) model.ResourceReservation {
+	return model.ResourceReservation{
+		ID:           1,
+		ResourcePool: "pool",
+		UserID:       ptrs.Ptr(userID),
+		Slots:        slots,
+		StartTime:    ptrs.Ptr(now.Add(-time.Hour)),
+		EndTime:      ptrs.Ptr(now.Add(time.Hour)),
+	}
+}
+
+func TestReservationHoldsSlotsForOwners(t *testing.T) {
+	now := time.Now()
+	priority := 50
+	agents := []*mockAgent{{id: "agent1", slots: 4}}
+	groups := []*mockGroup{
+		{id: "group1", priority: &priority},
+		{id: "group2", priority: &priority},
+		{id: "group3", priority: &priority},
+	}
+	tasks := []*mockTask{
+		{id: "task1", slotsNeeded: 2, group: groups[0], jobSubmissionTime: now},
+		{id: "task2", slotsNeeded: 2, group: groups[1], jobSubmissionTime: now.Add(time.Second)},
+		{id: "task3", slotsNeeded: 2, group: groups[2], jobSubmissionTime: now.Add(2 * time.Second)},
+	}
+
+	conf := &config.SchedulerConfig{
+		Priority: &config.PrioritySchedulerConfig{DefaultPriority: &priority},
+	}
+	system := actor.NewSystem(t.Name())
+	rp := NewResourcePool(&config.ResourcePoolConfig{PoolName: "pool", Scheduler: conf},
+		nil, nil, NewPriorityScheduler(conf), BestFit)
+	rp.taskList, rp.groups, rp.agentStatesCache = setupSchedulerStates(
+		t, system, tasks, groups, agents)
+	setGroupOwners(rp.groups, map[string]model.UserID{"group1": 2, "group2": 2, "group3": 1})
+	rp.reservations = []model.ResourceReservation{newTestReservation(1, 2, now)}
+
+	// The second job of the other user would take the reserved slots, which the owner can use.
+	toAllocate, _ := rp.scheduler.Schedule(rp)
+	assertEqualToAllocate(t, toAllocate, []*mockTask{tasks[0], tasks[2]})
+
+	status := rp.reservationStatus()
+	assert.Equal(t, len(status), 1)
+	assert.Assert(t, status[0].Active)
+	assert.Equal(t, status[0].UsedSlots, 2)
+
+	// Once the window closes, the slots are free for anyone.
+	rp.reservations[0].EndTime = ptrs.Ptr(now.Add(-time.Minute))
+	toAllocate, _ = rp.scheduler.Schedule(rp)
+	assertEqualToAllocate(t, toAllocate, []*mockTask{tasks[0], tasks[1]})
+}
+
+func TestReservationPreemptsNonOwnersWhenWindowOpens(t *testing.T) {
+	now := time.Now()
+	agents := []*mockAgent{{id: "agent1", slots: 4}}
+	groups := []*mockGroup{
+		{id: "group1", weight: 1},
+		{id: "group2", weight: 1},
+		{id: "group3", weight: 1},
+	}
+	tasks := []*mockTask{
+		{
+			id: "task1", slotsNeeded: 2, group: groups[0], jobSubmissionTime: now,
+			allocatedAgent: agents[0], containerStarted: true,
+		},
+		{
+			id: "task2", slotsNeeded: 2, group: groups[1], jobSubmissionTime: now.Add(time.Second),
+			allocatedAgent: agents[0], containerStarted: true,
+		},
+		{id: "task3", slotsNeeded: 2, group: groups[2], jobSubmissionTime: now.Add(2 * time.Second)},
+	}
+
+	system := actor.NewSystem(t.Name())
+	rp := NewResourcePool(&config.ResourcePoolConfig{PoolName: "pool"},
+		nil, nil, NewFairShareScheduler(), BestFit)
+	rp.taskList, rp.groups, rp.agentStatesCache = setupSchedulerStates(
+		t, system, tasks, groups, agents)
+	setGroupOwners(rp.groups, map[string]model.UserID{"group1": 2, "group2": 2, "group3": 1})
+	assert.Assert(t, !rp.reservationWindowsChanged())
+	assert.Equal(t, len(rp.reservationPreemptions()), 0)
+
+	rp.reservations = []model.ResourceReservation{newTestReservation(1, 2, now)}
+	assert.Assert(t, rp.reservationWindowsChanged())
+	assert.Assert(t, !rp.reservationWindowsChanged())
+
+	// The newest task of the other user is preempted to make room for the reservation.
+	task2, ok := rp.taskList.GetTaskByID("task2")
+	assert.Assert(t, ok)
+	toRelease := rp.reservationPreemptions()
+	assert.Equal(t, len(toRelease), 1)
+	assert.Equal(t, toRelease[0], task2.AllocationRef)
+}
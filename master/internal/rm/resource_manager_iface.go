@@ -31,6 +31,7 @@ type ResourceManager interface {
 
 	// Scheduling related stuff
 	SetGroupMaxSlots(actor.Messenger, sproto.SetGroupMaxSlots)
+	SetGroupOwner(actor.Messenger, sproto.SetGroupOwner)
 	SetGroupWeight(actor.Messenger, sproto.SetGroupWeight) error
 	SetGroupPriority(actor.Messenger, sproto.SetGroupPriority) error
 	ExternalPreemptionPending(actor.Messenger, sproto.PendingPreemption) error
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"strconv"
+	"time"
 
 	"golang.org/x/exp/maps"
 
@@ -16,6 +17,7 @@ import (
 
 	"github.com/determined-ai/determined/master/internal/config"
 	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/internal/prom"
 	"github.com/determined-ai/determined/master/internal/rm/provisioner"
 	"github.com/determined-ai/determined/master/internal/sproto"
 	"github.com/determined-ai/determined/master/internal/task/taskmodel"
@@ -45,6 +47,11 @@ type ResourcePool struct {
 	groupActorToID   map[*actor.Ref]model.JobID
 	IDToGroupActor   map[model.JobID]*actor.Ref
 	scalingInfo      *sproto.ScalingInfo
+	quotas           []model.ResourceQuota
+	reservations     []model.ResourceReservation
+	// reservationsActive are the IDs of the reservations that were active at the last scheduling
+	// tick.
+	reservationsActive map[int]bool
 
 	reschedule bool
 
@@ -318,9 +325,14 @@ func (rp *ResourcePool) allocateResources(ctx *actor.Context, req *sproto.Alloca
 	return true
 }
 
-func (rp *ResourcePool) releaseResource(ctx *actor.Context, handler *actor.Ref) {
+func (rp *ResourcePool) releaseResource(
+	ctx *actor.Context, handler *actor.Ref, reason string,
+) {
 	ctx.Log().Infof("releasing resources taken by %s", handler.Address())
-	handler.System().Tell(handler, sproto.ReleaseResources{ResourcePool: rp.config.PoolName})
+	handler.System().Tell(handler, sproto.ReleaseResources{
+		ResourcePool: rp.config.PoolName,
+		Reason:       reason,
+	})
 }
 
 func (rp *ResourcePool) resourcesReleased(
@@ -432,6 +444,7 @@ func (rp *ResourcePool) Receive(ctx *actor.Context) error {
 	case
 		groupActorStopped,
 		sproto.SetGroupMaxSlots,
+		sproto.SetGroupOwner,
 		sproto.SetAllocationName,
 		sproto.AllocateRequest,
 		sproto.ResourcesReleased:
@@ -470,6 +483,32 @@ func (rp *ResourcePool) Receive(ctx *actor.Context) error {
 		}()
 		ctx.Respond(getResourceSummary(rp.agentStatesCache))
 
+	case GetSimulationSnapshot:
+		reschedule = false
+		rp.agentStatesCache = rp.fetchAgentStates(ctx)
+		defer func() {
+			rp.agentStatesCache = nil
+		}()
+		ctx.Respond(rp.simulationSnapshot())
+
+	case SetResourceQuotas:
+		rp.quotas = msg.Quotas
+
+	case GetResourceQuotaStatus:
+		reschedule = false
+		ctx.Respond(rp.quotaStatus())
+
+	case SetResourceReservations:
+		rp.reservations = msg.Reservations
+
+	case GetResourceReservationStatus:
+		reschedule = false
+		rp.agentStatesCache = rp.fetchAgentStates(ctx)
+		defer func() {
+			rp.agentStatesCache = nil
+		}()
+		ctx.Respond(rp.reservationStatus())
+
 	case aproto.GetRPConfig:
 		reschedule = false
 		ctx.Respond(aproto.GetRPResponse{
@@ -479,7 +518,11 @@ func (rp *ResourcePool) Receive(ctx *actor.Context) error {
 		})
 
 	case schedulerTick:
+		if rp.reservationWindowsChanged() {
+			rp.reschedule = true
+		}
 		if rp.reschedule {
+			start := time.Now()
 			rp.agentStatesCache = rp.fetchAgentStates(ctx)
 			defer func() {
 				rp.agentStatesCache = nil
@@ -490,9 +533,16 @@ func (rp *ResourcePool) Receive(ctx *actor.Context) error {
 				rp.allocateResources(ctx, req)
 			}
 			for _, taskActor := range toRelease {
-				rp.releaseResource(ctx, taskActor)
+				rp.releaseResource(ctx, taskActor, sproto.ReleaseReasonScheduler)
+			}
+			for _, taskActor := range rp.reservationPreemptions() {
+				if !containsRef(toRelease, taskActor) {
+					rp.releaseResource(ctx, taskActor, sproto.ReleaseReasonReservation)
+				}
 			}
 			rp.sendScalingInfo(ctx)
+			prom.ObserveSchedulingPass(rp.config.PoolName, time.Since(start))
+			rp.reportMetrics()
 		}
 		rp.reschedule = false
 		reschedule = false
@@ -647,7 +697,7 @@ func (rp *ResourcePool) receiveJobQueueMsg(ctx *actor.Context) error {
 		ctx.Respond(jobStats(rp.taskList))
 
 	case sproto.GetJobQ:
-		ctx.Respond(rp.scheduler.JobQInfo(rp))
+		ctx.Respond(rp.jobQInfo())
 
 	case sproto.MoveJob:
 		err := rp.moveJob(ctx, msg.ID, msg.Anchor, msg.Ahead)
@@ -707,6 +757,10 @@ func (rp *ResourcePool) receiveRequestMsg(ctx *actor.Context) error {
 	case sproto.SetGroupMaxSlots:
 		rp.getOrCreateGroup(ctx, msg.Handler).maxSlots = msg.MaxSlots
 
+	case sproto.SetGroupOwner:
+		g := rp.getOrCreateGroup(ctx, msg.Handler)
+		g.userID, g.workspaceID = msg.UserID, msg.WorkspaceID
+
 	case sproto.SetAllocationName:
 		rp.receiveSetTaskName(ctx, msg)
 
@@ -775,6 +829,38 @@ func (rp *ResourcePool) refreshAgentStateCacheFor(ctx *actor.Context, agents []*
 	}
 }
 
+// reportMetrics exports the pending jobs, agents and slots of the resource pool, as of the
+// current scheduling pass.
+func (rp *ResourcePool) reportMetrics() {
+	pending := make(map[*actor.Ref]bool)
+	for it := rp.taskList.iterator(); it.next(); {
+		req := it.value()
+		if rp.taskList.GetAllocations(req.AllocationRef) == nil {
+			pending[req.Group] = true
+		}
+	}
+	prom.SetPendingJobs(rp.config.PoolName, len(pending))
+
+	var enabled, draining, disabled int
+	slots := make(map[string]prom.SlotCounts)
+	for _, a := range rp.agentStatesCache {
+		switch {
+		case a.draining:
+			draining++
+		case !a.enabled:
+			disabled++
+		default:
+			enabled++
+		}
+		c := slots[a.Label]
+		c.Used += a.NumUsedSlots()
+		c.Total += a.NumSlots()
+		slots[a.Label] = c
+	}
+	prom.SetAgents(rp.config.PoolName, enabled, draining, disabled)
+	prom.SetSlots(rp.config.PoolName, slots)
+}
+
 // containerResources contains information for tasks have been allocated but not yet started.
 type containerResources struct {
 	req         *sproto.AllocateRequest
@@ -8,6 +8,7 @@ import (
 	"github.com/determined-ai/determined/master/pkg/model"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/mock"
 
 	"gotest.tools/assert"
@@ -17,6 +18,7 @@ import (
 	"github.com/determined-ai/determined/master/internal/sproto"
 	"github.com/determined-ai/determined/master/pkg/actor"
 	"github.com/determined-ai/determined/master/pkg/cproto"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
 )
 
 func TestCleanUpTaskWhenTaskActorStopsWithError(t *testing.T) {
@@ -212,6 +214,47 @@ func TestSettingGroupPriority(t *testing.T) {
 	assert.Equal(t, *rp.groups[groupRefOne].priority, updatedPriority)
 }
 
+func TestGroupOwnerAfterResourcePoolChange(t *testing.T) {
+	system := actor.NewSystem(t.Name())
+	_, oldRef := setupResourcePool(
+		t, nil, system, &config.ResourcePoolConfig{PoolName: "old"}, nil, nil, nil)
+	_, newRef := setupResourcePool(
+		t, nil, system, &config.ResourcePoolConfig{PoolName: "new"}, nil, nil, nil)
+	system.Ask(newRef, SetResourceQuotas{Quotas: []model.ResourceQuota{
+		{ResourcePool: "new", WorkspaceID: ptrs.Ptr(1), MaxSlots: 1},
+	}}).Get()
+
+	group := &mockGroup{id: "group"}
+	groupRef, created := system.ActorOf(actor.Addr(group.id), group)
+	assert.Assert(t, created)
+	owner := sproto.SetGroupOwner{
+		UserID:      ptrs.Ptr(model.UserID(1)),
+		WorkspaceID: ptrs.Ptr(1),
+		Handler:     groupRef,
+	}
+
+	// The job starts in the old pool, then leaves it for the new one.
+	system.Ask(oldRef, owner).Get()
+	oldTask, created := system.ActorOf(actor.Addr("old-task"), &mockTask{
+		rmRef: oldRef, id: "old-task", jobID: "job", group: group, slotsNeeded: 2,
+	})
+	assert.Assert(t, created)
+	system.Ask(oldTask, SendRequestResourcesToResourceManager{}).Get()
+	system.Ask(oldTask, SendResourcesReleasedToResourceManager{}).Get()
+
+	// Its owner is set again for the new pool, whose workspace quota then holds the job back.
+	system.Ask(newRef, owner).Get()
+	newTask, created := system.ActorOf(actor.Addr("new-task"), &mockTask{
+		rmRef: newRef, id: "new-task", jobID: "job", group: group, slotsNeeded: 2,
+	})
+	assert.Assert(t, created)
+	system.Ask(newTask, SendRequestResourcesToResourceManager{}).Get()
+
+	status := system.Ask(newRef, GetResourceQuotaStatus{}).Get().([]ResourceQuotaStatus)
+	assert.Equal(t, len(status), 1)
+	assert.DeepEqual(t, status[0].BlockedJobs, []model.JobID{"job"})
+}
+
 func TestAddRemoveAgent(t *testing.T) {
 	system := actor.NewSystem(t.Name())
 	db := &mocks.DB{}
@@ -455,3 +498,67 @@ func TestMoveMessagesAcrossPrioLanesBehind(t *testing.T) {
 	assert.Equal(t, secondAnchor, model.JobID("job3"))
 	assert.Equal(t, anchorPriority, 50)
 }
+
+// gaugeValue returns the value of the gauge with the given name and labels in the default
+// registry.
+func gaugeValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NilError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			if len(m.GetLabel()) != len(labels) {
+				continue
+			}
+			for _, l := range m.GetLabel() {
+				if labels[l.GetName()] != l.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("no gauge %s%v", name, labels)
+	return 0
+}
+
+func TestReportMetrics(t *testing.T) {
+	system := actor.NewSystem(t.Name())
+	agents := []*mockAgent{
+		{id: "agent1", slots: 2, label: "a"},
+		{id: "agent2", slots: 1, label: "b"},
+	}
+	tasks := []*mockTask{
+		{id: "allocated-task1", slotsNeeded: 1, allocatedAgent: agents[0], containerStarted: true},
+		{id: "unallocated-task2", slotsNeeded: 1},
+		{id: "unallocated-task3", slotsNeeded: 1},
+	}
+	rp, _ := setupResourcePool(
+		t, nil, system, &config.ResourcePoolConfig{PoolName: "metrics"}, tasks, nil, agents)
+	rp.agentStatesCache[system.Get(actor.Addr("agent2"))].enabled = false
+	rp.reportMetrics()
+
+	pool := map[string]string{"resource_pool": "metrics"}
+	assert.Equal(t, gaugeValue(t, "det_scheduler_pending_jobs", pool), 2.0)
+	for state, expected := range map[string]float64{"enabled": 1, "draining": 0, "disabled": 1} {
+		assert.Equal(t, gaugeValue(t, "det_agents", map[string]string{
+			"resource_pool": "metrics", "state": state,
+		}), expected)
+	}
+	for _, tc := range []struct {
+		label, state string
+		expected     float64
+	}{
+		{"a", "used", 1},
+		{"a", "total", 2},
+		{"b", "used", 0},
+		{"b", "total", 0},
+	} {
+		assert.Equal(t, gaugeValue(t, "det_slots", map[string]string{
+			"resource_pool": "metrics", "agent_label": tc.label, "state": tc.state,
+		}), tc.expected)
+	}
+}
@@ -17,7 +17,7 @@ func NewRoundRobinScheduler() Scheduler {
 }
 
 func (p *roundRobinScheduler) Schedule(rp *ResourcePool) ([]*sproto.AllocateRequest, []*actor.Ref) {
-	return roundRobinSchedule(rp.taskList, rp.groups, rp.agentStatesCache, rp.fittingMethod)
+	return roundRobinSchedule(rp.schedulableTasks(), rp.groups, rp.agentStatesCache, rp.fittingMethod)
 }
 
 func (p *roundRobinScheduler) JobQInfo(rp *ResourcePool) map[model.JobID]*sproto.RMJobInfo {
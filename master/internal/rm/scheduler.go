@@ -29,6 +29,8 @@ func MakeScheduler(conf *config.SchedulerConfig) Scheduler {
 		return NewFairShareScheduler()
 	case config.RoundRobinScheduling:
 		return NewRoundRobinScheduler()
+	case config.BackfillScheduling:
+		return NewBackfillScheduler(conf)
 	default:
 		panic(fmt.Sprintf("invalid scheduler: %s", conf.GetType()))
 	}
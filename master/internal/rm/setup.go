@@ -25,6 +25,8 @@ func New(
 		return NewAgentResourceManager(system, db, echo, config, opts, cert)
 	case config.ResourceManager.KubernetesRM != nil:
 		return NewKubernetesResourceManager(system, db, echo, config, opts, cert)
+	case config.ResourceManager.DispatcherRM() != nil:
+		return NewDispatcherResourceManager(system, db, echo, config, opts, cert)
 	default:
 		panic("no expected resource manager config is defined")
 	}
@@ -0,0 +1,605 @@
+package rm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/check"
+	"github.com/determined-ai/determined/master/pkg/cproto"
+	"github.com/determined-ai/determined/master/pkg/device"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+const (
+	simulatedPoolName = "simulation"
+	// maxSimulatedSchedulingPasses bounds how many times the scheduler runs at a single point in
+	// simulated time. Several passes are needed since preempted resources are only offered to
+	// other tasks on the next pass, like in a live resource pool.
+	maxSimulatedSchedulingPasses = 16
+)
+
+// GetSimulationSnapshot is a message to request the state of a resource pool as a Simulation.
+type GetSimulationSnapshot struct{}
+
+// SimulatedAgent is an agent in a scheduler simulation.
+type SimulatedAgent struct {
+	ID                    string `json:"id"`
+	Label                 string `json:"label"`
+	Slots                 int    `json:"slots"`
+	MaxZeroSlotContainers int    `json:"max_zero_slot_containers"`
+}
+
+// SimulatedAllocation is the part of a request that is already running on an agent when a
+// simulation starts.
+type SimulatedAllocation struct {
+	AgentID string `json:"agent_id"`
+	Slots   int    `json:"slots"`
+}
+
+// SimulatedRequest is a request for resources in a scheduler simulation. Requests with the same
+// group are scheduled as one job; its max slots, weight and priority are taken from the first of
+// its requests to be submitted.
+type SimulatedRequest struct {
+	ID          string  `json:"id"`
+	Group       string  `json:"group,omitempty"`
+	SlotsNeeded int     `json:"slots_needed"`
+	Preemptible *bool   `json:"preemptible,omitempty"`
+	Label       string  `json:"label"`
+	MaxSlots    *int    `json:"max_slots,omitempty"`
+	Weight      float64 `json:"weight,omitempty"`
+	Priority    *int    `json:"priority,omitempty"`
+
+	// SubmitSeconds is when the request is made, relative to the start of the simulation.
+	SubmitSeconds float64 `json:"submit_seconds"`
+	// DurationSeconds is how long the request runs once scheduled. Preempted requests keep their
+	// progress when rescheduled. The backfill scheduler is told the remaining duration as the
+	// expected runtime of the request.
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	// Allocations describes where the request is running at the start of the simulation.
+	Allocations []SimulatedAllocation `json:"allocations,omitempty"`
+}
+
+// Simulation describes a resource pool to run a scheduler against.
+type Simulation struct {
+	Scheduler *config.SchedulerConfig `json:"scheduler"`
+	Agents    []SimulatedAgent        `json:"agents"`
+	Requests  []SimulatedRequest      `json:"requests"`
+
+	// DefaultDurationSeconds is the duration of requests that do not set one. If neither is set,
+	// a request runs until the end of the simulation.
+	DefaultDurationSeconds float64 `json:"default_duration_seconds,omitempty"`
+	// HorizonSeconds ends the simulation early. Otherwise, it ends when no request can make
+	// progress.
+	HorizonSeconds float64 `json:"horizon_seconds,omitempty"`
+}
+
+// SimulatedRequestResult reports how a request was scheduled in a simulation.
+type SimulatedRequestResult struct {
+	ID    string `json:"id"`
+	Group string `json:"group"`
+	// StartSeconds is when the request was first scheduled, if it was.
+	StartSeconds *float64 `json:"start_seconds"`
+	// EndSeconds is when the request finished, if it did.
+	EndSeconds *float64 `json:"end_seconds"`
+	// WaitSeconds is how long the request was queued, including after preemptions.
+	WaitSeconds float64 `json:"wait_seconds"`
+	Preemptions int     `json:"preemptions"`
+}
+
+// SimulationResult reports the outcome of a simulation.
+type SimulationResult struct {
+	Scheduler     string `json:"scheduler"`
+	FittingPolicy string `json:"fitting_policy"`
+	// DurationSeconds is how much time was simulated.
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Utilization is the fraction of slot time in the pool that was allocated.
+	Utilization    float64                  `json:"utilization"`
+	Preemptions    int                      `json:"preemptions"`
+	AvgWaitSeconds float64                  `json:"avg_wait_seconds"`
+	MaxWaitSeconds float64                  `json:"max_wait_seconds"`
+	Requests       []SimulatedRequestResult `json:"requests"`
+}
+
+// Simulate runs the scheduler of the simulation over its agents and requests, without affecting
+// any live resource pool.
+func Simulate(sim Simulation) (*SimulationResult, error) {
+	if err := sim.validate(); err != nil {
+		return nil, err
+	}
+
+	system := actor.NewSystem(simulatedPoolName)
+	defer func() {
+		_ = system.Ref.StopAndAwaitTermination()
+	}()
+
+	s := newSimulator(system, sim)
+	if err := s.preallocate(); err != nil {
+		return nil, err
+	}
+	s.run()
+	return s.result(), nil
+}
+
+func (sim Simulation) validate() error {
+	if sim.Scheduler == nil {
+		return errors.New("scheduler configuration is required")
+	}
+	if err := check.Validate(*sim.Scheduler); err != nil {
+		return errors.Wrap(err, "invalid scheduler configuration")
+	}
+	if sim.Scheduler.Priority != nil && sim.Scheduler.Priority.DefaultPriority == nil {
+		return errors.New("invalid scheduler configuration: default_priority is required")
+	}
+	if sim.DefaultDurationSeconds < 0 || sim.HorizonSeconds < 0 {
+		return errors.New("default_duration_seconds and horizon_seconds must not be negative")
+	}
+
+	agents := make(map[string]bool, len(sim.Agents))
+	for _, a := range sim.Agents {
+		switch {
+		case a.ID == "":
+			return errors.New("every agent must have an ID")
+		case agents[a.ID]:
+			return errors.Errorf("duplicate agent %s", a.ID)
+		case a.Slots < 0 || a.MaxZeroSlotContainers < 0:
+			return errors.Errorf("agent %s must not have a negative number of slots", a.ID)
+		}
+		agents[a.ID] = true
+	}
+
+	requests := make(map[string]bool, len(sim.Requests))
+	for _, r := range sim.Requests {
+		switch {
+		case r.ID == "":
+			return errors.New("every request must have an ID")
+		case requests[r.ID]:
+			return errors.Errorf("duplicate request %s", r.ID)
+		case r.SlotsNeeded < 0:
+			return errors.Errorf("request %s must not need a negative number of slots", r.ID)
+		case r.SubmitSeconds < 0 || r.DurationSeconds < 0:
+			return errors.Errorf(
+				"request %s must not have a negative submit_seconds or duration_seconds", r.ID)
+		case r.Weight < 0:
+			return errors.Errorf("request %s must not have a negative weight", r.ID)
+		case len(r.Allocations) > 0 && r.SubmitSeconds != 0:
+			return errors.Errorf("request %s is already running so must be submitted at 0", r.ID)
+		}
+		requests[r.ID] = true
+
+		if len(r.Allocations) == 0 {
+			continue
+		}
+		slots := 0
+		for _, alloc := range r.Allocations {
+			if !agents[alloc.AgentID] {
+				return errors.Errorf("request %s is running on unknown agent %s", r.ID, alloc.AgentID)
+			}
+			slots += alloc.Slots
+		}
+		if slots != r.SlotsNeeded {
+			return errors.Errorf("request %s needs %d slots but is running on %d",
+				r.ID, r.SlotsNeeded, slots)
+		}
+	}
+	return nil
+}
+
+// simulatedActor stands in for the agent, group and allocation actors that the scheduler
+// expects; schedulers only use them as keys and for their registration time.
+type simulatedActor struct{}
+
+func (simulatedActor) Receive(ctx *actor.Context) error {
+	switch ctx.Message().(type) {
+	case actor.PreStart, actor.PostStop:
+		return nil
+	default:
+		return actor.ErrUnexpectedMessage(ctx)
+	}
+}
+
+// simulatedTask tracks a request through a simulation. Times are in seconds from the start.
+type simulatedTask struct {
+	req      SimulatedRequest
+	allocReq *sproto.AllocateRequest
+
+	remaining    float64
+	pendingSince float64
+	runningSince float64
+	running      bool
+	done         bool
+
+	result SimulatedRequestResult
+}
+
+type simulator struct {
+	sim    Simulation
+	system *actor.System
+	rp     *ResourcePool
+	epoch  time.Time
+
+	now             float64
+	totalSlots      int
+	usedSlotSeconds float64
+	preemptions     int
+
+	agents    map[string]*AgentState
+	groups    map[string]*actor.Ref
+	tasks     []*simulatedTask
+	byRef     map[*actor.Ref]*simulatedTask
+	submitted int
+}
+
+func newSimulator(system *actor.System, sim Simulation) *simulator {
+	conf := &config.ResourcePoolConfig{PoolName: simulatedPoolName, Scheduler: sim.Scheduler}
+	rp := NewResourcePool(conf, nil, nil,
+		MakeScheduler(sim.Scheduler), MakeFitFunction(sim.Scheduler.FittingPolicy))
+	rp.agentStatesCache = make(map[*actor.Ref]*AgentState, len(sim.Agents))
+
+	s := &simulator{
+		sim:    sim,
+		system: system,
+		rp:     rp,
+		epoch:  time.Now(),
+		agents: make(map[string]*AgentState, len(sim.Agents)),
+		groups: make(map[string]*actor.Ref),
+		byRef:  make(map[*actor.Ref]*simulatedTask, len(sim.Requests)),
+	}
+	if b, ok := rp.scheduler.(*backfillScheduler); ok {
+		b.now = s.clock
+	}
+
+	for i, a := range sim.Agents {
+		ref := system.MustActorOf(actor.Addr(fmt.Sprintf("agent-%d", i)), simulatedActor{})
+		state := NewAgentState(sproto.AddAgent{Agent: ref, Label: a.Label}, a.MaxZeroSlotContainers)
+		for j := 0; j < a.Slots; j++ {
+			state.Devices[device.Device{ID: device.ID(j)}] = nil
+		}
+		rp.agentStatesCache[ref] = state
+		s.agents[a.ID] = state
+		s.totalSlots += a.Slots
+	}
+
+	for _, r := range sim.Requests {
+		if r.Group == "" {
+			r.Group = r.ID
+		}
+		duration := r.DurationSeconds
+		if duration == 0 {
+			duration = sim.DefaultDurationSeconds
+		}
+		if duration == 0 {
+			duration = math.Inf(1)
+		}
+		s.tasks = append(s.tasks, &simulatedTask{
+			req:       r,
+			remaining: duration,
+			result:    SimulatedRequestResult{ID: r.ID, Group: r.Group},
+		})
+	}
+	sort.SliceStable(s.tasks, func(i, j int) bool {
+		return s.tasks[i].req.SubmitSeconds < s.tasks[j].req.SubmitSeconds
+	})
+	return s
+}
+
+// preallocate submits the requests that are already running and places them on their agents.
+func (s *simulator) preallocate() error {
+	s.submitDue()
+	for _, t := range s.tasks[:s.submitted] {
+		if len(t.req.Allocations) == 0 {
+			continue
+		}
+		fits := make([]*fittingState, 0, len(t.req.Allocations))
+		for _, alloc := range t.req.Allocations {
+			fits = append(fits, &fittingState{Agent: s.agents[alloc.AgentID], Slots: alloc.Slots})
+		}
+		if err := s.allocate(t, fits); err != nil {
+			return errors.Wrapf(err, "cannot place running request %s", t.req.ID)
+		}
+	}
+	return nil
+}
+
+func (s *simulator) run() {
+	for {
+		s.completeFinished()
+		s.submitDue()
+		s.schedule()
+
+		next, ok := s.nextEvent()
+		if s.sim.HorizonSeconds > 0 && (!ok || next > s.sim.HorizonSeconds) {
+			s.advance(s.sim.HorizonSeconds)
+			return
+		} else if !ok {
+			return
+		}
+		s.advance(next)
+	}
+}
+
+// clock returns the simulated time.
+func (s *simulator) clock() time.Time {
+	return s.epoch.Add(seconds(s.now))
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func (s *simulator) submitDue() {
+	for ; s.submitted < len(s.tasks); s.submitted++ {
+		t := s.tasks[s.submitted]
+		if t.req.SubmitSeconds > s.now {
+			return
+		}
+		s.submit(t)
+	}
+}
+
+func (s *simulator) submit(t *simulatedTask) {
+	groupRef, ok := s.groups[t.req.Group]
+	if !ok {
+		groupRef = s.system.MustActorOf(
+			actor.Addr(fmt.Sprintf("group-%d", len(s.groups))), simulatedActor{})
+		s.groups[t.req.Group] = groupRef
+
+		g := &group{handler: groupRef, maxSlots: t.req.MaxSlots, weight: t.req.Weight}
+		if g.weight == 0 {
+			g.weight = 1
+		}
+		if s.sim.Scheduler.Priority != nil {
+			g.priority = s.sim.Scheduler.Priority.DefaultPriority
+			if t.req.Priority != nil {
+				g.priority = t.req.Priority
+			}
+		}
+		s.rp.groups[groupRef] = g
+	}
+
+	ref := s.system.MustActorOf(
+		actor.Addr(fmt.Sprintf("allocation-%d", s.submitted)), simulatedActor{})
+	preemptible := true
+	if t.req.Preemptible != nil {
+		preemptible = *t.req.Preemptible
+	}
+	t.allocReq = &sproto.AllocateRequest{
+		AllocationID:      model.AllocationID(t.req.ID),
+		Name:              t.req.ID,
+		JobID:             model.JobID(t.req.Group),
+		JobSubmissionTime: s.epoch.Add(seconds(t.req.SubmitSeconds)),
+		IsUserVisible:     true,
+		AllocationRef:     ref,
+		Group:             groupRef,
+		SlotsNeeded:       t.req.SlotsNeeded,
+		Preemptible:       preemptible,
+		AgentLabel:        t.req.Label,
+		ResourcePool:      simulatedPoolName,
+	}
+	if !math.IsInf(t.remaining, 1) {
+		t.allocReq.ExpectedRuntime = ptrs.Ptr(seconds(t.remaining))
+	}
+	if _, ok := s.rp.queuePositions[t.allocReq.JobID]; !ok {
+		s.rp.queuePositions[t.allocReq.JobID] = initalizeQueuePosition(
+			t.allocReq.JobSubmissionTime, false)
+	}
+	s.rp.taskList.AddTask(t.allocReq)
+	s.byRef[ref] = t
+	t.pendingSince = s.now
+}
+
+// schedule runs the scheduler until it stops making changes, applying its decisions the same way
+// a live resource pool would, except that preempted tasks release their resources immediately.
+func (s *simulator) schedule() {
+	for i := 0; i < maxSimulatedSchedulingPasses; i++ {
+		toAllocate, toRelease := s.rp.scheduler.Schedule(s.rp)
+		if len(toAllocate) == 0 && len(toRelease) == 0 {
+			return
+		}
+		for _, req := range toAllocate {
+			fits := findFits(req, s.rp.agentStatesCache, s.rp.fittingMethod)
+			if len(fits) == 0 {
+				continue
+			}
+			// findFits only returns fits with enough free devices, so this cannot fail.
+			_ = s.allocate(s.byRef[req.AllocationRef], fits)
+		}
+		for _, ref := range toRelease {
+			t := s.byRef[ref]
+			if t == nil || !t.running {
+				continue
+			}
+			s.release(t)
+			s.rp.taskList.RemoveAllocations(ref)
+			t.remaining -= s.now - t.runningSince
+			t.pendingSince = s.now
+			t.result.Preemptions++
+			s.preemptions++
+		}
+	}
+}
+
+func (s *simulator) allocate(t *simulatedTask, fits []*fittingState) error {
+	resources := sproto.ResourceList{}
+	for _, fit := range fits {
+		containerID := cproto.NewID()
+		devices, err := fit.Agent.AllocateFreeDevices(fit.Slots, containerID)
+		if err != nil {
+			return err
+		}
+		cr := &containerResources{
+			req:         t.allocReq,
+			agent:       fit.Agent,
+			devices:     devices,
+			containerID: containerID,
+		}
+		resources[cr.Summary().ResourcesID] = cr
+	}
+	s.rp.taskList.SetAllocations(t.allocReq.AllocationRef, &sproto.ResourcesAllocated{
+		ID:                t.allocReq.AllocationID,
+		ResourcePool:      simulatedPoolName,
+		Resources:         resources,
+		JobSubmissionTime: t.allocReq.JobSubmissionTime,
+	})
+
+	t.result.WaitSeconds += s.now - t.pendingSince
+	if t.result.StartSeconds == nil {
+		t.result.StartSeconds = ptrs.Ptr(s.now)
+	}
+	t.running = true
+	t.runningSince = s.now
+	return nil
+}
+
+func (s *simulator) release(t *simulatedTask) {
+	if allocated := s.rp.taskList.GetAllocations(t.allocReq.AllocationRef); allocated != nil {
+		for _, r := range allocated.Resources {
+			cr := r.(*containerResources)
+			cr.agent.DeallocateContainer(cr.containerID)
+		}
+	}
+	t.running = false
+}
+
+func (s *simulator) completeFinished() {
+	for _, t := range s.tasks[:s.submitted] {
+		if t.running && t.runningSince+t.remaining <= s.now {
+			s.release(t)
+			s.rp.taskList.RemoveTaskByHandler(t.allocReq.AllocationRef)
+			t.done = true
+			t.result.EndSeconds = ptrs.Ptr(s.now)
+		}
+	}
+}
+
+// nextEvent returns the next time at which a request is submitted or finishes.
+func (s *simulator) nextEvent() (float64, bool) {
+	next := math.Inf(1)
+	if s.submitted < len(s.tasks) {
+		next = s.tasks[s.submitted].req.SubmitSeconds
+	}
+	for _, t := range s.tasks[:s.submitted] {
+		if t.running {
+			next = math.Min(next, t.runningSince+t.remaining)
+		}
+	}
+	return next, !math.IsInf(next, 1)
+}
+
+func (s *simulator) advance(to float64) {
+	for _, t := range s.tasks[:s.submitted] {
+		if t.running {
+			s.usedSlotSeconds += float64(t.req.SlotsNeeded) * (to - s.now)
+		}
+	}
+	s.now = to
+}
+
+func (s *simulator) result() *SimulationResult {
+	res := &SimulationResult{
+		Scheduler:       s.sim.Scheduler.GetType(),
+		FittingPolicy:   s.sim.Scheduler.FittingPolicy,
+		DurationSeconds: s.now,
+		Preemptions:     s.preemptions,
+		Requests:        make([]SimulatedRequestResult, 0, len(s.tasks)),
+	}
+	if capacity := float64(s.totalSlots) * s.now; capacity > 0 {
+		res.Utilization = s.usedSlotSeconds / capacity
+	}
+
+	for _, t := range s.tasks[:s.submitted] {
+		if !t.running && !t.done {
+			t.result.WaitSeconds += s.now - t.pendingSince
+		}
+		res.AvgWaitSeconds += t.result.WaitSeconds
+		res.MaxWaitSeconds = math.Max(res.MaxWaitSeconds, t.result.WaitSeconds)
+	}
+	if s.submitted > 0 {
+		res.AvgWaitSeconds /= float64(s.submitted)
+	}
+	for _, t := range s.tasks {
+		res.Requests = append(res.Requests, t.result)
+	}
+	return res
+}
+
+// simulationSnapshot describes the agents and requests of the resource pool as a simulation
+// starting now. Requests are ordered as in the queue, and running requests keep their agents.
+func (rp *ResourcePool) simulationSnapshot() Simulation {
+	sim := Simulation{Scheduler: rp.config.Scheduler}
+
+	agentIDs := make(map[*actor.Ref]string, len(rp.agentStatesCache))
+	for ref, state := range rp.agentStatesCache {
+		// Disabled agents keep their running tasks, so count those slots like draining ones.
+		slots := state.NumSlots()
+		if used := state.NumUsedSlots(); used > slots {
+			slots = used
+		}
+		agentIDs[ref] = state.string()
+		sim.Agents = append(sim.Agents, SimulatedAgent{
+			ID:                    state.string(),
+			Label:                 state.Label,
+			Slots:                 slots,
+			MaxZeroSlotContainers: state.maxZeroSlotContainers,
+		})
+	}
+	sort.Slice(sim.Agents, func(i, j int) bool { return sim.Agents[i].ID < sim.Agents[j].ID })
+
+	for _, req := range sortTasksByPosition(rp.taskList, rp.queuePositions) {
+		g := rp.groups[req.Group]
+		r := SimulatedRequest{
+			ID:          string(req.AllocationID),
+			Group:       string(req.JobID),
+			SlotsNeeded: req.SlotsNeeded,
+			Preemptible: ptrs.Ptr(req.Preemptible),
+			Label:       req.AgentLabel,
+		}
+		if r.Group == "" {
+			r.Group = req.Group.Address().String()
+		}
+		if g != nil {
+			r.MaxSlots, r.Weight, r.Priority = g.maxSlots, g.weight, g.priority
+		}
+		r.Allocations = snapshotAllocations(
+			req, rp.taskList.GetAllocations(req.AllocationRef), agentIDs)
+		sim.Requests = append(sim.Requests, r)
+	}
+	return sim
+}
+
+// snapshotAllocations returns where a task is running, or nothing if it is not fully running on
+// known agents, in which case the simulation treats it as pending.
+func snapshotAllocations(
+	req *sproto.AllocateRequest,
+	allocated *sproto.ResourcesAllocated,
+	agentIDs map[*actor.Ref]string,
+) []SimulatedAllocation {
+	if allocated == nil {
+		return nil
+	}
+	var allocs []SimulatedAllocation
+	slots := 0
+	for _, res := range allocated.Resources {
+		cr, ok := res.(*containerResources)
+		if !ok {
+			return nil
+		}
+		agentID, ok := agentIDs[cr.agent.Handler]
+		if !ok {
+			return nil
+		}
+		allocs = append(allocs, SimulatedAllocation{AgentID: agentID, Slots: len(cr.devices)})
+		slots += len(cr.devices)
+	}
+	if slots != req.SlotsNeeded {
+		return nil
+	}
+	return allocs
+}
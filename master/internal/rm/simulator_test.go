@@ -0,0 +1,170 @@
+package rm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func simulationRequestsByID(res *SimulationResult) map[string]SimulatedRequestResult {
+	byID := make(map[string]SimulatedRequestResult, len(res.Requests))
+	for _, r := range res.Requests {
+		byID[r.ID] = r
+	}
+	return byID
+}
+
+func TestSimulatePreemption(t *testing.T) {
+	agents := []SimulatedAgent{{ID: "agent1", Slots: 4}}
+	requests := []SimulatedRequest{
+		{ID: "low", SlotsNeeded: 4, Priority: ptrs.Ptr(50), DurationSeconds: 100},
+		{ID: "high", SlotsNeeded: 4, Priority: ptrs.Ptr(10), SubmitSeconds: 10, DurationSeconds: 50},
+	}
+
+	// With preemption, the high priority request takes over the agent as soon as it is submitted.
+	res, err := Simulate(Simulation{
+		Scheduler: &config.SchedulerConfig{
+			Priority: &config.PrioritySchedulerConfig{
+				Preemption:      true,
+				DefaultPriority: ptrs.Ptr(config.DefaultSchedulingPriority),
+			},
+			FittingPolicy: best,
+		},
+		Agents:   agents,
+		Requests: requests,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, res.Scheduler, config.PriorityScheduling)
+	assert.Equal(t, res.DurationSeconds, 150.0)
+	assert.Equal(t, res.Utilization, 1.0)
+	assert.Equal(t, res.Preemptions, 1)
+	byID := simulationRequestsByID(res)
+	assert.Equal(t, *byID["high"].StartSeconds, 10.0)
+	assert.Equal(t, *byID["high"].EndSeconds, 60.0)
+	assert.Equal(t, byID["high"].WaitSeconds, 0.0)
+	assert.Equal(t, *byID["low"].StartSeconds, 0.0)
+	assert.Equal(t, *byID["low"].EndSeconds, 150.0)
+	assert.Equal(t, byID["low"].WaitSeconds, 50.0)
+	assert.Equal(t, byID["low"].Preemptions, 1)
+
+	// Without preemption, the same requests run in order of submission.
+	res, err = Simulate(Simulation{
+		Scheduler: &config.SchedulerConfig{
+			RoundRobin:    &config.RoundRobinSchedulerConfig{},
+			FittingPolicy: best,
+		},
+		Agents:   agents,
+		Requests: requests,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, res.Preemptions, 0)
+	byID = simulationRequestsByID(res)
+	assert.Equal(t, *byID["high"].StartSeconds, 100.0)
+	assert.Equal(t, byID["high"].WaitSeconds, 90.0)
+	assert.Equal(t, res.AvgWaitSeconds, 45.0)
+	assert.Equal(t, res.MaxWaitSeconds, 90.0)
+}
+
+func TestSimulateHorizonAndUnschedulable(t *testing.T) {
+	res, err := Simulate(Simulation{
+		Scheduler: config.DefaultSchedulerConfig(),
+		Agents:    []SimulatedAgent{{ID: "agent1", Slots: 4}, {ID: "agent2", Slots: 4}},
+		Requests: []SimulatedRequest{
+			{ID: "forever", SlotsNeeded: 2},
+			{ID: "too-big", SlotsNeeded: 16, SubmitSeconds: 5},
+			{ID: "late", SlotsNeeded: 1, SubmitSeconds: 500},
+		},
+		HorizonSeconds: 100,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, res.DurationSeconds, 100.0)
+	assert.Equal(t, res.Utilization, 0.25)
+	byID := simulationRequestsByID(res)
+	assert.Assert(t, byID["forever"].EndSeconds == nil)
+	assert.Assert(t, byID["too-big"].StartSeconds == nil)
+	assert.Equal(t, byID["too-big"].WaitSeconds, 95.0)
+	assert.Assert(t, byID["late"].StartSeconds == nil)
+	assert.Equal(t, byID["late"].WaitSeconds, 0.0)
+}
+
+func TestSimulateValidation(t *testing.T) {
+	cases := []Simulation{
+		{},
+		{
+			Scheduler: config.DefaultSchedulerConfig(),
+			Agents:    []SimulatedAgent{{ID: "agent1"}, {ID: "agent1"}},
+		},
+		{
+			Scheduler: config.DefaultSchedulerConfig(),
+			Requests:  []SimulatedRequest{{ID: "task1", SubmitSeconds: -1}},
+		},
+		{
+			Scheduler: config.DefaultSchedulerConfig(),
+			Agents:    []SimulatedAgent{{ID: "agent1", Slots: 1}},
+			Requests: []SimulatedRequest{{
+				ID:          "task1",
+				SlotsNeeded: 1,
+				Allocations: []SimulatedAllocation{{AgentID: "agent2", Slots: 1}},
+			}},
+		},
+	}
+	for _, sim := range cases {
+		_, err := Simulate(sim)
+		assert.ErrorContains(t, err, "")
+	}
+
+	// Running requests must fit on their agents.
+	_, err := Simulate(Simulation{
+		Scheduler: config.DefaultSchedulerConfig(),
+		Agents:    []SimulatedAgent{{ID: "agent1", Slots: 1}},
+		Requests: []SimulatedRequest{{
+			ID:          "task1",
+			SlotsNeeded: 2,
+			Allocations: []SimulatedAllocation{{AgentID: "agent1", Slots: 2}},
+		}},
+	})
+	assert.ErrorContains(t, err, "cannot place running request task1")
+}
+
+func TestSimulateResourcePoolSnapshot(t *testing.T) {
+	agents := []*mockAgent{
+		{id: "agent1", slots: 4, maxZeroSlotContainers: 100},
+	}
+	tasks := []*mockTask{
+		{id: "running", slotsNeeded: 4, allocatedAgent: agents[0], containerStarted: true},
+		{id: "pending", slotsNeeded: 2},
+	}
+
+	system := actor.NewSystem(t.Name())
+	rp := NewResourcePool(
+		&config.ResourcePoolConfig{PoolName: "pool", Scheduler: config.DefaultSchedulerConfig()},
+		nil, nil, NewFairShareScheduler(), BestFit)
+	rp.taskList, rp.groups, rp.agentStatesCache = setupSchedulerStates(
+		t, system, tasks, nil, agents)
+
+	sim := rp.simulationSnapshot()
+	assert.Equal(t, len(sim.Agents), 1)
+	assert.Equal(t, sim.Agents[0].Slots, 4)
+	assert.Equal(t, len(sim.Requests), 2)
+	assert.Equal(t, sim.Requests[0].ID, "running")
+	assert.DeepEqual(t, sim.Requests[0].Allocations,
+		[]SimulatedAllocation{{AgentID: sim.Agents[0].ID, Slots: 4}})
+	assert.Assert(t, sim.Requests[1].Allocations == nil)
+
+	sim.DefaultDurationSeconds = 60
+	res, err := Simulate(sim)
+	assert.NilError(t, err)
+	// Fair share gives each job half of the agent, so the running request is preempted until the
+	// pending one finishes.
+	byID := simulationRequestsByID(res)
+	assert.Equal(t, res.Preemptions, 1)
+	assert.Equal(t, *byID["pending"].StartSeconds, 0.0)
+	assert.Equal(t, *byID["pending"].EndSeconds, 60.0)
+	assert.Equal(t, byID["running"].WaitSeconds, 60.0)
+	assert.Equal(t, *byID["running"].EndSeconds, 120.0)
+	assert.Equal(t, res.Utilization, 0.75)
+}
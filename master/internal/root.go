@@ -16,7 +16,7 @@ func root(ctx *actor.Context) error {
 		return nil
 	case actor.ChildFailed:
 		switch msg.Child.Address() {
-		case sproto.K8sRMAddr, sproto.AgentRMAddr:
+		case sproto.K8sRMAddr, sproto.AgentRMAddr, sproto.DispatcherRMAddr:
 			ctx.Log().WithField("crash", msg).Errorf(clusterCrashMessage)
 		case sproto.PodsAddr, sproto.AgentsAddr:
 			ctx.Log().WithField("crash", msg).Errorf(clusterCrashMessage)
@@ -24,7 +24,7 @@ func root(ctx *actor.Context) error {
 		return nil
 	case actor.ChildStopped:
 		switch msg.Child.Address() {
-		case sproto.K8sRMAddr, sproto.AgentRMAddr:
+		case sproto.K8sRMAddr, sproto.AgentRMAddr, sproto.DispatcherRMAddr:
 			ctx.Log().WithField("crash", msg).Errorf(clusterCrashMessage)
 		case sproto.PodsAddr, sproto.AgentsAddr:
 			ctx.Log().WithField("crash", msg).Errorf(clusterCrashMessage)
@@ -2,6 +2,7 @@ package sproto
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -33,6 +34,10 @@ type RMJobInfo struct { // rename ?
 	State          SchedulingState
 	RequestedSlots int
 	AllocatedSlots int
+	// ReservedStart is when the scheduler has reserved resources to start the job, if it has.
+	ReservedStart *time.Time
+	// BlockedByQuota is whether the job is held back by a quota of its workspace or owner.
+	BlockedByQuota bool
 }
 
 // GetJobSummary requests a summary of the job.
@@ -91,6 +96,13 @@ type (
 		ResourcePool string
 		Handler      *actor.Ref
 	}
+	// SetGroupOwner sets the user and workspace that own the group, to enforce their quotas.
+	SetGroupOwner struct {
+		UserID       *model.UserID
+		WorkspaceID  *int
+		ResourcePool string
+		Handler      *actor.Ref
+	}
 	// SetResourcePool switches the resource pool that the job belongs to.
 	SetResourcePool struct {
 		ResourcePool string
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
 
 	"github.com/determined-ai/determined/master/pkg/actor"
@@ -16,6 +17,18 @@ import (
 	"github.com/determined-ai/determined/master/pkg/tasks"
 )
 
+// Reasons that the resources of an allocation are released, as reported in metrics.
+const (
+	// ReleaseReasonScheduler is when the scheduler makes room for other work.
+	ReleaseReasonScheduler = "scheduler"
+	// ReleaseReasonReservation is when a resource reservation becomes active.
+	ReleaseReasonReservation = "reservation"
+	// ReleaseReasonPriorityChange is when the priority of the job changes.
+	ReleaseReasonPriorityChange = "priority_change"
+	// ReleaseReasonPositionChange is when the position of the job in the queue changes.
+	ReleaseReasonPositionChange = "position_change"
+)
+
 // Task-related cluster level messages.
 type (
 	// AllocateRequest notifies resource managers to assign resources to a task.
@@ -39,13 +52,19 @@ type (
 		AgentLabel          string
 		ResourcePool        string
 		FittingRequirements FittingRequirements
+		// ExpectedRuntime is how long the allocation is expected to run once started, if known.
+		ExpectedRuntime *time.Duration
 
 		// Behavioral configuration.
-		Preemptible  bool
-		IdleTimeout  *IdleTimeoutConfig
-		ProxyPort    *ProxyPortConfig
-		StreamEvents *EventStreamConfig
-		Restore      bool
+		Preemptible     bool
+		IdleTimeout     *IdleTimeoutConfig
+		ProgressTimeout *ProgressTimeoutConfig
+		ProxyPort       *ProxyPortConfig
+		StreamEvents    *EventStreamConfig
+		Restore         bool
+
+		// TraceParent is the span that the span of the allocation is a child of, if any.
+		TraceParent trace.SpanContext
 	}
 
 	// IdleTimeoutConfig configures how idle timeouts should behave.
@@ -57,6 +76,14 @@ type (
 		Debug           bool
 	}
 
+	// ProgressTimeoutConfig configures how long an allocation may go without its task noting
+	// progress before it's killed.
+	ProgressTimeoutConfig struct {
+		TimeoutDuration time.Duration
+		// Restart marks the error the allocation exits with as one its task may be restarted after.
+		Restart bool
+	}
+
 	// ProxyPortConfig configures a proxy the allocation should start.
 	ProxyPortConfig struct {
 		ServiceID       string
@@ -156,6 +183,8 @@ type (
 		// If specified as true (default false), Requestor wants to force
 		// a preemption attempt instead of an immediate kill.
 		ForcePreemption bool
+		// Reason is why the resources are released, one of the ReleaseReason constants.
+		Reason string
 	}
 	// ResourcesRuntimeInfo is all the inforamation provided at runtime to make a task spec.
 	ResourcesRuntimeInfo struct {
@@ -180,6 +209,8 @@ const (
 	ResourcesTypeDockerContainer ResourcesType = "docker-container"
 	// ResourcesTypeSlurmJob indicates the resources are a handle for a slurm job.
 	ResourcesTypeSlurmJob ResourcesType = "slurm-job"
+	// ResourcesTypePbsJob indicates the resources are a handle for a PBS job.
+	ResourcesTypePbsJob ResourcesType = "pbs-job"
 )
 
 // Clone clones ResourcesAllocated. Used to not pass mutable refs to other actors.
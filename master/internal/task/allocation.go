@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/determined-ai/determined/master/internal/cluster"
 	"github.com/determined-ai/determined/master/internal/db"
@@ -23,6 +26,7 @@ import (
 	"github.com/determined-ai/determined/master/pkg/cproto"
 	detLogger "github.com/determined-ai/determined/master/pkg/logger"
 	"github.com/determined-ai/determined/master/pkg/model"
+	opentelemetry "github.com/determined-ai/determined/master/pkg/opentelemetry"
 	"github.com/determined-ai/determined/master/pkg/ptrs"
 	"github.com/determined-ai/determined/master/pkg/tasks"
 )
@@ -59,6 +63,13 @@ type (
 		killCooldown *time.Time
 		// tracks if we have finished termination.
 		exited bool
+		// When resources were requested, to measure how long the allocation was queued.
+		requestedAt time.Time
+		// Marks that the scheduler asked us to release our resources, so each preemption is
+		// counted once.
+		releaseRequested bool
+		// Traces the allocation from when it requests resources until it stops.
+		span trace.Span
 
 		// State for specific sub-behaviors of an allocation.
 		// Encapsulates the preemption state of the currently allocated task.
@@ -69,6 +80,8 @@ type (
 		rendezvous *rendezvous
 		// Encapsulates the logic of watching for idle timeouts.
 		idleTimeoutWatcher *IdleTimeoutWatcher
+		// Encapsulates the logic of watching for progress timeouts.
+		progressTimeoutWatcher *ProgressTimeoutWatcher
 		// proxy state
 		proxies []string
 		// proxyAddress is provided by determined.exec.prep_container if the RM doesn't provide it.
@@ -181,6 +194,13 @@ func (a *Allocation) Receive(ctx *actor.Context) error {
 	case actor.PreStart:
 		allocationmap.RegisterAllocation(a.model.AllocationID, ctx.Self())
 		ctx.AddLabels(a.logCtx)
+		_, a.span = opentelemetry.Tracer().Start(
+			trace.ContextWithSpanContext(context.Background(), a.req.TraceParent), "allocation",
+			trace.WithAttributes(
+				opentelemetry.AllocationIDKey.String(string(a.req.AllocationID)),
+				opentelemetry.TaskIDKey.String(string(a.req.TaskID)),
+				opentelemetry.JobIDKey.String(string(a.req.JobID)),
+			))
 		if err := a.RequestResources(ctx); err != nil {
 			a.Error(ctx, err)
 		}
@@ -203,12 +223,17 @@ func (a *Allocation) Receive(ctx *actor.Context) error {
 			ctx.Respond(fmt.Errorf("unknown resources %s", msg.ResourcesID))
 		}
 	case sproto.ReleaseResources:
+		if !a.releaseRequested {
+			a.releaseRequested = true
+			prom.IncAllocationPreemptions(a.req.ResourcePool, msg.Reason)
+		}
 		a.Terminate(ctx, "allocation being preempted by the scheduler", msg.ForcePreemption)
 	case sproto.ChangeRP:
 		a.Terminate(ctx, "allocation resource pool changed", false)
 	case actor.PostStop:
 		a.Cleanup(ctx)
 		allocationmap.UnregisterAllocation(a.model.AllocationID)
+		a.endSpan()
 	case sproto.ContainerLog:
 		a.sendEvent(ctx, msg.ToEvent())
 
@@ -338,6 +363,16 @@ func (a *Allocation) Receive(ctx *actor.Context) error {
 		if err := a.idleTimeoutWatcher.ReceiveMsg(ctx); err != nil {
 			a.Error(ctx, err)
 		}
+	case ProgressTimeoutWatcherTick, ProgressWatcherNoteProgress:
+		if a.progressTimeoutWatcher == nil {
+			if ctx.ExpectingResponse() {
+				ctx.Respond(ErrBehaviorDisabled{progressWatcher})
+			}
+			return nil
+		}
+		if err := a.progressTimeoutWatcher.ReceiveMsg(ctx); err != nil {
+			a.Error(ctx, err)
+		}
 
 	default:
 		a.Error(ctx, actor.ErrUnexpectedMessage(ctx))
@@ -367,6 +402,7 @@ func (a *Allocation) RequestResources(ctx *actor.Context) error {
 	}
 
 	a.req.AllocationRef = ctx.Self()
+	a.requestedAt = time.Now()
 	if err := a.rm.Allocate(ctx, a.req); err != nil {
 		return errors.Wrap(err, "failed to request allocation")
 	}
@@ -399,6 +435,19 @@ func (a *Allocation) Cleanup(ctx *actor.Context) {
 	}
 }
 
+// endSpan records how the allocation ended on its span and ends it.
+func (a *Allocation) endSpan() {
+	if a.span == nil {
+		return
+	}
+	a.span.SetAttributes(opentelemetry.StateKey.String(string(a.getModelState())))
+	if a.exitErr != nil {
+		a.span.RecordError(a.exitErr)
+		a.span.SetStatus(codes.Error, a.exitErr.Error())
+	}
+	a.span.End()
+}
+
 // ResourcesAllocated handles receiving resources from the resource manager. Note: it makes a single
 // ask to the parent to build its task spec.. this is mostly a hack to defer lots of computationally
 // heavy stuff unless it is necessarily (which also works to spread occurrences of the same work
@@ -412,6 +461,8 @@ func (a *Allocation) ResourcesAllocated(ctx *actor.Context, msg sproto.Resources
 		}
 
 		a.setModelState(model.AllocationStateAssigned)
+		prom.ObserveAllocationQueueTime(msg.ResourcePool, time.Since(a.requestedAt))
+		a.span.AddEvent("resources allocated")
 	} else {
 		ctx.Log().Debugf("ResourcesAllocated restored state: %s", a.getModelState())
 	}
@@ -455,6 +506,18 @@ func (a *Allocation) ResourcesAllocated(ctx *actor.Context, msg sproto.Resources
 		a.idleTimeoutWatcher.PreStart(ctx)
 	}
 
+	if cfg := a.req.ProgressTimeout; cfg != nil {
+		a.progressTimeoutWatcher = NewProgressTimeoutWatcher(cfg,
+			func(ctx *actor.Context, err ErrNoProgress) {
+				a.logger.Insert(ctx, a.enrichLog(model.TaskLog{
+					Level: ptrs.Ptr(model.LogLevelError),
+					Log:   fmt.Sprintf("killing %s: %s", a.req.Name, err),
+				}))
+				a.Error(ctx, err)
+			})
+		a.progressTimeoutWatcher.PreStart(ctx)
+	}
+
 	if !a.req.Restore {
 		token, err := a.db.StartAllocationSession(a.model.AllocationID, spec.Owner)
 		if err != nil {
@@ -562,6 +625,9 @@ func (a *Allocation) ResourcesStateChanged(
 		}
 
 		a.setMostProgressedModelState(model.AllocationStateRunning)
+		a.span.AddEvent("container started", trace.WithAttributes(
+			attribute.String("container_id", coalesceString(msg.ContainerIDStr(), "")),
+		))
 
 		a.resources[msg.ResourcesID].Started = msg.ResourcesStarted
 		if err := a.resources[msg.ResourcesID].Persist(); err != nil {
@@ -907,7 +973,15 @@ func (a *Allocation) terminated(ctx *actor.Context, reason string) {
 	if a.rendezvous != nil {
 		defer a.rendezvous.close()
 	}
+	var noProgress ErrNoProgress
 	switch {
+	case errors.As(a.exitErr, &noProgress):
+		// The allocation was killed for making no progress, which the task must know about to
+		// decide whether to restart it.
+		exitReason = fmt.Sprintf("allocation killed: %s", noProgress)
+		ctx.Log().Info(exitReason)
+		exit.Err = noProgress
+		return
 	case a.killedWhileRunning:
 		exitReason = fmt.Sprintf("allocation stopped after %s", reason)
 		ctx.Log().Info(exitReason)
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/determined-ai/determined/master/internal/sproto"
 	"github.com/determined-ai/determined/master/pkg/cproto"
@@ -70,10 +71,22 @@ func (e ErrStaleResources) Error() string {
 	return fmt.Sprintf("stale resources %s", e.ID)
 }
 
+// ErrNoProgress is returned when a task makes no progress within its progress timeout.
+type ErrNoProgress struct {
+	Timeout time.Duration
+	// Restart is whether the task may be restarted after the error.
+	Restart bool
+}
+
+func (e ErrNoProgress) Error() string {
+	return fmt.Sprintf("no progress was reported for %s", e.Timeout)
+}
+
 // All behaviors for allocations.
 const (
-	preemption  = "preemption"
-	idleWatcher = "idle_watcher"
+	preemption      = "preemption"
+	idleWatcher     = "idle_watcher"
+	progressWatcher = "progress_watcher"
 )
 
 // ErrBehaviorDisabled is returned an operation is tried without the behavior being enabled.
@@ -0,0 +1,76 @@
+package task
+
+import (
+	"time"
+
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/actor/actors"
+)
+
+// maxProgressWatcherTickInterval is how often progress timeouts are checked at most.
+const maxProgressWatcherTickInterval = 30 * time.Second
+
+type (
+	// ProgressTimeoutWatcherTick is the incoming message that should be handled.
+	ProgressTimeoutWatcherTick struct{}
+	// ProgressWatcherNoteProgress notes that the task made progress to delay its progress timeout.
+	ProgressWatcherNoteProgress struct {
+		At time.Time
+	}
+)
+
+// ProgressTimeoutWatcher kills a task actor that makes no progress for too long. The time until
+// the timeout starts counting when the watcher starts, once the task has been given resources.
+type ProgressTimeoutWatcher struct {
+	TickInterval time.Duration
+	Timeout      time.Duration
+	Restart      bool
+	Action       func(ctx *actor.Context, err ErrNoProgress)
+
+	lastProgress time.Time
+}
+
+// NewProgressTimeoutWatcher creates a new progress timeout watcher.
+func NewProgressTimeoutWatcher(
+	cfg *sproto.ProgressTimeoutConfig, action func(ctx *actor.Context, err ErrNoProgress),
+) *ProgressTimeoutWatcher {
+	tickInterval := maxProgressWatcherTickInterval
+	if cfg.TimeoutDuration < tickInterval {
+		tickInterval = cfg.TimeoutDuration
+	}
+	return &ProgressTimeoutWatcher{
+		TickInterval: tickInterval,
+		Timeout:      cfg.TimeoutDuration,
+		Restart:      cfg.Restart,
+		Action:       action,
+	}
+}
+
+// PreStart should be called when the task actor is given resources.
+func (p *ProgressTimeoutWatcher) PreStart(ctx *actor.Context) {
+	p.lastProgress = time.Now()
+	actors.NotifyAfter(ctx, p.TickInterval, ProgressTimeoutWatcherTick{})
+}
+
+// ReceiveMsg should be called on receiving related messages.
+func (p *ProgressTimeoutWatcher) ReceiveMsg(ctx *actor.Context) error {
+	switch msg := ctx.Message().(type) {
+	case ProgressTimeoutWatcherTick:
+		if time.Now().After(p.lastProgress.Add(p.Timeout)) {
+			p.Action(ctx, ErrNoProgress{Timeout: p.Timeout, Restart: p.Restart})
+			return nil
+		}
+		actors.NotifyAfter(ctx, p.TickInterval, ProgressTimeoutWatcherTick{})
+
+	case ProgressWatcherNoteProgress:
+		if msg.At.After(p.lastProgress) {
+			p.lastProgress = msg.At
+		}
+
+	default:
+		return actor.ErrUnexpectedMessage(ctx)
+	}
+
+	return nil
+}
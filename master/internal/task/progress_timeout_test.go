@@ -0,0 +1,72 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/internal/sproto"
+	"github.com/determined-ai/determined/master/pkg/actor"
+)
+
+type MockProgressTimeoutWatchee struct {
+	progressTimeoutWatcher *ProgressTimeoutWatcher
+}
+
+func (m *MockProgressTimeoutWatchee) Receive(ctx *actor.Context) error {
+	switch ctx.Message().(type) {
+	case actor.PreStart:
+		m.progressTimeoutWatcher.PreStart(ctx)
+	case ProgressTimeoutWatcherTick, ProgressWatcherNoteProgress:
+		return m.progressTimeoutWatcher.ReceiveMsg(ctx)
+	case actor.PostStop:
+	default:
+		return actor.ErrUnexpectedMessage(ctx)
+	}
+	return nil
+}
+
+func TestProgressTimeoutWatcher(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	fired := make(chan ErrNoProgress, 1)
+
+	m := MockProgressTimeoutWatchee{
+		progressTimeoutWatcher: NewProgressTimeoutWatcher(
+			&sproto.ProgressTimeoutConfig{TimeoutDuration: timeout, Restart: true},
+			func(ctx *actor.Context, err ErrNoProgress) {
+				fired <- err
+			},
+		),
+	}
+	assert.Equal(t, m.progressTimeoutWatcher.TickInterval, timeout)
+
+	system := actor.NewSystem(t.Name())
+	mActor, created := system.ActorOf(actor.Addr("MockProgressTimeoutWatchee"), &m)
+	assert.Assert(t, created)
+
+	// Progress reported more often than the timeout keeps the task alive.
+	for i := 0; i < 4; i++ {
+		time.Sleep(timeout / 2)
+		system.Ask(mActor, ProgressWatcherNoteProgress{At: time.Now()}).Get()
+	}
+	system.Ask(mActor, actor.Ping{}).Get()
+	assert.Equal(t, len(fired), 0)
+
+	// Without progress, the action fires once the timeout passes.
+	select {
+	case err := <-fired:
+		assert.Equal(t, err.Timeout, timeout)
+		assert.Equal(t, err.Restart, true)
+	case <-time.After(10 * timeout):
+		t.Fatal("progress timeout action did not fire")
+	}
+}
+
+func TestNewProgressTimeoutWatcherTickInterval(t *testing.T) {
+	w := NewProgressTimeoutWatcher(
+		&sproto.ProgressTimeoutConfig{TimeoutDuration: time.Hour}, nil,
+	)
+	assert.Equal(t, w.TickInterval, maxProgressWatcherTickInterval)
+	assert.Equal(t, w.Timeout, time.Hour)
+}
@@ -18,11 +18,13 @@ import (
 	"github.com/determined-ai/determined/master/pkg/ptrs"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/determined-ai/determined/master/internal/db"
 	"github.com/determined-ai/determined/master/internal/sproto"
 	"github.com/determined-ai/determined/master/pkg/actor"
 	"github.com/determined-ai/determined/master/pkg/model"
+	opentelemetry "github.com/determined-ai/determined/master/pkg/opentelemetry"
 	"github.com/determined-ai/determined/master/pkg/schemas"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
 	"github.com/determined-ai/determined/master/pkg/ssh"
@@ -80,9 +82,16 @@ type trial struct {
 
 	// a ref to the current allocation
 	allocation *actor.Ref
+	// the ID of the current allocation
+	allocationID model.AllocationID
 	// a note of the user initated exit reason, if any.
 	userInitiatedExit *model.ExitedReason
 
+	// traceParent is the span of the experiment, which the span of the trial is a child of.
+	traceParent trace.SpanContext
+	// span traces the trial from when its actor starts until it stops.
+	span trace.Span
+
 	logCtx logger.Context
 }
 
@@ -141,7 +150,14 @@ func isNonRetryableError(err error) bool {
 func (t *trial) Receive(ctx *actor.Context) error {
 	switch msg := ctx.Message().(type) {
 	case actor.PreStart:
+		_, t.span = opentelemetry.Tracer().Start(
+			trace.ContextWithSpanContext(context.Background(), t.traceParent), "trial",
+			trace.WithAttributes(
+				opentelemetry.ExperimentIDKey.Int(t.experimentID),
+				opentelemetry.TaskIDKey.String(string(t.taskID)),
+			))
 		if t.idSet {
+			t.span.SetAttributes(opentelemetry.TrialIDKey.Int(t.id))
 			if err := t.recover(); err != nil {
 				return err
 			}
@@ -154,9 +170,15 @@ func (t *trial) Receive(ctx *actor.Context) error {
 
 		return t.maybeAllocateTask(ctx)
 	case actor.PostStop:
+		defer func() {
+			t.span.SetAttributes(opentelemetry.StateKey.String(string(t.state)))
+			t.span.End()
+		}()
+
 		if !t.idSet {
 			return nil
 		}
+		unwatchTrialProgress(t.id)
 		if !model.TerminalStates[t.state] {
 			return t.transition(ctx, model.StateWithReason{
 				State:               model.ErrorState,
@@ -294,8 +316,11 @@ func (t *trial) maybeAllocateTask(ctx *actor.Context) error {
 				SingleAgent: false,
 			},
 
-			Preemptible: true,
-			Restore:     true,
+			Preemptible:     true,
+			ProgressTimeout: t.progressTimeout(),
+			ExpectedRuntime: t.expectedRuntime(),
+			Restore:         true,
+			TraceParent:     t.span.SpanContext(),
 		}
 		ctx.Log().
 			WithField("allocation-id", ar.AllocationID).
@@ -303,6 +328,7 @@ func (t *trial) maybeAllocateTask(ctx *actor.Context) error {
 		t.allocation, _ = ctx.ActorOf(t.runID, taskAllocator(
 			t.logCtx, ar, t.db, t.rm, t.taskLogger,
 		))
+		t.allocationID = ar.AllocationID
 		return nil
 	}
 
@@ -329,7 +355,10 @@ func (t *trial) maybeAllocateTask(ctx *actor.Context) error {
 			SingleAgent: false,
 		},
 
-		Preemptible: true,
+		Preemptible:     true,
+		ProgressTimeout: t.progressTimeout(),
+		ExpectedRuntime: t.expectedRuntime(),
+		TraceParent:     t.span.SpanContext(),
 	}
 
 	ctx.Log().
@@ -338,6 +367,7 @@ func (t *trial) maybeAllocateTask(ctx *actor.Context) error {
 
 	prom.AssociateJobExperiment(t.jobID, strconv.Itoa(t.experimentID), t.config.Labels())
 	t.allocation, _ = ctx.ActorOf(t.runID, taskAllocator(t.logCtx, ar, t.db, t.rm, t.taskLogger))
+	t.allocationID = ar.AllocationID
 	ctx.Ask(t.allocation, actor.Ping{}).Get()
 
 	return nil
@@ -406,6 +436,7 @@ func (t *trial) buildTaskSpec(ctx *actor.Context) (tasks.TaskSpec, error) {
 
 		t.id = modelTrial.ID
 		t.idSet = true
+		t.span.SetAttributes(opentelemetry.TrialIDKey.Int(t.id))
 		t.logCtx = logger.MergeContexts(t.logCtx, logger.Context{"trial-id": t.id})
 		ctx.AddLabel("trial-id", t.id)
 		t.rm.SetAllocationName(ctx, sproto.SetAllocationName{
@@ -418,6 +449,9 @@ func (t *trial) buildTaskSpec(ctx *actor.Context) (tasks.TaskSpec, error) {
 	if err := t.db.UpdateTrialRunID(t.id, t.runID); err != nil {
 		return tasks.TaskSpec{}, errors.Wrap(err, "failed to save trial run ID")
 	}
+	if t.progressTimeout() != nil && t.allocation != nil {
+		watchTrialProgress(t.id, t.allocationID, t.allocation)
+	}
 
 	var stepsCompleted int
 	latestCheckpoint, err := t.db.LatestCheckpointForTrial(t.id)
@@ -450,10 +484,14 @@ func (t *trial) allocationExited(ctx *actor.Context, exit *task.AllocationExited
 		ctx.Log().WithError(err).Error("trial allocation failed")
 	}
 	t.allocation = nil
+	if t.idSet {
+		unwatchTrialProgress(t.id)
+	}
 
 	prom.DisassociateJobExperiment(t.jobID, strconv.Itoa(t.experimentID), t.config.Labels())
 
 	// Decide if this is permanent.
+	var noProgress task.ErrNoProgress
 	switch {
 	case model.StoppingStates[t.state]:
 		if exit.Err != nil {
@@ -488,6 +526,12 @@ func (t *trial) allocationExited(ctx *actor.Context, exit *task.AllocationExited
 			InformationalReason: fmt.Sprintf(
 				"trial allocation exited with unrecoverable failure %v", exit.Err),
 		})
+	case errors.As(exit.Err, &noProgress) && !noProgress.Restart:
+		return t.transition(ctx, model.StateWithReason{
+			State: model.ErrorState,
+			InformationalReason: fmt.Sprintf(
+				"trial killed by its progress timeout: %v", noProgress),
+		})
 	case exit.Err != nil && isNonRetryableError(exit.Err):
 		// These are errors that no matter how many times we retry, the outcome will
 		// be the same, so don't bother retrying. Fail right away to allow the user
@@ -627,6 +671,28 @@ func (t *trial) transition(ctx *actor.Context, s model.StateWithReason) error {
 	return nil
 }
 
+// progressTimeout returns how the allocations of the trial should watch its progress, or nil if
+// they shouldn't.
+func (t *trial) progressTimeout() *sproto.ProgressTimeoutConfig {
+	cfg := t.config.ProgressTimeout()
+	if cfg.Timeout() == 0 {
+		return nil
+	}
+	return &sproto.ProgressTimeoutConfig{
+		TimeoutDuration: cfg.Timeout(),
+		Restart:         cfg.Action() == expconf.ProgressTimeoutRestart,
+	}
+}
+
+// expectedRuntime returns how long the experiment config expects the trial to run, if it says.
+func (t *trial) expectedRuntime() *time.Duration {
+	seconds := t.config.Resources().ExpectedRuntimeSeconds()
+	if seconds == nil {
+		return nil
+	}
+	return ptrs.Ptr(time.Duration(*seconds) * time.Second)
+}
+
 func (t *trial) enrichTaskLog(log model.TaskLog) (model.TaskLog, error) {
 	if !t.idSet {
 		return model.TaskLog{}, fmt.Errorf(
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/determined-ai/determined/master/internal/task"
+	"github.com/determined-ai/determined/master/pkg/actor"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// progressWatchedTrials holds the allocations of the trials that are killed when they make no
+// progress, by trial ID and by allocation ID, so that reports from those trials can be noted as
+// progress without asking their experiments where they are.
+var progressWatchedTrials = struct {
+	sync.Mutex
+	byTrial      map[int]*actor.Ref
+	byAllocation map[model.AllocationID]*actor.Ref
+	allocations  map[int]model.AllocationID
+}{
+	byTrial:      map[int]*actor.Ref{},
+	byAllocation: map[model.AllocationID]*actor.Ref{},
+	allocations:  map[int]model.AllocationID{},
+}
+
+func watchTrialProgress(trialID int, allocationID model.AllocationID, allocation *actor.Ref) {
+	progressWatchedTrials.Lock()
+	defer progressWatchedTrials.Unlock()
+	if prev, ok := progressWatchedTrials.allocations[trialID]; ok {
+		delete(progressWatchedTrials.byAllocation, prev)
+	}
+	progressWatchedTrials.byTrial[trialID] = allocation
+	progressWatchedTrials.byAllocation[allocationID] = allocation
+	progressWatchedTrials.allocations[trialID] = allocationID
+}
+
+func unwatchTrialProgress(trialID int) {
+	progressWatchedTrials.Lock()
+	defer progressWatchedTrials.Unlock()
+	if allocationID, ok := progressWatchedTrials.allocations[trialID]; ok {
+		delete(progressWatchedTrials.byAllocation, allocationID)
+	}
+	delete(progressWatchedTrials.byTrial, trialID)
+	delete(progressWatchedTrials.allocations, trialID)
+}
+
+// noteTrialProgress delays the progress timeout of the trial, if it has one.
+func noteTrialProgress(system *actor.System, trialID int) {
+	progressWatchedTrials.Lock()
+	allocation := progressWatchedTrials.byTrial[trialID]
+	progressWatchedTrials.Unlock()
+	if allocation != nil {
+		system.Tell(allocation, task.ProgressWatcherNoteProgress{At: time.Now()})
+	}
+}
+
+// noteAllocationProgress delays the progress timeout of the trial running in the allocation, if
+// it has one.
+func noteAllocationProgress(system *actor.System, allocationID model.AllocationID) {
+	progressWatchedTrials.Lock()
+	allocation := progressWatchedTrials.byAllocation[allocationID]
+	progressWatchedTrials.Unlock()
+	if allocation != nil {
+		system.Tell(allocation, task.ProgressWatcherNoteProgress{At: time.Now()})
+	}
+}
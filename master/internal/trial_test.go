@@ -183,6 +183,14 @@ func TestTrialSimultaneousCancelAndAllocation(t *testing.T) {
 	require.True(t, model.TerminalStates[tr.state])
 }
 
+func TestTrialExpectedRuntime(t *testing.T) {
+	tr := &trial{config: expconf.ExperimentConfig{RawResources: &expconf.ResourcesConfig{}}}
+	require.Nil(t, tr.expectedRuntime())
+
+	tr.config.RawResources.RawExpectedRuntimeSeconds = ptrs.Ptr(90)
+	require.Equal(t, 90*time.Second, *tr.expectedRuntime())
+}
+
 func setup(t *testing.T) (*actor.System, *mocks.DB, model.RequestID, *trial, *actor.Ref) {
 	require.NoError(t, etc.SetRootPath("../static/srv"))
 	system := actor.NewSystem("system")
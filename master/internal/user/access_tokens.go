@@ -0,0 +1,191 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/internal/context"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// ErrServiceAccountLogin is returned when a service account attempts to log in.
+var ErrServiceAccountLogin = errors.New(
+	"service accounts cannot log in; authenticate with an access token instead")
+
+// accessToken is an access token as it's reported by the API. The token itself is only reported
+// when it's created.
+type accessToken struct {
+	ID          model.SessionID `json:"id"`
+	Description string          `json:"description"`
+	Expiry      time.Time       `json:"expiry"`
+	ReadOnly    bool            `json:"read_only"`
+	WorkspaceID *int            `json:"workspace_id,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	LastUsedAt  *time.Time      `json:"last_used_at,omitempty"`
+	Token       string          `json:"token,omitempty"`
+}
+
+func toAccessToken(session model.UserSession) accessToken {
+	return accessToken{
+		ID:          session.ID,
+		Description: session.Description.ValueOrZero(),
+		Expiry:      session.Expiry,
+		ReadOnly:    session.ReadOnly,
+		WorkspaceID: session.ScopeWorkspaceID,
+		CreatedAt:   session.CreatedAt,
+		LastUsedAt:  session.LastUsedAt,
+	}
+}
+
+// checkAccessTokenScope returns an error if the session is a limited access token that doesn't
+// permit the request. Workspace-limited tokens are only accepted by the gRPC API, which can tell
+// what workspace a request is about.
+func checkAccessTokenScope(c echo.Context, session *model.UserSession) error {
+	method := c.Request().Method
+	switch {
+	case !session.AccessToken:
+		return nil
+	case session.ScopeWorkspaceID != nil:
+		return echo.NewHTTPError(http.StatusForbidden,
+			"workspace-limited access tokens can only be used with the /api/v1 API")
+	case session.ReadOnly && method != http.MethodGet && method != http.MethodHead:
+		return echo.NewHTTPError(http.StatusForbidden, "access token is read-only")
+	}
+	return nil
+}
+
+// tokenOwner returns the user named in the path whose access tokens the current user manages.
+func tokenOwner(c echo.Context, username string) (*model.User, error) {
+	userNotFoundErr := echo.NewHTTPError(http.StatusNotFound,
+		fmt.Sprintf("failed to get user '%s'", username))
+	user, err := UserByUsername(username)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return nil, userNotFoundErr
+	case err != nil:
+		return nil, err
+	}
+
+	currUser := c.(*context.DetContext).MustGetUser()
+	if err := AuthZProvider.Get().CanManageUsersAccessTokens(currUser, *user); err != nil {
+		return nil, canViewUserErrorHandle(currUser, *user,
+			errors.Wrap(forbiddenError, err.Error()), userNotFoundErr)
+	}
+	return user, nil
+}
+
+func (s *Service) getAccessTokens(c echo.Context) (interface{}, error) {
+	args := struct {
+		Username string `path:"username"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	user, err := tokenOwner(c, args.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := AccessTokens(c.Request().Context(), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]accessToken, 0, len(sessions))
+	for _, session := range sessions {
+		tokens = append(tokens, toAccessToken(session))
+	}
+	return tokens, nil
+}
+
+func (s *Service) postAccessToken(c echo.Context) (interface{}, error) {
+	type request struct {
+		Description string     `json:"description"`
+		Expiry      *time.Time `json:"expiry"`
+		ReadOnly    bool       `json:"read_only"`
+		WorkspaceID *int       `json:"workspace_id"`
+	}
+
+	args := struct {
+		Username string `path:"username"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	var params request
+	if err := json.NewDecoder(c.Request().Body).Decode(&params); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	}
+
+	if s.extConfig.JwtKey != "" {
+		return nil, echo.NewHTTPError(http.StatusMisdirectedRequest,
+			"authentication is configured to be external")
+	}
+	if params.Description == "" {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "description is required")
+	}
+	expiry := time.Now().Add(DefaultAccessTokenLifespan)
+	if params.Expiry != nil {
+		if params.Expiry.Before(time.Now()) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "expiry must be in the future")
+		}
+		expiry = *params.Expiry
+	}
+
+	ctx := c.Request().Context()
+	if params.WorkspaceID != nil {
+		exists, err := db.Bun().NewSelect().Table("workspaces").
+			Where("id = ?", *params.WorkspaceID).Exists(ctx)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("workspace %d not found", *params.WorkspaceID))
+		}
+	}
+
+	user, err := tokenOwner(c, args.Username)
+	if err != nil {
+		return nil, err
+	}
+	token, session, err := StartAccessToken(ctx, user.ID, AccessTokenOptions{
+		Description:      params.Description,
+		Expiry:           expiry,
+		ReadOnly:         params.ReadOnly,
+		ScopeWorkspaceID: params.WorkspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := toAccessToken(*session)
+	out.Token = token
+	return out, nil
+}
+
+func (s *Service) deleteAccessToken(c echo.Context) (interface{}, error) {
+	args := struct {
+		Username string `path:"username"`
+		TokenID  int    `path:"token_id"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	user, err := tokenOwner(c, args.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = RevokeAccessToken(c.Request().Context(), user.ID, model.SessionID(args.TokenID))
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound,
+			fmt.Sprintf("access token %d not found", args.TokenID))
+	}
+	return nil, err
+}
@@ -10,6 +10,7 @@ import (
 func RegisterAPIHandler(echo *echo.Echo, m *Service, middleware ...echo.MiddlewareFunc) {
 	echo.POST("/logout", api.Route(m.postLogout), middleware...)
 	echo.POST("/login", api.Route(m.postLogin))
+	echo.GET("/login-failures", api.Route(m.getLoginFailures), middleware...)
 	usersGroup := echo.Group("/users", middleware...)
 	usersGroup.GET("", api.Route(m.getUsers))
 	usersGroup.POST("", api.Route(m.postUser))
@@ -17,4 +18,7 @@ func RegisterAPIHandler(echo *echo.Echo, m *Service, middleware ...echo.Middlewa
 	usersGroup.PATCH("/:username", api.Route(m.patchUser))
 	usersGroup.PATCH("/:username/username", api.Route(m.patchUsername))
 	usersGroup.GET("/:username/image", api.Route(m.getUserImage))
+	usersGroup.GET("/:username/tokens", api.Route(m.getAccessTokens))
+	usersGroup.POST("/:username/tokens", api.Route(m.postAccessToken))
+	usersGroup.DELETE("/:username/tokens/:token_id", api.Route(m.deleteAccessToken))
 }
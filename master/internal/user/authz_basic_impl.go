@@ -43,6 +43,14 @@ func (a *UserAuthZBasic) CanSetUsersPassword(curUser, targetUser model.User) err
 	return nil
 }
 
+// CanSetUsersPasswordChangeRequired returns an error if the user is not an admin.
+func (a *UserAuthZBasic) CanSetUsersPasswordChangeRequired(curUser, targetUser model.User) error {
+	if !curUser.Admin {
+		return fmt.Errorf("only admin privileged users can require password changes")
+	}
+	return nil
+}
+
 // CanSetUsersActive returns an error if the user is not an admin.
 func (a *UserAuthZBasic) CanSetUsersActive(curUser, targetUser model.User, toActiveVal bool) error {
 	if !curUser.Admin {
@@ -91,6 +99,16 @@ func (a *UserAuthZBasic) CanGetUsersImage(curUser, targetUser model.User) error
 	return nil
 }
 
+// CanManageUsersAccessTokens returns an error if the user is neither managing their own access
+// tokens nor an admin managing those of a service account.
+func (a *UserAuthZBasic) CanManageUsersAccessTokens(curUser, targetUser model.User) error {
+	if curUser.ID != targetUser.ID && !(curUser.Admin && targetUser.ServiceAccount) {
+		return fmt.Errorf("only admin privileged users can manage the access tokens of " +
+			"service accounts, and other users only their own")
+	}
+	return nil
+}
+
 // CanGetUsersOwnSettings always returns nil.
 func (a *UserAuthZBasic) CanGetUsersOwnSettings(curUser model.User) error {
 	return nil
@@ -34,6 +34,8 @@ type UserAuthZ interface {
 	// POST /api/v1/users/:user_id/password
 	CanSetUsersPassword(curUser, targetUser model.User) error
 	// PATCH /users/:username
+	CanSetUsersPasswordChangeRequired(curUser, targetUser model.User) error
+	// PATCH /users/:username
 	CanSetUsersActive(curUser, targetUser model.User, toActiveVal bool) error
 	// PATCH /users/:username
 	CanSetUsersAdmin(curUser, targetUser model.User, toAdminVal bool) error
@@ -49,6 +51,11 @@ type UserAuthZ interface {
 	// GET /users/:username/image
 	CanGetUsersImage(curUser, targetUsername model.User) error
 
+	// GET /users/:username/tokens
+	// POST /users/:username/tokens
+	// DELETE /users/:username/tokens/:token_id
+	CanManageUsersAccessTokens(curUser, targetUser model.User) error
+
 	// GET /api/v1/users/setting
 	CanGetUsersOwnSettings(curUser model.User) error
 	// POST /api/v1/users/setting/reset
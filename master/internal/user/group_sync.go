@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// Sources of groups synced from an identity provider.
+const (
+	GroupSourceExternalSession = "external_session"
+	GroupSourceOIDC            = "oidc"
+)
+
+// externalGroupSyncInterval is how often the groups of a user are synced with the claims of their
+// external session tokens when the groups named in them don't change.
+const externalGroupSyncInterval = 5 * time.Minute
+
+// GroupSyncFunc syncs the group memberships of a user with the groups named by their identity
+// provider.
+type GroupSyncFunc func(ctx context.Context, userID model.UserID, groups []string,
+	source string) error
+
+var (
+	groupSync GroupSyncFunc
+
+	externalGroupsMu     sync.Mutex
+	externalGroupsSynced = map[model.UserID]syncedGroups{}
+)
+
+type syncedGroups struct {
+	groups string
+	at     time.Time
+}
+
+// SetGroupSync sets how the groups named in the claims of external session tokens are synced.
+// The user group package can't be used here directly, since it depends on this one.
+func SetGroupSync(f GroupSyncFunc) {
+	groupSync = f
+}
+
+// GroupsFromClaim returns the group names in a claim of an identity token, which providers give
+// either as a list of names or as a single name.
+func GroupsFromClaim(claims map[string]interface{}, claim string) []string {
+	switch v := claims[claim].(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+		return groups
+	}
+	return nil
+}
+
+// syncExternalGroups syncs the groups of the user with the groups named by their external session
+// token, if they changed since the last sync or it's been a while.
+func syncExternalGroups(ctx context.Context, userID model.UserID, groups []string) {
+	if groupSync == nil {
+		return
+	}
+	sorted := append([]string{}, groups...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, "\n")
+
+	externalGroupsMu.Lock()
+	last, ok := externalGroupsSynced[userID]
+	externalGroupsMu.Unlock()
+	if ok && last.groups == key && time.Since(last.at) < externalGroupSyncInterval {
+		return
+	}
+
+	if err := groupSync(ctx, userID, groups, GroupSourceExternalSession); err != nil {
+		log.WithError(err).WithField("user-id", userID).Warn("failed to sync groups of user")
+		return
+	}
+	externalGroupsMu.Lock()
+	externalGroupsSynced[userID] = syncedGroups{groups: key, at: time.Now()}
+	externalGroupsMu.Unlock()
+}
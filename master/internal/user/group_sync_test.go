@@ -0,0 +1,38 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestGroupsFromClaim(t *testing.T) {
+	claims := map[string]interface{}{
+		"groups": []interface{}{"a", "b", 3},
+		"group":  "c",
+	}
+	require.Equal(t, []string{"a", "b"}, GroupsFromClaim(claims, "groups"))
+	require.Equal(t, []string{"c"}, GroupsFromClaim(claims, "group"))
+	require.Nil(t, GroupsFromClaim(claims, "missing"))
+}
+
+func TestSyncExternalGroups(t *testing.T) {
+	var synced [][]string
+	SetGroupSync(func(_ context.Context, _ model.UserID, groups []string, source string) error {
+		require.Equal(t, GroupSourceExternalSession, source)
+		synced = append(synced, groups)
+		return nil
+	})
+	t.Cleanup(func() { SetGroupSync(nil) })
+
+	ctx := context.Background()
+	syncExternalGroups(ctx, 1, []string{"a", "b"})
+	syncExternalGroups(ctx, 1, []string{"b", "a"})
+	require.Len(t, synced, 1, "unchanged groups should not be synced on every request")
+
+	syncExternalGroups(ctx, 1, []string{"a"})
+	require.Equal(t, [][]string{{"a", "b"}, {"a"}}, synced)
+}
@@ -0,0 +1,160 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/api"
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+const (
+	defaultLoginFailuresLimit = 100
+	maxLoginFailuresLimit     = 1000
+)
+
+var (
+	// ErrInvalidCredentials is returned when the password given to log in is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrUserInactive is returned when a deactivated user attempts to log in.
+	ErrUserInactive = errors.New("user not active")
+)
+
+// LockedOutError is returned when logging in is refused because of too many failed attempts.
+type LockedOutError struct {
+	Until time.Time
+}
+
+func (e LockedOutError) Error() string {
+	return fmt.Sprintf("too many failed login attempts; try again after %s",
+		e.Until.UTC().Format(time.RFC3339))
+}
+
+// LoginFailureReason is why an attempt to log in failed.
+type LoginFailureReason string
+
+const (
+	// LoginFailureUserNotFound is an attempt to log in as a user that doesn't exist.
+	LoginFailureUserNotFound LoginFailureReason = "USER_NOT_FOUND"
+	// LoginFailureInvalidCredentials is an attempt to log in with the wrong password.
+	LoginFailureInvalidCredentials LoginFailureReason = "INVALID_CREDENTIALS"
+	// LoginFailureLockedOut is an attempt to log in while locked out, which isn't checked.
+	LoginFailureLockedOut LoginFailureReason = "LOCKED_OUT"
+)
+
+// LoginFailure is a failed attempt to log in as it's stored in the database. Failures are cleared
+// once logging in as the same user from the same address succeeds, so that they no longer count
+// towards lockouts, but they're kept for auditing. Failures from other addresses still count
+// towards the user's lockout from any address.
+type LoginFailure struct {
+	bun.BaseModel `bun:"table:login_failures"`
+
+	ID         int64              `bun:"id,pk,autoincrement" json:"id"`
+	Username   string             `bun:"username,notnull"    json:"username"`
+	RemoteAddr string             `bun:"remote_addr,notnull" json:"remote_addr"`
+	Reason     LoginFailureReason `bun:"reason,notnull"      json:"reason"`
+	FailedAt   time.Time          `bun:"failed_at,notnull"   json:"failed_at"`
+	Cleared    bool               `bun:"cleared,notnull"     json:"cleared"`
+}
+
+// Authenticate checks the client-side hashed password of the user logging in from the address and
+// returns the user if they may start a session. Failed attempts are recorded, and once there are
+// too many of them for the user from the address, or for the user from any address, further
+// attempts are refused with a LockedOutError without checking the password.
+func Authenticate(
+	ctx context.Context, username, hashedPassword, remoteAddr string,
+) (*model.User, error) {
+	username = strings.ToLower(username)
+	lockout := config.GetMasterConfig().Security.LoginLockout
+
+	fail := func(reason LoginFailureReason, err error) (*model.User, error) {
+		if rErr := addLoginFailure(ctx, username, remoteAddr, reason); rErr != nil {
+			log.WithError(rErr).WithField("username", username).
+				Error("failed to record login failure")
+		}
+		log.WithFields(log.Fields{
+			"username": username, "remote-addr": remoteAddr, "reason": reason,
+		}).Info("login failed")
+		return nil, err
+	}
+
+	for _, check := range []struct {
+		remoteAddr        *string
+		maxFailedAttempts int
+	}{
+		{&remoteAddr, lockout.MaxFailedAttempts},
+		{nil, lockout.MaxFailedAttemptsPerUser},
+	} {
+		if check.maxFailedAttempts <= 0 {
+			continue
+		}
+		until, err := lockedOutUntil(ctx, username, check.remoteAddr, check.maxFailedAttempts,
+			lockout)
+		if err != nil {
+			return nil, err
+		}
+		if until != nil {
+			return fail(LoginFailureLockedOut, LockedOutError{Until: *until})
+		}
+	}
+
+	u, err := UserByUsername(username)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return fail(LoginFailureUserNotFound, db.ErrNotFound)
+	case err != nil:
+		return nil, err
+	}
+	if !u.ValidatePassword(hashedPassword) {
+		return fail(LoginFailureInvalidCredentials, ErrInvalidCredentials)
+	}
+	if !u.Active {
+		return nil, ErrUserInactive
+	}
+	if u.ServiceAccount {
+		return nil, ErrServiceAccountLogin
+	}
+
+	if err := clearLoginFailures(ctx, username, remoteAddr); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *Service) getLoginFailures(c echo.Context) (interface{}, error) {
+	args := struct {
+		Username *string `query:"username"`
+		Limit    *int    `query:"limit"`
+		Offset   *int    `query:"offset"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+
+	var username string
+	if args.Username != nil {
+		username = strings.ToLower(*args.Username)
+	}
+	limit, offset := defaultLoginFailuresLimit, 0
+	if args.Limit != nil {
+		limit = *args.Limit
+	}
+	if args.Offset != nil {
+		offset = *args.Offset
+	}
+	if limit <= 0 || limit > maxLoginFailuresLimit || offset < 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"limit must be between 1 and %d and offset must not be negative", maxLoginFailuresLimit))
+	}
+	return LoginFailures(c.Request().Context(), username, limit, offset)
+}
@@ -0,0 +1,121 @@
+//go:build integration
+// +build integration
+
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func addTestUser(t *testing.T, password string) *model.User {
+	u := &model.User{Username: uuid.New().String(), Active: true}
+	require.NoError(t, u.UpdatePasswordHash(password))
+	require.NoError(t, AddUserExec(u))
+	return u
+}
+
+func TestAuthenticateLockout(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+	security := &config.GetMasterConfig().Security
+	defer func(lockout config.LoginLockoutConfig) { security.LoginLockout = lockout }(
+		security.LoginLockout)
+	security.LoginLockout = config.LoginLockoutConfig{
+		MaxFailedAttempts: 2,
+		Window:            model.Duration(time.Minute),
+		Duration:          model.Duration(time.Minute),
+	}
+
+	u := addTestUser(t, "right")
+	_, err := Authenticate(ctx, u.Username, "right", "10.0.0.1")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = Authenticate(ctx, u.Username, "wrong", "10.0.0.1")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	_, err = Authenticate(ctx, u.Username, "right", "10.0.0.1")
+	require.ErrorAs(t, err, &LockedOutError{})
+
+	// Lockouts are per address, and logging in clears the failures that led to them.
+	_, err = Authenticate(ctx, u.Username, "right", "10.0.0.2")
+	require.NoError(t, err)
+	_, err = Authenticate(ctx, "nobody-"+u.Username, "right", "10.0.0.2")
+	require.ErrorIs(t, err, db.ErrNotFound)
+
+	failures, err := LoginFailures(ctx, u.Username, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, failures, 3)
+	require.Equal(t, LoginFailureLockedOut, failures[0].Reason)
+	require.Equal(t, LoginFailureInvalidCredentials, failures[1].Reason)
+
+	_, err = db.Bun().NewUpdate().Model((*LoginFailure)(nil)).
+		Set("failed_at = failed_at - interval '1 hour'").
+		Where("username = ?", u.Username).Exec(ctx)
+	require.NoError(t, err)
+	_, err = Authenticate(ctx, u.Username, "right", "10.0.0.1")
+	require.NoError(t, err)
+}
+
+func TestAuthenticateLockoutPerUser(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+	security := &config.GetMasterConfig().Security
+	defer func(lockout config.LoginLockoutConfig) { security.LoginLockout = lockout }(
+		security.LoginLockout)
+	security.LoginLockout = config.LoginLockoutConfig{
+		MaxFailedAttempts:        2,
+		MaxFailedAttemptsPerUser: 3,
+		Window:                   model.Duration(time.Minute),
+		Duration:                 model.Duration(time.Minute),
+	}
+
+	// Failures from different addresses count towards the lockout from any address.
+	u := addTestUser(t, "right")
+	for _, addr := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		_, err := Authenticate(ctx, u.Username, "wrong", addr)
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	_, err := Authenticate(ctx, u.Username, "right", "10.0.0.4")
+	require.ErrorAs(t, err, &LockedOutError{})
+
+	// Other users aren't affected.
+	_, err = Authenticate(ctx, addTestUser(t, "right").Username, "right", "10.0.0.1")
+	require.NoError(t, err)
+}
+
+func TestValidatePasswordChangeHistory(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+	security := &config.GetMasterConfig().Security
+	defer func(policy config.PasswordPolicyConfig) { security.PasswordPolicy = policy }(
+		security.PasswordPolicy)
+	security.PasswordPolicy = config.PasswordPolicyConfig{HistorySize: 2}
+
+	u := addTestUser(t, "first")
+	require.ErrorIs(t, ValidatePasswordChange(ctx, *u, nil, "first"), ErrPasswordPolicy)
+	require.NoError(t, ValidatePasswordChange(ctx, *u, nil, "second"))
+
+	require.NoError(t, u.UpdatePasswordHash("second"))
+	require.NoError(t, pgDB.UpdateUser(u, []string{"password_hash"}, nil))
+	require.ErrorIs(t, ValidatePasswordChange(ctx, *u, nil, "first"), ErrPasswordPolicy)
+
+	require.NoError(t, u.UpdatePasswordHash("third"))
+	require.NoError(t, pgDB.UpdateUser(u, []string{"password_hash"}, nil))
+	require.NoError(t, ValidatePasswordChange(ctx, *u, nil, "first"))
+	require.ErrorIs(t, ValidatePasswordChange(ctx, *u, nil, "second"), ErrPasswordPolicy)
+
+	security.PasswordPolicy.MinLength = 8
+	require.ErrorIs(t, ValidatePasswordChange(ctx, *u, nil, "fourth"), ErrPasswordPolicy)
+	require.NoError(t, ValidatePasswordChange(ctx, *u, ptrs.Ptr("fourth-password"), "fourth"))
+}
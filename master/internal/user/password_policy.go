@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+var (
+	// ErrPasswordPolicy is returned, wrapped with what's wrong, for passwords that don't satisfy
+	// the password policy.
+	ErrPasswordPolicy = errors.New("password does not satisfy the password policy")
+	// ErrPasswordChangeRequired is returned for calls made by users who must change their password
+	// before doing anything else.
+	ErrPasswordChangeRequired = errors.New(
+		"password change required; set a new password before continuing")
+)
+
+// CheckPasswordPolicy returns an error wrapping ErrPasswordPolicy if the plaintext password doesn't
+// satisfy the rules of the policy.
+func CheckPasswordPolicy(policy config.PasswordPolicyConfig, password string) error {
+	var problems []string
+	if n := len([]rune(password)); n < policy.MinLength {
+		problems = append(problems, fmt.Sprintf("be at least %d characters long", policy.MinLength))
+	}
+	for _, class := range []struct {
+		required bool
+		name     string
+		is       func(rune) bool
+	}{
+		{policy.RequireUppercase, "an uppercase letter", unicode.IsUpper},
+		{policy.RequireLowercase, "a lowercase letter", unicode.IsLower},
+		{policy.RequireDigit, "a digit", unicode.IsDigit},
+		{policy.RequireSymbol, "a symbol", isPasswordSymbol},
+	} {
+		if class.required && strings.IndexFunc(password, class.is) < 0 {
+			problems = append(problems, "contain "+class.name)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Wrapf(ErrPasswordPolicy, "password must %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func isPasswordSymbol(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// ValidatePasswordChange returns an error wrapping ErrPasswordPolicy if the user may not change
+// their password to the given one under the configured password policy. The plaintext password is
+// nil when only its client-side hash is known, in which case the rules of the policy can't be
+// checked; hashedPassword is what's stored, hashed again, as the user's password.
+func ValidatePasswordChange(
+	ctx context.Context, u model.User, plaintext *string, hashedPassword string,
+) error {
+	policy := config.GetMasterConfig().Security.PasswordPolicy
+	if policy.HasRules() {
+		if plaintext == nil {
+			return errors.Wrap(ErrPasswordPolicy,
+				"the password policy can only be checked for passwords set with SetUserPassword")
+		}
+		if err := CheckPasswordPolicy(policy, *plaintext); err != nil {
+			return err
+		}
+	}
+	if policy.HistorySize == 0 || hashedPassword == "" {
+		return nil
+	}
+
+	previous, err := passwordHistory(ctx, u.ID, policy.HistorySize-1)
+	if err != nil {
+		return err
+	}
+	if u.PasswordHash.Valid {
+		previous = append(previous, u.PasswordHash.String)
+	}
+	for _, hash := range previous {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(hashedPassword)) == nil {
+			return errors.Wrapf(ErrPasswordPolicy,
+				"password must not be one of the last %d passwords used", policy.HistorySize)
+		}
+	}
+	return nil
+}
+
+// NewUserPasswordChangeRequired returns whether users created without a password must set one
+// the first time they log in, which is the case when the password policy has rules.
+func NewUserPasswordChangeRequired() bool {
+	return config.GetMasterConfig().Security.PasswordPolicy.HasRules()
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/config"
+)
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	require.NoError(t, CheckPasswordPolicy(config.PasswordPolicyConfig{}, ""))
+
+	policy := config.PasswordPolicyConfig{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+	require.NoError(t, CheckPasswordPolicy(policy, "Passw0rd!"))
+	require.NoError(t, CheckPasswordPolicy(policy, "ÜBERsicher1€"))
+
+	for password, problem := range map[string]string{
+		"":          "be at least 8 characters long",
+		"Pa0!":      "be at least 8 characters long",
+		"passw0rd!": "contain an uppercase letter",
+		"PASSW0RD!": "contain a lowercase letter",
+		"Password!": "contain a digit",
+		"Passw0rd1": "contain a symbol",
+	} {
+		err := CheckPasswordPolicy(policy, password)
+		require.ErrorIs(t, err, ErrPasswordPolicy, password)
+		require.Contains(t, err.Error(), problem, password)
+	}
+}
@@ -0,0 +1,96 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/o1egl/paseto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v3"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+const (
+	// DefaultAccessTokenLifespan is how long access tokens last if no expiry is given.
+	DefaultAccessTokenLifespan = 30 * 24 * time.Hour
+	// accessTokenTouchInterval is how stale the last-used time of an access token may get before
+	// it's updated, so that tokens in heavy use don't cause a write on every request.
+	accessTokenTouchInterval = time.Minute
+)
+
+// AccessTokenOptions are the settings of a new access token.
+type AccessTokenOptions struct {
+	Description string
+	Expiry      time.Time
+	// ReadOnly tokens may only be used for requests that don't change anything.
+	ReadOnly bool
+	// ScopeWorkspaceID, if set, limits the token to requests about the workspace.
+	ScopeWorkspaceID *int
+}
+
+// StartAccessToken creates an access token for the user and returns it along with its session.
+func StartAccessToken(
+	ctx context.Context, userID model.UserID, opts AccessTokenOptions,
+) (string, *model.UserSession, error) {
+	session := &model.UserSession{
+		UserID:           userID,
+		Expiry:           opts.Expiry,
+		AccessToken:      true,
+		Description:      null.StringFrom(opts.Description),
+		ReadOnly:         opts.ReadOnly,
+		ScopeWorkspaceID: opts.ScopeWorkspaceID,
+		CreatedAt:        time.Now(),
+	}
+	if _, err := db.Bun().NewInsert().Model(session).
+		ExcludeColumn("id").Returning("id").Exec(ctx); err != nil {
+		return "", nil, errors.Wrap(err, "error creating access token")
+	}
+
+	token, err := paseto.NewV2().Sign(db.GetTokenKeys().PrivateKey, session, nil)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to generate access token")
+	}
+	return token, session, nil
+}
+
+// AccessTokens returns the access tokens of the user, including expired ones.
+func AccessTokens(ctx context.Context, userID model.UserID) ([]model.UserSession, error) {
+	var sessions []model.UserSession
+	if err := db.Bun().NewSelect().Model(&sessions).
+		Where("user_id = ?", userID).
+		Where("access_token").
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, errors.Wrapf(err, "error listing access tokens of user %d", userID)
+	}
+	return sessions, nil
+}
+
+// RevokeAccessToken deletes an access token of the user.
+func RevokeAccessToken(ctx context.Context, userID model.UserID, id model.SessionID) error {
+	return db.MustHaveAffectedRows(db.Bun().NewDelete().Table("user_sessions").
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("access_token").
+		Exec(ctx))
+}
+
+// touchAccessToken records that the access token was just used.
+func touchAccessToken(ctx context.Context, session *model.UserSession) {
+	now := time.Now()
+	if session.LastUsedAt != nil && now.Sub(*session.LastUsedAt) < accessTokenTouchInterval {
+		return
+	}
+	if _, err := db.Bun().NewUpdate().Table("user_sessions").
+		Set("last_used_at = ?", now).
+		Where("id = ?", session.ID).
+		Exec(ctx); err != nil {
+		log.WithError(err).WithField("user_sessions.id", session.ID).
+			Warn("failed to update last use of access token")
+		return
+	}
+	session.LastUsedAt = &now
+}
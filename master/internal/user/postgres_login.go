@@ -0,0 +1,102 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func addLoginFailure(
+	ctx context.Context, username, remoteAddr string, reason LoginFailureReason,
+) error {
+	_, err := db.Bun().NewInsert().Model(&LoginFailure{
+		Username:   username,
+		RemoteAddr: remoteAddr,
+		Reason:     reason,
+		FailedAt:   time.Now(),
+	}).Exec(ctx)
+	return err
+}
+
+// lockedOutUntil returns when the lockout of the user logging in from the address, or from any
+// address if remoteAddr is nil, ends, or nil if they aren't locked out after maxFailedAttempts.
+// Attempts refused because of a lockout don't extend it.
+func lockedOutUntil(
+	ctx context.Context, username string, remoteAddr *string, maxFailedAttempts int,
+	lockout config.LoginLockoutConfig,
+) (*time.Time, error) {
+	var failedAt []time.Time
+	q := db.Bun().NewSelect().Model((*LoginFailure)(nil)).
+		Column("failed_at").
+		Where("username = ?", username).
+		Where("NOT cleared").
+		Where("reason != ?", LoginFailureLockedOut).
+		Where("failed_at > ?", time.Now().Add(-time.Duration(lockout.Window))).
+		Order("failed_at DESC").
+		Limit(maxFailedAttempts)
+	if remoteAddr != nil {
+		q = q.Where("remote_addr = ?", *remoteAddr)
+	}
+	if err := q.Scan(ctx, &failedAt); err != nil {
+		return nil, errors.Wrap(err, "error checking login failures")
+	}
+	if len(failedAt) < maxFailedAttempts {
+		return nil, nil
+	}
+	until := failedAt[0].Add(time.Duration(lockout.Duration))
+	if !until.After(time.Now()) {
+		return nil, nil
+	}
+	return &until, nil
+}
+
+func clearLoginFailures(ctx context.Context, username, remoteAddr string) error {
+	_, err := db.Bun().NewUpdate().Model((*LoginFailure)(nil)).
+		Set("cleared = true").
+		Where("username = ?", username).
+		Where("remote_addr = ?", remoteAddr).
+		Where("NOT cleared").
+		Exec(ctx)
+	return errors.Wrap(err, "error clearing login failures")
+}
+
+// LoginFailures returns the failed attempts to log in, newest first, optionally only those of the
+// user with the given name.
+func LoginFailures(
+	ctx context.Context, username string, limit, offset int,
+) ([]LoginFailure, error) {
+	failures := []LoginFailure{}
+	q := db.Bun().NewSelect().Model(&failures)
+	if username != "" {
+		q = q.Where("username = ?", username)
+	}
+	if err := q.Order("failed_at DESC", "id DESC").Limit(limit).Offset(offset).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+// passwordHistory returns the hashes of the most recent passwords the user has replaced, newest
+// first.
+func passwordHistory(ctx context.Context, userID model.UserID, limit int) ([]string, error) {
+	var hashes []string
+	if limit <= 0 {
+		return hashes, nil
+	}
+	err := db.Bun().NewSelect().Table("user_password_history").
+		Column("password_hash").
+		Where("user_id = ?", userID).
+		Order("replaced_at DESC", "id DESC").
+		Limit(limit).
+		Scan(ctx, &hashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting password history")
+	}
+	return hashes, nil
+}
@@ -23,7 +23,8 @@ func UserByID(userID model.UserID) (*model.FullUser, error) {
 	var fu model.FullUser
 	query := `
 SELECT
-	u.id, u.username, u.display_name, u.admin, u.active,
+	u.id, u.username, u.display_name, u.admin, u.active, u.service_account,
+	u.password_change_required,
 	h.uid AS agent_uid, h.gid AS agent_gid, h.user_ AS agent_user, h.group_ AS agent_group
 FROM users u
 LEFT OUTER JOIN agent_user_groups h ON (u.id = h.user_id)
@@ -103,6 +104,15 @@ func UserByExternalToken(tokenText string,
 		}
 	}
 
+	if ext.GroupsClaim != "" {
+		// The token is verified above; this only reads the claims left out of externalToken.
+		all := jwt.MapClaims{}
+		if _, _, err := new(jwt.Parser).ParseUnverified(tokenText, all); err != nil {
+			return nil, nil, err
+		}
+		syncExternalGroups(context.TODO(), user.ID, GroupsFromClaim(all, ext.GroupsClaim))
+	}
+
 	session := &model.UserSession{
 		ID:     model.SessionID(user.ID),
 		UserID: user.ID,
@@ -136,6 +146,9 @@ func UserByToken(token string, ext *model.ExternalSessions) (
 	if session.Expiry.Before(time.Now()) {
 		return nil, nil, db.ErrNotFound
 	}
+	if session.AccessToken {
+		touchAccessToken(context.Background(), &session)
+	}
 
 	var user model.User
 	query := `
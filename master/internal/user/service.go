@@ -48,6 +48,7 @@ var unauthenticatedPointsList = []string{
 	"/agents",
 	"/det/.*",
 	"/login",
+	"/oidc/.*",
 	"/api/v1/.*",
 	"/proxy/:service/.*",
 	"/agents\\?id=.*",
@@ -57,6 +58,12 @@ var unauthenticatedPointsList = []string{
 var adminAuthPointsList = []string{
 	"/config",
 	"/agents/.*/slots/.*",
+	"/resource-pools/.*/simulate.*",
+	"/resource-pools/.*/quotas.*",
+	"/resource-pools/.*/reservations.*",
+	"/audit-events.*",
+	"/resources/storage.*",
+	"/login-failures.*",
 }
 
 var unauthenticatedPointsPattern = regexp.MustCompile("^" +
@@ -181,6 +188,12 @@ func (s *Service) ProcessAuthentication(next echo.HandlerFunc) echo.HandlerFunc
 			if adminOnly && !user.Admin {
 				return echo.NewHTTPError(http.StatusForbidden, "user not admin")
 			}
+			if err := checkAccessTokenScope(c, session); err != nil {
+				return err
+			}
+			if user.PasswordChangeRequired && !allowedWithPasswordChangeRequired(c, *user) {
+				return echo.NewHTTPError(http.StatusForbidden, ErrPasswordChangeRequired.Error())
+			}
 
 			// Set data on the request context that might be useful to
 			// event handlers.
@@ -203,7 +216,7 @@ func (s *Service) ProcessProxyAuthentication(c echo.Context) (done bool, err err
 		return true, redirectToLogin(c)
 	}
 
-	user, _, err := UserByToken(token, s.extConfig)
+	user, session, err := UserByToken(token, s.extConfig)
 	if errors.Is(err, db.ErrNotFound) {
 		return true, redirectToLogin(c)
 	} else if err != nil {
@@ -212,6 +225,10 @@ func (s *Service) ProcessProxyAuthentication(c echo.Context) (done bool, err err
 	if !user.Active {
 		return true, redirectToLogin(c)
 	}
+	if session.IsLimited() {
+		return true, echo.NewHTTPError(http.StatusForbidden,
+			"limited access tokens cannot be used with proxied services")
+	}
 
 	taskID := c.Param("service")
 	ownerID, err := db.GetCommandOwnerID(c.Request().Context(), model.TaskID(taskID))
@@ -234,6 +251,21 @@ func redirectToLogin(c echo.Context) error {
 	)
 }
 
+// allowedWithPasswordChangeRequired returns whether a user who must change their password may
+// make the request, which is only the case for requests that let them do so.
+func allowedWithPasswordChangeRequired(c echo.Context, user model.User) bool {
+	switch method, path := c.Request().Method, c.Path(); {
+	case method == http.MethodPost && path == "/logout":
+		return true
+	case method == http.MethodGet && path == "/users/me":
+		return true
+	case method == http.MethodPatch && path == "/users/:username":
+		return strings.EqualFold(c.Param("username"), user.Username)
+	default:
+		return false
+	}
+}
+
 func (s *Service) postLogout(c echo.Context) (interface{}, error) {
 	// Delete the cookie if one is set.
 	if cookie, err := c.Cookie("auth"); err == nil {
@@ -278,27 +310,23 @@ func (s *Service) postLogin(c echo.Context) (interface{}, error) {
 		return nil, echo.NewHTTPError(http.StatusBadRequest)
 	}
 
-	// Get the user from the database.
-	user, err := UserByUsername(params.Username)
-	switch err {
-	case nil:
-	case db.ErrNotFound:
+	user, err := Authenticate(c.Request().Context(), params.Username, params.Password,
+		c.RealIP())
+	var lockedOut LockedOutError
+	switch {
+	case err == nil:
+	case errors.Is(err, db.ErrNotFound):
 		return nil, echo.NewHTTPError(http.StatusForbidden, "user not found")
+	case errors.As(err, &lockedOut):
+		return nil, echo.NewHTTPError(http.StatusTooManyRequests, lockedOut.Error())
+	case errors.Is(err, ErrInvalidCredentials), errors.Is(err, ErrUserInactive),
+		errors.Is(err, ErrServiceAccountLogin):
+		return nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
 	default:
 		return nil, err
 	}
 
-	// The user must be active.
-	if !user.Active {
-		return nil, echo.NewHTTPError(http.StatusForbidden, "user not active")
-	}
-
-	var token string
-	if !user.ValidatePassword(params.Password) {
-		return nil, echo.NewHTTPError(http.StatusForbidden, "invalid credentials")
-	}
-
-	token, err = s.db.StartUserSession(user)
+	token, err := s.db.StartUserSession(user)
 	if err != nil {
 		return nil, err
 	}
@@ -355,6 +383,8 @@ func (s *Service) patchUser(c echo.Context) (interface{}, error) {
 			Active   *bool   `json:"active,omitempty"`
 			Admin    *bool   `json:"admin,omitempty"`
 
+			PasswordChangeRequired *bool `json:"password_change_required,omitempty"`
+
 			AgentUserGroup *agentUserGroup `json:"agent_user_group,omitempty"`
 		}
 		response struct {
@@ -399,10 +429,34 @@ func (s *Service) patchUser(c echo.Context) (interface{}, error) {
 				errors.Wrap(forbiddenError, err.Error()), userNotFoundErr)
 		}
 
+		// Passwords set through this endpoint are hashed by the client, so only the password
+		// history can be checked.
+		err = ValidatePasswordChange(c.Request().Context(), *user, nil, *params.Password)
+		if errors.Is(err, ErrPasswordPolicy) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		} else if err != nil {
+			return nil, err
+		}
+
 		if err = user.UpdatePasswordHash(*params.Password); err != nil {
 			return nil, err
 		}
 		toUpdate = append(toUpdate, "password_hash")
+		if currUser.ID == user.ID && params.PasswordChangeRequired == nil {
+			user.PasswordChangeRequired = false
+			toUpdate = append(toUpdate, "password_change_required")
+		}
+	}
+
+	if params.PasswordChangeRequired != nil {
+		err = AuthZProvider.Get().CanSetUsersPasswordChangeRequired(currUser, *user)
+		if err != nil {
+			return nil, canViewUserErrorHandle(currUser, *user,
+				errors.Wrap(forbiddenError, err.Error()), userNotFoundErr)
+		}
+
+		user.PasswordChangeRequired = *params.PasswordChangeRequired
+		toUpdate = append(toUpdate, "password_change_required")
 	}
 
 	if params.Active != nil {
@@ -512,9 +566,10 @@ func (s *Service) patchUsername(c echo.Context) (interface{}, error) {
 func (s *Service) postUser(c echo.Context) (interface{}, error) {
 	type (
 		request struct {
-			Username string `json:"username"`
-			Admin    bool   `json:"admin"`
-			Active   bool   `json:"active"`
+			Username       string `json:"username"`
+			Admin          bool   `json:"admin"`
+			Active         bool   `json:"active"`
+			ServiceAccount bool   `json:"service_account"`
 
 			AgentUserGroup *agentUserGroup `json:"agent_user_group,omitempty"`
 		}
@@ -545,10 +600,14 @@ func (s *Service) postUser(c echo.Context) (interface{}, error) {
 	params.Username = strings.ToLower(params.Username)
 
 	userToAdd := model.User{
-		Username: params.Username,
-		Admin:    params.Admin,
-		Active:   params.Active,
-	}
+		Username:       params.Username,
+		Admin:          params.Admin,
+		Active:         params.Active,
+		ServiceAccount: params.ServiceAccount,
+	}
+	// Users created here have no password, so they must set one that satisfies the password
+	// policy the first time they log in.
+	userToAdd.PasswordChangeRequired = !params.ServiceAccount && NewUserPasswordChangeRequired()
 	currUser := c.(*context.DetContext).MustGetUser()
 	if err = AuthZProvider.Get().CanCreateUser(currUser, userToAdd, ug); err != nil {
 		return nil, errors.Wrap(forbiddenError, err.Error())
@@ -4,11 +4,13 @@
 package user
 
 import (
+	stdcontext "context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -82,6 +84,11 @@ func TestAuthzPatchUser(t *testing.T) {
 		{"CanSetUsersPassword", []any{model.User{}, mock.Anything}, `{"password":"new"}`},
 		{"CanSetUsersActive", []any{model.User{}, mock.Anything, false}, `{"active":false}`},
 		{"CanSetUsersAdmin", []any{model.User{}, mock.Anything, true}, `{"admin":true}`},
+		{
+			"CanSetUsersPasswordChangeRequired",
+			[]any{model.User{}, mock.Anything},
+			`{"password_change_required":true}`,
+		},
 		{
 			"CanSetUsersAgentUserGroup",
 			[]any{
@@ -239,3 +246,49 @@ func TestAuthzGetUserImage(t *testing.T) {
 	_, err = svc.getUserImage(ctx)
 	require.Equal(t, db.ErrNotFound.Error(), err.Error())
 }
+
+func TestAccessTokens(t *testing.T) {
+	setup(t)
+	admin, err := UserByUsername("admin")
+	require.NoError(t, err)
+
+	token, session, err := StartAccessToken(stdcontext.Background(), admin.ID,
+		AccessTokenOptions{
+			Description: "ci",
+			Expiry:      time.Now().Add(time.Hour),
+			ReadOnly:    true,
+		})
+	require.NoError(t, err)
+
+	user, used, err := UserByToken(token, &model.ExternalSessions{})
+	require.NoError(t, err)
+	require.Equal(t, admin.ID, user.ID)
+	require.Equal(t, session.ID, used.ID)
+	require.True(t, used.IsLimited())
+	require.NotNil(t, used.LastUsedAt)
+
+	tokens, err := AccessTokens(stdcontext.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, "ci", tokens[0].Description.ValueOrZero())
+
+	require.NoError(t, RevokeAccessToken(stdcontext.Background(), admin.ID, session.ID))
+	require.ErrorIs(t,
+		RevokeAccessToken(stdcontext.Background(), admin.ID, session.ID), db.ErrNotFound)
+	_, _, err = UserByToken(token, &model.ExternalSessions{})
+	require.Error(t, err)
+}
+
+func TestAuthzGetAccessTokens(t *testing.T) {
+	svc, authzUser, ctx := setup(t)
+	ctx.SetParamNames("username")
+	ctx.SetParamValues("admin")
+
+	expectedErr := errors.Wrap(forbiddenError, "canManageUsersAccessTokensError")
+	authzUser.On("CanManageUsersAccessTokens", model.User{}, mock.Anything).
+		Return(fmt.Errorf("canManageUsersAccessTokensError")).Once()
+	authzUser.On("CanGetUser", model.User{}, mock.Anything).Return(true, nil).Once()
+
+	_, err := svc.getAccessTokens(ctx)
+	require.Equal(t, expectedErr.Error(), err.Error())
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
 )
 
 func TestStandardAuth(t *testing.T) {
@@ -39,6 +42,15 @@ func TestAdminAuth(t *testing.T) {
 
 	c.SetRequest(httptest.NewRequest(http.MethodPatch, "/agents/id/slots/1/enable", nil))
 	require.Equal(t, authAdmin, service.getAuthLevel(c))
+
+	c.SetRequest(httptest.NewRequest(http.MethodPost, "/resource-pools/default/simulate", nil))
+	require.Equal(t, authAdmin, service.getAuthLevel(c))
+
+	c.SetRequest(httptest.NewRequest(http.MethodDelete, "/resource-pools/default/quotas/1", nil))
+	require.Equal(t, authAdmin, service.getAuthLevel(c))
+
+	c.SetRequest(httptest.NewRequest(http.MethodPost, "/resource-pools/default/reservations", nil))
+	require.Equal(t, authAdmin, service.getAuthLevel(c))
 }
 
 func TestNoAuth(t *testing.T) {
@@ -57,3 +69,44 @@ func TestNoAuth(t *testing.T) {
 	c.SetRequest(httptest.NewRequest(http.MethodPatch, "/agents?id=1", nil))
 	require.Equal(t, authNone, service.getAuthLevel(c))
 }
+
+func TestAccessTokenScope(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(nil, nil)
+	c.SetRequest(httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	require.NoError(t, checkAccessTokenScope(c, &model.UserSession{}))
+	require.NoError(t, checkAccessTokenScope(c, &model.UserSession{AccessToken: true}))
+	require.Error(t, checkAccessTokenScope(c, &model.UserSession{AccessToken: true, ReadOnly: true}))
+	require.Error(t, checkAccessTokenScope(c, &model.UserSession{
+		AccessToken: true, ScopeWorkspaceID: ptrs.Ptr(1),
+	}))
+
+	c.SetRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+	require.NoError(t, checkAccessTokenScope(c, &model.UserSession{AccessToken: true, ReadOnly: true}))
+}
+
+func TestAllowedWithPasswordChangeRequired(t *testing.T) {
+	e := echo.New()
+	user := model.User{Username: "alice"}
+	for _, tc := range []struct {
+		method, path, username string
+		allowed                bool
+	}{
+		{http.MethodPost, "/logout", "", true},
+		{http.MethodGet, "/users/me", "", true},
+		{http.MethodPatch, "/users/:username", "Alice", true},
+		{http.MethodPatch, "/users/:username", "bob", false},
+		{http.MethodGet, "/users", "", false},
+		{http.MethodPost, "/users/:username/tokens", "alice", false},
+	} {
+		c := e.NewContext(httptest.NewRequest(tc.method, "/", nil), nil)
+		c.SetPath(tc.path)
+		if tc.username != "" {
+			c.SetParamNames("username")
+			c.SetParamValues(tc.username)
+		}
+		require.Equal(t, tc.allowed, allowedWithPasswordChangeRequired(c, user),
+			"%s %s", tc.method, tc.path)
+	}
+}
@@ -0,0 +1,171 @@
+package usergroup
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// SyncUserGroups makes the user a member of the groups named by their identity provider, creating
+// the groups that don't exist yet, and removes them from the groups a previous sync added them to
+// that are no longer named. Memberships made through the API are left alone. Every change is
+// recorded as a GroupSyncEvent from the given source.
+func SyncUserGroups(ctx context.Context, uid model.UserID, names []string, source string) error {
+	tx, err := db.Bun().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(db.MatchSentinelError(err),
+			"Error starting transaction for syncing groups of user %d", uid)
+	}
+	defer func() {
+		txErr := tx.Rollback()
+		if txErr != nil && txErr != sql.ErrTxDone {
+			logrus.WithError(txErr).Error("error rolling back transaction in SyncUserGroups")
+		}
+	}()
+
+	events, err := syncUserGroupsTx(ctx, tx, uid, names)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		for i := range events {
+			events[i].Source = source
+		}
+		if _, err := tx.NewInsert().Model(&events).Exec(ctx); err != nil {
+			return errors.Wrapf(db.MatchSentinelError(err),
+				"Error recording group sync of user %d", uid)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(db.MatchSentinelError(err),
+			"Error committing group sync of user %d", uid)
+	}
+	for _, e := range events {
+		logrus.WithFields(logrus.Fields{
+			"user-id": uid,
+			"group":   e.GroupName,
+			"source":  source,
+		}).Infof("group sync: %s", e.Action)
+	}
+	return nil
+}
+
+func syncUserGroupsTx(
+	ctx context.Context, tx bun.Tx, uid model.UserID, names []string,
+) ([]GroupSyncEvent, error) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name != "" {
+			wanted[name] = true
+		}
+	}
+	var events []GroupSyncEvent
+
+	var groups []Group
+	if len(wanted) > 0 {
+		missing := make([]Group, 0, len(wanted))
+		for name := range wanted {
+			missing = append(missing, Group{Name: name})
+		}
+		// Concurrent syncs may create the same group; whoever comes second joins the existing one.
+		var created []Group
+		if _, err := tx.NewInsert().Model(&missing).
+			On("CONFLICT (group_name) DO NOTHING").
+			Returning("*").
+			Exec(ctx, &created); err != nil {
+			return nil, errors.Wrapf(db.MatchSentinelError(err), "Error creating synced groups")
+		}
+		for _, g := range created {
+			events = append(events, GroupSyncEvent{
+				UserID: uid, GroupID: g.ID, GroupName: g.Name, Action: GroupSyncCreateGroup,
+			})
+		}
+
+		if err := tx.NewSelect().Model(&groups).
+			Where("group_name IN (?)", bun.In(keys(wanted))).
+			Scan(ctx); err != nil {
+			return nil, errors.Wrapf(db.MatchSentinelError(err), "Error getting synced groups")
+		}
+	}
+
+	var memberships []GroupMembership
+	if err := tx.NewSelect().Model(&memberships).
+		Where("user_id = ?", uid).
+		Scan(ctx); err != nil {
+		return nil, errors.Wrapf(db.MatchSentinelError(err),
+			"Error getting group memberships of user %d", uid)
+	}
+	member := make(map[int]GroupMembership, len(memberships))
+	for _, m := range memberships {
+		member[m.GroupID] = m
+	}
+
+	var add []GroupMembership
+	keep := make(map[int]bool, len(groups))
+	for _, g := range groups {
+		if g.OwnerID != 0 {
+			logrus.Warnf("not syncing user %d into personal group %s", uid, g.Name)
+			continue
+		}
+		keep[g.ID] = true
+		if _, ok := member[g.ID]; ok {
+			continue
+		}
+		add = append(add, GroupMembership{UserID: uid, GroupID: g.ID, Synced: true})
+		events = append(events, GroupSyncEvent{
+			UserID: uid, GroupID: g.ID, GroupName: g.Name, Action: GroupSyncAddMember,
+		})
+	}
+	if len(add) > 0 {
+		if _, err := tx.NewInsert().Model(&add).
+			On("CONFLICT (user_id, group_id) DO NOTHING").
+			Exec(ctx); err != nil {
+			return nil, errors.Wrapf(db.MatchSentinelError(err),
+				"Error adding user %d to synced groups", uid)
+		}
+	}
+
+	var remove []int
+	for _, m := range memberships {
+		if m.Synced && !keep[m.GroupID] {
+			remove = append(remove, m.GroupID)
+		}
+	}
+	if len(remove) > 0 {
+		var removed []Group
+		if err := tx.NewSelect().Model(&removed).
+			Where("id IN (?)", bun.In(remove)).
+			Scan(ctx); err != nil {
+			return nil, errors.Wrapf(db.MatchSentinelError(err), "Error getting synced groups")
+		}
+		if _, err := tx.NewDelete().Model((*GroupMembership)(nil)).
+			Where("user_id = ?", uid).
+			Where("group_id IN (?)", bun.In(remove)).
+			Exec(ctx); err != nil {
+			return nil, errors.Wrapf(db.MatchSentinelError(err),
+				"Error removing user %d from synced groups", uid)
+		}
+		for _, g := range removed {
+			events = append(events, GroupSyncEvent{
+				UserID: uid, GroupID: g.ID, GroupName: g.Name, Action: GroupSyncRemoveMember,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func keys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+//go:build integration
+// +build integration
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestSyncUserGroups(t *testing.T) {
+	ctx := context.Background()
+	pgDB := db.MustResolveTestPostgres(t)
+	db.MustMigrateTestPostgres(t, pgDB, pathToMigrations)
+
+	u := model.User{Username: fmt.Sprintf("group-sync-%d", testUser.ID), Active: true}
+	uid, err := pgDB.AddUser(&u, nil)
+	require.NoError(t, err)
+	manual, _, err := AddGroupWithMembers(ctx, Group{Name: "group-sync-manual"}, uid)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, deleteUser(ctx, uid))
+		_, err := db.Bun().NewDelete().Table("groups").
+			Where("group_name LIKE 'group-sync-%'").Exec(ctx)
+		require.NoError(t, err)
+	})
+
+	groupNames := func() []string {
+		groups, _, _, err := SearchGroupsWithoutPersonalGroups(ctx, "", uid, 0, 0)
+		require.NoError(t, err)
+		var names []string
+		for _, g := range groups {
+			names = append(names, g.Name)
+		}
+		return names
+	}
+	events := func() []GroupSyncAction {
+		var actions []GroupSyncAction
+		require.NoError(t, db.Bun().NewSelect().Table("group_sync_events").Column("action").
+			Where("user_id = ?", uid).Order("id").Scan(ctx, &actions))
+		return actions
+	}
+
+	// Missing groups are created and the user is added to them.
+	err = SyncUserGroups(ctx, uid,
+		[]string{"group-sync-a", "group-sync-b", "group-sync-manual"}, "test")
+	require.NoError(t, err)
+	require.ElementsMatch(t,
+		[]string{"group-sync-a", "group-sync-b", "group-sync-manual"}, groupNames())
+	require.ElementsMatch(t, []GroupSyncAction{
+		GroupSyncCreateGroup, GroupSyncCreateGroup, GroupSyncAddMember, GroupSyncAddMember,
+	}, events())
+
+	// Syncing the same groups again changes nothing.
+	require.NoError(t, SyncUserGroups(ctx, uid, []string{"group-sync-a", "group-sync-b"}, "test"))
+	require.Len(t, events(), 4)
+
+	// The user leaves the groups the provider no longer names, except those joined through the API.
+	require.NoError(t, SyncUserGroups(ctx, uid, []string{"group-sync-b"}, "test"))
+	require.ElementsMatch(t, []string{"group-sync-b", "group-sync-manual"}, groupNames())
+	require.Equal(t, GroupSyncRemoveMember, events()[4])
+
+	require.NoError(t, SyncUserGroups(ctx, uid, nil, "test"))
+	require.Equal(t, []string{manual.Name}, groupNames())
+}
@@ -1,6 +1,8 @@
 package usergroup
 
 import (
+	"time"
+
 	"github.com/uptrace/bun"
 
 	"github.com/determined-ai/determined/master/pkg/model"
@@ -42,4 +44,33 @@ type GroupMembership struct {
 
 	UserID  model.UserID `bun:"user_id,notnull"`
 	GroupID int          `bun:"group_id,notnull"`
+	// Synced is true if the membership was made by syncing the groups of the user with their
+	// identity provider, which may also remove it.
+	Synced bool `bun:"synced,notnull"`
+}
+
+// GroupSyncEvent records a change made by syncing the groups of a user with their identity
+// provider.
+type GroupSyncEvent struct {
+	bun.BaseModel `bun:"table:group_sync_events"`
+
+	ID        int             `bun:"id,pk,autoincrement"`
+	UserID    model.UserID    `bun:"user_id,notnull"`
+	GroupID   int             `bun:"group_id,nullzero"`
+	GroupName string          `bun:"group_name,notnull"`
+	Action    GroupSyncAction `bun:"action,notnull"`
+	Source    string          `bun:"source,notnull"`
+	CreatedAt time.Time       `bun:"created_at,notnull,default:current_timestamp"`
 }
+
+// GroupSyncAction is a kind of change made by syncing groups.
+type GroupSyncAction string
+
+const (
+	// GroupSyncCreateGroup creates a group the identity provider named that didn't exist yet.
+	GroupSyncCreateGroup GroupSyncAction = "CREATE_GROUP"
+	// GroupSyncAddMember adds the user to a group.
+	GroupSyncAddMember GroupSyncAction = "ADD_MEMBER"
+	// GroupSyncRemoveMember removes the user from a group the identity provider no longer names.
+	GroupSyncRemoveMember GroupSyncAction = "REMOVE_MEMBER"
+)
@@ -2,15 +2,24 @@ package webhooks
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
 
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/determined-ai/determined/master/internal/api"
+	detContext "github.com/determined-ai/determined/master/internal/context"
 	"github.com/determined-ai/determined/master/internal/grpcutil"
 
 	"github.com/determined-ai/determined/proto/pkg/apiv1"
 )
 
+const defaultDeliveriesLimit = 100
+
 // WebhooksAPIServer is an embedded api server struct.
 type WebhooksAPIServer struct{}
 
@@ -52,6 +61,11 @@ func (a *WebhooksAPIServer) PostWebhook(
 		return nil, err
 	}
 	w := WebhookFromProto(req.Webhook)
+	for _, t := range w.Triggers {
+		if err := validateTrigger(t); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 	if err := AddWebhook(ctx, &w); err != nil {
 		return nil, err
 	}
@@ -80,5 +94,49 @@ func (a *WebhooksAPIServer) TestWebhook(
 	if err != nil {
 		return nil, err
 	}
-	panic("unimplemented")
+	w, err := GetWebhook(ctx, WebhookID(req.Id))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, status.Errorf(codes.NotFound, "webhook %d not found", req.Id)
+	case err != nil:
+		return nil, err
+	}
+	completed, err := SendTestEvent(ctx, *w)
+	if err != nil {
+		log.WithError(err).Infof("test event for webhook %d was not delivered", w.ID)
+	}
+	return &apiv1.TestWebhookResponse{Completed: completed}, nil
+}
+
+// RegisterAPIHandler registers the REST handlers for webhook deliveries.
+func RegisterAPIHandler(echo *echo.Echo) {
+	echo.GET("/webhooks/:webhook_id/deliveries", api.Route(getDeliveries))
+}
+
+func getDeliveries(c echo.Context) (interface{}, error) {
+	args := struct {
+		WebhookID int  `path:"webhook_id"`
+		Limit     *int `query:"limit"`
+		Offset    *int `query:"offset"`
+	}{}
+	if err := api.BindArgs(&args, c); err != nil {
+		return nil, err
+	}
+	curUser := c.(*detContext.DetContext).MustGetUser()
+	if err := AuthZProvider.Get().CanEditWebhooks(&curUser); err != nil {
+		return nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	limit, offset := defaultDeliveriesLimit, 0
+	if args.Limit != nil {
+		limit = *args.Limit
+	}
+	if args.Offset != nil {
+		offset = *args.Offset
+	}
+	if limit <= 0 || offset < 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest,
+			"limit must be positive and offset must not be negative")
+	}
+	return GetDeliveries(c.Request().Context(), WebhookID(args.WebhookID), limit, offset)
 }
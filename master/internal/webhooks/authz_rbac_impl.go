@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/internal/rbac"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+)
+
+// WebhookAuthZRBAC is role-based access control for webhooks.
+type WebhookAuthZRBAC struct{}
+
+// CanEditWebhooks requires the permission to edit webhooks.
+func (a *WebhookAuthZRBAC) CanEditWebhooks(
+	curUser *model.User,
+) (serverError error) {
+	return rbac.CheckForPermission(context.TODO(), *curUser, rbac.GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_EDIT_WEBHOOKS)
+}
+
+func init() {
+	AuthZProvider.Register("rbac", &WebhookAuthZRBAC{})
+}
@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// Comparator is how a reported metric is compared against a threshold.
+type Comparator string
+
+const (
+	// ComparatorGreaterThan fires when the metric is strictly greater than the threshold.
+	ComparatorGreaterThan Comparator = ">"
+	// ComparatorGreaterThanOrEqual fires when the metric is greater than or equal to the threshold.
+	ComparatorGreaterThanOrEqual Comparator = ">="
+	// ComparatorLessThan fires when the metric is strictly less than the threshold.
+	ComparatorLessThan Comparator = "<"
+	// ComparatorLessThanOrEqual fires when the metric is less than or equal to the threshold.
+	ComparatorLessThanOrEqual Comparator = "<="
+)
+
+// MetricType is the kind of metrics report a metric threshold is evaluated against.
+type MetricType string
+
+const (
+	// MetricTypeTraining evaluates the threshold against training metrics.
+	MetricTypeTraining MetricType = "training"
+	// MetricTypeValidation evaluates the threshold against validation metrics.
+	MetricTypeValidation MetricType = "validation"
+)
+
+// MetricThresholdCondition is the condition of a METRIC_THRESHOLD_EXCEEDED trigger. At most one of
+// ExperimentID, ProjectID and WorkspaceID may be set to limit which trials are evaluated.
+type MetricThresholdCondition struct {
+	MetricName   string     `json:"metric_name"`
+	Comparator   Comparator `json:"comparator"`
+	Threshold    float64    `json:"threshold"`
+	MetricType   MetricType `json:"metric_type"`
+	ExperimentID *int       `json:"experiment_id,omitempty"`
+	ProjectID    *int       `json:"project_id,omitempty"`
+	WorkspaceID  *int       `json:"workspace_id,omitempty"`
+}
+
+// ParseMetricThresholdCondition parses and validates the condition of a METRIC_THRESHOLD_EXCEEDED
+// trigger.
+func ParseMetricThresholdCondition(raw map[string]interface{}) (*MetricThresholdCondition, error) {
+	bs, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var c MetricThresholdCondition
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, errors.Wrap(err, "invalid metric threshold condition")
+	}
+	if c.MetricType == "" {
+		c.MetricType = MetricTypeValidation
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c MetricThresholdCondition) validate() error {
+	if c.MetricName == "" {
+		return errors.New("metric threshold condition requires a metric_name")
+	}
+	switch c.Comparator {
+	case ComparatorGreaterThan, ComparatorGreaterThanOrEqual,
+		ComparatorLessThan, ComparatorLessThanOrEqual:
+	default:
+		return fmt.Errorf("invalid comparator %q, must be one of >, >=, <, <=", c.Comparator)
+	}
+	switch c.MetricType {
+	case MetricTypeTraining, MetricTypeValidation:
+	default:
+		return fmt.Errorf("invalid metric_type %q, must be training or validation", c.MetricType)
+	}
+	scopes := 0
+	for _, id := range []*int{c.ExperimentID, c.ProjectID, c.WorkspaceID} {
+		if id != nil {
+			scopes++
+		}
+	}
+	if scopes > 1 {
+		return errors.New(
+			"metric threshold condition may set at most one of experiment_id, project_id " +
+				"and workspace_id")
+	}
+	return nil
+}
+
+// exceeded reports whether value crosses the condition's threshold.
+func (c MetricThresholdCondition) exceeded(value float64) bool {
+	switch c.Comparator {
+	case ComparatorGreaterThan:
+		return value > c.Threshold
+	case ComparatorGreaterThanOrEqual:
+		return value >= c.Threshold
+	case ComparatorLessThan:
+		return value < c.Threshold
+	case ComparatorLessThanOrEqual:
+		return value <= c.Threshold
+	default:
+		return false
+	}
+}
+
+// inScope reports whether a trial of the given experiment is covered by the condition.
+func (c MetricThresholdCondition) inScope(e trialScope) bool {
+	switch {
+	case c.ExperimentID != nil:
+		return *c.ExperimentID == e.ExperimentID
+	case c.ProjectID != nil:
+		return *c.ProjectID == e.ProjectID
+	case c.WorkspaceID != nil:
+		return *c.WorkspaceID == e.WorkspaceID
+	default:
+		return true
+	}
+}
+
+// validateTrigger checks that a trigger's condition is well-formed for its type.
+func validateTrigger(t *Trigger) error {
+	switch t.TriggerType {
+	case TriggerTypeStateChange:
+		state, ok := t.Condition["state"].(string)
+		if !ok {
+			return errors.New("experiment state change condition requires a state")
+		}
+		if _, ok := model.ExperimentTransitions[model.State(state)]; !ok {
+			return fmt.Errorf("invalid experiment state %q", state)
+		}
+		return nil
+	case TriggerTypeMetricThresholdExceeded:
+		_, err := ParseMetricThresholdCondition(t.Condition)
+		return err
+	default:
+		return fmt.Errorf("unknown trigger type %q", t.TriggerType)
+	}
+}
@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetricThresholdCondition(t *testing.T) {
+	c, err := ParseMetricThresholdCondition(map[string]interface{}{
+		"metric_name": "validation_loss",
+		"comparator":  "<",
+		"threshold":   0.1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MetricTypeValidation, c.MetricType)
+	require.True(t, c.exceeded(0.05))
+	require.False(t, c.exceeded(0.1))
+
+	for name, raw := range map[string]map[string]interface{}{
+		"missing metric": {"comparator": ">", "threshold": 1},
+		"bad comparator": {"metric_name": "loss", "comparator": "==", "threshold": 1},
+		"bad type": {
+			"metric_name": "loss", "comparator": ">", "threshold": 1, "metric_type": "test",
+		},
+		"unknown field": {"metric_name": "loss", "comparator": ">", "metricValue": 1},
+		"two scopes": {
+			"metric_name": "loss", "comparator": ">", "threshold": 1,
+			"experiment_id": 1, "project_id": 2,
+		},
+	} {
+		_, err := ParseMetricThresholdCondition(raw)
+		require.Error(t, err, name)
+	}
+}
+
+func TestMetricThresholdConditionInScope(t *testing.T) {
+	scope := trialScope{ExperimentID: 1, ProjectID: 2, WorkspaceID: 3}
+	one, two, three := 1, 2, 3
+
+	require.True(t, MetricThresholdCondition{}.inScope(scope))
+	require.True(t, MetricThresholdCondition{ExperimentID: &one}.inScope(scope))
+	require.False(t, MetricThresholdCondition{ExperimentID: &two}.inScope(scope))
+	require.True(t, MetricThresholdCondition{ProjectID: &two}.inScope(scope))
+	require.True(t, MetricThresholdCondition{WorkspaceID: &three}.inScope(scope))
+	require.False(t, MetricThresholdCondition{WorkspaceID: &one}.inScope(scope))
+}
+
+func TestValidateTrigger(t *testing.T) {
+	require.NoError(t, validateTrigger(&Trigger{
+		TriggerType: TriggerTypeStateChange,
+		Condition:   map[string]interface{}{"state": "COMPLETED"},
+	}))
+	require.Error(t, validateTrigger(&Trigger{
+		TriggerType: TriggerTypeStateChange,
+		Condition:   map[string]interface{}{"state": "DONE"},
+	}))
+	require.Error(t, validateTrigger(&Trigger{
+		TriggerType: TriggerTypeMetricThresholdExceeded,
+		Condition:   map[string]interface{}{"metric_name": "loss"},
+	}))
+	// Conditions in the legacy untyped shape are rejected rather than stored.
+	require.Error(t, validateTrigger(&Trigger{
+		TriggerType: TriggerTypeMetricThresholdExceeded,
+		Condition:   map[string]interface{}{"metricName": "loss", "metricValue": 0.5},
+	}))
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Deliveries is a slice of Delivery objects.
+type Deliveries []Delivery
+
+// Delivery corresponds to a row in the "webhook_deliveries" DB table. Each row records a single
+// attempt to deliver an event to a webhook.
+type Delivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries"`
+
+	ID          DeliveryID  `bun:"id,pk,autoincrement" json:"id"`
+	WebhookID   WebhookID   `bun:"webhook_id,notnull" json:"webhook_id"`
+	TriggerID   *TriggerID  `bun:"trigger_id" json:"trigger_id"`
+	EventID     uuid.UUID   `bun:"event_id,type:uuid,notnull" json:"event_id"`
+	EventType   TriggerType `bun:"event_type,notnull" json:"event_type"`
+	Attempt     int         `bun:"attempt,notnull" json:"attempt"`
+	Payload     []byte      `bun:"payload,type:jsonb,notnull" json:"-"`
+	StatusCode  *int        `bun:"status_code" json:"status_code"`
+	Error       *string     `bun:"error" json:"error"`
+	Delivered   bool        `bun:"delivered,notnull" json:"delivered"`
+	AttemptedAt time.Time   `bun:"attempted_at,notnull" json:"attempted_at"`
+}
+
+// DeliveryID is the type for Delivery IDs.
+type DeliveryID int
@@ -0,0 +1,176 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+// invalidTriggers holds the IDs of metric threshold triggers whose conditions can't be parsed, so
+// that each is only warned about once rather than on every metrics report.
+var invalidTriggers sync.Map
+
+// ReportExperimentStateChanged schedules deliveries for every EXPERIMENT_STATE_CHANGE trigger
+// whose condition matches the experiment's new state.
+func ReportExperimentStateChanged(ctx context.Context, e model.Experiment) {
+	triggers, err := getTriggersByType(ctx, TriggerTypeStateChange)
+	if err != nil {
+		log.WithError(err).Errorf("failed to look up webhooks for experiment %d", e.ID)
+		return
+	}
+
+	var exp *ExperimentPayload
+	for _, t := range triggers {
+		if t.Condition["state"] != string(e.State) {
+			continue
+		}
+		if exp == nil {
+			if exp, err = resolveExperimentPayload(ctx, e); err != nil {
+				log.WithError(err).Errorf("failed to build webhook payload for experiment %d", e.ID)
+				return
+			}
+		}
+		p := newEventPayload(TriggerTypeStateChange)
+		p.Condition = t.Condition
+		p.Data.Experiment = exp
+		enqueue(outgoing{webhook: *t.Webhook, triggerID: ptrs.Ptr(t.ID), payload: p})
+	}
+}
+
+// ReportTrialMetrics schedules deliveries for every METRIC_THRESHOLD_EXCEEDED trigger whose
+// metric crosses its threshold in a trial's reported metrics. Each trigger fires at most once per
+// trial.
+func ReportTrialMetrics(
+	ctx context.Context, trialID, stepsCompleted int, metricType MetricType,
+	metrics map[string]interface{},
+) {
+	triggers, err := getTriggersByType(ctx, TriggerTypeMetricThresholdExceeded)
+	if err != nil {
+		log.WithError(err).Errorf("failed to look up webhooks for trial %d", trialID)
+		return
+	}
+	if len(triggers) == 0 {
+		return
+	}
+
+	scope, err := getTrialScope(ctx, trialID)
+	if err != nil {
+		log.WithError(err).Errorf("failed to look up experiment for trial %d", trialID)
+		return
+	}
+
+	var exp *ExperimentPayload
+	for _, t := range triggers {
+		c, pErr := ParseMetricThresholdCondition(t.Condition)
+		if pErr != nil {
+			if _, warned := invalidTriggers.LoadOrStore(t.ID, true); !warned {
+				log.WithError(pErr).Warnf("skipping trigger %d with invalid condition", t.ID)
+			}
+			continue
+		}
+		if c.MetricType != metricType || !c.inScope(*scope) {
+			continue
+		}
+		value, ok := metrics[c.MetricName].(float64)
+		if !ok || !c.exceeded(value) {
+			continue
+		}
+
+		switch first, mErr := markTriggerFired(ctx, t.ID, trialID); {
+		case mErr != nil:
+			log.WithError(mErr).Errorf("failed to record trigger %d for trial %d", t.ID, trialID)
+			continue
+		case !first:
+			continue
+		}
+
+		if exp == nil {
+			if exp, err = resolveExperimentPayload(ctx, scope.experiment); err != nil {
+				log.WithError(err).Errorf("failed to build webhook payload for trial %d", trialID)
+				return
+			}
+		}
+		p := newEventPayload(TriggerTypeMetricThresholdExceeded)
+		p.Condition = t.Condition
+		p.Data.Experiment = exp
+		p.Data.Metric = &MetricPayload{
+			TrialID:        trialID,
+			StepsCompleted: stepsCompleted,
+			Type:           metricType,
+			Name:           c.MetricName,
+			Value:          value,
+			Comparator:     c.Comparator,
+			Threshold:      c.Threshold,
+		}
+		enqueue(outgoing{webhook: *t.Webhook, triggerID: ptrs.Ptr(t.ID), payload: p})
+	}
+}
+
+// SendTestEvent makes a single, synchronous attempt to deliver a mock event to a webhook.
+func SendTestEvent(ctx context.Context, w Webhook) (bool, error) {
+	s := getShipper()
+	if s == nil {
+		return false, fmt.Errorf("webhook shipper is not initialized")
+	}
+	p := newEventPayload(TriggerTypeStateChange)
+	p.Condition = map[string]interface{}{"state": model.CompletedState}
+	p.Data.TestData = ptrs.Ptr("test")
+	return s.deliver(ctx, outgoing{webhook: w, payload: p}, 1)
+}
+
+func resolveExperimentPayload(ctx context.Context, e model.Experiment) (*ExperimentPayload, error) {
+	p := experimentPayload(e)
+	if err := db.Bun().NewSelect().
+		Table("projects").
+		ColumnExpr("projects.name AS project_name").
+		ColumnExpr("workspaces.id AS workspace_id").
+		ColumnExpr("workspaces.name AS workspace_name").
+		Join("JOIN workspaces ON workspaces.id = projects.workspace_id").
+		Where("projects.id = ?", e.ProjectID).
+		Scan(ctx, &p.ProjectName, &p.WorkspaceID, &p.WorkspaceName); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// trialScope identifies where a trial lives, for scoping metric threshold conditions.
+type trialScope struct {
+	ExperimentID int
+	ProjectID    int
+	WorkspaceID  int
+
+	experiment model.Experiment
+}
+
+func getTrialScope(ctx context.Context, trialID int) (*trialScope, error) {
+	var exp model.Experiment
+	if err := db.Bun().NewSelect().
+		Table("experiments").
+		Column("id", "state", "config", "start_time", "end_time", "project_id").
+		Where("id = (SELECT experiment_id FROM trials WHERE id = ?)", trialID).
+		Scan(ctx, &exp); err != nil {
+		return nil, err
+	}
+
+	var workspaceID int
+	if err := db.Bun().NewSelect().
+		Table("projects").
+		Column("workspace_id").
+		Where("id = ?", exp.ProjectID).
+		Scan(ctx, &workspaceID); err != nil {
+		return nil, err
+	}
+
+	return &trialScope{
+		ExperimentID: exp.ID,
+		ProjectID:    exp.ProjectID,
+		WorkspaceID:  workspaceID,
+		experiment:   exp,
+	}, nil
+}
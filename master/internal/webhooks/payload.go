@@ -0,0 +1,158 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// EventPayload is the body delivered to DEFAULT webhooks.
+type EventPayload struct {
+	ID        uuid.UUID              `json:"event_id"`
+	Type      TriggerType            `json:"event_type"`
+	Timestamp int64                  `json:"timestamp"`
+	Condition map[string]interface{} `json:"condition"`
+	Data      EventData              `json:"event_data"`
+}
+
+// EventData is the event-specific part of an EventPayload; its shape depends on the event type.
+type EventData struct {
+	TestData   *string            `json:"data,omitempty"`
+	Experiment *ExperimentPayload `json:"experiment,omitempty"`
+	Metric     *MetricPayload     `json:"metric,omitempty"`
+}
+
+// ExperimentPayload describes the experiment that caused an event.
+type ExperimentPayload struct {
+	ID            int         `json:"id"`
+	State         model.State `json:"state"`
+	Name          string      `json:"name"`
+	Duration      int         `json:"duration"`
+	ResourcePool  string      `json:"resource_pool"`
+	SlotsPerTrial int         `json:"slots"`
+	WorkspaceName string      `json:"workspace"`
+	ProjectName   string      `json:"project"`
+
+	// These are used to build hyperlinks in Slack messages but are not part of the payload.
+	WorkspaceID int `json:"-"`
+	ProjectID   int `json:"-"`
+}
+
+// MetricPayload describes a reported metric that caused an event.
+type MetricPayload struct {
+	TrialID        int        `json:"trial_id"`
+	StepsCompleted int        `json:"steps_completed"`
+	Type           MetricType `json:"type"`
+	Name           string     `json:"name"`
+	Value          float64    `json:"value"`
+	Comparator     Comparator `json:"comparator"`
+	Threshold      float64    `json:"threshold"`
+}
+
+// experimentPayload builds an ExperimentPayload; the workspace and project are filled in by the
+// caller since they require a DB lookup.
+func experimentPayload(e model.Experiment) *ExperimentPayload {
+	end := time.Now()
+	if e.EndTime != nil {
+		end = *e.EndTime
+	}
+	return &ExperimentPayload{
+		ID:            e.ID,
+		State:         e.State,
+		Name:          e.Config.Name().String(),
+		Duration:      int(end.Sub(e.StartTime) / time.Second),
+		ResourcePool:  e.Config.Resources().ResourcePool(),
+		SlotsPerTrial: e.Config.Resources().SlotsPerTrial(),
+		ProjectID:     e.ProjectID,
+	}
+}
+
+// SlackMessage is the body delivered to SLACK webhooks.
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackBlock is a layout block of a SlackMessage.
+type SlackBlock struct {
+	Type string     `json:"type"`
+	Text *SlackText `json:"text,omitempty"`
+}
+
+// SlackText is a text object within a SlackBlock.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func slackSection(text string) SlackBlock {
+	return SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: text}}
+}
+
+// slackLink formats text as a link to a WebUI path, or as plain text if there is no base URL.
+func slackLink(baseURL, path, text string) string {
+	if baseURL == "" {
+		return text
+	}
+	return fmt.Sprintf("<%s/det/%s|%s>", strings.TrimSuffix(baseURL, "/"), path, text)
+}
+
+// slackMessage renders a Slack message summarizing an event.
+func slackMessage(p EventPayload, baseURL string) SlackMessage {
+	if p.Data.TestData != nil {
+		return SlackMessage{
+			Text:   *p.Data.TestData,
+			Blocks: []SlackBlock{slackSection(*p.Data.TestData)},
+		}
+	}
+
+	e := p.Data.Experiment
+	if e == nil {
+		text := fmt.Sprintf("Determined event %s", p.Type)
+		return SlackMessage{Text: text, Blocks: []SlackBlock{slackSection(text)}}
+	}
+
+	expLink := slackLink(baseURL, fmt.Sprintf("experiments/%d", e.ID),
+		fmt.Sprintf("Experiment %d (%s)", e.ID, e.Name))
+	var summary string
+	if m := p.Data.Metric; m != nil {
+		summary = fmt.Sprintf("%s: %s metric `%s` of trial %d is %v (threshold %s %v)",
+			expLink, m.Type, m.Name, m.TrialID, m.Value, m.Comparator, m.Threshold)
+	} else {
+		summary = fmt.Sprintf("%s is %s", expLink, e.State)
+	}
+
+	details := []string{
+		fmt.Sprintf("*Duration:* %s", time.Duration(e.Duration)*time.Second),
+		fmt.Sprintf("*Resource pool:* %s", e.ResourcePool),
+		fmt.Sprintf("*Slots:* %d", e.SlotsPerTrial),
+	}
+	if e.WorkspaceName != "" {
+		details = append(details, fmt.Sprintf("*Workspace:* %s",
+			slackLink(baseURL, fmt.Sprintf("workspaces/%d", e.WorkspaceID), e.WorkspaceName)))
+	}
+	if e.ProjectName != "" {
+		details = append(details, fmt.Sprintf("*Project:* %s",
+			slackLink(baseURL, fmt.Sprintf("projects/%d", e.ProjectID), e.ProjectName)))
+	}
+
+	return SlackMessage{
+		Text:   summary,
+		Blocks: []SlackBlock{slackSection(summary), slackSection(strings.Join(details, "\n"))},
+	}
+}
+
+// renderPayload returns the body that should be delivered to a webhook of the given type.
+func renderPayload(t WebhookType, p EventPayload, baseURL string) ([]byte, error) {
+	switch t {
+	case WebhookTypeSlack:
+		return json.Marshal(slackMessage(p, baseURL))
+	default:
+		return json.Marshal(p)
+	}
+}
@@ -2,6 +2,8 @@ package webhooks
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 
 	"github.com/uptrace/bun"
 
@@ -50,3 +52,104 @@ func DeleteWebhook(ctx context.Context, id WebhookID) error {
 	}
 	return nil
 }
+
+// AddDelivery records a delivery attempt in the DB.
+func AddDelivery(ctx context.Context, d *Delivery) error {
+	_, err := db.Bun().NewInsert().Model(d).Exec(ctx)
+	return err
+}
+
+// GetDeliveries returns the most recent delivery attempts for a webhook, newest first.
+func GetDeliveries(
+	ctx context.Context, id WebhookID, limit, offset int,
+) (Deliveries, error) {
+	deliveries := Deliveries{}
+	err := db.Bun().NewSelect().
+		Model(&deliveries).
+		Where("webhook_id = ?", id).
+		Order("attempted_at DESC", "id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetWebhook returns a single Webhook and its Triggers from the DB.
+func GetWebhook(ctx context.Context, id WebhookID) (*Webhook, error) {
+	var w Webhook
+	err := db.Bun().NewSelect().
+		Model(&w).
+		Relation("Triggers").
+		Where("webhook.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// getTriggersByType returns all Triggers of the given type, along with their Webhooks.
+func getTriggersByType(ctx context.Context, t TriggerType) (Triggers, error) {
+	triggers := Triggers{}
+	err := db.Bun().NewSelect().
+		Model(&triggers).
+		Relation("Webhook").
+		Where("trigger_type = ?", t).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
+// metricThresholdFiring records that a metric threshold trigger has fired for a trial.
+type metricThresholdFiring struct {
+	bun.BaseModel `bun:"table:webhook_metric_threshold_firings"`
+
+	TriggerID TriggerID `bun:"trigger_id,pk"`
+	TrialID   int       `bun:"trial_id,pk"`
+}
+
+// markTriggerFired records that a trigger fired for a trial, returning false if it already had.
+func markTriggerFired(ctx context.Context, triggerID TriggerID, trialID int) (bool, error) {
+	res, err := db.Bun().NewInsert().
+		Model(&metricThresholdFiring{TriggerID: triggerID, TrialID: trialID}).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// GetOrCreateSigningKey returns the webhook signing key stored in the DB, generating and storing
+// a random one if none exists yet.
+func GetOrCreateSigningKey(ctx context.Context) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	if _, err := db.Bun().NewUpdate().
+		Table("cluster_id").
+		Set("webhook_signing_key = ?", hex.EncodeToString(b)).
+		Where("webhook_signing_key IS NULL").
+		Exec(ctx); err != nil {
+		return "", err
+	}
+
+	var key string
+	if err := db.Bun().NewSelect().
+		Table("cluster_id").
+		Column("webhook_signing_key").
+		Scan(ctx, &key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
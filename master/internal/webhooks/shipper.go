@@ -0,0 +1,247 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of a delivery.
+	SignatureHeader = "X-Determined-AI-Signature"
+	// TimestampHeader is the header carrying the unix timestamp that was signed.
+	TimestampHeader = "X-Determined-AI-Signature-Timestamp"
+	// EventIDHeader is the header carrying the ID of the delivered event, which is stable across
+	// retries so receivers can de-duplicate.
+	EventIDHeader = "X-Determined-AI-Event-ID"
+
+	shipperWorkers   = 4
+	shipperQueueSize = 1024
+	maxBackoff       = 5 * time.Minute
+)
+
+var (
+	shipperMu     sync.Mutex
+	activeShipper *shipper
+)
+
+// outgoing is a single event destined for a single webhook.
+type outgoing struct {
+	webhook   Webhook
+	triggerID *TriggerID
+	payload   EventPayload
+}
+
+// shipper delivers events to webhooks from a pool of background workers, retrying failed
+// deliveries with exponential backoff and recording every attempt in the DB.
+type shipper struct {
+	conf   config.WebhooksConfig
+	client *http.Client
+	queue  chan outgoing
+	// sleep is overridden in tests to avoid waiting out the backoff.
+	sleep func(ctx context.Context, d time.Duration) bool
+	// record is overridden in tests that run without a DB.
+	record func(ctx context.Context, d *Delivery) error
+}
+
+func newShipper(conf config.WebhooksConfig) *shipper {
+	return &shipper{
+		conf:   conf,
+		client: &http.Client{Timeout: time.Duration(conf.Timeout)},
+		queue:  make(chan outgoing, shipperQueueSize),
+		sleep:  sleepCtx,
+		record: AddDelivery,
+	}
+}
+
+// Init starts the background workers that deliver webhook events. It must be called once before
+// events are reported; events reported before Init are dropped.
+func Init(ctx context.Context, conf config.WebhooksConfig) {
+	shipperMu.Lock()
+	defer shipperMu.Unlock()
+	if activeShipper != nil {
+		panic("webhook shipper is already initialized")
+	}
+	activeShipper = newShipper(conf)
+	for i := 0; i < shipperWorkers; i++ {
+		go activeShipper.run(ctx)
+	}
+}
+
+func getShipper() *shipper {
+	shipperMu.Lock()
+	defer shipperMu.Unlock()
+	return activeShipper
+}
+
+// enqueue schedules an event for delivery without blocking the caller.
+func enqueue(o outgoing) {
+	s := getShipper()
+	if s == nil {
+		log.Debugf("dropping webhook event %s, shipper not initialized", o.payload.ID)
+		return
+	}
+	select {
+	case s.queue <- o:
+	default:
+		log.Errorf("dropping webhook event %s for webhook %d, delivery queue is full",
+			o.payload.ID, o.webhook.ID)
+	}
+}
+
+func (s *shipper) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case o := <-s.queue:
+			if _, err := s.deliver(ctx, o, s.conf.MaxAttempts); err != nil {
+				log.WithError(err).Warnf("failed to deliver event %s to webhook %d",
+					o.payload.ID, o.webhook.ID)
+			}
+		}
+	}
+}
+
+// deliver sends an event to a webhook, making up to maxAttempts attempts. It returns whether the
+// event was delivered successfully.
+func (s *shipper) deliver(ctx context.Context, o outgoing, maxAttempts int) (bool, error) {
+	body, err := renderPayload(o.webhook.WebhookType, o.payload, s.conf.BaseURL)
+	if err != nil {
+		return false, errors.Wrap(err, "rendering webhook payload")
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 && !s.sleep(ctx, backoff(time.Duration(s.conf.InitialBackoff), attempt)) {
+			return false, ctx.Err()
+		}
+
+		statusCode, err := s.post(ctx, o, body)
+		d := &Delivery{
+			WebhookID:   o.webhook.ID,
+			TriggerID:   o.triggerID,
+			EventID:     o.payload.ID,
+			EventType:   o.payload.Type,
+			Attempt:     attempt,
+			Payload:     body,
+			Delivered:   err == nil,
+			AttemptedAt: time.Now().UTC(),
+		}
+		if statusCode != 0 {
+			d.StatusCode = ptrs.Ptr(statusCode)
+		}
+		if err != nil {
+			d.Error = ptrs.Ptr(err.Error())
+		}
+		if rErr := s.record(ctx, d); rErr != nil {
+			log.WithError(rErr).Errorf("failed to record delivery of event %s", o.payload.ID)
+		}
+
+		if err == nil {
+			return true, nil
+		}
+		lastErr = err
+		if !retryable(statusCode) {
+			break
+		}
+	}
+	return false, lastErr
+}
+
+// post makes a single delivery attempt, returning the response status code, if any.
+func (s *shipper) post(ctx context.Context, o outgoing, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, o.payload.ID.String())
+	ts := time.Now().Unix()
+	req.Header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(SignatureHeader, sign([]byte(s.conf.SigningKey), ts, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if cErr := resp.Body.Close(); cErr != nil {
+			log.WithError(cErr).Debug("failed to close webhook response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of "<timestamp>,<body>" under key. Receivers should
+// recompute it using the TimestampHeader value and reject stale timestamps to prevent replays.
+func sign(key []byte, ts int64, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strconv.FormatInt(ts, 10)))
+	mac.Write([]byte(","))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns how long to wait before the given attempt (1-indexed).
+func backoff(initial time.Duration, attempt int) time.Duration {
+	d := initial
+	for i := 2; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// retryable reports whether a failed attempt with the given status code may succeed if retried.
+// A zero status code means the request failed before a response was received.
+func retryable(statusCode int) bool {
+	switch {
+	case statusCode == 0:
+		return true
+	case statusCode == http.StatusTooManyRequests, statusCode == http.StatusRequestTimeout:
+		return true
+	case statusCode >= 500:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func newEventPayload(t TriggerType) EventPayload {
+	return EventPayload{
+		ID:        uuid.New(),
+		Type:      t,
+		Timestamp: time.Now().Unix(),
+	}
+}
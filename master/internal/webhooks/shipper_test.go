@@ -0,0 +1,124 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/internal/config"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func testShipper(t *testing.T) (*shipper, *[]Delivery) {
+	s := newShipper(config.WebhooksConfig{
+		SigningKey:     "secret",
+		MaxAttempts:    3,
+		InitialBackoff: model.Duration(time.Second),
+		Timeout:        model.Duration(5 * time.Second),
+	})
+	var recorded []Delivery
+	s.sleep = func(context.Context, time.Duration) bool { return true }
+	s.record = func(_ context.Context, d *Delivery) error {
+		recorded = append(recorded, *d)
+		return nil
+	}
+	return s, &recorded
+}
+
+func TestDeliverRetriesAndSigns(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		ts, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, sign([]byte("secret"), ts, body), r.Header.Get(SignatureHeader))
+		require.NotEmpty(t, r.Header.Get(EventIDHeader))
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s, recorded := testShipper(t)
+	p := newEventPayload(TriggerTypeStateChange)
+	p.Data.Experiment = &ExperimentPayload{ID: 1, State: model.CompletedState}
+	delivered, err := s.deliver(context.Background(), outgoing{
+		webhook:   Webhook{ID: 1, URL: srv.URL, WebhookType: WebhookTypeDefault},
+		triggerID: ptrs.Ptr(TriggerID(2)),
+		payload:   p,
+	}, 3)
+	require.NoError(t, err)
+	require.True(t, delivered)
+	require.Equal(t, 3, calls)
+	require.Len(t, *recorded, 3)
+	for i, d := range *recorded {
+		require.Equal(t, i+1, d.Attempt)
+		require.Equal(t, p.ID, d.EventID)
+		require.Equal(t, i == 2, d.Delivered)
+	}
+	require.Equal(t, http.StatusServiceUnavailable, *(*recorded)[0].StatusCode)
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s, recorded := testShipper(t)
+	delivered, err := s.deliver(context.Background(), outgoing{
+		webhook: Webhook{ID: 1, URL: srv.URL, WebhookType: WebhookTypeSlack},
+		payload: newEventPayload(TriggerTypeStateChange),
+	}, 3)
+	require.Error(t, err)
+	require.False(t, delivered)
+	require.Equal(t, 1, calls)
+	require.Len(t, *recorded, 1)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Second, backoff(time.Second, 2))
+	require.Equal(t, 2*time.Second, backoff(time.Second, 3))
+	require.Equal(t, 8*time.Second, backoff(time.Second, 5))
+	require.Equal(t, maxBackoff, backoff(time.Second, 20))
+}
+
+func TestRenderSlackPayload(t *testing.T) {
+	p := newEventPayload(TriggerTypeStateChange)
+	p.Data.Experiment = &ExperimentPayload{ID: 7, Name: "mnist", State: model.CompletedState}
+	b, err := renderPayload(WebhookTypeSlack, p, "")
+	require.NoError(t, err)
+
+	var msg SlackMessage
+	require.NoError(t, json.Unmarshal(b, &msg))
+	require.Equal(t, "Experiment 7 (mnist) is COMPLETED", msg.Text)
+	require.NotEmpty(t, msg.Blocks)
+
+	b, err = renderPayload(WebhookTypeSlack, p, "https://det.example.com/")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &msg))
+	require.Equal(t,
+		"<https://det.example.com/det/experiments/7|Experiment 7 (mnist)> is COMPLETED", msg.Text)
+}
+
+func TestSign(t *testing.T) {
+	// Matches the verification snippet in the webhook documentation:
+	// hmac.new(key, f"{timestamp},{body}", sha256).hexdigest()
+	require.Equal(t,
+		"2406a70cd48d1a36dff0308ffbdc78d13f84f4771e293eea34571a8219eaa33b",
+		sign([]byte("key"), 1665689991, []byte(`{"event_id":"x"}`)))
+}
@@ -5,11 +5,15 @@ package webhooks
 
 import (
 	"context"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
 )
 
 func TestWebhooks(t *testing.T) {
@@ -65,6 +69,66 @@ func TestWebhooks(t *testing.T) {
 		require.NoError(t, err, "errored when deleting webhook")
 	})
 
+	t.Run("delivery attempts should be recorded and listed newest first", func(t *testing.T) {
+		testWebhookSix.Triggers = testWebhookSixTriggers
+		require.NoError(t, AddWebhook(ctx, &testWebhookSix))
+
+		eventID := uuid.New()
+		for attempt := 1; attempt <= 2; attempt++ {
+			d := Delivery{
+				WebhookID:   testWebhookSix.ID,
+				TriggerID:   ptrs.Ptr(testWebhookSixTrigger.ID),
+				EventID:     eventID,
+				EventType:   TriggerTypeStateChange,
+				Attempt:     attempt,
+				Payload:     []byte(`{}`),
+				StatusCode:  ptrs.Ptr(500),
+				AttemptedAt: time.Now().UTC(),
+			}
+			require.NoError(t, AddDelivery(ctx, &d))
+		}
+
+		deliveries, err := GetDeliveries(ctx, testWebhookSix.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		require.Equal(t, 2, deliveries[0].Attempt)
+		require.Equal(t, eventID, deliveries[0].EventID)
+
+		deliveries, err = GetDeliveries(ctx, testWebhookSix.ID, 10, 1)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, 1, deliveries[0].Attempt)
+	})
+
+	t.Run("legacy metric threshold triggers should be converted", func(t *testing.T) {
+		testWebhookSeven.Triggers = testWebhookSevenTriggers
+		require.NoError(t, AddWebhook(ctx, &testWebhookSeven))
+
+		migration, err := os.ReadFile(
+			"../../static/migrations/20221112090000_webhook-legacy-metric-thresholds.tx.up.sql")
+		require.NoError(t, err)
+		_, err = db.Bun().ExecContext(ctx, string(migration))
+		require.NoError(t, err)
+
+		triggers, err := getTriggersByType(ctx, TriggerTypeMetricThresholdExceeded)
+		require.NoError(t, err)
+		for _, trigger := range triggers {
+			if trigger.ID != testWebhookSevenTrigger.ID {
+				continue
+			}
+			c, err := ParseMetricThresholdCondition(trigger.Condition)
+			require.NoError(t, err)
+			require.Equal(t, MetricThresholdCondition{
+				MetricName: "validation_loss",
+				Comparator: ComparatorGreaterThan,
+				Threshold:  0.5,
+				MetricType: MetricTypeValidation,
+			}, *c)
+			return
+		}
+		t.Fatalf("trigger %d not found", testWebhookSevenTrigger.ID)
+	})
+
 	t.Cleanup(func() { cleanUp(ctx, t) })
 }
 
@@ -94,6 +158,16 @@ var (
 		URL:         "http://twebhooktwo.com",
 		WebhookType: WebhookTypeDefault,
 	}
+	testWebhookSix = Webhook{
+		ID:          8000,
+		URL:         "http://twebhookthree.com",
+		WebhookType: WebhookTypeDefault,
+	}
+	testWebhookSeven = Webhook{
+		ID:          9000,
+		URL:         "http://twebhookfour.com",
+		WebhookType: WebhookTypeDefault,
+	}
 	testWebhookFourTrigger = Trigger{
 		ID:          6001,
 		TriggerType: TriggerTypeStateChange,
@@ -106,9 +180,23 @@ var (
 		Condition:   map[string]interface{}{"state": "COMPLETED"},
 		WebhookID:   7000,
 	}
-	testWebhookFourTriggers = []*Trigger{&testWebhookFourTrigger}
-	testWebhookFiveTriggers = []*Trigger{&testWebhookFiveTrigger}
-	testTriggerOne          = Trigger{
+	testWebhookSixTrigger = Trigger{
+		ID:          8001,
+		TriggerType: TriggerTypeStateChange,
+		Condition:   map[string]interface{}{"state": "ERROR"},
+		WebhookID:   8000,
+	}
+	testWebhookSevenTrigger = Trigger{
+		ID:          9001,
+		TriggerType: TriggerTypeMetricThresholdExceeded,
+		Condition:   map[string]interface{}{"metricName": "validation_loss", "metricValue": 0.5},
+		WebhookID:   9000,
+	}
+	testWebhookFourTriggers  = []*Trigger{&testWebhookFourTrigger}
+	testWebhookFiveTriggers  = []*Trigger{&testWebhookFiveTrigger}
+	testWebhookSixTriggers   = []*Trigger{&testWebhookSixTrigger}
+	testWebhookSevenTriggers = []*Trigger{&testWebhookSevenTrigger}
+	testTriggerOne           = Trigger{
 		ID:          1001,
 		TriggerType: TriggerTypeStateChange,
 		Condition:   map[string]interface{}{"state": "COMPLETED"},
@@ -125,8 +213,9 @@ var (
 		ID:          2002,
 		TriggerType: TriggerTypeMetricThresholdExceeded,
 		Condition: map[string]interface{}{
-			"metricName":  "validation_accuracy",
-			"metricValue": 0.95,
+			"metric_name": "validation_accuracy",
+			"comparator":  ">=",
+			"threshold":   0.95,
 		},
 		WebhookID: 2000,
 	}
@@ -150,6 +239,8 @@ func cleanUp(ctx context.Context, t *testing.T) {
 	require.NoError(t, DeleteWebhook(ctx, testWebhookThree.ID))
 	require.NoError(t, DeleteWebhook(ctx, testWebhookFour.ID))
 	require.NoError(t, DeleteWebhook(ctx, testWebhookFive.ID))
+	require.NoError(t, DeleteWebhook(ctx, testWebhookSix.ID))
+	require.NoError(t, DeleteWebhook(ctx, testWebhookSeven.ID))
 }
 
 func getWebhookIds(webhooks Webhooks) []WebhookID {
@@ -66,6 +66,17 @@ func (a *WorkspaceAuthZBasic) CanSetWorkspacesAgentUserGroup(
 	return nil
 }
 
+// CanSetWorkspacesCheckpointRetention returns an error if the user is not an admin
+// or not the owner of the workspace.
+func (a *WorkspaceAuthZBasic) CanSetWorkspacesCheckpointRetention(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	if !curUser.Admin && curUser.ID != model.UserID(workspace.UserId) {
+		return fmt.Errorf("only admins may set other user's workspaces checkpoint retention")
+	}
+	return nil
+}
+
 // CanDeleteWorkspace returns an error if the user is not an admin
 // or not the owner of the workspace.
 func (a *WorkspaceAuthZBasic) CanDeleteWorkspace(
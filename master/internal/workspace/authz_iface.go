@@ -32,6 +32,10 @@ type WorkspaceAuthZ interface {
 	CanSetWorkspacesName(curUser model.User, workspace *workspacev1.Workspace) error
 	CanSetWorkspacesAgentUserGroup(curUser model.User, workspace *workspacev1.Workspace) error
 
+	// PUT /workspaces/:workspace_id/checkpoint-retention
+	// DELETE /workspaces/:workspace_id/checkpoint-retention
+	CanSetWorkspacesCheckpointRetention(curUser model.User, workspace *workspacev1.Workspace) error
+
 	// DELETE /api/v1/workspaces/:workspace_id
 	CanDeleteWorkspace(curUser model.User, workspace *workspacev1.Workspace) error
 
@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/determined-ai/determined/master/internal/rbac"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/proto/pkg/projectv1"
+	"github.com/determined-ai/determined/proto/pkg/rbacv1"
+	"github.com/determined-ai/determined/proto/pkg/workspacev1"
+)
+
+// WorkspaceAuthZRBAC is role-based access control for workspaces.
+type WorkspaceAuthZRBAC struct{}
+
+// CanGetWorkspace returns whether the user can view the workspace.
+func (a *WorkspaceAuthZRBAC) CanGetWorkspace(
+	curUser model.User, workspace *workspacev1.Workspace,
+) (canGetWorkspace bool, serverError error) {
+	return rbac.HasPermission(context.TODO(), curUser, rbac.WorkspaceScope(int(workspace.Id)),
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_WORKSPACE)
+}
+
+// FilterWorkspaceProjects returns the projects the user can view.
+func (a *WorkspaceAuthZRBAC) FilterWorkspaceProjects(
+	curUser model.User, projects []*projectv1.Project,
+) ([]*projectv1.Project, error) {
+	scopes, err := rbac.PermittedScopesFor(context.TODO(), curUser,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_PROJECT)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*projectv1.Project
+	for _, p := range projects {
+		if scopes.ContainsProject(int(p.WorkspaceId), int(p.Id)) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterWorkspaces returns the workspaces the user can view.
+func (a *WorkspaceAuthZRBAC) FilterWorkspaces(
+	curUser model.User, workspaces []*workspacev1.Workspace,
+) ([]*workspacev1.Workspace, error) {
+	scopes, err := rbac.PermittedScopesFor(context.TODO(), curUser,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_WORKSPACE)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*workspacev1.Workspace
+	for _, w := range workspaces {
+		if scopes.ContainsWorkspace(int(w.Id)) {
+			filtered = append(filtered, w)
+		}
+	}
+	return filtered, nil
+}
+
+// CanCreateWorkspace requires the permission to create workspaces.
+func (a *WorkspaceAuthZRBAC) CanCreateWorkspace(curUser model.User) error {
+	return rbac.CheckForPermission(context.TODO(), curUser, rbac.GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_CREATE_WORKSPACE)
+}
+
+// CanCreateWorkspaceWithAgentUserGroup requires the permission to set agent user groups of all
+// workspaces.
+func (a *WorkspaceAuthZRBAC) CanCreateWorkspaceWithAgentUserGroup(curUser model.User) error {
+	return rbac.CheckForPermission(context.TODO(), curUser, rbac.GlobalScope(),
+		rbacv1.PermissionType_PERMISSION_TYPE_SET_WORKSPACE_AGENT_USER_GROUP)
+}
+
+// CanSetWorkspacesName requires the permission to update the workspace.
+func (a *WorkspaceAuthZRBAC) CanSetWorkspacesName(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_WORKSPACE)
+}
+
+// CanSetWorkspacesAgentUserGroup requires the permission to set the agent user group of the
+// workspace.
+func (a *WorkspaceAuthZRBAC) CanSetWorkspacesAgentUserGroup(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_SET_WORKSPACE_AGENT_USER_GROUP)
+}
+
+// CanSetWorkspacesCheckpointRetention requires the permission to update the workspace.
+func (a *WorkspaceAuthZRBAC) CanSetWorkspacesCheckpointRetention(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_WORKSPACE)
+}
+
+// CanDeleteWorkspace requires the permission to delete the workspace.
+func (a *WorkspaceAuthZRBAC) CanDeleteWorkspace(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_DELETE_WORKSPACE)
+}
+
+// CanArchiveWorkspace requires the permission to update the workspace.
+func (a *WorkspaceAuthZRBAC) CanArchiveWorkspace(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_WORKSPACE)
+}
+
+// CanUnarchiveWorkspace requires the permission to update the workspace.
+func (a *WorkspaceAuthZRBAC) CanUnarchiveWorkspace(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_UPDATE_WORKSPACE)
+}
+
+// CanPinWorkspace requires the permission to view the workspace.
+func (a *WorkspaceAuthZRBAC) CanPinWorkspace(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_WORKSPACE)
+}
+
+// CanUnpinWorkspace requires the permission to view the workspace.
+func (a *WorkspaceAuthZRBAC) CanUnpinWorkspace(
+	curUser model.User, workspace *workspacev1.Workspace,
+) error {
+	return checkWorkspacePermission(curUser, workspace,
+		rbacv1.PermissionType_PERMISSION_TYPE_VIEW_WORKSPACE)
+}
+
+func checkWorkspacePermission(
+	curUser model.User, workspace *workspacev1.Workspace, permission rbacv1.PermissionType,
+) error {
+	return rbac.CheckForPermission(context.TODO(), curUser, rbac.WorkspaceScope(int(workspace.Id)),
+		permission)
+}
+
+func init() {
+	AuthZProvider.Register("rbac", &WorkspaceAuthZRBAC{})
+}
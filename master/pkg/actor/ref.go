@@ -4,15 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"reflect"
 	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/opentracing/opentracing-go"
-
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
@@ -57,11 +54,6 @@ type Ref struct {
 	err       error
 	listeners []chan error
 	shutdown  bool
-
-	tracing struct {
-		tracer opentracing.Tracer
-		closer io.Closer
-	}
 }
 
 func newRef(system *System, parent *Ref, address Address, actor Actor) *Ref {
@@ -87,10 +79,6 @@ func newRef(system *System, parent *Ref, address Address, actor Actor) *Ref {
 		inbox:        newInbox(),
 	}
 
-	if traceEnabled {
-		addTracer(ref)
-	}
-
 	go ref.run()
 	return ref
 }
@@ -353,11 +341,6 @@ func (r *Ref) close() {
 		close(listener)
 	}
 
-	// Close all resources used for tracing.
-	if traceEnabled {
-		closeTracer(r)
-	}
-
 	r.shutdown = true
 }
 
@@ -31,6 +31,14 @@ type MasterInfo struct {
 	ClusterID   string        `json:"cluster_id"`
 	ClusterName string        `json:"cluster_name"`
 	Telemetry   TelemetryInfo `json:"telemetry"`
+
+	SSOProviders []SSOProviderInfo `json:"sso_providers,omitempty"`
+}
+
+// SSOProviderInfo describes a single sign-on provider users can sign in with.
+type SSOProviderInfo struct {
+	Name   string `json:"name"`
+	SSOURL string `json:"sso_url"`
 }
 
 // MasterMessage is a union type for all messages sent from agents.
@@ -0,0 +1,182 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArchiveReader defines an interface to read the regular files in an archive file, one at a time.
+type ArchiveReader interface {
+	// Next advances to the next regular file in the archive, returning its path and size. It
+	// returns io.EOF when there are no more files.
+	Next() (path string, size int64, err error)
+	Read(b []byte) (int, error)
+	Close() error
+}
+
+// NewArchiveReader returns a new ArchiveReader for archiveType that reads from r. Zip files can
+// only be read with random access, so they are first spooled to a temporary file.
+func NewArchiveReader(r io.Reader, archiveType ArchiveType) (ArchiveReader, error) {
+	switch archiveType {
+	case ArchiveTgz:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return &tarArchiveReader{gz: gz, tr: tar.NewReader(gz)}, nil
+
+	case ArchiveZip:
+		f, err := os.CreateTemp("", "det-checkpoint-*.zip")
+		if err != nil {
+			return nil, err
+		}
+		zr, err := spoolZip(f, r)
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			return nil, err
+		}
+		return &zipArchiveReader{spool: f, files: zr.File, pos: -1}, nil
+
+	default:
+		return nil, fmt.Errorf(
+			"archive type must be %s or %s but got %s", ArchiveTgz, ArchiveZip, archiveType)
+	}
+}
+
+func spoolZip(f *os.File, r io.Reader) (*zip.Reader, error) {
+	size, err := io.Copy(f, r)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(f, size)
+}
+
+// cleanPath validates a path from an archive and returns it in canonical form, rejecting paths
+// that would escape the directory the archive is extracted to.
+func cleanPath(p string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") ||
+		cleaned == "." {
+		return "", fmt.Errorf("invalid path in archive: %q", p)
+	}
+	return cleaned, nil
+}
+
+type tarArchiveReader struct {
+	gz *gzip.Reader
+	tr *tar.Reader
+}
+
+func (ar *tarArchiveReader) Next() (string, int64, error) {
+	for {
+		hdr, err := ar.tr.Next()
+		if err != nil {
+			return "", 0, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		p, err := cleanPath(hdr.Name)
+		if err != nil {
+			return "", 0, err
+		}
+		return p, hdr.Size, nil
+	}
+}
+
+func (ar *tarArchiveReader) Read(p []byte) (int, error) {
+	return ar.tr.Read(p)
+}
+
+func (ar *tarArchiveReader) Close() error {
+	return ar.gz.Close()
+}
+
+type zipArchiveReader struct {
+	spool   *os.File
+	files   []*zip.File
+	pos     int
+	current io.ReadCloser
+}
+
+func (ar *zipArchiveReader) Next() (string, int64, error) {
+	if err := ar.closeCurrent(); err != nil {
+		return "", 0, err
+	}
+	for {
+		ar.pos++
+		if ar.pos >= len(ar.files) {
+			return "", 0, io.EOF
+		}
+		f := ar.files[ar.pos]
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		p, err := cleanPath(f.Name)
+		if err != nil {
+			return "", 0, err
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", 0, err
+		}
+		ar.current = rc
+		return p, int64(f.UncompressedSize64), nil
+	}
+}
+
+func (ar *zipArchiveReader) Read(p []byte) (int, error) {
+	// Mirror tar.Reader, which reports io.EOF when Next() has not been called.
+	if ar.current == nil {
+		return 0, io.EOF
+	}
+	return ar.current.Read(p)
+}
+
+func (ar *zipArchiveReader) closeCurrent() error {
+	if ar.current == nil {
+		return nil
+	}
+	err := ar.current.Close()
+	ar.current = nil
+	return err
+}
+
+// Close closes the current file and removes the spooled archive.
+func (ar *zipArchiveReader) Close() error {
+	err := ar.closeCurrent()
+	if cErr := ar.spool.Close(); err == nil {
+		err = cErr
+	}
+	if rErr := os.Remove(ar.spool.Name()); err == nil {
+		err = rErr
+	}
+	return err
+}
+
+// ForEachFile calls fn for every regular file in the archive, during which ar reads the content
+// of that file. It returns the size of every file by path, in the format of checkpoint resources.
+func ForEachFile(
+	ar ArchiveReader, fn func(path string, size int64) error,
+) (map[string]int64, error) {
+	resources := map[string]int64{}
+	for {
+		p, size, err := ar.Next()
+		if err == io.EOF {
+			return resources, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if err := fn(p, size); err != nil {
+			return nil, err
+		}
+		resources[p] = size
+	}
+}
@@ -0,0 +1,141 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// AzureDownloader implements downloading a checkpoint from Azure Blob Storage
+// and sends it to the client in an archive file.
+type AzureDownloader struct {
+	location
+	aw archive.ArchiveWriter
+}
+
+// location identifies where a checkpoint's blobs live and how to connect to them.
+type location struct {
+	container        string
+	prefix           string
+	connectionString *string
+	accountURL       *string
+	credential       *string
+}
+
+// newLocation splits a container that includes a path after the container name, e.g.
+// "container/some/path", so that blobs are looked up under that path.
+func newLocation(
+	container string, id string, connectionString *string, accountURL *string, credential *string,
+) location {
+	prefix := id + "/"
+	if parts := strings.SplitN(container, "/", 2); len(parts) == 2 {
+		container = parts[0]
+		prefix = strings.Trim(parts[1], "/") + "/" + prefix
+	}
+	return location{
+		container:        container,
+		prefix:           prefix,
+		connectionString: connectionString,
+		accountURL:       accountURL,
+		credential:       credential,
+	}
+}
+
+// Download downloads the checkpoint.
+func (d *AzureDownloader) Download(ctx context.Context) error {
+	client, err := d.containerClient()
+	if err != nil {
+		return fmt.Errorf("checkpoint download failed: %w", err)
+	}
+
+	pager := client.ListBlobsFlat(&azblob.ContainerListBlobFlatSegmentOptions{Prefix: &d.prefix})
+	for pager.NextPage(ctx) {
+		for _, item := range pager.PageResponse().Segment.BlobItems {
+			if err := d.downloadBlob(ctx, client, item); err != nil {
+				return fmt.Errorf("checkpoint download failed: %w", err)
+			}
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return fmt.Errorf("checkpoint download failed: %w", err)
+	}
+	return nil
+}
+
+func (d *AzureDownloader) downloadBlob(
+	ctx context.Context, client azblob.ContainerClient, item *azblob.BlobItemInternal,
+) error {
+	var size int64
+	if item.Properties != nil && item.Properties.ContentLength != nil {
+		size = *item.Properties.ContentLength
+	}
+	if err := d.aw.WriteHeader(strings.TrimPrefix(*item.Name, d.prefix), size); err != nil {
+		return err
+	}
+
+	resp, err := client.NewBlobClient(*item.Name).Download(ctx, nil)
+	if err != nil {
+		return err
+	}
+	body := resp.Body(nil)
+	defer func() {
+		_ = body.Close()
+	}()
+	_, err = io.Copy(d.aw, body)
+	return err
+}
+
+// containerClient connects the same way the harness does: through a connection string if one is
+// configured, and otherwise through the account URL, optionally with an account key credential.
+func (l location) containerClient() (azblob.ContainerClient, error) {
+	if l.connectionString != nil {
+		return azblob.NewContainerClientFromConnectionString(
+			*l.connectionString, l.container, nil)
+	}
+	if l.accountURL == nil {
+		return azblob.ContainerClient{}, fmt.Errorf(
+			"either connection_string or account_url must be specified")
+	}
+
+	containerURL := strings.TrimSuffix(*l.accountURL, "/") + "/" + l.container
+	if l.credential == nil {
+		return azblob.NewContainerClientWithNoCredential(containerURL, nil)
+	}
+	u, err := url.Parse(*l.accountURL)
+	if err != nil {
+		return azblob.ContainerClient{}, err
+	}
+	accountName := strings.Split(u.Hostname(), ".")[0]
+	cred, err := azblob.NewSharedKeyCredential(accountName, *l.credential)
+	if err != nil {
+		return azblob.ContainerClient{}, err
+	}
+	return azblob.NewContainerClientWithSharedKey(containerURL, cred, nil)
+}
+
+// Close closes the underlying ArchiveWriter.
+func (d *AzureDownloader) Close() error {
+	return d.aw.Close()
+}
+
+// NewAzureDownloader returns a new AzureDownloader. The container may include a path after the
+// container name, e.g. "container/some/path", in which case blobs are looked up under that path.
+func NewAzureDownloader(
+	aw archive.ArchiveWriter,
+	container string,
+	id string,
+	connectionString *string,
+	accountURL *string,
+	credential *string,
+) *AzureDownloader {
+	return &AzureDownloader{
+		location: newLocation(container, id, connectionString, accountURL, credential),
+		aw:       aw,
+	}
+}
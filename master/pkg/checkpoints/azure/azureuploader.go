@@ -0,0 +1,81 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// AzureUploader implements uploading a checkpoint from an archive file to Azure Blob Storage.
+type AzureUploader struct {
+	location
+	ar archive.ArchiveReader
+	// blobs are the blobs Upload started writing.
+	blobs []string
+}
+
+// Upload uploads every file in the archive, returning the checkpoint's resources.
+func (u *AzureUploader) Upload(ctx context.Context) (map[string]int64, error) {
+	client, err := u.containerClient()
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %w", err)
+	}
+
+	resources, err := archive.ForEachFile(u.ar, func(path string, size int64) error {
+		u.blobs = append(u.blobs, u.prefix+path)
+		_, err := client.NewBlockBlobClient(u.prefix+path).UploadStreamToBlockBlob(
+			ctx, u.ar, azblob.UploadStreamToBlockBlobOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %w", err)
+	}
+	return resources, nil
+}
+
+// Cleanup deletes the blobs Upload started writing.
+func (u *AzureUploader) Cleanup(ctx context.Context) error {
+	if len(u.blobs) == 0 {
+		return nil
+	}
+	client, err := u.containerClient()
+	if err != nil {
+		return fmt.Errorf("checkpoint cleanup failed: %w", err)
+	}
+	for _, name := range u.blobs {
+		if _, err := client.NewBlobClient(name).Delete(ctx, nil); err != nil {
+			var storageErr *azblob.StorageError
+			if errors.As(err, &storageErr) &&
+				storageErr.ErrorCode == azblob.StorageErrorCodeBlobNotFound {
+				continue
+			}
+			return fmt.Errorf("checkpoint cleanup failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying ArchiveReader.
+func (u *AzureUploader) Close() error {
+	return u.ar.Close()
+}
+
+// NewAzureUploader returns a new AzureUploader. The container may include a path after the
+// container name, in the same way as for NewAzureDownloader.
+func NewAzureUploader(
+	ar archive.ArchiveReader,
+	container string,
+	id string,
+	connectionString *string,
+	accountURL *string,
+	credential *string,
+) *AzureUploader {
+	return &AzureUploader{
+		location: newLocation(container, id, connectionString, accountURL, credential),
+		ar:       ar,
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 
 	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+	"github.com/determined-ai/determined/master/pkg/checkpoints/azure"
+	"github.com/determined-ai/determined/master/pkg/checkpoints/gcs"
 	"github.com/determined-ai/determined/master/pkg/checkpoints/s3"
+	"github.com/determined-ai/determined/master/pkg/checkpoints/sharedfs"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
 )
 
@@ -43,10 +47,109 @@ func NewDownloader(
 		}
 		return s3.NewS3Downloader(
 			aw, storage.Bucket(), strings.TrimLeft(prefix+"/"+id, "/")), nil
+	case expconf.GCSConfig:
+		prefix := ""
+		if storage.Prefix() != nil {
+			prefix = *storage.Prefix()
+		}
+		return gcs.NewGCSDownloader(
+			aw, storage.Bucket(), strings.TrimLeft(prefix+"/"+id, "/")), nil
+	case expconf.AzureConfig:
+		return azure.NewAzureDownloader(aw, storage.Container(), id,
+			storage.ConnectionString(), storage.AccountURL(), storage.Credential()), nil
+	case expconf.SharedFSConfig:
+		root, err := sharedFSStoragePath(storage)
+		if err != nil {
+			return nil, err
+		}
+		return sharedfs.NewSharedFSDownloader(aw, filepath.Join(root, id)), nil
+	default:
+		return nil, fmt.Errorf("checkpoint download via master is only supported on S3, GCS, "+
+			"Azure and shared_fs, but the checkpoint's storage type is %s",
+			storageConfig2Str(storage))
+	}
+}
+
+// CheckpointUploader defines the interface for uploading checkpoints.
+type CheckpointUploader interface {
+	// Upload stores the checkpoint, returning the size of each of its files by path.
+	Upload(ctx context.Context) (map[string]int64, error)
+	// Cleanup deletes whatever a failed Upload wrote to storage.
+	Cleanup(ctx context.Context) error
+	Close() error
+}
+
+// NewUploader returns a new CheckpointUploader that reads an archive from r.
+//
+// - r: the underlying Reader that CheckpointUploader reads the archive from
+// - id: the UUID string of the checkpoint to be uploaded
+// - storageConfig: the CheckpointStorageConfig
+// - archiveType: The ArchiveType (file format) of the archive
+func NewUploader(
+	r io.Reader,
+	id string,
+	storageConfig *expconf.CheckpointStorageConfig,
+	archiveType archive.ArchiveType,
+) (CheckpointUploader, error) {
+	// Check the storage type before reading the archive, which may spool it to disk.
+	switch storage := storageConfig.GetUnionMember().(type) {
+	case expconf.S3Config, expconf.GCSConfig, expconf.AzureConfig, expconf.SharedFSConfig:
 	default:
-		return nil, fmt.Errorf("checkpoint download via master is only supported on S3"+
-			", but the checkpoint's storage type is %s", storageConfig2Str(storage))
+		return nil, fmt.Errorf("checkpoint upload via master is only supported on S3, GCS, "+
+			"Azure and shared_fs, but the storage type is %s", storageConfig2Str(storage))
+	}
+
+	ar, err := archive.NewArchiveReader(r, archiveType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch storage := storageConfig.GetUnionMember().(type) {
+	case expconf.S3Config:
+		prefix := ""
+		if storage.Prefix() != nil {
+			prefix = *storage.Prefix()
+		}
+		return s3.NewS3Uploader(
+			ar, storage.Bucket(), strings.TrimLeft(prefix+"/"+id, "/")), nil
+	case expconf.GCSConfig:
+		prefix := ""
+		if storage.Prefix() != nil {
+			prefix = *storage.Prefix()
+		}
+		return gcs.NewGCSUploader(
+			ar, storage.Bucket(), strings.TrimLeft(prefix+"/"+id, "/")), nil
+	case expconf.AzureConfig:
+		return azure.NewAzureUploader(ar, storage.Container(), id,
+			storage.ConnectionString(), storage.AccountURL(), storage.Credential()), nil
+	default:
+		root, err := sharedFSStoragePath(storage.(expconf.SharedFSConfig))
+		if err != nil {
+			_ = ar.Close()
+			return nil, err
+		}
+		return sharedfs.NewSharedFSUploader(ar, filepath.Join(root, id)), nil
+	}
+}
+
+// sharedFSStoragePath returns where checkpoints are stored on the host, which the master must
+// also have mounted. It mirrors how the harness resolves storage_path against host_path.
+func sharedFSStoragePath(storage expconf.SharedFSConfig) (string, error) {
+	hostPath := filepath.Clean(storage.HostPath())
+	if storage.StoragePath() == nil {
+		return hostPath, nil
+	}
+	storagePath := *storage.StoragePath()
+	if !filepath.IsAbs(storagePath) {
+		storagePath = filepath.Join(hostPath, storagePath)
+	}
+	storagePath = filepath.Clean(storagePath)
+	rel, err := filepath.Rel(hostPath, storagePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("storage_path %s must be a subdirectory of host_path %s",
+			*storage.StoragePath(), hostPath)
 	}
+	return storagePath, nil
 }
 
 func storageConfig2Str(config any) string {
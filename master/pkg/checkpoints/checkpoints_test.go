@@ -0,0 +1,323 @@
+package checkpoints
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+	"github.com/determined-ai/determined/master/pkg/checkpoints/gcs"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+const testCheckpointID = "7e0bad2c-61b6-4a0c-8b17-a4b1c1f8b1a5"
+
+var mockCheckpointContent = map[string]string{
+	"emptyFile":        "",
+	"data.txt":         "This is mock data.",
+	"lib/math.py":      "def triple(x):\n  return x * 3",
+	"lib/deep/file.py": `print("hello")`,
+}
+
+func readTgz(t *testing.T, content io.Reader) map[string]string {
+	zr, err := gzip.NewReader(content)
+	require.NoError(t, err)
+	tr := tar.NewReader(zr)
+	got := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return got
+		}
+		require.NoError(t, err)
+		buf := &strings.Builder{}
+		_, err = io.Copy(buf, tr) //nolint: gosec
+		require.NoError(t, err)
+		got[hdr.Name] = buf.String()
+	}
+}
+
+func download(t *testing.T, d CheckpointDownloader, buf *bytes.Buffer) map[string]string {
+	require.NoError(t, d.Download(context.Background()))
+	require.NoError(t, d.Close())
+	return readTgz(t, buf)
+}
+
+func TestSharedFSDownload(t *testing.T) {
+	hostPath := t.TempDir()
+	for name, content := range mockCheckpointContent {
+		path := filepath.Join(hostPath, "checkpoints", testCheckpointID, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	require.NoError(t, os.MkdirAll(
+		filepath.Join(hostPath, "checkpoints", testCheckpointID, "emptyDir"), 0o700))
+
+	storage := expconf.CheckpointStorageConfig{RawSharedFSConfig: &expconf.SharedFSConfig{
+		RawHostPath:    ptrs.Ptr(hostPath),
+		RawStoragePath: ptrs.Ptr("checkpoints"),
+	}}
+	var buf bytes.Buffer
+	d, err := NewDownloader(&buf, testCheckpointID, &storage, archive.ArchiveTgz)
+	require.NoError(t, err)
+	require.Equal(t, mockCheckpointContent, download(t, d, &buf))
+
+	d, err = NewDownloader(&buf, "missing", &storage, archive.ArchiveTgz)
+	require.NoError(t, err)
+	require.Error(t, d.Download(context.Background()))
+}
+
+func TestSharedFSUploadRoundTrip(t *testing.T) {
+	storage := expconf.CheckpointStorageConfig{RawSharedFSConfig: &expconf.SharedFSConfig{
+		RawHostPath: ptrs.Ptr(t.TempDir()),
+	}}
+	for _, archiveType := range []archive.ArchiveType{archive.ArchiveTgz, archive.ArchiveZip} {
+		var in bytes.Buffer
+		aw, err := archive.NewArchiveWriter(&in, archiveType)
+		require.NoError(t, err)
+		for name, content := range mockCheckpointContent {
+			require.NoError(t, aw.WriteHeader(name, int64(len(content))))
+			_, err = aw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, aw.Close())
+
+		id := string(archiveType) + "-" + testCheckpointID
+		u, err := NewUploader(&in, id, &storage, archiveType)
+		require.NoError(t, err)
+		resources, err := u.Upload(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, u.Close())
+		require.Len(t, resources, len(mockCheckpointContent))
+		require.Equal(t, int64(len(mockCheckpointContent["data.txt"])), resources["data.txt"])
+
+		var out bytes.Buffer
+		d, err := NewDownloader(&out, id, &storage, archive.ArchiveTgz)
+		require.NoError(t, err)
+		require.Equal(t, mockCheckpointContent, download(t, d, &out))
+	}
+}
+
+func TestUploadRejectsEscapingPaths(t *testing.T) {
+	hostPath := t.TempDir()
+	storage := expconf.CheckpointStorageConfig{RawSharedFSConfig: &expconf.SharedFSConfig{
+		RawHostPath:    ptrs.Ptr(hostPath),
+		RawStoragePath: ptrs.Ptr("checkpoints"),
+	}}
+	var in bytes.Buffer
+	aw, err := archive.NewArchiveWriter(&in, archive.ArchiveTgz)
+	require.NoError(t, err)
+	require.NoError(t, aw.WriteHeader("../../escaped.txt", 1))
+	_, err = aw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, aw.Close())
+
+	u, err := NewUploader(&in, testCheckpointID, &storage, archive.ArchiveTgz)
+	require.NoError(t, err)
+	_, err = u.Upload(context.Background())
+	require.ErrorContains(t, err, "invalid path in archive")
+	require.NoError(t, u.Close())
+	require.NoFileExists(t, filepath.Join(hostPath, "escaped.txt"))
+}
+
+func TestSharedFSUploadCleanup(t *testing.T) {
+	hostPath := t.TempDir()
+	storage := expconf.CheckpointStorageConfig{RawSharedFSConfig: &expconf.SharedFSConfig{
+		RawHostPath: ptrs.Ptr(hostPath),
+	}}
+	var in bytes.Buffer
+	aw, err := archive.NewArchiveWriter(&in, archive.ArchiveTgz)
+	require.NoError(t, err)
+	// The second file can't be written, since its parent directory is a file.
+	for _, name := range []string{"a", "a/b"} {
+		require.NoError(t, aw.WriteHeader(name, 1))
+		_, err = aw.Write([]byte("x"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, aw.Close())
+
+	u, err := NewUploader(&in, testCheckpointID, &storage, archive.ArchiveTgz)
+	require.NoError(t, err)
+	_, err = u.Upload(context.Background())
+	require.Error(t, err)
+	require.NoError(t, u.Close())
+	require.DirExists(t, filepath.Join(hostPath, testCheckpointID))
+
+	require.NoError(t, u.Cleanup(context.Background()))
+	require.NoDirExists(t, filepath.Join(hostPath, testCheckpointID))
+}
+
+// An upload that finds the checkpoint already in place must not delete it on cleanup.
+func TestSharedFSUploadCleanupExisting(t *testing.T) {
+	hostPath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(hostPath, testCheckpointID), 0o700))
+	storage := expconf.CheckpointStorageConfig{RawSharedFSConfig: &expconf.SharedFSConfig{
+		RawHostPath: ptrs.Ptr(hostPath),
+	}}
+	var in bytes.Buffer
+	aw, err := archive.NewArchiveWriter(&in, archive.ArchiveTgz)
+	require.NoError(t, err)
+	require.NoError(t, aw.Close())
+	u, err := NewUploader(&in, testCheckpointID, &storage, archive.ArchiveTgz)
+	require.NoError(t, err)
+	_, err = u.Upload(context.Background())
+	require.ErrorContains(t, err, "already exists")
+	require.NoError(t, u.Cleanup(context.Background()))
+	require.DirExists(t, filepath.Join(hostPath, testCheckpointID))
+}
+
+func TestSharedFSStoragePath(t *testing.T) {
+	cases := []struct {
+		storagePath *string
+		expected    string
+		err         bool
+	}{
+		{nil, "/mnt/shared", false},
+		{ptrs.Ptr("checkpoints"), "/mnt/shared/checkpoints", false},
+		{ptrs.Ptr("/mnt/shared/checkpoints"), "/mnt/shared/checkpoints", false},
+		{ptrs.Ptr("..checkpoints"), "/mnt/shared/..checkpoints", false},
+		{ptrs.Ptr("../elsewhere"), "", true},
+		{ptrs.Ptr(".."), "", true},
+		{ptrs.Ptr("/mnt/other"), "", true},
+	}
+	for _, tc := range cases {
+		path, err := sharedFSStoragePath(expconf.SharedFSConfig{
+			RawHostPath:    ptrs.Ptr("/mnt/shared/"),
+			RawStoragePath: tc.storagePath,
+		})
+		if tc.err {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, path)
+	}
+}
+
+// newFakeGCSServer serves just enough of the GCS JSON and XML APIs to list and read objects.
+func newFakeGCSServer(t *testing.T, bucket string, objects map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == fmt.Sprintf("/storage/v1/b/%s/o", bucket) {
+			type item struct {
+				Name string `json:"name"`
+				Size string `json:"size"`
+			}
+			var items []item
+			for name, content := range objects {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					items = append(items, item{Name: name, Size: fmt.Sprint(len(content))})
+				}
+			}
+			sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"kind":  "storage#objects",
+				"items": items,
+			}))
+			return
+		}
+		content, ok := objects[strings.TrimPrefix(r.URL.Path, "/"+bucket+"/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}))
+}
+
+func TestGCSDownload(t *testing.T) {
+	objects := map[string]string{"prefix/other/ignored.txt": "not part of the checkpoint"}
+	for name, content := range mockCheckpointContent {
+		objects["prefix/"+testCheckpointID+"/"+name] = content
+	}
+	srv := newFakeGCSServer(t, "bucket", objects)
+	defer srv.Close()
+	// The emulator host makes the client read objects over plain HTTP; the endpoint option is
+	// still needed for listing.
+	t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(srv.URL, "http://"))
+
+	var buf bytes.Buffer
+	aw, err := archive.NewArchiveWriter(&buf, archive.ArchiveTgz)
+	require.NoError(t, err)
+	d := gcs.NewGCSDownloader(aw, "bucket", "prefix/"+testCheckpointID,
+		option.WithEndpoint(srv.URL+"/storage/v1/"), option.WithoutAuthentication())
+	require.Equal(t, mockCheckpointContent, download(t, d, &buf))
+}
+
+// newFakeAzureServer serves just enough of the Azure Blob Storage API, as emulated by Azurite, to
+// list and read blobs in a container.
+func newFakeAzureServer(
+	t *testing.T, account, container string, blobs map[string]string,
+) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		containerPath := fmt.Sprintf("/%s/%s", account, container)
+		if r.URL.Path == containerPath && r.URL.Query().Get("comp") == "list" {
+			var names []string
+			for name := range blobs {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			var sb strings.Builder
+			sb.WriteString(`<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+			for _, name := range names {
+				fmt.Fprintf(&sb,
+					"<Blob><Name>%s</Name><Properties><Content-Length>%d</Content-Length>"+
+						"</Properties></Blob>", name, len(blobs[name]))
+			}
+			sb.WriteString("</Blobs><NextMarker /></EnumerationResults>")
+			w.Header().Set("Content-Type", "application/xml")
+			_, err := w.Write([]byte(sb.String()))
+			require.NoError(t, err)
+			return
+		}
+		content, ok := blobs[strings.TrimPrefix(r.URL.Path, containerPath+"/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		w.Header().Set("ETag", fmt.Sprintf("\"%x\"", len(content)))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}))
+}
+
+func TestAzureDownload(t *testing.T) {
+	blobs := map[string]string{"other/ignored.txt": "not part of the checkpoint"}
+	for name, content := range mockCheckpointContent {
+		blobs["path/"+testCheckpointID+"/"+name] = content
+	}
+	srv := newFakeAzureServer(t, "devstoreaccount1", "container", blobs)
+	defer srv.Close()
+
+	// This is Azurite's well-known development account key.
+	connectionString := "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;" +
+		"AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/" +
+		"KBHBeksoGMGw==;BlobEndpoint=" + srv.URL + "/devstoreaccount1;"
+	storage := expconf.CheckpointStorageConfig{RawAzureConfig: &expconf.AzureConfig{
+		RawContainer:        ptrs.Ptr("container/path"),
+		RawConnectionString: ptrs.Ptr(connectionString),
+	}}
+	var buf bytes.Buffer
+	d, err := NewDownloader(&buf, testCheckpointID, &storage, archive.ArchiveTgz)
+	require.NoError(t, err)
+	require.Equal(t, mockCheckpointContent, download(t, d, &buf))
+}
@@ -0,0 +1,89 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// GCSDownloader implements downloading a checkpoint from GCS
+// and sends it to the client in an archive file.
+type GCSDownloader struct {
+	aw     archive.ArchiveWriter
+	bucket string
+	prefix string
+	opts   []option.ClientOption
+}
+
+// Download downloads the checkpoint.
+func (d *GCSDownloader) Download(ctx context.Context) error {
+	// We do not pass in credentials explicitly. Instead, we rely on
+	// the existing Google application default credentials.
+	client, err := storage.NewClient(ctx, d.opts...)
+	if err != nil {
+		return fmt.Errorf("checkpoint download failed: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	prefix := d.prefix
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	bucket := client.Bucket(d.bucket)
+	it := bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("checkpoint download failed: %w", err)
+		}
+		if err := d.downloadObject(ctx, bucket.Object(attrs.Name), attrs, prefix); err != nil {
+			return fmt.Errorf("checkpoint download failed: %w", err)
+		}
+	}
+}
+
+func (d *GCSDownloader) downloadObject(
+	ctx context.Context, obj *storage.ObjectHandle, attrs *storage.ObjectAttrs, prefix string,
+) error {
+	if err := d.aw.WriteHeader(strings.TrimPrefix(attrs.Name, prefix), attrs.Size); err != nil {
+		return err
+	}
+	r, err := obj.NewReader(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	_, err = io.Copy(d.aw, r)
+	return err
+}
+
+// Close closes the underlying ArchiveWriter.
+func (d *GCSDownloader) Close() error {
+	return d.aw.Close()
+}
+
+// NewGCSDownloader returns a new GCSDownloader. Any options are passed on to the GCS client.
+func NewGCSDownloader(
+	aw archive.ArchiveWriter, bucket string, prefix string, opts ...option.ClientOption,
+) *GCSDownloader {
+	return &GCSDownloader{
+		aw:     aw,
+		bucket: bucket,
+		prefix: prefix,
+		opts:   opts,
+	}
+}
@@ -0,0 +1,93 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// GCSUploader implements uploading a checkpoint from an archive file to GCS.
+type GCSUploader struct {
+	ar     archive.ArchiveReader
+	bucket string
+	prefix string
+	opts   []option.ClientOption
+	// objects are the objects Upload started writing.
+	objects []string
+}
+
+// Upload uploads every file in the archive, returning the checkpoint's resources.
+func (u *GCSUploader) Upload(ctx context.Context) (map[string]int64, error) {
+	// We do not pass in credentials explicitly. Instead, we rely on
+	// the existing Google application default credentials.
+	client, err := storage.NewClient(ctx, u.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	bucket := client.Bucket(u.bucket)
+	prefix := strings.TrimSuffix(u.prefix, "/") + "/"
+	resources, err := archive.ForEachFile(u.ar, func(path string, size int64) error {
+		u.objects = append(u.objects, prefix+path)
+		w := bucket.Object(prefix + path).NewWriter(ctx)
+		if _, err := io.Copy(w, u.ar); err != nil {
+			_ = w.Close()
+			return err
+		}
+		return w.Close()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %w", err)
+	}
+	return resources, nil
+}
+
+// Cleanup deletes the objects Upload started writing.
+func (u *GCSUploader) Cleanup(ctx context.Context) error {
+	if len(u.objects) == 0 {
+		return nil
+	}
+	client, err := storage.NewClient(ctx, u.opts...)
+	if err != nil {
+		return fmt.Errorf("checkpoint cleanup failed: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	bucket := client.Bucket(u.bucket)
+	for _, name := range u.objects {
+		err := bucket.Object(name).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("checkpoint cleanup failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying ArchiveReader.
+func (u *GCSUploader) Close() error {
+	return u.ar.Close()
+}
+
+// NewGCSUploader returns a new GCSUploader. Any options are passed on to the GCS client.
+func NewGCSUploader(
+	ar archive.ArchiveReader, bucket string, prefix string, opts ...option.ClientOption,
+) *GCSUploader {
+	return &GCSUploader{
+		ar:     ar,
+		bucket: bucket,
+		prefix: prefix,
+		opts:   opts,
+	}
+}
@@ -0,0 +1,88 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// S3Uploader implements uploading a checkpoint from an archive file to S3.
+type S3Uploader struct {
+	ar     archive.ArchiveReader
+	bucket string
+	prefix string
+	// sess and keys are what Upload connected with and the objects it started writing.
+	sess *session.Session
+	keys []string
+}
+
+// Upload uploads every file in the archive, returning the checkpoint's resources.
+func (u *S3Uploader) Upload(ctx context.Context) (map[string]int64, error) {
+	region, err := GetS3BucketRegion(ctx, u.bucket)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Region: &region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.sess = sess
+	// We do not pass in credentials explicitly. Instead, we reply on
+	// the existing AWS credentials.
+	uploader := s3manager.NewUploader(sess)
+
+	prefix := strings.TrimSuffix(u.prefix, "/") + "/"
+	resources, err := archive.ForEachFile(u.ar, func(path string, size int64) error {
+		u.keys = append(u.keys, prefix+path)
+		_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+			Bucket: &u.bucket,
+			Key:    aws.String(prefix + path),
+			Body:   u.ar,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %w", err)
+	}
+	return resources, nil
+}
+
+// Cleanup deletes the objects Upload started writing.
+func (u *S3Uploader) Cleanup(ctx context.Context) error {
+	if u.sess == nil {
+		return nil
+	}
+	client := s3.New(u.sess)
+	for _, key := range u.keys {
+		if _, err := client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: &u.bucket,
+			Key:    aws.String(key),
+		}); err != nil {
+			return fmt.Errorf("checkpoint cleanup failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying ArchiveReader.
+func (u *S3Uploader) Close() error {
+	return u.ar.Close()
+}
+
+// NewS3Uploader returns a new S3Uploader.
+func NewS3Uploader(ar archive.ArchiveReader, bucket string, prefix string) *S3Uploader {
+	return &S3Uploader{
+		ar:     ar,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
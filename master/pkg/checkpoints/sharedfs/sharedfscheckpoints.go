@@ -0,0 +1,83 @@
+package sharedfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// SharedFSDownloader implements downloading a checkpoint from a shared filesystem mounted on the
+// master and sends it to the client in an archive file.
+type SharedFSDownloader struct {
+	aw   archive.ArchiveWriter
+	root string
+}
+
+// Download downloads the checkpoint.
+func (d *SharedFSDownloader) Download(ctx context.Context) error {
+	info, err := os.Stat(d.root)
+	switch {
+	case err != nil:
+		return fmt.Errorf("checkpoint download failed: %w", err)
+	case !info.IsDir():
+		return fmt.Errorf("checkpoint download failed: %s is not a directory", d.root)
+	}
+
+	return filepath.WalkDir(d.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		// Like the object store downloaders, only regular files are archived; directories are
+		// implied by the paths of the files within them.
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(d.root, path)
+		if err != nil {
+			return err
+		}
+		return d.writeFile(path, filepath.ToSlash(rel))
+	})
+}
+
+func (d *SharedFSDownloader) writeFile(path, name string) error {
+	f, err := os.Open(path) //nolint:gosec // The path is built from the checkpoint's storage config.
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := d.aw.WriteHeader(name, info.Size()); err != nil {
+		return err
+	}
+	// Copy exactly the size written in the header, in case the file grows while it is read.
+	_, err = io.CopyN(d.aw, f, info.Size())
+	return err
+}
+
+// Close closes the underlying ArchiveWriter.
+func (d *SharedFSDownloader) Close() error {
+	return d.aw.Close()
+}
+
+// NewSharedFSDownloader returns a new SharedFSDownloader that archives the directory root.
+func NewSharedFSDownloader(aw archive.ArchiveWriter, root string) *SharedFSDownloader {
+	return &SharedFSDownloader{
+		aw:   aw,
+		root: root,
+	}
+}
@@ -0,0 +1,74 @@
+package sharedfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/determined-ai/determined/master/pkg/checkpoints/archive"
+)
+
+// SharedFSUploader implements uploading a checkpoint from an archive file to a shared filesystem
+// mounted on the master.
+type SharedFSUploader struct {
+	ar   archive.ArchiveReader
+	root string
+	// created is set once Upload starts writing into root, which it must not have found in place.
+	created bool
+}
+
+// Upload unpacks the archive into the checkpoint directory, returning the checkpoint's resources.
+func (u *SharedFSUploader) Upload(ctx context.Context) (map[string]int64, error) {
+	if _, err := os.Stat(u.root); err == nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %s already exists", u.root)
+	}
+	u.created = true
+	resources, err := archive.ForEachFile(u.ar, func(path string, size int64) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return u.writeFile(filepath.Join(u.root, filepath.FromSlash(path)))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint upload failed: %w", err)
+	}
+	return resources, nil
+}
+
+func (u *SharedFSUploader) writeFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	f, err := os.Create(path) //nolint:gosec // The path is validated by the ArchiveReader.
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, u.ar); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Cleanup removes the checkpoint directory, if Upload created it.
+func (u *SharedFSUploader) Cleanup(ctx context.Context) error {
+	if !u.created {
+		return nil
+	}
+	return os.RemoveAll(u.root)
+}
+
+// Close closes the underlying ArchiveReader.
+func (u *SharedFSUploader) Close() error {
+	return u.ar.Close()
+}
+
+// NewSharedFSUploader returns a new SharedFSUploader that unpacks into the directory root.
+func NewSharedFSUploader(ar archive.ArchiveReader, root string) *SharedFSUploader {
+	return &SharedFSUploader{
+		ar:   ar,
+		root: root,
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/check"
+)
+
+// CheckpointRetentionPolicy limits how long and how much the checkpoints of finished experiments
+// in a workspace or a project are kept. Exactly one of WorkspaceID and ProjectID is set; the policy
+// of a project replaces the policy of its workspace.
+type CheckpointRetentionPolicy struct {
+	bun.BaseModel `bun:"table:checkpoint_retention_policies"`
+	ID            int  `bun:"id,pk,autoincrement" json:"id"`
+	WorkspaceID   *int `bun:"workspace_id" json:"workspace_id,omitempty"`
+	ProjectID     *int `bun:"project_id" json:"project_id,omitempty"`
+	// MaxAgeSeconds is how long after they're reported checkpoints are kept, if set.
+	MaxAgeSeconds *int64 `bun:"max_age_seconds" json:"max_age_seconds,omitempty"`
+	// MaxTotalBytes is how large the checkpoints of each project may be in total, if set. The
+	// oldest checkpoints are deleted first.
+	MaxTotalBytes *int64 `bun:"max_total_bytes" json:"max_total_bytes,omitempty"`
+	// KeepRegistered keeps checkpoints that are registered as model versions.
+	KeepRegistered bool `bun:"keep_registered,notnull" json:"keep_registered"`
+}
+
+// DefaultCheckpointRetentionPolicy returns a policy with the default settings.
+func DefaultCheckpointRetentionPolicy() CheckpointRetentionPolicy {
+	return CheckpointRetentionPolicy{KeepRegistered: true}
+}
+
+// Validate implements the check.Validatable interface.
+func (p CheckpointRetentionPolicy) Validate() []error {
+	errs := []error{
+		check.True((p.WorkspaceID == nil) != (p.ProjectID == nil),
+			"exactly one of workspace_id and project_id must be set"),
+	}
+	if p.MaxAgeSeconds != nil {
+		errs = append(errs, check.GreaterThan(*p.MaxAgeSeconds, int64(0),
+			"max_age_seconds must be positive"))
+	}
+	if p.MaxTotalBytes != nil {
+		errs = append(errs, check.GreaterThanOrEqualTo(*p.MaxTotalBytes, int64(0),
+			"max_total_bytes must be non-negative"))
+	}
+	return errs
+}
+
+// MaxAge returns how long after they're reported checkpoints are kept, or zero if they're kept
+// regardless of their age.
+func (p CheckpointRetentionPolicy) MaxAge() time.Duration {
+	if p.MaxAgeSeconds == nil {
+		return 0
+	}
+	return time.Duration(*p.MaxAgeSeconds) * time.Second
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/check"
+)
+
+// ResourceQuota limits the resources that the jobs of a workspace or a user can use in a resource
+// pool. Exactly one of WorkspaceID and UserID is set.
+type ResourceQuota struct {
+	bun.BaseModel `bun:"table:resource_quotas"`
+	ID            int     `bun:"id,pk,autoincrement" json:"id"`
+	ResourcePool  string  `bun:"resource_pool,notnull" json:"resource_pool"`
+	WorkspaceID   *int    `bun:"workspace_id" json:"workspace_id,omitempty"`
+	UserID        *UserID `bun:"user_id" json:"user_id,omitempty"`
+	// MaxSlots is the number of slots that the jobs may use at the same time.
+	MaxSlots int `bun:"max_slots,notnull" json:"max_slots"`
+	// MaxQueuedJobs is the number of jobs that may wait to be scheduled at the same time, if set.
+	MaxQueuedJobs *int `bun:"max_queued_jobs" json:"max_queued_jobs,omitempty"`
+}
+
+// Validate implements the check.Validatable interface.
+func (q ResourceQuota) Validate() []error {
+	errs := []error{
+		check.True((q.WorkspaceID == nil) != (q.UserID == nil),
+			"exactly one of workspace_id and user_id must be set"),
+		check.GreaterThanOrEqualTo(q.MaxSlots, 0, "max_slots must be non-negative"),
+	}
+	if q.MaxQueuedJobs != nil {
+		errs = append(errs,
+			check.GreaterThanOrEqualTo(*q.MaxQueuedJobs, 0, "max_queued_jobs must be non-negative"))
+	}
+	return errs
+}
+
+// AppliesTo returns whether the quota covers jobs owned by the user in the workspace.
+func (q ResourceQuota) AppliesTo(userID *UserID, workspaceID *int) bool {
+	switch {
+	case q.WorkspaceID != nil:
+		return workspaceID != nil && *q.WorkspaceID == *workspaceID
+	case q.UserID != nil:
+		return userID != nil && *q.UserID == *userID
+	default:
+		return false
+	}
+}
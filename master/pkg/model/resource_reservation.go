@@ -0,0 +1,149 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/pkg/check"
+)
+
+// timeOfDayLayout is the layout of the daily start and end times of a reservation.
+const timeOfDayLayout = "15:04"
+
+// ResourceReservation reserves slots of a resource pool for the jobs of a workspace or a user
+// during a time window. Exactly one of WorkspaceID and UserID is set.
+//
+// A reservation without a daily window is active from StartTime until EndTime. A reservation with
+// a daily window is active between DailyStart and DailyEnd on each of its Weekdays, or on every day
+// if none are given, within StartTime and EndTime if they are set.
+type ResourceReservation struct {
+	bun.BaseModel `bun:"table:resource_reservations"`
+	ID            int     `bun:"id,pk,autoincrement" json:"id"`
+	ResourcePool  string  `bun:"resource_pool,notnull" json:"resource_pool"`
+	WorkspaceID   *int    `bun:"workspace_id" json:"workspace_id,omitempty"`
+	UserID        *UserID `bun:"user_id" json:"user_id,omitempty"`
+	Slots         int     `bun:"slots,notnull" json:"slots"`
+	Description   string  `bun:"description,notnull" json:"description"`
+
+	StartTime *time.Time `bun:"start_time" json:"start_time,omitempty"`
+	EndTime   *time.Time `bun:"end_time" json:"end_time,omitempty"`
+
+	// Weekdays are the lowercase names of the days on which the daily window applies.
+	Weekdays []string `bun:"weekdays,array" json:"weekdays,omitempty"`
+	// DailyStart and DailyEnd are the times of day, as HH:MM, between which the reservation is
+	// active.
+	DailyStart *string `bun:"daily_start" json:"daily_start,omitempty"`
+	DailyEnd   *string `bun:"daily_end" json:"daily_end,omitempty"`
+	// Timezone is the IANA name of the time zone of the daily window. Defaults to UTC.
+	Timezone *string `bun:"timezone" json:"timezone,omitempty"`
+}
+
+// Validate implements the check.Validatable interface.
+func (r ResourceReservation) Validate() []error {
+	errs := []error{
+		check.True((r.WorkspaceID == nil) != (r.UserID == nil),
+			"exactly one of workspace_id and user_id must be set"),
+		check.GreaterThan(r.Slots, 0, "slots must be positive"),
+	}
+	if r.StartTime != nil && r.EndTime != nil {
+		errs = append(errs, check.True(r.EndTime.After(*r.StartTime),
+			"end_time must be after start_time"))
+	}
+
+	if r.DailyStart == nil && r.DailyEnd == nil {
+		errs = append(errs,
+			check.True(r.StartTime != nil && r.EndTime != nil,
+				"start_time and end_time must be set for a reservation without a daily window"),
+			check.True(len(r.Weekdays) == 0 && r.Timezone == nil,
+				"weekdays and timezone require daily_start and daily_end"),
+		)
+		return errs
+	}
+
+	if r.DailyStart == nil || r.DailyEnd == nil {
+		return append(errs, check.True(false, "daily_start and daily_end must be set together"))
+	}
+	start, err := time.Parse(timeOfDayLayout, *r.DailyStart)
+	errs = append(errs, check.True(err == nil, "daily_start must be formatted as HH:MM"))
+	end, err := time.Parse(timeOfDayLayout, *r.DailyEnd)
+	errs = append(errs, check.True(err == nil, "daily_end must be formatted as HH:MM"))
+	errs = append(errs, check.True(end.After(start), "daily_end must be after daily_start"))
+	for _, day := range r.Weekdays {
+		_, ok := weekdays[strings.ToLower(day)]
+		errs = append(errs, check.True(ok, "unknown weekday %q", day))
+	}
+	if r.Timezone != nil {
+		_, err := time.LoadLocation(*r.Timezone)
+		errs = append(errs, check.True(err == nil, "unknown timezone %q", *r.Timezone))
+	}
+	return errs
+}
+
+// AppliesTo returns whether the reservation is held for jobs owned by the user in the workspace.
+func (r ResourceReservation) AppliesTo(userID *UserID, workspaceID *int) bool {
+	switch {
+	case r.WorkspaceID != nil:
+		return workspaceID != nil && *r.WorkspaceID == *workspaceID
+	case r.UserID != nil:
+		return userID != nil && *r.UserID == *userID
+	default:
+		return false
+	}
+}
+
+// Active returns whether the reservation is in effect at the given time. Reservations that fail
+// validation are never active.
+func (r ResourceReservation) Active(now time.Time) bool {
+	if r.StartTime != nil && now.Before(*r.StartTime) {
+		return false
+	}
+	if r.EndTime != nil && !now.Before(*r.EndTime) {
+		return false
+	}
+	if r.DailyStart == nil || r.DailyEnd == nil {
+		return r.StartTime != nil && r.EndTime != nil
+	}
+
+	loc := time.UTC
+	if r.Timezone != nil {
+		var err error
+		if loc, err = time.LoadLocation(*r.Timezone); err != nil {
+			return false
+		}
+	}
+	now = now.In(loc)
+	if len(r.Weekdays) > 0 {
+		onDay := false
+		for _, day := range r.Weekdays {
+			if weekday, ok := weekdays[strings.ToLower(day)]; ok && weekday == now.Weekday() {
+				onDay = true
+			}
+		}
+		if !onDay {
+			return false
+		}
+	}
+
+	start, err := time.Parse(timeOfDayLayout, *r.DailyStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(timeOfDayLayout, *r.DailyEnd)
+	if err != nil {
+		return false
+	}
+	minutes := now.Hour()*60 + now.Minute()
+	return minutes >= start.Hour()*60+start.Minute() && minutes < end.Hour()*60+end.Minute()
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/check"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func TestResourceReservationActive(t *testing.T) {
+	workdays := ResourceReservation{
+		UserID:     ptrs.Ptr(UserID(1)),
+		Slots:      16,
+		Weekdays:   []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		DailyStart: ptrs.Ptr("09:00"),
+		DailyEnd:   ptrs.Ptr("18:00"),
+		Timezone:   ptrs.Ptr("America/New_York"),
+	}
+	assert.NilError(t, check.Validate(workdays))
+
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NilError(t, err)
+	// 2022-10-24 is a Monday.
+	assert.Assert(t, workdays.Active(time.Date(2022, 10, 24, 9, 0, 0, 0, ny)))
+	assert.Assert(t, workdays.Active(time.Date(2022, 10, 24, 17, 59, 0, 0, ny)))
+	assert.Assert(t, !workdays.Active(time.Date(2022, 10, 24, 18, 0, 0, 0, ny)))
+	assert.Assert(t, !workdays.Active(time.Date(2022, 10, 24, 12, 0, 0, 0, time.UTC)))
+	assert.Assert(t, !workdays.Active(time.Date(2022, 10, 23, 12, 0, 0, 0, ny)))
+
+	deadline := ResourceReservation{
+		WorkspaceID: ptrs.Ptr(1),
+		Slots:       8,
+		StartTime:   ptrs.Ptr(time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)),
+		EndTime:     ptrs.Ptr(time.Date(2022, 11, 15, 0, 0, 0, 0, time.UTC)),
+	}
+	assert.NilError(t, check.Validate(deadline))
+	assert.Assert(t, !deadline.Active(time.Date(2022, 10, 31, 23, 0, 0, 0, time.UTC)))
+	assert.Assert(t, deadline.Active(time.Date(2022, 11, 7, 0, 0, 0, 0, time.UTC)))
+	assert.Assert(t, !deadline.Active(time.Date(2022, 11, 15, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestResourceReservationValidate(t *testing.T) {
+	for _, r := range []ResourceReservation{
+		{UserID: ptrs.Ptr(UserID(1)), Slots: 1},
+		{Slots: 1, StartTime: ptrs.Ptr(time.Now()), EndTime: ptrs.Ptr(time.Now().Add(time.Hour))},
+		{
+			UserID: ptrs.Ptr(UserID(1)), Slots: 1,
+			DailyStart: ptrs.Ptr("18:00"), DailyEnd: ptrs.Ptr("09:00"),
+		},
+		{
+			UserID: ptrs.Ptr(UserID(1)), Slots: 1, Weekdays: []string{"someday"},
+			DailyStart: ptrs.Ptr("09:00"), DailyEnd: ptrs.Ptr("18:00"),
+		},
+	} {
+		assert.Assert(t, check.Validate(r) != nil)
+	}
+}
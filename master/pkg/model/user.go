@@ -31,6 +31,10 @@ type User struct {
 	Admin         bool        `db:"admin" json:"admin"`
 	Active        bool        `db:"active" json:"active"`
 	ModifiedAt    time.Time   `db:"modified_at" json:"modified_at"`
+	// ServiceAccount users cannot log in and authenticate only with access tokens.
+	ServiceAccount bool `db:"service_account" json:"service_account"`
+	// PasswordChangeRequired users must change their password before they can do anything else.
+	PasswordChangeRequired bool `db:"password_change_required" json:"password_change_required"`
 }
 
 // UserSession corresponds to a row in the "user_sessions" DB table.
@@ -39,6 +43,20 @@ type UserSession struct {
 	ID            SessionID `db:"id" json:"id"`
 	UserID        UserID    `db:"user_id" json:"user_id"`
 	Expiry        time.Time `db:"expiry" json:"expiry"`
+
+	// The fields below describe access tokens and are left out of the signed token.
+	AccessToken      bool        `db:"access_token" json:"-"`
+	Description      null.String `db:"description" json:"-"`
+	ReadOnly         bool        `db:"read_only" json:"-"`
+	ScopeWorkspaceID *int        `db:"scope_workspace_id" json:"-"`
+	CreatedAt        time.Time   `db:"created_at" json:"-"`
+	LastUsedAt       *time.Time  `db:"last_used_at" json:"-"`
+}
+
+// IsLimited returns whether the session is an access token whose scope restricts the requests it
+// can be used for.
+func (s *UserSession) IsLimited() bool {
+	return s.AccessToken && (s.ReadOnly || s.ScopeWorkspaceID != nil)
 }
 
 // A FullUser is a User joined with any other user relations.
@@ -50,6 +68,9 @@ type FullUser struct {
 	Active      bool        `db:"active" json:"active"`
 	ModifiedAt  time.Time   `db:"modified_at" json:"modified_at"`
 
+	ServiceAccount         bool `db:"service_account" json:"service_account"`
+	PasswordChangeRequired bool `db:"password_change_required" json:"password_change_required"`
+
 	AgentUID   null.Int    `db:"agent_uid" json:"agent_uid"`
 	AgentGID   null.Int    `db:"agent_gid" json:"agent_gid"`
 	AgentUser  null.String `db:"agent_user" json:"agent_user"`
@@ -66,6 +87,9 @@ func (u FullUser) ToUser() User {
 		Admin:        u.Admin,
 		Active:       u.Active,
 		ModifiedAt:   u.ModifiedAt,
+
+		ServiceAccount:         u.ServiceAccount,
+		PasswordChangeRequired: u.PasswordChangeRequired,
 	}
 }
 
@@ -137,6 +161,9 @@ type ExternalSessions struct {
 	LoginURI  string `json:"login_uri"`
 	LogoutURI string `json:"logout_uri"`
 	JwtKey    string `json:"jwt_key"`
+	// GroupsClaim is the claim of the JWTs that lists the groups of the user, if any; the groups of
+	// users are synced with it.
+	GroupsClaim string `json:"groups_claim"`
 }
 
 // UserWebSetting is a record of user web setting.
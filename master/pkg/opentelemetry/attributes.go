@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer of the master.
+const TracerName = "determined-master"
+
+// MeterName is the name of the meter of the master.
+const MeterName = "determined-master"
+
+// Attributes that tie spans to the Determined objects they are about.
+const (
+	// ExperimentIDKey is the ID of an experiment.
+	ExperimentIDKey = attribute.Key("determined.experiment_id")
+	// TrialIDKey is the ID of a trial.
+	TrialIDKey = attribute.Key("determined.trial_id")
+	// TaskIDKey is the ID of a task.
+	TaskIDKey = attribute.Key("determined.task_id")
+	// AllocationIDKey is the ID of an allocation.
+	AllocationIDKey = attribute.Key("determined.allocation_id")
+	// JobIDKey is the ID of a job.
+	JobIDKey = attribute.Key("determined.job_id")
+	// StateKey is the final state of an experiment, trial or allocation.
+	StateKey = attribute.Key("determined.state")
+)
+
+// Tracer returns the tracer of the master. Until ConfigureOtel is called, it records nothing.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Meter returns the meter of the master. Until ConfigureOtel is called, its instruments record
+// nothing.
+func Meter() metric.Meter {
+	return otel.Meter(MeterName)
+}
@@ -5,9 +5,11 @@ import (
 	"log"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
@@ -16,7 +18,10 @@ import (
 // maintain a single tracer provider.
 var tracer *sdktrace.TracerProvider
 
-// ConfigureOtel initiates a new tracer and sets it as the default for otel.
+// meterProvider pushes the metrics of the master along with its traces.
+var meterProvider *sdkmetric.MeterProvider
+
+// ConfigureOtel initiates a new tracer and meter provider and sets them as the defaults for otel.
 func ConfigureOtel(endpoint string, serviceName string) *sdktrace.TracerProvider {
 	// avoid repeatedly re-creating the tracer.
 	if tracer != nil {
@@ -30,12 +35,19 @@ func ConfigureOtel(endpoint string, serviceName string) *sdktrace.TracerProvider
 	if err != nil {
 		log.Fatalf("failed to initialize exporter: %v", err)
 	}
+	metricExp, err := newMetricExporter(ctx, endpoint)
+	if err != nil {
+		log.Fatalf("failed to initialize metric exporter: %v", err)
+	}
 
 	// Create a new tracer provider with a batch span processor and the otlp exporter.
 	tracer = newTraceProvider(exp, serviceName)
+	meterProvider = newMeterProvider(metricExp, serviceName)
 
 	// Set the Tracer Provider and the W3C Trace Context propagator as globals
 	otel.SetTracerProvider(tracer)
+	// Instruments created through the global meter before now start recording too.
+	otel.SetMeterProvider(meterProvider)
 
 	// Register the trace context and baggage propagators
 	// so data is propagated across services/processes.
@@ -59,15 +71,31 @@ func newExporter(ctx context.Context, endpoint string) (*otlptrace.Exporter, err
 	return otlptrace.New(ctx, client)
 }
 
-func newTraceProvider(exp *otlptrace.Exporter, serviceName string) *sdktrace.TracerProvider {
+func newMetricExporter(ctx context.Context, endpoint string) (*otlpmetricgrpc.Exporter, error) {
+	return otlpmetricgrpc.New(ctx,
+		otlpmetricgrpc.WithEndpoint(endpoint),
+		otlpmetricgrpc.WithInsecure(),
+	)
+}
+
+func newResource(serviceName string) *resource.Resource {
 	// The service.name attribute is required.
-	resource := resource.NewWithAttributes(
+	return resource.NewWithAttributes(
 		semconv.SchemaURL,
 		semconv.ServiceNameKey.String(serviceName),
 	)
+}
 
+func newTraceProvider(exp *otlptrace.Exporter, serviceName string) *sdktrace.TracerProvider {
 	return sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exp),
-		sdktrace.WithResource(resource),
+		sdktrace.WithResource(newResource(serviceName)),
+	)
+}
+
+func newMeterProvider(exp sdkmetric.Exporter, serviceName string) *sdkmetric.MeterProvider {
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+		sdkmetric.WithResource(newResource(serviceName)),
 	)
 }
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// collector is an OTLP trace collector that keeps the spans it receives.
+type collector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	spans []*tracev1.Span
+}
+
+func (c *collector) Export(
+	_ context.Context, req *collectortrace.ExportTraceServiceRequest,
+) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+// metricsCollector is an OTLP metrics collector that keeps the metrics it receives.
+type metricsCollector struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	mu      sync.Mutex
+	metrics []*metricsv1.Metric
+}
+
+func (c *metricsCollector) Export(
+	_ context.Context, req *collectormetrics.ExportMetricsServiceRequest,
+) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rm := range req.ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			c.metrics = append(c.metrics, sm.Metrics...)
+		}
+	}
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func TestConfigureOtel(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	c := &collector{}
+	mc := &metricsCollector{}
+	srv := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(srv, c)
+	collectormetrics.RegisterMetricsServiceServer(srv, mc)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	defer srv.Stop()
+
+	// Instruments are usually created before the master configures OpenTelemetry.
+	counter, err := Meter().Int64Counter("det.test.events")
+	require.NoError(t, err)
+
+	tp := ConfigureOtel(lis.Addr().String(), "determined-master")
+	require.Same(t, tp, ConfigureOtel(lis.Addr().String(), "determined-master"))
+
+	ctx, parent := Tracer().Start(context.Background(), "experiment")
+	parent.SetAttributes(ExperimentIDKey.Int(1))
+	_, child := Tracer().Start(ctx, "trial")
+	child.SetAttributes(TrialIDKey.Int(2))
+	child.End()
+	parent.End()
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	require.Len(t, c.spans, 2)
+	byName := map[string]*tracev1.Span{}
+	for _, s := range c.spans {
+		byName[s.Name] = s
+	}
+	require.Equal(t, byName["experiment"].SpanId, byName["trial"].ParentSpanId)
+	require.Equal(t, byName["experiment"].TraceId, byName["trial"].TraceId)
+	require.Equal(t, string(TrialIDKey), byName["trial"].Attributes[0].Key)
+	require.Equal(t, int64(2), byName["trial"].Attributes[0].Value.GetIntValue())
+
+	counter.Add(context.Background(), 3)
+	require.NoError(t, meterProvider.ForceFlush(context.Background()))
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	require.Len(t, mc.metrics, 1)
+	require.Equal(t, "det.test.events", mc.metrics[0].Name)
+	points := mc.metrics[0].GetSum().DataPoints
+	require.Len(t, points, 1)
+	require.Equal(t, int64(3), points[0].GetAsInt())
+}
@@ -35,6 +35,7 @@ type ExperimentConfigV0 struct {
 	RawOptimizations            *OptimizationsConfigV0      `json:"optimizations"`
 	RawPerformInitialValidation *bool                       `json:"perform_initial_validation"`
 	RawProfiling                *ProfilingConfigV0          `json:"profiling"`
+	RawProgressTimeout          *ProgressTimeoutConfigV0    `json:"progress_timeout"`
 	RawProject                  *string                     `json:"project"`
 	RawRecordsPerEpoch          *int                        `json:"records_per_epoch"`
 	RawReproducibility          *ReproducibilityConfigV0    `json:"reproducibility"`
@@ -212,6 +213,9 @@ type ResourcesConfigV0 struct {
 	RawAgentLabel     *string  `json:"agent_label"`
 	RawResourcePool   *string  `json:"resource_pool"`
 	RawPriority       *int     `json:"priority"`
+	// ExpectedRuntimeSeconds is how long each trial is expected to run once it starts, which
+	// schedulers that plan ahead use to make reservations.
+	RawExpectedRuntimeSeconds *int `json:"expected_runtime_seconds"`
 
 	RawDevices DevicesConfigV0 `json:"devices"`
 }
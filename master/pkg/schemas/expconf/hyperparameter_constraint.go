@@ -0,0 +1,264 @@
+package expconf
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// HPConstraint is a parsed hyperparameter constraint, which compares two arithmetic expressions
+// of numbers and flattened hyperparameter names, like "batch_size * accum_steps <= 512".
+type HPConstraint struct {
+	source      string
+	op          string
+	left, right *hpExpr
+}
+
+// hpExpr is a node of an arithmetic expression: a number, a hyperparameter name or an operator
+// applied to two operands.
+type hpExpr struct {
+	op          byte
+	value       float64
+	name        string
+	left, right *hpExpr
+}
+
+// ParseHPConstraint parses a hyperparameter constraint.
+func ParseHPConstraint(source string) (*HPConstraint, error) {
+	tokens, err := tokenizeHPConstraint(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid constraint %q", source)
+	}
+	p := hpConstraintParser{tokens: tokens}
+	c := &HPConstraint{source: source}
+	if c.left, err = p.sum(); err != nil {
+		return nil, errors.Wrapf(err, "invalid constraint %q", source)
+	}
+	switch op := p.next(); op {
+	case "<", "<=", ">", ">=", "==", "!=":
+		c.op = op
+	default:
+		return nil, errors.Errorf("invalid constraint %q: expected a comparison", source)
+	}
+	if c.right, err = p.sum(); err != nil {
+		return nil, errors.Wrapf(err, "invalid constraint %q", source)
+	}
+	if p.peek() != "" {
+		return nil, errors.Errorf("invalid constraint %q: unexpected %q", source, p.peek())
+	}
+	return c, nil
+}
+
+// String returns the constraint as it was written.
+func (c HPConstraint) String() string {
+	return c.source
+}
+
+// Names returns the sorted names of the hyperparameters the constraint refers to.
+func (c HPConstraint) Names() []string {
+	seen := map[string]bool{}
+	c.left.names(seen)
+	c.right.names(seen)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Eval evaluates the constraint with the values of hyperparameters that lookup returns. It returns
+// false for ok if lookup doesn't have a value for one of the hyperparameters.
+func (c HPConstraint) Eval(lookup func(name string) (float64, bool)) (satisfied, ok bool) {
+	left, ok := c.left.eval(lookup)
+	if !ok {
+		return false, false
+	}
+	right, ok := c.right.eval(lookup)
+	if !ok {
+		return false, false
+	}
+	switch c.op {
+	case "<":
+		return left < right, true
+	case "<=":
+		return left <= right, true
+	case ">":
+		return left > right, true
+	case ">=":
+		return left >= right, true
+	case "==":
+		return left == right, true
+	default:
+		return left != right, true
+	}
+}
+
+func (e *hpExpr) names(seen map[string]bool) {
+	switch {
+	case e.op != 0:
+		e.left.names(seen)
+		e.right.names(seen)
+	case e.name != "":
+		seen[e.name] = true
+	}
+}
+
+func (e *hpExpr) eval(lookup func(name string) (float64, bool)) (float64, bool) {
+	switch {
+	case e.op == 0 && e.name == "":
+		return e.value, true
+	case e.op == 0:
+		return lookup(e.name)
+	}
+	left, ok := e.left.eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	right, ok := e.right.eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	switch e.op {
+	case '+':
+		return left + right, true
+	case '-':
+		return left - right, true
+	case '*':
+		return left * right, true
+	default:
+		return left / right, true
+	}
+}
+
+func tokenizeHPConstraint(source string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(source); {
+		c := rune(source[i])
+		start := i
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '_' || unicode.IsLetter(c):
+			for i < len(source) && (source[i] == '_' || source[i] == '.' ||
+				unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i]))) {
+				i++
+			}
+		case c == '.' || unicode.IsDigit(c):
+			for i < len(source) && (source[i] == '.' || unicode.IsDigit(rune(source[i])) ||
+				source[i] == 'e' || source[i] == 'E' ||
+				((source[i] == '+' || source[i] == '-') && (source[i-1] == 'e' || source[i-1] == 'E'))) {
+				i++
+			}
+			if _, err := strconv.ParseFloat(source[start:i], 64); err != nil {
+				return nil, errors.Errorf("invalid number %q", source[start:i])
+			}
+		case strings.ContainsRune("<>=!", c):
+			i++
+			if i < len(source) && source[i] == '=' {
+				i++
+			}
+			if source[start:i] == "=" || source[start:i] == "!" {
+				return nil, errors.Errorf("unexpected %q", source[start:i])
+			}
+		case strings.ContainsRune("+-*/()", c):
+			i++
+		default:
+			return nil, errors.Errorf("unexpected %q", c)
+		}
+		tokens = append(tokens, source[start:i])
+	}
+	return tokens, nil
+}
+
+// hpConstraintParser is a recursive descent parser for the arithmetic expressions of constraints.
+type hpConstraintParser struct {
+	tokens []string
+}
+
+func (p *hpConstraintParser) peek() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+	return p.tokens[0]
+}
+
+func (p *hpConstraintParser) next() string {
+	token := p.peek()
+	if token != "" {
+		p.tokens = p.tokens[1:]
+	}
+	return token
+}
+
+// sum parses terms separated by + and -.
+func (p *hpConstraintParser) sum() (*hpExpr, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.next()[0]
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = &hpExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+// term parses factors separated by * and /.
+func (p *hpConstraintParser) term() (*hpExpr, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "*" || p.peek() == "/" {
+		op := p.next()[0]
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = &hpExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+// factor parses a number, a name, a negated factor or a parenthesized sum.
+func (p *hpConstraintParser) factor() (*hpExpr, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, errors.New("unexpected end of constraint")
+	case token == "-":
+		operand, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &hpExpr{op: '-', left: &hpExpr{}, right: operand}, nil
+	case token == "(":
+		e, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("expected )")
+		}
+		return e, nil
+	case token[0] == '.' || unicode.IsDigit(rune(token[0])):
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &hpExpr{value: value}, nil
+	case token[0] == '_' || unicode.IsLetter(rune(token[0])):
+		return &hpExpr{name: token}, nil
+	default:
+		return nil, errors.Errorf("unexpected %q", token)
+	}
+}
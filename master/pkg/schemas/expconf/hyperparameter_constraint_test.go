@@ -0,0 +1,108 @@
+package expconf
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/check"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+)
+
+func TestHPConstraint(t *testing.T) {
+	values := map[string]float64{"batch_size": 64, "accum_steps": 8, "optimizer.lr": 0.1}
+	lookup := func(name string) (float64, bool) {
+		v, ok := values[name]
+		return v, ok
+	}
+
+	for _, tc := range []struct {
+		source    string
+		satisfied bool
+	}{
+		{"batch_size * accum_steps <= 512", true},
+		{"batch_size * accum_steps < 512", false},
+		{"batch_size*accum_steps>512", false},
+		{"batch_size / accum_steps == 8", true},
+		{"batch_size - 2 * accum_steps != 48", false},
+		{"(batch_size - 2) * accum_steps >= 496", true},
+		{"-optimizer.lr > -1e-1", false},
+		{"optimizer.lr * 1e3 == 100", true},
+	} {
+		c, err := ParseHPConstraint(tc.source)
+		assert.NilError(t, err, tc.source)
+		satisfied, ok := c.Eval(lookup)
+		assert.Assert(t, ok, tc.source)
+		assert.Equal(t, satisfied, tc.satisfied, tc.source)
+	}
+
+	c, err := ParseHPConstraint("momentum * batch_size < 10")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, c.Names(), []string{"batch_size", "momentum"})
+	_, ok := c.Eval(lookup)
+	assert.Assert(t, !ok)
+}
+
+func TestHPConstraintInvalid(t *testing.T) {
+	for _, source := range []string{
+		"batch_size",
+		"batch_size <= ",
+		"batch_size = 512",
+		"batch_size <= 512 <= 1024",
+		"(batch_size <= 512",
+		"batch_size % 2 == 0",
+		"1.2.3 < batch_size",
+	} {
+		_, err := ParseHPConstraint(source)
+		assert.ErrorContains(t, err, "invalid constraint", source)
+	}
+}
+
+func TestHyperparametersValidate(t *testing.T) {
+	categorical := func(conditions map[string][]interface{}, vals ...interface{}) Hyperparameter {
+		h := CategoricalHyperparameter{RawVals: vals}
+		if conditions != nil {
+			h.RawConditions = &conditions
+		}
+		return Hyperparameter{RawCategoricalHyperparameter: &h}
+	}
+	valid := Hyperparameters{
+		"optimizer": categorical(nil, "sgd", "adam"),
+		"momentum":  categorical(map[string][]interface{}{"optimizer": {"sgd"}}, 0.9, 0.99),
+		"batch_size": Hyperparameter{RawIntHyperparameter: &IntHyperparameter{
+			RawMinval: 16, RawMaxval: 256,
+			RawConstraints: ptrs.Ptr([]string{"batch_size * train.accum_steps <= 512"}),
+		}},
+		"train": Hyperparameter{RawNestedHyperparameter: &map[string]Hyperparameter{
+			"accum_steps": categorical(nil, 1, 2, 4),
+		}},
+	}
+	assert.NilError(t, check.Validate(valid))
+
+	invalid := Hyperparameters{
+		"optimizer": categorical(map[string][]interface{}{"momentum": {0.9}}, "sgd", "adam"),
+		"momentum":  categorical(map[string][]interface{}{"optimizer": {"sgd"}}, 0.9, 0.99),
+		"dropout":   categorical(map[string][]interface{}{"layers": {1}}, 0.1, 0.2),
+		"batch_size": Hyperparameter{RawIntHyperparameter: &IntHyperparameter{
+			RawMinval: 16, RawMaxval: 256,
+			RawConstraints: ptrs.Ptr([]string{
+				"batch_size * accum_steps <= 512",
+				"batch_size * optimizer <= 512",
+				"batch_size <=",
+			}),
+		}},
+	}
+	errs := invalid.Validate()
+	assert.Equal(t, len(errs), 5)
+	for i, msg := range []string{
+		"hyperparameter batch_size has constraint \"batch_size * accum_steps <= 512\" on unknown " +
+			"hyperparameter accum_steps",
+		"hyperparameter batch_size has constraint \"batch_size * optimizer <= 512\" on " +
+			"non-numeric hyperparameter optimizer",
+		"hyperparameter batch_size: invalid constraint \"batch_size <=\"",
+		"hyperparameter dropout has a condition on unknown hyperparameter layers",
+		"hyperparameter conditions form a cycle: momentum -> optimizer -> momentum",
+	} {
+		assert.ErrorContains(t, errs[i], msg)
+	}
+}
@@ -3,6 +3,9 @@ package expconf
 import (
 	"encoding/json"
 	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/determined-ai/determined/master/pkg/union"
 )
@@ -134,26 +137,204 @@ func (h HyperparametersV0) Each(f func(name string, param HyperparameterV0)) {
 	}
 }
 
+// Conditions returns the values that other hyperparameters, named by their flattened names, must
+// take for the hyperparameter to be active. Inactive hyperparameters are left out of the
+// hyperparameters of a trial.
+func (h HyperparameterV0) Conditions() map[string][]interface{} {
+	var conditions *map[string][]interface{}
+	switch {
+	case h.RawConstHyperparameter != nil:
+		conditions = h.RawConstHyperparameter.RawConditions
+	case h.RawIntHyperparameter != nil:
+		conditions = h.RawIntHyperparameter.RawConditions
+	case h.RawDoubleHyperparameter != nil:
+		conditions = h.RawDoubleHyperparameter.RawConditions
+	case h.RawLogHyperparameter != nil:
+		conditions = h.RawLogHyperparameter.RawConditions
+	case h.RawCategoricalHyperparameter != nil:
+		conditions = h.RawCategoricalHyperparameter.RawConditions
+	}
+	if conditions == nil {
+		return nil
+	}
+	return *conditions
+}
+
+// Constraints returns the constraints that the values of the hyperparameters of a trial must
+// satisfy when the hyperparameter is active.
+func (h HyperparameterV0) Constraints() []string {
+	var constraints *[]string
+	switch {
+	case h.RawConstHyperparameter != nil:
+		constraints = h.RawConstHyperparameter.RawConstraints
+	case h.RawIntHyperparameter != nil:
+		constraints = h.RawIntHyperparameter.RawConstraints
+	case h.RawDoubleHyperparameter != nil:
+		constraints = h.RawDoubleHyperparameter.RawConstraints
+	case h.RawLogHyperparameter != nil:
+		constraints = h.RawLogHyperparameter.RawConstraints
+	case h.RawCategoricalHyperparameter != nil:
+		constraints = h.RawCategoricalHyperparameter.RawConstraints
+	}
+	if constraints == nil {
+		return nil
+	}
+	return *constraints
+}
+
+// IsNumeric returns whether every value the hyperparameter can take is a number.
+func (h HyperparameterV0) IsNumeric() bool {
+	isNumber := func(v interface{}) bool {
+		switch v.(type) {
+		case int, int64, float64:
+			return true
+		default:
+			return false
+		}
+	}
+	switch {
+	case h.RawIntHyperparameter != nil, h.RawDoubleHyperparameter != nil,
+		h.RawLogHyperparameter != nil:
+		return true
+	case h.RawConstHyperparameter != nil:
+		return isNumber(h.RawConstHyperparameter.Val())
+	case h.RawCategoricalHyperparameter != nil:
+		for _, v := range h.RawCategoricalHyperparameter.Vals() {
+			if !isNumber(v) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate implements the check.Validatable interface. It checks that the conditions and
+// constraints of the hyperparameters refer to hyperparameters that exist and that the conditions
+// don't depend on each other in a cycle.
+func (h HyperparametersV0) Validate() []error {
+	var errs []error
+	flat := FlattenHPs(h)
+	flat.Each(func(name string, param HyperparameterV0) {
+		conditions := param.Conditions()
+		targets := make([]string, 0, len(conditions))
+		for target := range conditions {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			if _, ok := flat[target]; !ok {
+				errs = append(errs, errors.Errorf(
+					"hyperparameter %s has a condition on unknown hyperparameter %s", name, target))
+			} else if len(conditions[target]) == 0 {
+				errs = append(errs, errors.Errorf(
+					"hyperparameter %s has a condition on %s without any values", name, target))
+			}
+		}
+
+		for _, source := range param.Constraints() {
+			constraint, err := ParseHPConstraint(source)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "hyperparameter %s", name))
+				continue
+			}
+			for _, ref := range constraint.Names() {
+				if refParam, ok := flat[ref]; !ok {
+					errs = append(errs, errors.Errorf(
+						"hyperparameter %s has constraint %q on unknown hyperparameter %s",
+						name, source, ref))
+				} else if !refParam.IsNumeric() {
+					errs = append(errs, errors.Errorf(
+						"hyperparameter %s has constraint %q on non-numeric hyperparameter %s",
+						name, source, ref))
+				}
+			}
+		}
+	})
+	if cycle := conditionCycle(flat); cycle != nil {
+		errs = append(errs, errors.Errorf(
+			"hyperparameter conditions form a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+	return errs
+}
+
+// conditionCycle returns a cycle of hyperparameters that have conditions on each other, if any.
+func conditionCycle(flat HyperparametersV0) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		targets := make([]string, 0, len(flat[name].Conditions()))
+		for target := range flat[name].Conditions() {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			if _, ok := flat[target]; !ok {
+				continue
+			}
+			if cycle := visit(target); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	var cycle []string
+	flat.Each(func(name string, _ HyperparameterV0) {
+		if cycle == nil && state[name] == unvisited {
+			cycle = visit(name)
+		}
+	})
+	return cycle
+}
+
 //go:generate ../gen.sh
 // ConstHyperparameterV0 is a constant.
 type ConstHyperparameterV0 struct {
-	RawVal interface{} `json:"val"`
+	RawVal         interface{}               `json:"val"`
+	RawConditions  *map[string][]interface{} `json:"conditions,omitempty"`
+	RawConstraints *[]string                 `json:"constraints,omitempty"`
 }
 
 //go:generate ../gen.sh
 // IntHyperparameterV0 is an interval of ints.
 type IntHyperparameterV0 struct {
-	RawMinval int  `json:"minval"`
-	RawMaxval int  `json:"maxval"`
-	RawCount  *int `json:"count,omitempty"`
+	RawMinval      int                       `json:"minval"`
+	RawMaxval      int                       `json:"maxval"`
+	RawCount       *int                      `json:"count,omitempty"`
+	RawConditions  *map[string][]interface{} `json:"conditions,omitempty"`
+	RawConstraints *[]string                 `json:"constraints,omitempty"`
 }
 
 //go:generate ../gen.sh
 // DoubleHyperparameterV0 is an interval of float64s.
 type DoubleHyperparameterV0 struct {
-	RawMinval float64 `json:"minval"`
-	RawMaxval float64 `json:"maxval"`
-	RawCount  *int    `json:"count,omitempty"`
+	RawMinval      float64                   `json:"minval"`
+	RawMaxval      float64                   `json:"maxval"`
+	RawCount       *int                      `json:"count,omitempty"`
+	RawConditions  *map[string][]interface{} `json:"conditions,omitempty"`
+	RawConstraints *[]string                 `json:"constraints,omitempty"`
 }
 
 //go:generate ../gen.sh
@@ -162,13 +343,17 @@ type LogHyperparameterV0 struct {
 	// Minimum value is `base ^ minval`.
 	RawMinval float64 `json:"minval"`
 	// Maximum value is `base ^ maxval`.
-	RawMaxval float64 `json:"maxval"`
-	RawBase   float64 `json:"base"`
-	RawCount  *int    `json:"count,omitempty"`
+	RawMaxval      float64                   `json:"maxval"`
+	RawBase        float64                   `json:"base"`
+	RawCount       *int                      `json:"count,omitempty"`
+	RawConditions  *map[string][]interface{} `json:"conditions,omitempty"`
+	RawConstraints *[]string                 `json:"constraints,omitempty"`
 }
 
 //go:generate ../gen.sh
 // CategoricalHyperparameterV0 is a collection of values (levels) of the category.
 type CategoricalHyperparameterV0 struct {
-	RawVals []interface{} `json:"vals"`
+	RawVals        []interface{}             `json:"vals"`
+	RawConditions  *map[string][]interface{} `json:"conditions,omitempty"`
+	RawConstraints *[]string                 `json:"constraints,omitempty"`
 }
@@ -32,17 +32,23 @@ type (
 	Length                    = LengthV0
 	LogHyperparameter         = LogHyperparameterV0
 	OptimizationsConfig       = OptimizationsConfigV0
+	PBTConfig                 = PBTConfigV0
+	PBTExploreConfig          = PBTExploreConfigV0
+	PBTReplaceConfig          = PBTReplaceConfigV0
 	ProfilingConfig           = ProfilingConfigV0
+	ProgressTimeoutConfig     = ProgressTimeoutConfigV0
 	RandomConfig              = RandomConfigV0
 	ReproducibilityConfig     = ReproducibilityConfigV0
 	ResourcesConfig           = ResourcesConfigV0
 	S3Config                  = S3ConfigV0
 	S3DataLayerConfig         = S3DataLayerConfigV0
 	SearcherConfig            = SearcherConfigV0
+	SearcherMetric            = SearcherMetricV0
 	SharedFSConfig            = SharedFSConfigV0
 	SharedFSDataLayerConfig   = SharedFSDataLayerConfigV0
 	SingleConfig              = SingleConfigV0
 	SlurmConfig               = SlurmConfigV0
+	TPEConfig                 = TPEConfigV0
 	PbsConfig                 = PbsConfigV0
 )
 
@@ -0,0 +1,25 @@
+package expconf
+
+import "time"
+
+// Actions to take on trials that make no progress within their progress timeout.
+const (
+	ProgressTimeoutKill    = "kill"
+	ProgressTimeoutRestart = "restart"
+)
+
+//go:generate ../gen.sh
+// ProgressTimeoutConfigV0 configures what happens to trials that stop making progress.
+type ProgressTimeoutConfigV0 struct {
+	RawSeconds *int    `json:"seconds"`
+	RawAction  *string `json:"action"`
+}
+
+// Timeout returns how long trials may go without reporting progress, metrics or checkpoints, or
+// zero if they aren't watched.
+func (p ProgressTimeoutConfigV0) Timeout() time.Duration {
+	if p.RawSeconds == nil {
+		return 0
+	}
+	return time.Duration(*p.RawSeconds) * time.Second
+}
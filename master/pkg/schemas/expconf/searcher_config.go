@@ -15,6 +15,8 @@ type SearcherConfigV0 struct {
 	RawSingleConfig       *SingleConfigV0       `union:"name,single" json:"-"`
 	RawRandomConfig       *RandomConfigV0       `union:"name,random" json:"-"`
 	RawGridConfig         *GridConfigV0         `union:"name,grid" json:"-"`
+	RawTPEConfig          *TPEConfigV0          `union:"name,tpe" json:"-"`
+	RawPBTConfig          *PBTConfigV0          `union:"name,pbt" json:"-"`
 	RawAsyncHalvingConfig *AsyncHalvingConfigV0 `union:"name,async_halving" json:"-"`
 	RawAdaptiveASHAConfig *AdaptiveASHAConfigV0 `union:"name,adaptive_asha" json:"-"`
 	RawCustomConfig       *CustomConfigV0       `union:"name,custom" json:"-"`
@@ -24,10 +26,18 @@ type SearcherConfigV0 struct {
 	RawAdaptiveConfig       *AdaptiveConfigV0       `union:"name,adaptive" json:"-"`
 	RawAdaptiveSimpleConfig *AdaptiveSimpleConfigV0 `union:"name,adaptive_simple" json:"-"`
 
-	RawMetric               *string `json:"metric"`
-	RawSmallerIsBetter      *bool   `json:"smaller_is_better"`
-	RawSourceTrialID        *int    `json:"source_trial_id"`
-	RawSourceCheckpointUUID *string `json:"source_checkpoint_uuid"`
+	RawMetric               *string            `json:"metric"`
+	RawSmallerIsBetter      *bool              `json:"smaller_is_better"`
+	RawMetrics              []SearcherMetricV0 `json:"metrics"`
+	RawSourceTrialID        *int               `json:"source_trial_id"`
+	RawSourceCheckpointUUID *string            `json:"source_checkpoint_uuid"`
+}
+
+//go:generate ../gen.sh
+// SearcherMetricV0 is one of the metrics of a search that ranks trials by several metrics.
+type SearcherMetricV0 struct {
+	RawName            string `json:"name"`
+	RawSmallerIsBetter *bool  `json:"smaller_is_better"`
 }
 
 // Merge implements schemas.Mergeable.
@@ -49,6 +59,25 @@ func (s *SearcherConfigV0) UnmarshalJSON(data []byte) error {
 	return errors.Wrap(json.Unmarshal(data, DefaultParser(s)), "failed to parse searcher config")
 }
 
+// Validate implements the check.Validatable interface.
+func (s SearcherConfigV0) Validate() []error {
+	if s.RawMetrics == nil {
+		return nil
+	}
+	var errs []error
+	if len(s.RawMetrics) < 2 {
+		errs = append(errs, errors.New("'metrics' must list at least two metrics"))
+	}
+	seen := map[string]bool{}
+	for _, m := range s.RawMetrics {
+		if seen[m.RawName] {
+			errs = append(errs, errors.Errorf("'metrics' lists metric %s more than once", m.RawName))
+		}
+		seen[m.RawName] = true
+	}
+	return errs
+}
+
 // Unit implements the model.InUnits interface.
 func (s SearcherConfigV0) Unit() Unit {
 	switch {
@@ -58,6 +87,10 @@ func (s SearcherConfigV0) Unit() Unit {
 		return s.RawRandomConfig.Unit()
 	case s.RawGridConfig != nil:
 		return s.RawGridConfig.Unit()
+	case s.RawTPEConfig != nil:
+		return s.RawTPEConfig.Unit()
+	case s.RawPBTConfig != nil:
+		return s.RawPBTConfig.Unit()
 	case s.RawAsyncHalvingConfig != nil:
 		return s.RawAsyncHalvingConfig.Unit()
 	case s.RawAdaptiveASHAConfig != nil:
@@ -105,6 +138,51 @@ func (r RandomConfigV0) Unit() Unit {
 	return r.RawMaxLength.Unit
 }
 
+//go:generate ../gen.sh
+// TPEConfigV0 configures a Tree-structured Parzen Estimator search.
+type TPEConfigV0 struct {
+	RawMaxLength           *LengthV0 `json:"max_length"`
+	RawMaxTrials           *int      `json:"max_trials"`
+	RawMaxConcurrentTrials *int      `json:"max_concurrent_trials"`
+	RawNStartupTrials      *int      `json:"n_startup_trials"`
+	RawGamma               *float64  `json:"gamma"`
+	RawNEICandidates       *int      `json:"n_ei_candidates"`
+}
+
+// Unit implements the model.InUnits interface.
+func (t TPEConfigV0) Unit() Unit {
+	return t.RawMaxLength.Unit
+}
+
+//go:generate ../gen.sh
+// PBTConfigV0 configures a Population Based Training search.
+type PBTConfigV0 struct {
+	RawPopulationSize  *int                `json:"population_size"`
+	RawNumRounds       *int                `json:"num_rounds"`
+	RawLengthPerRound  *LengthV0           `json:"length_per_round"`
+	RawReplaceFunction *PBTReplaceConfigV0 `json:"replace_function"`
+	RawExploreFunction *PBTExploreConfigV0 `json:"explore_function"`
+}
+
+// Unit implements the model.InUnits interface.
+func (p PBTConfigV0) Unit() Unit {
+	return p.RawLengthPerRound.Unit
+}
+
+//go:generate ../gen.sh
+// PBTReplaceConfigV0 configures which trials a PBT search replaces after each round.
+type PBTReplaceConfigV0 struct {
+	RawTruncateFraction float64 `json:"truncate_fraction"`
+}
+
+//go:generate ../gen.sh
+// PBTExploreConfigV0 configures how a PBT search changes the hyperparameters of the trials it
+// copies.
+type PBTExploreConfigV0 struct {
+	RawResampleProbability float64 `json:"resample_probability"`
+	RawPerturbFactor       float64 `json:"perturb_factor"`
+}
+
 //go:generate ../gen.sh
 // GridConfigV0 configures a grid search.
 type GridConfigV0 struct {
@@ -16,6 +16,22 @@ func (c *CategoricalHyperparameterV0) SetVals(val []interface{}) {
 	c.RawVals = val
 }
 
+func (c CategoricalHyperparameterV0) Conditions() *map[string][]interface{} {
+	return c.RawConditions
+}
+
+func (c *CategoricalHyperparameterV0) SetConditions(val *map[string][]interface{}) {
+	c.RawConditions = val
+}
+
+func (c CategoricalHyperparameterV0) Constraints() *[]string {
+	return c.RawConstraints
+}
+
+func (c *CategoricalHyperparameterV0) SetConstraints(val *[]string) {
+	c.RawConstraints = val
+}
+
 func (c CategoricalHyperparameterV0) ParsedSchema() interface{} {
 	return schemas.ParsedCategoricalHyperparameterV0()
 }
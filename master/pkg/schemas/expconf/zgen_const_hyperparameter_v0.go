@@ -16,6 +16,22 @@ func (c *ConstHyperparameterV0) SetVal(val interface{}) {
 	c.RawVal = val
 }
 
+func (c ConstHyperparameterV0) Conditions() *map[string][]interface{} {
+	return c.RawConditions
+}
+
+func (c *ConstHyperparameterV0) SetConditions(val *map[string][]interface{}) {
+	c.RawConditions = val
+}
+
+func (c ConstHyperparameterV0) Constraints() *[]string {
+	return c.RawConstraints
+}
+
+func (c *ConstHyperparameterV0) SetConstraints(val *[]string) {
+	c.RawConstraints = val
+}
+
 func (c ConstHyperparameterV0) ParsedSchema() interface{} {
 	return schemas.ParsedConstHyperparameterV0()
 }
@@ -32,6 +32,22 @@ func (d *DoubleHyperparameterV0) SetCount(val *int) {
 	d.RawCount = val
 }
 
+func (d DoubleHyperparameterV0) Conditions() *map[string][]interface{} {
+	return d.RawConditions
+}
+
+func (d *DoubleHyperparameterV0) SetConditions(val *map[string][]interface{}) {
+	d.RawConditions = val
+}
+
+func (d DoubleHyperparameterV0) Constraints() *[]string {
+	return d.RawConstraints
+}
+
+func (d *DoubleHyperparameterV0) SetConstraints(val *[]string) {
+	d.RawConstraints = val
+}
+
 func (d DoubleHyperparameterV0) ParsedSchema() interface{} {
 	return schemas.ParsedDoubleHyperparameterV0()
 }
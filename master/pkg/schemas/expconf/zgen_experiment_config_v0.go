@@ -188,6 +188,17 @@ func (e *ExperimentConfigV0) SetProfiling(val ProfilingConfigV0) {
 	e.RawProfiling = &val
 }
 
+func (e ExperimentConfigV0) ProgressTimeout() ProgressTimeoutConfigV0 {
+	if e.RawProgressTimeout == nil {
+		panic("You must call WithDefaults on ExperimentConfigV0 before .ProgressTimeout")
+	}
+	return *e.RawProgressTimeout
+}
+
+func (e *ExperimentConfigV0) SetProgressTimeout(val ProgressTimeoutConfigV0) {
+	e.RawProgressTimeout = &val
+}
+
 func (e ExperimentConfigV0) Project() string {
 	if e.RawProject == nil {
 		panic("You must call WithDefaults on ExperimentConfigV0 before .Project")
@@ -32,6 +32,22 @@ func (i *IntHyperparameterV0) SetCount(val *int) {
 	i.RawCount = val
 }
 
+func (i IntHyperparameterV0) Conditions() *map[string][]interface{} {
+	return i.RawConditions
+}
+
+func (i *IntHyperparameterV0) SetConditions(val *map[string][]interface{}) {
+	i.RawConditions = val
+}
+
+func (i IntHyperparameterV0) Constraints() *[]string {
+	return i.RawConstraints
+}
+
+func (i *IntHyperparameterV0) SetConstraints(val *[]string) {
+	i.RawConstraints = val
+}
+
 func (i IntHyperparameterV0) ParsedSchema() interface{} {
 	return schemas.ParsedIntHyperparameterV0()
 }
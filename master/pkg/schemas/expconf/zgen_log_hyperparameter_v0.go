@@ -40,6 +40,22 @@ func (l *LogHyperparameterV0) SetCount(val *int) {
 	l.RawCount = val
 }
 
+func (l LogHyperparameterV0) Conditions() *map[string][]interface{} {
+	return l.RawConditions
+}
+
+func (l *LogHyperparameterV0) SetConditions(val *map[string][]interface{}) {
+	l.RawConditions = val
+}
+
+func (l LogHyperparameterV0) Constraints() *[]string {
+	return l.RawConstraints
+}
+
+func (l *LogHyperparameterV0) SetConstraints(val *[]string) {
+	l.RawConstraints = val
+}
+
 func (l LogHyperparameterV0) ParsedSchema() interface{} {
 	return schemas.ParsedLogHyperparameterV0()
 }
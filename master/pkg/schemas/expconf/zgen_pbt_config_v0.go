@@ -0,0 +1,76 @@
+// Code generated by gen.py. DO NOT EDIT.
+
+package expconf
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v2"
+
+	"github.com/determined-ai/determined/master/pkg/schemas"
+)
+
+func (p PBTConfigV0) PopulationSize() int {
+	if p.RawPopulationSize == nil {
+		panic("You must call WithDefaults on PBTConfigV0 before .PopulationSize")
+	}
+	return *p.RawPopulationSize
+}
+
+func (p *PBTConfigV0) SetPopulationSize(val int) {
+	p.RawPopulationSize = &val
+}
+
+func (p PBTConfigV0) NumRounds() int {
+	if p.RawNumRounds == nil {
+		panic("You must call WithDefaults on PBTConfigV0 before .NumRounds")
+	}
+	return *p.RawNumRounds
+}
+
+func (p *PBTConfigV0) SetNumRounds(val int) {
+	p.RawNumRounds = &val
+}
+
+func (p PBTConfigV0) LengthPerRound() LengthV0 {
+	if p.RawLengthPerRound == nil {
+		panic("You must call WithDefaults on PBTConfigV0 before .LengthPerRound")
+	}
+	return *p.RawLengthPerRound
+}
+
+func (p *PBTConfigV0) SetLengthPerRound(val LengthV0) {
+	p.RawLengthPerRound = &val
+}
+
+func (p PBTConfigV0) ReplaceFunction() PBTReplaceConfigV0 {
+	if p.RawReplaceFunction == nil {
+		panic("You must call WithDefaults on PBTConfigV0 before .ReplaceFunction")
+	}
+	return *p.RawReplaceFunction
+}
+
+func (p *PBTConfigV0) SetReplaceFunction(val PBTReplaceConfigV0) {
+	p.RawReplaceFunction = &val
+}
+
+func (p PBTConfigV0) ExploreFunction() PBTExploreConfigV0 {
+	if p.RawExploreFunction == nil {
+		panic("You must call WithDefaults on PBTConfigV0 before .ExploreFunction")
+	}
+	return *p.RawExploreFunction
+}
+
+func (p *PBTConfigV0) SetExploreFunction(val PBTExploreConfigV0) {
+	p.RawExploreFunction = &val
+}
+
+func (p PBTConfigV0) ParsedSchema() interface{} {
+	return schemas.ParsedPBTConfigV0()
+}
+
+func (p PBTConfigV0) SanityValidator() *jsonschema.Schema {
+	return schemas.GetSanityValidator("http://determined.ai/schemas/expconf/v0/searcher-pbt.json")
+}
+
+func (p PBTConfigV0) CompletenessValidator() *jsonschema.Schema {
+	return schemas.GetCompletenessValidator("http://determined.ai/schemas/expconf/v0/searcher-pbt.json")
+}
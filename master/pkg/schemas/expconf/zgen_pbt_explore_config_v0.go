@@ -0,0 +1,37 @@
+// Code generated by gen.py. DO NOT EDIT.
+
+package expconf
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v2"
+
+	"github.com/determined-ai/determined/master/pkg/schemas"
+)
+
+func (p PBTExploreConfigV0) ResampleProbability() float64 {
+	return p.RawResampleProbability
+}
+
+func (p *PBTExploreConfigV0) SetResampleProbability(val float64) {
+	p.RawResampleProbability = val
+}
+
+func (p PBTExploreConfigV0) PerturbFactor() float64 {
+	return p.RawPerturbFactor
+}
+
+func (p *PBTExploreConfigV0) SetPerturbFactor(val float64) {
+	p.RawPerturbFactor = val
+}
+
+func (p PBTExploreConfigV0) ParsedSchema() interface{} {
+	return schemas.ParsedPBTExploreConfigV0()
+}
+
+func (p PBTExploreConfigV0) SanityValidator() *jsonschema.Schema {
+	return schemas.GetSanityValidator("http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json")
+}
+
+func (p PBTExploreConfigV0) CompletenessValidator() *jsonschema.Schema {
+	return schemas.GetCompletenessValidator("http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json")
+}
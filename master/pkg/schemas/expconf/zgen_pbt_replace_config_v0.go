@@ -0,0 +1,29 @@
+// Code generated by gen.py. DO NOT EDIT.
+
+package expconf
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v2"
+
+	"github.com/determined-ai/determined/master/pkg/schemas"
+)
+
+func (p PBTReplaceConfigV0) TruncateFraction() float64 {
+	return p.RawTruncateFraction
+}
+
+func (p *PBTReplaceConfigV0) SetTruncateFraction(val float64) {
+	p.RawTruncateFraction = val
+}
+
+func (p PBTReplaceConfigV0) ParsedSchema() interface{} {
+	return schemas.ParsedPBTReplaceConfigV0()
+}
+
+func (p PBTReplaceConfigV0) SanityValidator() *jsonschema.Schema {
+	return schemas.GetSanityValidator("http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json")
+}
+
+func (p PBTReplaceConfigV0) CompletenessValidator() *jsonschema.Schema {
+	return schemas.GetCompletenessValidator("http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json")
+}
@@ -0,0 +1,40 @@
+// Code generated by gen.py. DO NOT EDIT.
+
+package expconf
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v2"
+
+	"github.com/determined-ai/determined/master/pkg/schemas"
+)
+
+func (p ProgressTimeoutConfigV0) Seconds() *int {
+	return p.RawSeconds
+}
+
+func (p *ProgressTimeoutConfigV0) SetSeconds(val *int) {
+	p.RawSeconds = val
+}
+
+func (p ProgressTimeoutConfigV0) Action() string {
+	if p.RawAction == nil {
+		panic("You must call WithDefaults on ProgressTimeoutConfigV0 before .Action")
+	}
+	return *p.RawAction
+}
+
+func (p *ProgressTimeoutConfigV0) SetAction(val string) {
+	p.RawAction = &val
+}
+
+func (p ProgressTimeoutConfigV0) ParsedSchema() interface{} {
+	return schemas.ParsedProgressTimeoutConfigV0()
+}
+
+func (p ProgressTimeoutConfigV0) SanityValidator() *jsonschema.Schema {
+	return schemas.GetSanityValidator("http://determined.ai/schemas/expconf/v0/progress-timeout.json")
+}
+
+func (p ProgressTimeoutConfigV0) CompletenessValidator() *jsonschema.Schema {
+	return schemas.GetCompletenessValidator("http://determined.ai/schemas/expconf/v0/progress-timeout.json")
+}
@@ -95,6 +95,14 @@ func (r *ResourcesConfigV0) SetPriority(val *int) {
 	r.RawPriority = val
 }
 
+func (r ResourcesConfigV0) ExpectedRuntimeSeconds() *int {
+	return r.RawExpectedRuntimeSeconds
+}
+
+func (r *ResourcesConfigV0) SetExpectedRuntimeSeconds(val *int) {
+	r.RawExpectedRuntimeSeconds = val
+}
+
 func (r ResourcesConfigV0) Devices() DevicesConfigV0 {
 	return r.RawDevices
 }
@@ -30,6 +30,14 @@ func (s *SearcherConfigV0) SetSmallerIsBetter(val bool) {
 	s.RawSmallerIsBetter = &val
 }
 
+func (s SearcherConfigV0) Metrics() []SearcherMetricV0 {
+	return s.RawMetrics
+}
+
+func (s *SearcherConfigV0) SetMetrics(val []SearcherMetricV0) {
+	s.RawMetrics = val
+}
+
 func (s SearcherConfigV0) SourceTrialID() *int {
 	return s.RawSourceTrialID
 }
@@ -56,6 +64,12 @@ func (s SearcherConfigV0) GetUnionMember() interface{} {
 	if s.RawGridConfig != nil {
 		return *s.RawGridConfig
 	}
+	if s.RawTPEConfig != nil {
+		return *s.RawTPEConfig
+	}
+	if s.RawPBTConfig != nil {
+		return *s.RawPBTConfig
+	}
 	if s.RawAsyncHalvingConfig != nil {
 		return *s.RawAsyncHalvingConfig
 	}
@@ -0,0 +1,40 @@
+// Code generated by gen.py. DO NOT EDIT.
+
+package expconf
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v2"
+
+	"github.com/determined-ai/determined/master/pkg/schemas"
+)
+
+func (s SearcherMetricV0) Name() string {
+	return s.RawName
+}
+
+func (s *SearcherMetricV0) SetName(val string) {
+	s.RawName = val
+}
+
+func (s SearcherMetricV0) SmallerIsBetter() bool {
+	if s.RawSmallerIsBetter == nil {
+		panic("You must call WithDefaults on SearcherMetricV0 before .SmallerIsBetter")
+	}
+	return *s.RawSmallerIsBetter
+}
+
+func (s *SearcherMetricV0) SetSmallerIsBetter(val bool) {
+	s.RawSmallerIsBetter = &val
+}
+
+func (s SearcherMetricV0) ParsedSchema() interface{} {
+	return schemas.ParsedSearcherMetricV0()
+}
+
+func (s SearcherMetricV0) SanityValidator() *jsonschema.Schema {
+	return schemas.GetSanityValidator("http://determined.ai/schemas/expconf/v0/searcher-metric.json")
+}
+
+func (s SearcherMetricV0) CompletenessValidator() *jsonschema.Schema {
+	return schemas.GetCompletenessValidator("http://determined.ai/schemas/expconf/v0/searcher-metric.json")
+}
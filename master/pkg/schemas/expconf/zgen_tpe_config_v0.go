@@ -0,0 +1,87 @@
+// Code generated by gen.py. DO NOT EDIT.
+
+package expconf
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v2"
+
+	"github.com/determined-ai/determined/master/pkg/schemas"
+)
+
+func (t TPEConfigV0) MaxLength() LengthV0 {
+	if t.RawMaxLength == nil {
+		panic("You must call WithDefaults on TPEConfigV0 before .MaxLength")
+	}
+	return *t.RawMaxLength
+}
+
+func (t *TPEConfigV0) SetMaxLength(val LengthV0) {
+	t.RawMaxLength = &val
+}
+
+func (t TPEConfigV0) MaxTrials() int {
+	if t.RawMaxTrials == nil {
+		panic("You must call WithDefaults on TPEConfigV0 before .MaxTrials")
+	}
+	return *t.RawMaxTrials
+}
+
+func (t *TPEConfigV0) SetMaxTrials(val int) {
+	t.RawMaxTrials = &val
+}
+
+func (t TPEConfigV0) MaxConcurrentTrials() int {
+	if t.RawMaxConcurrentTrials == nil {
+		panic("You must call WithDefaults on TPEConfigV0 before .MaxConcurrentTrials")
+	}
+	return *t.RawMaxConcurrentTrials
+}
+
+func (t *TPEConfigV0) SetMaxConcurrentTrials(val int) {
+	t.RawMaxConcurrentTrials = &val
+}
+
+func (t TPEConfigV0) NStartupTrials() int {
+	if t.RawNStartupTrials == nil {
+		panic("You must call WithDefaults on TPEConfigV0 before .NStartupTrials")
+	}
+	return *t.RawNStartupTrials
+}
+
+func (t *TPEConfigV0) SetNStartupTrials(val int) {
+	t.RawNStartupTrials = &val
+}
+
+func (t TPEConfigV0) Gamma() float64 {
+	if t.RawGamma == nil {
+		panic("You must call WithDefaults on TPEConfigV0 before .Gamma")
+	}
+	return *t.RawGamma
+}
+
+func (t *TPEConfigV0) SetGamma(val float64) {
+	t.RawGamma = &val
+}
+
+func (t TPEConfigV0) NEICandidates() int {
+	if t.RawNEICandidates == nil {
+		panic("You must call WithDefaults on TPEConfigV0 before .NEICandidates")
+	}
+	return *t.RawNEICandidates
+}
+
+func (t *TPEConfigV0) SetNEICandidates(val int) {
+	t.RawNEICandidates = &val
+}
+
+func (t TPEConfigV0) ParsedSchema() interface{} {
+	return schemas.ParsedTPEConfigV0()
+}
+
+func (t TPEConfigV0) SanityValidator() *jsonschema.Schema {
+	return schemas.GetSanityValidator("http://determined.ai/schemas/expconf/v0/searcher-tpe.json")
+}
+
+func (t TPEConfigV0) CompletenessValidator() *jsonschema.Schema {
+	return schemas.GetCompletenessValidator("http://determined.ai/schemas/expconf/v0/searcher-tpe.json")
+}
@@ -1109,6 +1109,14 @@ var (
             "default": {},
             "optionalRef": "http://determined.ai/schemas/expconf/v0/profiling.json"
         },
+        "progress_timeout": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": {},
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/progress-timeout.json"
+        },
         "project": {
             "type": [
                 "string",
@@ -1463,6 +1471,27 @@ var (
         "vals": {
             "type": "array",
             "minLength": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     }
 }
@@ -1481,7 +1510,28 @@ var (
         "type": {
             "const": "const"
         },
-        "val": true
+        "val": true,
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
+        }
     }
 }
 `)
@@ -1513,6 +1563,27 @@ var (
             ],
             "default": null,
             "minimum": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     },
     "compareProperties": {
@@ -1550,6 +1621,27 @@ var (
             ],
             "default": null,
             "minimum": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     },
     "compareProperties": {
@@ -1592,6 +1684,27 @@ var (
             ],
             "default": null,
             "minimum": 1
+        },
+        "conditions": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "additionalProperties": {
+                "type": "array",
+                "minLength": 1
+            }
+        },
+        "constraints": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "type": "string"
+            }
         }
     },
     "compareProperties": {
@@ -1861,6 +1974,33 @@ var (
         "b": "end_after_batch"
     }
 }
+`)
+	textProgressTimeoutConfigV0 = []byte(`{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/progress-timeout.json",
+    "title": "ProgressTimeoutConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [],
+    "properties": {
+        "seconds": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "action": {
+            "enum": [
+                null,
+                "kill",
+                "restart"
+            ],
+            "default": "kill"
+        }
+    }
+}
 `)
 	textRegistryAuthV0 = []byte(`{
     "$schema": "http://json-schema.org/draft-07/schema#",
@@ -1966,6 +2106,14 @@ var (
             "default": [],
             "optionalRef": "http://determined.ai/schemas/expconf/v0/devices.json"
         },
+        "expected_runtime_seconds": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 1,
+            "default": null
+        },
         "max_slots": {
             "type": [
                 "integer",
@@ -2235,6 +2383,16 @@ var (
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -2532,6 +2690,16 @@ var (
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -2675,6 +2843,164 @@ var (
         ]
     }
 }
+`)
+	textSearcherMetricV0 = []byte(`{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-metric.json",
+    "title": "SearcherMetric",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "name"
+    ],
+    "properties": {
+        "name": {
+            "type": "string"
+        },
+        "smaller_is_better": {
+            "type": [
+                "boolean",
+                "null"
+            ],
+            "default": true
+        }
+    }
+}
+`)
+	textPBTExploreConfigV0 = []byte(`{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json",
+    "title": "PBTExploreConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "resample_probability",
+        "perturb_factor"
+    ],
+    "properties": {
+        "resample_probability": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 1
+        },
+        "perturb_factor": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 1
+        }
+    }
+}
+`)
+	textPBTReplaceConfigV0 = []byte(`{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json",
+    "title": "PBTReplaceConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "truncate_fraction"
+    ],
+    "properties": {
+        "truncate_fraction": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 0.5
+        }
+    }
+}
+`)
+	textPBTConfigV0 = []byte(`{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-pbt.json",
+    "title": "PBTConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "name"
+    ],
+    "eventuallyRequired": [
+        "population_size",
+        "num_rounds",
+        "length_per_round",
+        "replace_function",
+        "explore_function",
+        "metric"
+    ],
+    "properties": {
+        "name": {
+            "const": "pbt"
+        },
+        "population_size": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "num_rounds": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "length_per_round": {
+            "type": [
+                "object",
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-length.json"
+        },
+        "replace_function": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json"
+        },
+        "explore_function": {
+            "type": [
+                "object",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json"
+        },
+        "metric": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        },
+        "smaller_is_better": {
+            "type": [
+                "boolean",
+                "null"
+            ],
+            "default": true
+        },
+        "source_trial_id": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null
+        },
+        "source_checkpoint_uuid": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        }
+    }
+}
 `)
 	textRandomConfigV0 = []byte(`{
     "$schema": "http://json-schema.org/draft-07/schema#",
@@ -2733,6 +3059,16 @@ var (
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -2896,6 +3232,105 @@ var (
         }
     }
 }
+`)
+	textTPEConfigV0 = []byte(`{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "$id": "http://determined.ai/schemas/expconf/v0/searcher-tpe.json",
+    "title": "TPEConfig",
+    "type": "object",
+    "additionalProperties": false,
+    "required": [
+        "name"
+    ],
+    "eventuallyRequired": [
+        "max_trials",
+        "max_length",
+        "metric"
+    ],
+    "properties": {
+        "name": {
+            "const": "tpe"
+        },
+        "max_concurrent_trials": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 0,
+            "default": 4
+        },
+        "max_trials": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "minimum": 1
+        },
+        "max_length": {
+            "type": [
+                "object",
+                "integer",
+                "null"
+            ],
+            "default": null,
+            "optionalRef": "http://determined.ai/schemas/expconf/v0/searcher-length.json"
+        },
+        "n_startup_trials": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 1,
+            "default": 10
+        },
+        "gamma": {
+            "type": [
+                "number",
+                "null"
+            ],
+            "exclusiveMinimum": 0,
+            "exclusiveMaximum": 1,
+            "default": 0.25
+        },
+        "n_ei_candidates": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "minimum": 1,
+            "default": 24
+        },
+        "metric": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        },
+        "smaller_is_better": {
+            "type": [
+                "boolean",
+                "null"
+            ],
+            "default": true
+        },
+        "source_trial_id": {
+            "type": [
+                "integer",
+                "null"
+            ],
+            "default": null
+        },
+        "source_checkpoint_uuid": {
+            "type": [
+                "string",
+                "null"
+            ],
+            "default": null
+        }
+    }
+}
 `)
 	textSearcherConfigV0 = []byte(`{
     "$schema": "http://json-schema.org/draft-07/schema#",
@@ -2909,7 +3344,7 @@ var (
     },
     "then": {
         "union": {
-            "defaultMessage": "is not an object where object[\"name\"] is one of 'single', 'random', 'grid', 'custom', or 'adaptive_asha'",
+            "defaultMessage": "is not an object where object[\"name\"] is one of 'single', 'random', 'grid', 'tpe', 'pbt', 'custom', or 'adaptive_asha'",
             "items": [
                 {
                     "unionKey": "const:name=single",
@@ -2923,6 +3358,14 @@ var (
                     "unionKey": "const:name=grid",
                     "$ref": "http://determined.ai/schemas/expconf/v0/searcher-grid.json"
                 },
+                {
+                    "unionKey": "const:name=tpe",
+                    "$ref": "http://determined.ai/schemas/expconf/v0/searcher-tpe.json"
+                },
+                {
+                    "unionKey": "const:name=pbt",
+                    "$ref": "http://determined.ai/schemas/expconf/v0/searcher-pbt.json"
+                },
                 {
                     "unionKey": "const:name=custom",
                     "$ref": "http://determined.ai/schemas/expconf/v0/searcher-custom.json"
@@ -2961,13 +3404,21 @@ var (
     "properties": {
         "bracket_rungs": true,
         "divisor": true,
+        "explore_function": true,
+        "gamma": true,
+        "length_per_round": true,
         "max_concurrent_trials": true,
         "max_length": true,
         "max_rungs": true,
         "max_trials": true,
         "mode": true,
+        "n_ei_candidates": true,
+        "n_startup_trials": true,
         "name": true,
+        "num_rounds": true,
         "num_rungs": true,
+        "population_size": true,
+        "replace_function": true,
         "stop_once": true,
         "metric": {
             "type": [
@@ -2983,6 +3434,16 @@ var (
             ],
             "default": true
         },
+        "metrics": {
+            "type": [
+                "array",
+                "null"
+            ],
+            "default": null,
+            "items": {
+                "$ref": "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+            }
+        },
         "source_trial_id": {
             "type": [
                 "integer",
@@ -3369,6 +3830,8 @@ var (
 
 	schemaProfilingConfigV0 interface{}
 
+	schemaProgressTimeoutConfigV0 interface{}
+
 	schemaRegistryAuthV0 interface{}
 
 	schemaReproducibilityConfigV0 interface{}
@@ -3391,12 +3854,22 @@ var (
 
 	schemaSearcherLengthV0 interface{}
 
+	schemaSearcherMetricV0 interface{}
+
+	schemaPBTExploreConfigV0 interface{}
+
+	schemaPBTReplaceConfigV0 interface{}
+
+	schemaPBTConfigV0 interface{}
+
 	schemaRandomConfigV0 interface{}
 
 	schemaSingleConfigV0 interface{}
 
 	schemaSyncHalvingConfigV0 interface{}
 
+	schemaTPEConfigV0 interface{}
+
 	schemaSearcherConfigV0 interface{}
 
 	schemaSecurityConfigV0 interface{}
@@ -4122,6 +4595,26 @@ func ParsedProfilingConfigV0() interface{} {
 	return schemaProfilingConfigV0
 }
 
+func ParsedProgressTimeoutConfigV0() interface{} {
+	cacheLock.RLock()
+	if schemaProgressTimeoutConfigV0 != nil {
+		cacheLock.RUnlock()
+		return schemaProgressTimeoutConfigV0
+	}
+	cacheLock.RUnlock()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if schemaProgressTimeoutConfigV0 != nil {
+		return schemaProgressTimeoutConfigV0
+	}
+	err := json.Unmarshal(textProgressTimeoutConfigV0, &schemaProgressTimeoutConfigV0)
+	if err != nil {
+		panic("invalid embedded json for ProgressTimeoutConfigV0")
+	}
+	return schemaProgressTimeoutConfigV0
+}
+
 func ParsedRegistryAuthV0() interface{} {
 	cacheLock.RLock()
 	if schemaRegistryAuthV0 != nil {
@@ -4342,6 +4835,86 @@ func ParsedSearcherLengthV0() interface{} {
 	return schemaSearcherLengthV0
 }
 
+func ParsedSearcherMetricV0() interface{} {
+	cacheLock.RLock()
+	if schemaSearcherMetricV0 != nil {
+		cacheLock.RUnlock()
+		return schemaSearcherMetricV0
+	}
+	cacheLock.RUnlock()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if schemaSearcherMetricV0 != nil {
+		return schemaSearcherMetricV0
+	}
+	err := json.Unmarshal(textSearcherMetricV0, &schemaSearcherMetricV0)
+	if err != nil {
+		panic("invalid embedded json for SearcherMetricV0")
+	}
+	return schemaSearcherMetricV0
+}
+
+func ParsedPBTExploreConfigV0() interface{} {
+	cacheLock.RLock()
+	if schemaPBTExploreConfigV0 != nil {
+		cacheLock.RUnlock()
+		return schemaPBTExploreConfigV0
+	}
+	cacheLock.RUnlock()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if schemaPBTExploreConfigV0 != nil {
+		return schemaPBTExploreConfigV0
+	}
+	err := json.Unmarshal(textPBTExploreConfigV0, &schemaPBTExploreConfigV0)
+	if err != nil {
+		panic("invalid embedded json for PBTExploreConfigV0")
+	}
+	return schemaPBTExploreConfigV0
+}
+
+func ParsedPBTReplaceConfigV0() interface{} {
+	cacheLock.RLock()
+	if schemaPBTReplaceConfigV0 != nil {
+		cacheLock.RUnlock()
+		return schemaPBTReplaceConfigV0
+	}
+	cacheLock.RUnlock()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if schemaPBTReplaceConfigV0 != nil {
+		return schemaPBTReplaceConfigV0
+	}
+	err := json.Unmarshal(textPBTReplaceConfigV0, &schemaPBTReplaceConfigV0)
+	if err != nil {
+		panic("invalid embedded json for PBTReplaceConfigV0")
+	}
+	return schemaPBTReplaceConfigV0
+}
+
+func ParsedPBTConfigV0() interface{} {
+	cacheLock.RLock()
+	if schemaPBTConfigV0 != nil {
+		cacheLock.RUnlock()
+		return schemaPBTConfigV0
+	}
+	cacheLock.RUnlock()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if schemaPBTConfigV0 != nil {
+		return schemaPBTConfigV0
+	}
+	err := json.Unmarshal(textPBTConfigV0, &schemaPBTConfigV0)
+	if err != nil {
+		panic("invalid embedded json for PBTConfigV0")
+	}
+	return schemaPBTConfigV0
+}
+
 func ParsedRandomConfigV0() interface{} {
 	cacheLock.RLock()
 	if schemaRandomConfigV0 != nil {
@@ -4402,6 +4975,26 @@ func ParsedSyncHalvingConfigV0() interface{} {
 	return schemaSyncHalvingConfigV0
 }
 
+func ParsedTPEConfigV0() interface{} {
+	cacheLock.RLock()
+	if schemaTPEConfigV0 != nil {
+		cacheLock.RUnlock()
+		return schemaTPEConfigV0
+	}
+	cacheLock.RUnlock()
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if schemaTPEConfigV0 != nil {
+		return schemaTPEConfigV0
+	}
+	err := json.Unmarshal(textTPEConfigV0, &schemaTPEConfigV0)
+	if err != nil {
+		panic("invalid embedded json for TPEConfigV0")
+	}
+	return schemaTPEConfigV0
+}
+
 func ParsedSearcherConfigV0() interface{} {
 	cacheLock.RLock()
 	if schemaSearcherConfigV0 != nil {
@@ -4667,6 +5260,8 @@ func schemaBytesMap() map[string][]byte {
 	cachedSchemaBytesMap[url] = textOptimizationsConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/profiling.json"
 	cachedSchemaBytesMap[url] = textProfilingConfigV0
+	url = "http://determined.ai/schemas/expconf/v0/progress-timeout.json"
+	cachedSchemaBytesMap[url] = textProgressTimeoutConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/registry-auth.json"
 	cachedSchemaBytesMap[url] = textRegistryAuthV0
 	url = "http://determined.ai/schemas/expconf/v0/reproducibility.json"
@@ -4689,12 +5284,22 @@ func schemaBytesMap() map[string][]byte {
 	cachedSchemaBytesMap[url] = textGridConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/searcher-length.json"
 	cachedSchemaBytesMap[url] = textSearcherLengthV0
+	url = "http://determined.ai/schemas/expconf/v0/searcher-metric.json"
+	cachedSchemaBytesMap[url] = textSearcherMetricV0
+	url = "http://determined.ai/schemas/expconf/v0/searcher-pbt-explore.json"
+	cachedSchemaBytesMap[url] = textPBTExploreConfigV0
+	url = "http://determined.ai/schemas/expconf/v0/searcher-pbt-replace.json"
+	cachedSchemaBytesMap[url] = textPBTReplaceConfigV0
+	url = "http://determined.ai/schemas/expconf/v0/searcher-pbt.json"
+	cachedSchemaBytesMap[url] = textPBTConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/searcher-random.json"
 	cachedSchemaBytesMap[url] = textRandomConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/searcher-single.json"
 	cachedSchemaBytesMap[url] = textSingleConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/searcher-sync-halving.json"
 	cachedSchemaBytesMap[url] = textSyncHalvingConfigV0
+	url = "http://determined.ai/schemas/expconf/v0/searcher-tpe.json"
+	cachedSchemaBytesMap[url] = textTPEConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/searcher.json"
 	cachedSchemaBytesMap[url] = textSearcherConfigV0
 	url = "http://determined.ai/schemas/expconf/v0/security.json"
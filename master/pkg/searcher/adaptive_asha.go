@@ -68,10 +68,12 @@ func getBracketMaxConcurrentTrials(
 	return bracketMaxConcurrentTrials
 }
 
-func newAdaptiveASHASearch(config expconf.AdaptiveASHAConfig, smallerIsBetter bool) SearchMethod {
+// adaptiveASHABrackets returns the number of rungs of each bracket of an adaptive ASHA search,
+// largest first.
+func adaptiveASHABrackets(config expconf.AdaptiveASHAConfig) []int {
 	modeFunc := parseAdaptiveMode(config.Mode())
 
-	brackets := config.BracketRungs()
+	brackets := append([]int(nil), config.BracketRungs()...)
 	if len(brackets) == 0 {
 		maxRungs := config.MaxRungs()
 		maxRungs = mathx.Min(
@@ -82,6 +84,11 @@ func newAdaptiveASHASearch(config expconf.AdaptiveASHAConfig, smallerIsBetter bo
 	}
 	// We prioritize brackets that perform more early stopping to try to max speedups early on.
 	sort.Sort(sort.Reverse(sort.IntSlice(brackets)))
+	return brackets
+}
+
+func newAdaptiveASHASearch(config expconf.AdaptiveASHAConfig, smallerIsBetter bool) SearchMethod {
+	brackets := adaptiveASHABrackets(config)
 	bracketMaxTrials := getBracketMaxTrials(
 		config.MaxTrials(), config.Divisor(), brackets)
 	bracketMaxConcurrentTrials := getBracketMaxConcurrentTrials(
@@ -35,6 +35,8 @@ type (
 	trialMetric struct {
 		RequestID model.RequestID       `json:"request_id"`
 		Metric    model.ExtendedFloat64 `json:"metric"`
+		// Objectives are set if the search ranks trials by several metrics.
+		Objectives []float64 `json:"objectives,omitempty"`
 		// fields below used by asha.go.
 		Promoted bool `json:"promoted"`
 	}
@@ -84,10 +86,44 @@ func (s *asyncHalvingSearch) Restore(state json.RawMessage) error {
 	return json.Unmarshal(state, &s.asyncHalvingSearchState)
 }
 
+// rankedByObjectives returns whether the trials of the rung are ranked by their objectives rather
+// than by their metrics.
+func (r *rung) rankedByObjectives(objectives []float64) bool {
+	if objectives != nil {
+		return true
+	}
+	for _, m := range r.Metrics {
+		if m.Objectives != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// insertByObjectives adds a trial to the rung, sorts the trials of the rung by non-dominated
+// sorting of their objectives and returns the index of the new trial.
+func (r *rung) insertByObjectives(m trialMetric) int {
+	r.Metrics = append(r.Metrics, m)
+	points := make([][]float64, 0, len(r.Metrics))
+	for _, tm := range r.Metrics {
+		points = append(points, tm.Objectives)
+	}
+	sorted := make([]trialMetric, 0, len(r.Metrics))
+	insertIndex := 0
+	for i, j := range paretoOrder(points) {
+		if j == len(r.Metrics)-1 {
+			insertIndex = i
+		}
+		sorted = append(sorted, r.Metrics[j])
+	}
+	r.Metrics = sorted
+	return insertIndex
+}
+
 // promotions handles bookkeeping of validation metrics and returns a RequestID to promote if
 // appropriate.
 func (r *rung) promotionsAsync(
-	requestID model.RequestID, metric float64, divisor float64,
+	requestID model.RequestID, metric float64, objectives []float64, divisor float64,
 ) []model.RequestID {
 	// See if there is a trial to promote. We are increasing the total number of trials seen by 1; the
 	// number of best trials that definitely should have been promoted so far (numPromote) can only
@@ -95,19 +131,28 @@ func (r *rung) promotionsAsync(
 	oldNumPromote := int(float64(len(r.Metrics)) / divisor)
 	numPromote := int(float64(len(r.Metrics)+1) / divisor)
 
-	// Insert the new trial result in the appropriate place in the sorted list.
-	insertIndex := sort.Search(
-		len(r.Metrics),
-		func(i int) bool { return float64(r.Metrics[i].Metric) > metric },
-	)
-	promoteNow := insertIndex < numPromote
-
-	r.Metrics = append(r.Metrics, trialMetric{})
-	copy(r.Metrics[insertIndex+1:], r.Metrics[insertIndex:])
-	r.Metrics[insertIndex] = trialMetric{
-		RequestID: requestID,
-		Metric:    model.ExtendedFloat64(metric),
-		Promoted:  promoteNow,
+	newMetric := trialMetric{
+		RequestID:  requestID,
+		Metric:     model.ExtendedFloat64(metric),
+		Objectives: objectives,
+	}
+	var promoteNow bool
+	if r.rankedByObjectives(objectives) {
+		insertIndex := r.insertByObjectives(newMetric)
+		promoteNow = insertIndex < numPromote
+		r.Metrics[insertIndex].Promoted = promoteNow
+	} else {
+		// Insert the new trial result in the appropriate place in the sorted list.
+		insertIndex := sort.Search(
+			len(r.Metrics),
+			func(i int) bool { return float64(r.Metrics[i].Metric) > metric },
+		)
+		promoteNow = insertIndex < numPromote
+
+		newMetric.Promoted = promoteNow
+		r.Metrics = append(r.Metrics, trialMetric{})
+		copy(r.Metrics[insertIndex+1:], r.Metrics[insertIndex:])
+		r.Metrics[insertIndex] = newMetric
 	}
 
 	// If the new trial is good enough, it should be promoted immediately (whether or not numPromote
@@ -125,6 +170,18 @@ func (r *rung) promotionsAsync(
 	}
 }
 
+// ashaMaxConcurrentTrials returns the number of trials an ASHA search trains at once.
+func ashaMaxConcurrentTrials(config expconf.AsyncHalvingConfig) int {
+	if config.MaxConcurrentTrials() > 0 {
+		return mathx.Min(config.MaxConcurrentTrials(), config.MaxTrials())
+	}
+	return mathx.Clamp(
+		1,
+		int(math.Pow(config.Divisor(), float64(config.NumRungs()-1))),
+		config.MaxTrials(),
+	)
+}
+
 func (s *asyncHalvingSearch) initialOperations(ctx context) ([]Operation, error) {
 	// The number of initialOperations will control the degree of parallelism
 	// of the search experiment since we guarantee that each validationComplete
@@ -134,21 +191,36 @@ func (s *asyncHalvingSearch) initialOperations(ctx context) ([]Operation, error)
 	// Otherwise we will default to a number of trials that will
 	// guarantee at least one trial at the top rung.
 	var ops []Operation
-	var maxConcurrentTrials int
+	maxConcurrentTrials := ashaMaxConcurrentTrials(s.AsyncHalvingConfig)
 
-	if s.MaxConcurrentTrials() > 0 {
-		maxConcurrentTrials = mathx.Min(s.MaxConcurrentTrials(), s.MaxTrials())
-	} else {
-		maxConcurrentTrials = mathx.Clamp(
-			1,
-			int(math.Pow(s.Divisor(), float64(s.NumRungs()-1))),
-			s.MaxTrials(),
-		)
+	for trial := 0; trial < maxConcurrentTrials; trial++ {
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
+		s.TrialRungs[create.RequestID] = 0
+		ops = append(ops, create)
+		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
+		s.PendingTrials++
 	}
+	return ops, nil
+}
 
-	for trial := 0; trial < maxConcurrentTrials; trial++ {
-		create := NewCreate(
-			ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+// continueSearch creates trials until there are max_trials of them. As they are validated, trials
+// that were closed out can be promoted and trained further.
+func (s *asyncHalvingSearch) continueSearch(
+	ctx context, trialLengths map[model.RequestID]uint64,
+) ([]Operation, error) {
+	var ops []Operation
+	newTrials := mathx.Min(
+		ashaMaxConcurrentTrials(s.AsyncHalvingConfig), s.MaxTrials()-len(s.TrialRungs)+s.InvalidTrials)
+	for trial := 0; trial < newTrials; trial++ {
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		s.TrialRungs[create.RequestID] = 0
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
@@ -180,12 +252,24 @@ func (s *asyncHalvingSearch) validationCompleted(
 	if !s.SmallerIsBetter {
 		metric *= -1
 	}
-	return s.promoteAsync(ctx, requestID, metric), nil
+	return s.promoteAsync(ctx, requestID, metric, nil)
+}
+
+// objectivesCompleted ranks trials by non-dominated sorting of their objectives rather than by
+// their metrics.
+func (s *asyncHalvingSearch) objectivesCompleted(
+	ctx context, requestID model.RequestID, metric float64, objectives []float64, op ValidateAfter,
+) ([]Operation, error) {
+	s.PendingTrials--
+	if !s.SmallerIsBetter {
+		metric *= -1
+	}
+	return s.promoteAsync(ctx, requestID, metric, objectives)
 }
 
 func (s *asyncHalvingSearch) promoteAsync(
-	ctx context, requestID model.RequestID, metric float64,
-) []Operation {
+	ctx context, requestID model.RequestID, metric float64, objectives []float64,
+) ([]Operation, error) {
 	// Upon a validation complete, we should return at least one more train&val workload
 	// unless the bracket of successive halving is finished.
 	rungIndex := s.TrialRungs[requestID]
@@ -198,8 +282,9 @@ func (s *asyncHalvingSearch) promoteAsync(
 	if rungIndex == s.NumRungs()-1 {
 		rung.Metrics = append(rung.Metrics,
 			trialMetric{
-				RequestID: requestID,
-				Metric:    model.ExtendedFloat64(metric),
+				RequestID:  requestID,
+				Metric:     model.ExtendedFloat64(metric),
+				Objectives: objectives,
 			},
 		)
 
@@ -213,11 +298,17 @@ func (s *asyncHalvingSearch) promoteAsync(
 		for _, promotionID := range rung.promotionsAsync(
 			requestID,
 			metric,
+			objectives,
 			s.Divisor(),
 		) {
 			s.TrialRungs[promotionID] = rungIndex + 1
 			nextRung.OutstandingTrials++
 			if !s.EarlyExitTrials[promotionID] {
+				if s.ClosedTrials[promotionID] {
+					// The trial was closed out before the search was continued.
+					delete(s.ClosedTrials, promotionID)
+					s.TrialsCompleted--
+				}
 				unitsNeeded := mathx.Max(nextRung.UnitsNeeded-rung.UnitsNeeded, 1)
 				ops = append(ops, NewValidateAfter(promotionID, unitsNeeded))
 				addedTrainWorkload = true
@@ -226,16 +317,19 @@ func (s *asyncHalvingSearch) promoteAsync(
 				// We make a recursive call that will behave the same
 				// as if we'd actually run the promoted job and received
 				// the worse possible result in return.
-				return s.promoteAsync(ctx, promotionID, ashaExitedMetricValue)
+				return s.promoteAsync(ctx, promotionID, ashaExitedMetricValue, nil)
 			}
 		}
 	}
 
 	allTrials := len(s.TrialRungs) - s.InvalidTrials
 	if !addedTrainWorkload && allTrials < s.MaxTrials() {
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
 		s.PendingTrials++
-		create := NewCreate(
-			ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		s.TrialRungs[create.RequestID] = 0
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
@@ -245,7 +339,7 @@ func (s *asyncHalvingSearch) promoteAsync(
 	if len(s.Rungs[0].Metrics) == s.MaxTrials() {
 		ops = append(ops, s.closeOutRungs()...)
 	}
-	return ops
+	return ops, nil
 }
 
 // closeOutRungs closes all remaining unpromoted trials in any rungs that have no more outstanding
@@ -309,8 +403,11 @@ func (s *asyncHalvingSearch) trialExitedEarly(
 			}
 		}
 		// Add new trial to searcher queue
-		create := NewCreate(
-			ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		s.TrialRungs[create.RequestID] = 0
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
@@ -319,5 +416,5 @@ func (s *asyncHalvingSearch) trialExitedEarly(
 	}
 	s.EarlyExitTrials[requestID] = true
 	s.ClosedTrials[requestID] = true
-	return s.promoteAsync(ctx, requestID, ashaExitedMetricValue), nil
+	return s.promoteAsync(ctx, requestID, ashaExitedMetricValue, nil)
 }
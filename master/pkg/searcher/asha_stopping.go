@@ -64,10 +64,24 @@ func (s *asyncHalvingStoppingSearch) Restore(state json.RawMessage) error {
 
 // promotions handles bookkeeping of validation metrics and decides whether to continue
 // training the current trial.
-func (r *rung) continueTraining(requestID model.RequestID, metric float64, divisor float64) bool {
+func (r *rung) continueTraining(
+	requestID model.RequestID, metric float64, objectives []float64, divisor float64,
+) bool {
 	// Compute cutoff for promotion to next rung to continue training.
 	numPromote := mathx.Max(int(float64(len(r.Metrics)+1)/divisor), 1)
 
+	newMetric := trialMetric{
+		RequestID:  requestID,
+		Metric:     model.ExtendedFloat64(metric),
+		Objectives: objectives,
+	}
+	if r.rankedByObjectives(objectives) {
+		insertIndex := r.insertByObjectives(newMetric)
+		promoteNow := insertIndex < numPromote
+		r.Metrics[insertIndex].Promoted = promoteNow
+		return promoteNow
+	}
+
 	// Insert the new trial result in the appropriate place in the sorted list.
 	insertIndex := sort.Search(
 		len(r.Metrics),
@@ -77,13 +91,10 @@ func (r *rung) continueTraining(requestID model.RequestID, metric float64, divis
 	// if there are fewere than divisor trials in the rung.
 	promoteNow := insertIndex < numPromote
 
+	newMetric.Promoted = promoteNow
 	r.Metrics = append(r.Metrics, trialMetric{})
 	copy(r.Metrics[insertIndex+1:], r.Metrics[insertIndex:])
-	r.Metrics[insertIndex] = trialMetric{
-		RequestID: requestID,
-		Metric:    model.ExtendedFloat64(metric),
-		Promoted:  promoteNow,
-	}
+	r.Metrics[insertIndex] = newMetric
 
 	return promoteNow
 }
@@ -97,21 +108,34 @@ func (s *asyncHalvingStoppingSearch) initialOperations(ctx context) ([]Operation
 	// Otherwise we will default to a number of trials that will
 	// guarantee at least one trial at the top rung.
 	var ops []Operation
-	var maxConcurrentTrials int
+	maxConcurrentTrials := ashaMaxConcurrentTrials(s.AsyncHalvingConfig)
 
-	if s.MaxConcurrentTrials() > 0 {
-		maxConcurrentTrials = mathx.Min(s.MaxConcurrentTrials(), s.MaxTrials())
-	} else {
-		maxConcurrentTrials = mathx.Clamp(
-			1,
-			int(math.Pow(s.Divisor(), float64(s.NumRungs()-1))),
-			s.MaxTrials(),
-		)
+	for trial := 0; trial < maxConcurrentTrials; trial++ {
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
+		s.TrialRungs[create.RequestID] = 0
+		ops = append(ops, create)
+		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
 	}
+	return ops, nil
+}
 
-	for trial := 0; trial < maxConcurrentTrials; trial++ {
-		create := NewCreate(
-			ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+// continueSearch creates trials until there are max_trials of them.
+func (s *asyncHalvingStoppingSearch) continueSearch(
+	ctx context, trialLengths map[model.RequestID]uint64,
+) ([]Operation, error) {
+	var ops []Operation
+	newTrials := mathx.Min(
+		ashaMaxConcurrentTrials(s.AsyncHalvingConfig), s.MaxTrials()-len(s.TrialRungs)+s.InvalidTrials)
+	for trial := 0; trial < newTrials; trial++ {
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		s.TrialRungs[create.RequestID] = 0
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
@@ -141,12 +165,23 @@ func (s *asyncHalvingStoppingSearch) validationCompleted(
 	if !s.SmallerIsBetter {
 		metric *= -1
 	}
-	return s.promoteAsync(ctx, requestID, metric), nil
+	return s.promoteAsync(ctx, requestID, metric, nil)
+}
+
+// objectivesCompleted ranks trials by non-dominated sorting of their objectives rather than by
+// their metrics.
+func (s *asyncHalvingStoppingSearch) objectivesCompleted(
+	ctx context, requestID model.RequestID, metric float64, objectives []float64, op ValidateAfter,
+) ([]Operation, error) {
+	if !s.SmallerIsBetter {
+		metric *= -1
+	}
+	return s.promoteAsync(ctx, requestID, metric, objectives)
 }
 
 func (s *asyncHalvingStoppingSearch) promoteAsync(
-	ctx context, requestID model.RequestID, metric float64,
-) []Operation {
+	ctx context, requestID model.RequestID, metric float64, objectives []float64,
+) ([]Operation, error) {
 	// Upon a validation complete, we should return at least one more train&val workload
 	// unless the bracket of successive halving is finished.
 	rungIndex := s.TrialRungs[requestID]
@@ -159,8 +194,9 @@ func (s *asyncHalvingStoppingSearch) promoteAsync(
 	if rungIndex == s.NumRungs()-1 {
 		rung.Metrics = append(rung.Metrics,
 			trialMetric{
-				RequestID: requestID,
-				Metric:    model.ExtendedFloat64(metric),
+				RequestID:  requestID,
+				Metric:     model.ExtendedFloat64(metric),
+				Objectives: objectives,
 			},
 		)
 
@@ -176,6 +212,7 @@ func (s *asyncHalvingStoppingSearch) promoteAsync(
 		promoteTrial := rung.continueTraining(
 			requestID,
 			metric,
+			objectives,
 			s.Divisor(),
 		)
 		// In contrast to promotion-based ASHA, we will not let early-exited trials add
@@ -197,14 +234,17 @@ func (s *asyncHalvingStoppingSearch) promoteAsync(
 
 	allTrials := len(s.TrialRungs) - s.InvalidTrials
 	if !addedTrainWorkload && allTrials < s.MaxTrials() {
-		create := NewCreate(
-			ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		s.TrialRungs[create.RequestID] = 0
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
 	}
 
-	return ops
+	return ops, nil
 }
 
 func (s *asyncHalvingStoppingSearch) progress(
@@ -242,8 +282,11 @@ func (s *asyncHalvingStoppingSearch) trialExitedEarly(
 			}
 		}
 		// Add new trial to searcher queue
-		create := NewCreate(
-			ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		s.TrialRungs[create.RequestID] = 0
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.Rungs[0].UnitsNeeded))
@@ -251,5 +294,5 @@ func (s *asyncHalvingStoppingSearch) trialExitedEarly(
 	}
 	s.EarlyExitTrials[requestID] = true
 	s.ClosedTrials[requestID] = true
-	return s.promoteAsync(ctx, requestID, ashaExitedMetricValue), nil
+	return s.promoteAsync(ctx, requestID, ashaExitedMetricValue, nil)
 }
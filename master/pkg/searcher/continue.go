@@ -0,0 +1,91 @@
+package searcher
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/schemas"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+// A completed search can be continued with a larger budget: the experiment restores its search
+// method from the snapshot it took when the search completed, with a searcher configuration with
+// a larger max_trials or max_length, and asks it for the operations that continue the search.
+// These may create new trials, and may also train trials that were closed further.
+
+// continuableSearchMethod is implemented by search methods whose searches can be continued after
+// they complete.
+type continuableSearchMethod interface {
+	// continueSearch returns the operations that continue a completed search, given how long each
+	// trial that was closed without exiting early was trained.
+	continueSearch(ctx context, trialLengths map[model.RequestID]uint64) ([]Operation, error)
+}
+
+// ContinueConfig returns the searcher configuration that continues a completed search with the
+// given max_trials and max_length, either of which may be nil to keep it as it is. Neither may be
+// lowered, and at least one must be raised.
+func ContinueConfig(
+	config expconf.SearcherConfig, maxTrials *int, maxLength *expconf.Length,
+) (expconf.SearcherConfig, error) {
+	if maxTrials == nil && maxLength == nil {
+		return expconf.SearcherConfig{}, errors.New(
+			"continuing a search requires a larger max_trials or max_length")
+	}
+
+	continued := schemas.Copy(config).(expconf.SearcherConfig)
+	var rawMaxTrials *int
+	var rawMaxLength *expconf.Length
+	switch {
+	case continued.RawRandomConfig != nil:
+		rawMaxTrials = continued.RawRandomConfig.RawMaxTrials
+		rawMaxLength = continued.RawRandomConfig.RawMaxLength
+	case continued.RawAsyncHalvingConfig != nil:
+		rawMaxTrials = continued.RawAsyncHalvingConfig.RawMaxTrials
+		rawMaxLength = continued.RawAsyncHalvingConfig.RawMaxLength
+	case continued.RawAdaptiveASHAConfig != nil:
+		// Raising max_trials can change the brackets of an adaptive ASHA search, so keep the ones
+		// the search was run with.
+		continued.RawAdaptiveASHAConfig.RawBracketRungs = adaptiveASHABrackets(
+			*continued.RawAdaptiveASHAConfig)
+		rawMaxTrials = continued.RawAdaptiveASHAConfig.RawMaxTrials
+		rawMaxLength = continued.RawAdaptiveASHAConfig.RawMaxLength
+	default:
+		return expconf.SearcherConfig{}, errors.New(
+			"only random, async_halving, and adaptive_asha searches can be continued")
+	}
+
+	raised := false
+	if maxTrials != nil {
+		switch {
+		case *maxTrials < *rawMaxTrials:
+			return expconf.SearcherConfig{}, errors.Errorf(
+				"max_trials can't be lowered from %d to %d", *rawMaxTrials, *maxTrials)
+		case *maxTrials > *rawMaxTrials:
+			raised = true
+		}
+		*rawMaxTrials = *maxTrials
+	}
+	if maxLength != nil {
+		switch {
+		case maxLength.Unit != rawMaxLength.Unit:
+			return expconf.SearcherConfig{}, errors.Errorf(
+				"max_length must be in %s, like the search being continued", rawMaxLength.Unit)
+		case maxLength.Units < rawMaxLength.Units:
+			return expconf.SearcherConfig{}, errors.Errorf(
+				"max_length can't be lowered from %d to %d %s",
+				rawMaxLength.Units, maxLength.Units, maxLength.Unit)
+		case maxLength.Units > rawMaxLength.Units && continued.RawRandomConfig == nil:
+			// The lengths of the rungs of ASHA searches follow from max_length.
+			return expconf.SearcherConfig{}, errors.New(
+				"the max_length of ASHA searches can't be raised")
+		case maxLength.Units > rawMaxLength.Units:
+			raised = true
+		}
+		*rawMaxLength = *maxLength
+	}
+	if !raised {
+		return expconf.SearcherConfig{}, errors.New(
+			"continuing a search requires a larger max_trials or max_length")
+	}
+	return continued, nil
+}
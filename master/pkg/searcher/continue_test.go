@@ -0,0 +1,208 @@
+//nolint:exhaustivestruct
+package searcher
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+// runSearch plays the part of an experiment and its trials for a search, starting from the given
+// operations, until the search shuts down. Each trial reports its index in requestIDs as its
+// metric, and the lengths trials are trained to are added to trained.
+func runSearch(
+	t *testing.T, s *Searcher, ops []Operation, requestIDs *[]model.RequestID,
+	trained map[model.RequestID][]uint64,
+) {
+	pending := map[model.RequestID][]Operation{}
+	shutdown, err := handleOperations(pending, requestIDs, ops)
+	assert.NilError(t, err)
+	for !shutdown {
+		requestID, err := pickTrial(nil, pending, *requestIDs, false)
+		assert.NilError(t, err)
+		operation := pending[requestID][0]
+		pending[requestID] = pending[requestID][1:]
+
+		switch operation := operation.(type) {
+		case Create:
+			ops, err = s.TrialCreated(requestID)
+		case ValidateAfter:
+			trained[requestID] = append(trained[requestID], operation.Length)
+			s.SetTrialProgress(requestID, PartialUnits(operation.Length))
+			var metric float64
+			for i, id := range *requestIDs {
+				if id == requestID {
+					metric = float64(i)
+				}
+			}
+			ops, err = s.ValidationCompleted(requestID, metric, nil, operation)
+		case Close:
+			delete(pending, requestID)
+			ops, err = s.TrialClosed(requestID)
+		}
+		assert.NilError(t, err)
+		shutdown, err = handleOperations(pending, requestIDs, ops)
+		assert.NilError(t, err)
+		// Progress panics if a search trains more than max_concurrent_trials trials at once.
+		s.Progress()
+	}
+	for requestID, ops := range pending {
+		assert.Equal(t, len(ops), 0, "trial %s has operations left", requestID)
+	}
+}
+
+// continueSearch continues a completed search with a larger max_trials or max_length.
+func continueSearch(
+	t *testing.T, s *Searcher, config expconf.SearcherConfig, maxTrials *int,
+	maxLength *expconf.Length,
+) (*Searcher, []Operation) {
+	snapshot, err := s.Snapshot()
+	assert.NilError(t, err)
+	continued, err := ContinueConfig(config, maxTrials, maxLength)
+	assert.NilError(t, err)
+	s = NewSearcher(0, NewSearchMethod(continued), nil)
+	assert.NilError(t, s.Restore(snapshot))
+	ops, err := s.Continue()
+	assert.NilError(t, err)
+	return s, ops
+}
+
+func TestContinueConfig(t *testing.T) {
+	random := schemas.WithDefaults(expconf.SearcherConfig{
+		RawMetric: ptrs.Ptr("loss"),
+		RawRandomConfig: &expconf.RandomConfig{
+			RawMaxTrials: ptrs.Ptr(4), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+		},
+	}).(expconf.SearcherConfig)
+
+	continued, err := ContinueConfig(random, ptrs.Ptr(8), ptrs.Ptr(expconf.NewLengthInBatches(600)))
+	assert.NilError(t, err)
+	assert.Equal(t, continued.RawRandomConfig.MaxTrials(), 8)
+	assert.Equal(t, continued.RawRandomConfig.MaxLength(), expconf.NewLengthInBatches(600))
+	assert.Equal(t, random.RawRandomConfig.MaxTrials(), 4)
+
+	_, err = ContinueConfig(random, nil, nil)
+	assert.ErrorContains(t, err, "requires a larger max_trials or max_length")
+	_, err = ContinueConfig(random, ptrs.Ptr(4), nil)
+	assert.ErrorContains(t, err, "requires a larger max_trials or max_length")
+	_, err = ContinueConfig(random, ptrs.Ptr(2), nil)
+	assert.ErrorContains(t, err, "max_trials can't be lowered from 4 to 2")
+	_, err = ContinueConfig(random, nil, ptrs.Ptr(expconf.NewLengthInEpochs(600)))
+	assert.ErrorContains(t, err, "max_length must be in batches")
+	_, err = ContinueConfig(random, nil, ptrs.Ptr(expconf.NewLengthInBatches(200)))
+	assert.ErrorContains(t, err, "max_length can't be lowered")
+
+	grid := schemas.WithDefaults(expconf.SearcherConfig{
+		RawMetric: ptrs.Ptr("loss"),
+		RawGridConfig: &expconf.GridConfig{
+			RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+		},
+	}).(expconf.SearcherConfig)
+	_, err = ContinueConfig(grid, ptrs.Ptr(8), nil)
+	assert.ErrorContains(t, err, "only random, async_halving, and adaptive_asha searches")
+
+	// Adaptive ASHA searches keep their brackets, which would otherwise change with max_trials.
+	adaptive := schemas.WithDefaults(expconf.SearcherConfig{
+		RawMetric: ptrs.Ptr("loss"),
+		RawAdaptiveASHAConfig: &expconf.AdaptiveASHAConfig{
+			RawMaxTrials: ptrs.Ptr(4), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(1000)),
+		},
+	}).(expconf.SearcherConfig)
+	brackets := adaptiveASHABrackets(*adaptive.RawAdaptiveASHAConfig)
+	continued, err = ContinueConfig(adaptive, ptrs.Ptr(100), nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, continued.RawAdaptiveASHAConfig.BracketRungs(), brackets)
+	_, err = ContinueConfig(adaptive, nil, ptrs.Ptr(expconf.NewLengthInBatches(2000)))
+	assert.ErrorContains(t, err, "max_length of ASHA searches can't be raised")
+}
+
+func TestRandomSearchContinue(t *testing.T) {
+	config := schemas.WithDefaults(expconf.SearcherConfig{
+		RawMetric: ptrs.Ptr("loss"),
+		RawRandomConfig: &expconf.RandomConfig{
+			RawMaxTrials:           ptrs.Ptr(2),
+			RawMaxLength:           ptrs.Ptr(expconf.NewLengthInBatches(100)),
+			RawMaxConcurrentTrials: ptrs.Ptr(1),
+		},
+	}).(expconf.SearcherConfig)
+	s := NewSearcher(0, NewSearchMethod(config), nil)
+	ops, err := s.InitialOperations()
+	assert.NilError(t, err)
+	var requestIDs []model.RequestID
+	trained := map[model.RequestID][]uint64{}
+	runSearch(t, s, ops, &requestIDs, trained)
+	assert.Equal(t, len(requestIDs), 2)
+
+	// The trials that were trained to the old max_length are trained further, one at a time, and
+	// then a new trial is trained.
+	s, ops = continueSearch(
+		t, s, config, ptrs.Ptr(3), ptrs.Ptr(expconf.NewLengthInBatches(250)))
+	runSearch(t, s, ops, &requestIDs, trained)
+	assert.Equal(t, len(requestIDs), 3)
+	assert.DeepEqual(t, trained, map[model.RequestID][]uint64{
+		requestIDs[0]: {100, 250},
+		requestIDs[1]: {100, 250},
+		requestIDs[2]: {250},
+	})
+	assert.Equal(t, s.Progress(), 1.0)
+}
+
+func TestASHASearchContinue(t *testing.T) {
+	config := schemas.WithDefaults(expconf.SearcherConfig{
+		RawMetric: ptrs.Ptr("loss"),
+		RawAsyncHalvingConfig: &expconf.AsyncHalvingConfig{
+			RawNumRungs:  ptrs.Ptr(2),
+			RawMaxTrials: ptrs.Ptr(2),
+			RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(4)),
+			RawDivisor:   ptrs.Ptr[float64](2),
+		},
+	}).(expconf.SearcherConfig)
+	s := NewSearcher(0, NewSearchMethod(config), nil)
+	ops, err := s.InitialOperations()
+	assert.NilError(t, err)
+	var requestIDs []model.RequestID
+	trained := map[model.RequestID][]uint64{}
+	runSearch(t, s, ops, &requestIDs, trained)
+	assert.DeepEqual(t, trained, map[model.RequestID][]uint64{
+		requestIDs[0]: {2, 4},
+		requestIDs[1]: {2},
+	})
+
+	// Once the new trials are validated, half of the four trials in the bottom rung are promoted,
+	// including the second trial, which was closed out when the search completed.
+	s, ops = continueSearch(t, s, config, ptrs.Ptr(4), nil)
+	runSearch(t, s, ops, &requestIDs, trained)
+	assert.DeepEqual(t, trained, map[model.RequestID][]uint64{
+		requestIDs[0]: {2, 4},
+		requestIDs[1]: {2, 4},
+		requestIDs[2]: {2},
+		requestIDs[3]: {2},
+	})
+}
+
+func TestAdaptiveASHASearchContinue(t *testing.T) {
+	config := schemas.WithDefaults(expconf.SearcherConfig{
+		RawMetric: ptrs.Ptr("loss"),
+		RawAdaptiveASHAConfig: &expconf.AdaptiveASHAConfig{
+			RawMaxTrials: ptrs.Ptr(4),
+			RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(1000)),
+		},
+	}).(expconf.SearcherConfig)
+	s := NewSearcher(0, NewSearchMethod(config), nil)
+	ops, err := s.InitialOperations()
+	assert.NilError(t, err)
+	var requestIDs []model.RequestID
+	trained := map[model.RequestID][]uint64{}
+	runSearch(t, s, ops, &requestIDs, trained)
+	assert.Equal(t, len(requestIDs), 4)
+
+	s, ops = continueSearch(t, s, config, ptrs.Ptr(16), nil)
+	runSearch(t, s, ops, &requestIDs, trained)
+	assert.Equal(t, len(requestIDs), 16)
+	assert.Equal(t, s.Progress(), 1.0)
+}
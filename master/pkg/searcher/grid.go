@@ -122,11 +122,22 @@ func newHyperparameterGrid(params expconf.Hyperparameters) []HParamSample {
 	})
 	points := cartesianProduct(axes)
 	var samples []HParamSample
+	// Points that only differ in inactive hyperparameters are the same once those are left out.
+	seen := map[string]bool{}
 	for _, axisValues := range points {
 		sample := HParamSample{}
 		for _, av := range axisValues {
 			applyToSample(av.Route, av.Value, sample)
 		}
+		sample = activeHParams(params, sample)
+		if !satisfiesConstraints(params, sample) {
+			continue
+		}
+		key, err := json.Marshal(sample)
+		if err == nil && seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
 		samples = append(samples, sample)
 	}
 	return samples
@@ -303,3 +303,21 @@ func TestGridSearchMethod(t *testing.T) {
 
 	runValueSimulationTestCases(t, testCases)
 }
+
+func TestGridConditionsAndConstraints(t *testing.T) {
+	grid := newHyperparameterGrid(conditionalTestHParams())
+
+	// adam leaves out momentum and nesterov, and sgd has 3 momentums, one of which adds 2 values
+	// of nesterov, for 1 + 2 + 2 = 5 optimizer settings. Of the 16 combinations of batch_size and
+	// accum_steps, 4 + 4 + 4 + 2 = 14 satisfy the constraint.
+	assert.Equal(t, len(grid), 5*14)
+	for _, sample := range grid {
+		_, hasMomentum := sample["momentum"]
+		assert.Equal(t, hasMomentum, sample["optimizer"] == "sgd", "%v", sample)
+		_, hasNesterov := sample["nesterov"]
+		assert.Equal(t, hasNesterov, sample["momentum"] == 0.5, "%v", sample)
+
+		accumSteps := sample["train"].(HParamSample)["accum_steps"].(int)
+		assert.Assert(t, sample["batch_size"].(int)*accumSteps <= 512, "%v", sample)
+	}
+}
@@ -3,6 +3,10 @@ package searcher
 import (
 	"fmt"
 	"math"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/determined-ai/determined/master/pkg/nprand"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
@@ -11,12 +15,148 @@ import (
 // HParamSample is a sampling of the hyperparameters for a model.
 type HParamSample map[string]interface{}
 
-func sampleAll(h expconf.Hyperparameters, rand *nprand.State) HParamSample {
-	results := make(HParamSample)
-	h.Each(func(name string, param expconf.Hyperparameter) {
-		results[name] = sampleOne(param, rand)
+// maxConstraintAttempts bounds how many samples are drawn when looking for one that satisfies the
+// constraints of the hyperparameters; if none of them do, the search fails.
+const maxConstraintAttempts = 1000
+
+func sampleAll(h expconf.Hyperparameters, rand *nprand.State) (HParamSample, error) {
+	return sampleValid(h, func() HParamSample {
+		results := make(HParamSample)
+		h.Each(func(name string, param expconf.Hyperparameter) {
+			results[name] = sampleOne(param, rand)
+		})
+		return results
 	})
-	return results
+}
+
+// sampleValid draws samples until one satisfies the constraints of the hyperparameters, leaving
+// out the hyperparameters whose conditions aren't met. Every hyperparameter is drawn each time,
+// active or not, so that the number of random draws doesn't depend on the values drawn. If none
+// of maxConstraintAttempts samples satisfies the constraints, they are taken to be unsatisfiable.
+func sampleValid(
+	h expconf.Hyperparameters, sample func() HParamSample,
+) (HParamSample, error) {
+	for attempt := 0; attempt < maxConstraintAttempts; attempt++ {
+		result := activeHParams(h, sample())
+		if satisfiesConstraints(h, result) {
+			return result, nil
+		}
+	}
+	return nil, errors.Errorf(
+		"none of %d sampled sets of hyperparameters satisfied the constraints, "+
+			"which may be impossible to satisfy", maxConstraintAttempts)
+}
+
+// activeHParams removes the hyperparameters whose conditions aren't met from a sample.
+func activeHParams(h expconf.Hyperparameters, sample HParamSample) HParamSample {
+	flat := expconf.FlattenHPs(h)
+	for changed := true; changed; {
+		changed = false
+		flat.Each(func(name string, param expconf.Hyperparameter) {
+			if _, ok := hparamValue(sample, name); !ok {
+				return
+			}
+			for target, vals := range param.Conditions() {
+				val, ok := hparamValue(sample, target)
+				if !ok || !containsHParamValue(vals, val) {
+					deleteHParam(sample, name)
+					changed = true
+					return
+				}
+			}
+		})
+	}
+	return sample
+}
+
+// satisfiesConstraints returns whether a sample satisfies the constraints of its active
+// hyperparameters. Constraints that refer to inactive hyperparameters are ignored.
+func satisfiesConstraints(h expconf.Hyperparameters, sample HParamSample) bool {
+	lookup := func(name string) (float64, bool) {
+		val, ok := hparamValue(sample, name)
+		if !ok {
+			return 0, false
+		}
+		return numericParam(val)
+	}
+	satisfied := true
+	expconf.FlattenHPs(h).Each(func(name string, param expconf.Hyperparameter) {
+		if _, ok := hparamValue(sample, name); !ok {
+			return
+		}
+		for _, source := range param.Constraints() {
+			// Constraints are validated when the experiment is created.
+			constraint, err := expconf.ParseHPConstraint(source)
+			if err != nil {
+				continue
+			}
+			if ok, evaluated := constraint.Eval(lookup); evaluated && !ok {
+				satisfied = false
+			}
+		}
+	})
+	return satisfied
+}
+
+// hparamValue returns the value of a hyperparameter in a sample by its flattened name.
+func hparamValue(sample map[string]interface{}, name string) (interface{}, bool) {
+	key, rest, nested := strings.Cut(name, ".")
+	val, ok := sample[key]
+	if !ok || !nested {
+		return val, ok
+	}
+	switch val := val.(type) {
+	case HParamSample:
+		return hparamValue(val, rest)
+	case map[string]interface{}:
+		return hparamValue(val, rest)
+	default:
+		return nil, false
+	}
+}
+
+// deleteHParam removes a hyperparameter from a sample by its flattened name.
+func deleteHParam(sample map[string]interface{}, name string) {
+	key, rest, nested := strings.Cut(name, ".")
+	if !nested {
+		delete(sample, key)
+		return
+	}
+	switch val := sample[key].(type) {
+	case HParamSample:
+		deleteHParam(val, rest)
+	case map[string]interface{}:
+		deleteHParam(val, rest)
+	}
+}
+
+// containsHParamValue returns whether val is one of vals, comparing numbers by value regardless of
+// whether they are ints or floats.
+func containsHParamValue(vals []interface{}, val interface{}) bool {
+	for _, v := range vals {
+		x, xNumeric := numericParam(v)
+		y, yNumeric := numericParam(val)
+		if xNumeric && yNumeric && x == y || reflect.DeepEqual(v, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// numericParam returns the value of a numeric hyperparameter as a float64. Integer
+// hyperparameters are float64s rather than ints once they have been through JSON, for example in
+// snapshots.
+func numericParam(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
 }
 
 func sampleOne(h expconf.Hyperparameter, rand *nprand.State) interface{} {
@@ -38,9 +178,11 @@ func sampleOne(h expconf.Hyperparameter, rand *nprand.State) interface{} {
 		return p.Vals()[rand.Intn(len(p.Vals()))]
 	case h.RawNestedHyperparameter != nil:
 		p := make(map[string]interface{})
-		for key, val := range *h.RawNestedHyperparameter {
-			p[key] = sampleOne(val, rand)
-		}
+		// Sample in a consistent order so that searches are reproducible.
+		expconf.Hyperparameters(*h.RawNestedHyperparameter).Each(
+			func(key string, val expconf.Hyperparameter) {
+				p[key] = sampleOne(val, rand)
+			})
 		return p
 	default:
 		panic(fmt.Sprintf("unexpected hyperparameter type: %+v", h))
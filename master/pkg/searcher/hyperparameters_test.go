@@ -6,6 +6,8 @@ import (
 	"gotest.tools/assert"
 
 	"github.com/determined-ai/determined/master/pkg/nprand"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
 )
 
@@ -60,7 +62,8 @@ func TestNestedSampling(t *testing.T) {
 		},
 	}
 	rand := nprand.New(0)
-	sample := sampleAll(spec, rand)
+	sample, err := sampleAll(spec, rand)
+	assert.NilError(t, err)
 	hpTarget := make(map[string]interface{})
 	hpTarget["type"] = "adam"
 	hpTarget["learning_rate"] = 0.01
@@ -90,8 +93,10 @@ func TestSamplingReproducibility(t *testing.T) {
 		rand1 := nprand.New(seed)
 		rand2 := nprand.New(seed)
 
-		sample1 := sampleAll(spec, rand1)
-		sample2 := sampleAll(spec, rand2)
+		sample1, err := sampleAll(spec, rand1)
+		assert.NilError(t, err)
+		sample2, err := sampleAll(spec, rand2)
+		assert.NilError(t, err)
 
 		assert.Equal(t, 5, len(sample1))
 		assert.Equal(t, 5, len(sample2))
@@ -106,3 +111,78 @@ func TestSamplingReproducibility(t *testing.T) {
 		assert.Equal(t, rand1.Bits64(), rand2.Bits64())
 	}
 }
+
+func conditionalTestHParams() expconf.Hyperparameters {
+	return expconf.Hyperparameters{
+		"optimizer": {RawCategoricalHyperparameter: &expconf.CategoricalHyperparameter{
+			RawVals: []interface{}{"sgd", "adam"},
+		}},
+		"momentum": {RawDoubleHyperparameter: &expconf.DoubleHyperparameter{
+			RawMinval: 0.5, RawMaxval: 0.99, RawCount: ptrs.Ptr(3),
+			RawConditions: &map[string][]interface{}{"optimizer": {"sgd"}},
+		}},
+		"nesterov": {RawCategoricalHyperparameter: &expconf.CategoricalHyperparameter{
+			RawVals:       []interface{}{true, false},
+			RawConditions: &map[string][]interface{}{"momentum": {0.5}},
+		}},
+		"batch_size": {RawCategoricalHyperparameter: &expconf.CategoricalHyperparameter{
+			RawVals: []interface{}{32, 64, 128, 256},
+		}},
+		"train": {RawNestedHyperparameter: &map[string]expconf.Hyperparameter{
+			"accum_steps": {RawIntHyperparameter: &expconf.IntHyperparameter{
+				RawMinval: 1, RawMaxval: 4, RawCount: ptrs.Ptr(4),
+				RawConstraints: ptrs.Ptr([]string{"batch_size * train.accum_steps <= 512"}),
+			}},
+		}},
+	}
+}
+
+func TestConditionalSampling(t *testing.T) {
+	spec := conditionalTestHParams()
+	rand := nprand.New(0)
+	for i := 0; i < 200; i++ {
+		sample, err := sampleAll(spec, rand)
+		assert.NilError(t, err)
+		_, hasMomentum := sample["momentum"]
+		assert.Equal(t, hasMomentum, sample["optimizer"] == "sgd", "%v", sample)
+		// momentum is never exactly 0.5 when sampled at random, so nesterov is never active.
+		_, hasNesterov := sample["nesterov"]
+		assert.Assert(t, !hasNesterov, "%v", sample)
+
+		accumSteps := sample["train"].(map[string]interface{})["accum_steps"].(int)
+		assert.Assert(t, sample["batch_size"].(int)*accumSteps <= 512, "%v", sample)
+	}
+}
+
+func TestUnsatisfiableConstraints(t *testing.T) {
+	spec := expconf.Hyperparameters{
+		"x": {RawIntHyperparameter: &expconf.IntHyperparameter{
+			RawMinval: 0, RawMaxval: 10, RawConstraints: ptrs.Ptr([]string{"x > 10"}),
+		}},
+	}
+	_, err := sampleAll(spec, nprand.New(0))
+	assert.ErrorContains(t, err, "satisfied the constraints")
+
+	// Searches fail rather than create trials whose hyperparameters violate the constraints.
+	for name, config := range map[string]expconf.SearcherConfig{
+		"random": {
+			RawMetric: ptrs.Ptr("loss"),
+			RawRandomConfig: &expconf.RandomConfig{
+				RawMaxTrials: ptrs.Ptr(2),
+				RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(100)),
+			},
+		},
+		"tpe": {
+			RawMetric: ptrs.Ptr("loss"),
+			RawTPEConfig: &expconf.TPEConfig{
+				RawMaxTrials: ptrs.Ptr(2),
+				RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(100)),
+			},
+		},
+	} {
+		config := schemas.WithDefaults(config).(expconf.SearcherConfig)
+		s := NewSearcher(0, NewSearchMethod(config), spec)
+		_, err := s.InitialOperations()
+		assert.ErrorContains(t, err, "satisfied the constraints", name)
+	}
+}
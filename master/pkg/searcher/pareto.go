@@ -0,0 +1,186 @@
+package searcher
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+// Searches that rank trials by several metrics work with the objectives of validations: the values
+// of the searcher's metrics, in order and negated if larger is better, so that smaller is always
+// better. A trial dominates another if its objectives are no worse in every metric and better in
+// at least one, and the Pareto front is the trials that no other trial dominates.
+
+// multiObjectiveSearchMethod is implemented by search methods that can rank trials by several
+// metrics at once.
+type multiObjectiveSearchMethod interface {
+	// objectivesCompleted is like validationCompleted, but also gets the objectives of the
+	// validation.
+	objectivesCompleted(ctx context, requestID model.RequestID, metric float64,
+		objectives []float64, op ValidateAfter) ([]Operation, error)
+}
+
+// completeValidation informs a search method that a validation completed, with its objectives if
+// the search ranks trials by several metrics and the method can use them.
+func completeValidation(
+	method SearchMethod, ctx context, requestID model.RequestID, metric float64,
+	objectives []float64, op ValidateAfter,
+) ([]Operation, error) {
+	if m, ok := method.(multiObjectiveSearchMethod); ok && objectives != nil {
+		return m.objectivesCompleted(ctx, requestID, metric, objectives, op)
+	}
+	return method.validationCompleted(ctx, requestID, metric, op)
+}
+
+// Objectives returns the objectives of a validation with the given validation metrics.
+func Objectives(
+	metrics []expconf.SearcherMetric, validationMetrics map[string]interface{},
+) ([]float64, error) {
+	objectives := make([]float64, 0, len(metrics))
+	for _, m := range metrics {
+		raw, ok := validationMetrics[m.Name()]
+		if !ok {
+			return nil, errors.Errorf("validation metrics do not include searcher metric %s", m.Name())
+		}
+		value, ok := numericParam(raw)
+		if !ok {
+			return nil, errors.Errorf("searcher metric %s is not a number: %v", m.Name(), raw)
+		}
+		if !m.SmallerIsBetter() {
+			value *= -1
+		}
+		objectives = append(objectives, value)
+	}
+	return objectives, nil
+}
+
+// dominates returns whether objectives a dominate objectives b. Trials without objectives, which
+// exited before validating, are dominated by every trial with objectives.
+func dominates(a, b []float64) bool {
+	switch {
+	case a == nil:
+		return false
+	case b == nil:
+		return true
+	}
+	better := false
+	for i := range a {
+		switch {
+		case a[i] > b[i]:
+			return false
+		case a[i] < b[i]:
+			better = true
+		}
+	}
+	return better
+}
+
+// nonDominatedFronts sorts the indexes of points into fronts by non-dominated sorting (Deb et al.,
+// 2002). The first front is the points that no point dominates, the second is the points that
+// only points of the first front dominate, and so on.
+func nonDominatedFronts(points [][]float64) [][]int {
+	dominatedBy := make([]int, len(points))
+	dominating := make([][]int, len(points))
+	var front []int
+	for i := range points {
+		for j := range points {
+			switch {
+			case dominates(points[i], points[j]):
+				dominating[i] = append(dominating[i], j)
+			case dominates(points[j], points[i]):
+				dominatedBy[i]++
+			}
+		}
+		if dominatedBy[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	var fronts [][]int
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominating[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		front = next
+	}
+	return fronts
+}
+
+// crowdingDistances returns the crowding distances of the points of a front: the sum over the
+// objectives of the distance between the neighbors of each point, relative to the range of the
+// front. The points at the ends of the range of any objective have infinite distance.
+func crowdingDistances(points [][]float64, front []int) map[int]float64 {
+	distances := make(map[int]float64, len(front))
+	for _, i := range front {
+		distances[i] = 0
+	}
+	if len(front) == 0 || points[front[0]] == nil {
+		return distances
+	}
+	sorted := append([]int(nil), front...)
+	for k := range points[front[0]] {
+		sort.SliceStable(sorted, func(a, b int) bool {
+			return points[sorted[a]][k] < points[sorted[b]][k]
+		})
+		low, high := points[sorted[0]][k], points[sorted[len(sorted)-1]][k]
+		distances[sorted[0]] = math.Inf(1)
+		distances[sorted[len(sorted)-1]] = math.Inf(1)
+		if high == low {
+			continue
+		}
+		for a := 1; a < len(sorted)-1; a++ {
+			distances[sorted[a]] += (points[sorted[a+1]][k] - points[sorted[a-1]][k]) / (high - low)
+		}
+	}
+	return distances
+}
+
+// paretoOrder returns the indexes of points from best to worst: by front, then by crowding
+// distance, largest first, so that points in sparse parts of a front are preferred, then by index.
+func paretoOrder(points [][]float64) []int {
+	order := make([]int, 0, len(points))
+	for _, front := range nonDominatedFronts(points) {
+		distances := crowdingDistances(points, front)
+		sort.SliceStable(front, func(a, b int) bool {
+			return distances[front[a]] > distances[front[b]]
+		})
+		order = append(order, front...)
+	}
+	return order
+}
+
+// paretoFront returns the trials that no other trial dominates, in the order of their request
+// IDs.
+func paretoFront(objectives map[model.RequestID][]float64) []model.RequestID {
+	requestIDs := make([]model.RequestID, 0, len(objectives))
+	for requestID := range objectives {
+		requestIDs = append(requestIDs, requestID)
+	}
+	sort.Slice(requestIDs, func(i, j int) bool { return requestIDs[i].Before(requestIDs[j]) })
+
+	points := make([][]float64, 0, len(requestIDs))
+	for _, requestID := range requestIDs {
+		points = append(points, objectives[requestID])
+	}
+	fronts := nonDominatedFronts(points)
+	if len(fronts) == 0 {
+		return nil
+	}
+	front := make([]model.RequestID, 0, len(fronts[0]))
+	for _, i := range fronts[0] {
+		front = append(front, requestIDs[i])
+	}
+	return front
+}
@@ -0,0 +1,128 @@
+//nolint:exhaustivestruct
+package searcher
+
+import (
+	"crypto/rand"
+	"math"
+	"sort"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+func TestObjectives(t *testing.T) {
+	metrics := []expconf.SearcherMetric{
+		{RawName: "accuracy", RawSmallerIsBetter: ptrs.Ptr(false)},
+		{RawName: "latency", RawSmallerIsBetter: ptrs.Ptr(true)},
+	}
+	objectives, err := Objectives(metrics, map[string]interface{}{
+		"accuracy": 0.9, "latency": 12, "loss": 0.3,
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, objectives, []float64{-0.9, 12})
+
+	_, err = Objectives(metrics, map[string]interface{}{"accuracy": 0.9})
+	assert.ErrorContains(t, err, "do not include searcher metric latency")
+	_, err = Objectives(metrics, map[string]interface{}{"accuracy": "high", "latency": 12})
+	assert.ErrorContains(t, err, "searcher metric accuracy is not a number")
+}
+
+func TestNonDominatedFronts(t *testing.T) {
+	points := [][]float64{
+		{1, 4},
+		{2, 2},
+		{4, 1},
+		{3, 3},
+		nil,
+		{2, 5},
+		{5, 5},
+	}
+	assert.DeepEqual(t, nonDominatedFronts(points), [][]int{{0, 1, 2}, {3, 5}, {6}, {4}})
+}
+
+func TestParetoOrder(t *testing.T) {
+	// All of the points are on the first front, and the ones at the ends of it come first, followed
+	// by the ones in the sparsest parts of it.
+	points := [][]float64{
+		{2, 3},
+		{1, 4},
+		{2.8, 1.8},
+		{4, 1},
+		{1.5, 3.2},
+	}
+	distances := crowdingDistances(points, []int{0, 1, 2, 3, 4})
+	assert.Equal(t, distances[1], math.Inf(1))
+	assert.Equal(t, distances[3], math.Inf(1))
+	assert.Assert(t, distances[2] > distances[0] && distances[0] > distances[4])
+	assert.DeepEqual(t, paretoOrder(points), []int{1, 3, 2, 0, 4})
+}
+
+func TestASHAPromotionsByObjectives(t *testing.T) {
+	var ids []model.RequestID
+	for i := 0; i < 4; i++ {
+		ids = append(ids, model.NewRequestID(rand.Reader))
+	}
+	r := &rung{}
+
+	// No trial can be promoted until the rung has two.
+	assert.Equal(t, len(r.promotionsAsync(ids[0], 0.9, []float64{1, 4}, 2)), 0)
+	// Neither of the first two trials dominates the other, so the first one is promoted.
+	assert.DeepEqual(t, r.promotionsAsync(ids[1], 0.1, []float64{4, 1}, 2), []model.RequestID{ids[0]})
+	// A trial that dominates the others is promoted right away, even if its metric is worse.
+	assert.DeepEqual(t,
+		r.promotionsAsync(ids[2], 1, []float64{0.5, 0.5}, 2), []model.RequestID{ids[2]})
+	// A trial that is dominated is not, even if its metric is better.
+	assert.Equal(t, len(r.promotionsAsync(ids[3], 0, []float64{5, 5}, 2)), 0)
+
+	var order []model.RequestID
+	for _, m := range r.Metrics {
+		order = append(order, m.RequestID)
+	}
+	assert.DeepEqual(t, order, []model.RequestID{ids[2], ids[0], ids[1], ids[3]})
+}
+
+func TestSearcherParetoFront(t *testing.T) {
+	conf := schemas.WithDefaults(expconf.RandomConfig{
+		RawMaxTrials: ptrs.Ptr(4), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+	}).(expconf.RandomConfig)
+	s := NewSearcher(0, newRandomSearch(conf), nil)
+	ops, err := s.InitialOperations()
+	assert.NilError(t, err)
+
+	var ids []model.RequestID
+	for _, op := range ops {
+		if create, ok := op.(Create); ok {
+			ids = append(ids, create.RequestID)
+			_, err = s.TrialCreated(create.RequestID)
+			assert.NilError(t, err)
+		}
+	}
+	assert.Equal(t, len(ids), 4)
+	front := func(ids ...model.RequestID) []model.RequestID {
+		sort.Slice(ids, func(i, j int) bool { return ids[i].Before(ids[j]) })
+		return ids
+	}
+
+	for i, objectives := range [][]float64{{1, 4}, {2, 2}, {3, 3}, {4, 1}} {
+		_, err = s.ValidationCompleted(ids[i], 0, objectives, NewValidateAfter(ids[i], 300))
+		assert.NilError(t, err)
+	}
+	assert.DeepEqual(t, s.ParetoFront(), front(ids[0], ids[1], ids[3]))
+
+	snapshot, err := s.Snapshot()
+	assert.NilError(t, err)
+	restored := NewSearcher(0, newRandomSearch(conf), nil)
+	assert.NilError(t, restored.Restore(snapshot))
+	assert.DeepEqual(t, restored.ParetoFront(), front(ids[0], ids[1], ids[3]))
+
+	// Trials with invalid hyperparameters leave the front and no longer keep the trials they
+	// dominated off of it.
+	_, err = restored.TrialExitedEarly(ids[1], model.InvalidHP)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, restored.ParetoFront(), front(ids[0], ids[2], ids[3]))
+}
@@ -0,0 +1,286 @@
+package searcher
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/determined-ai/determined/master/pkg/mathx"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+// pbtExitedMetric is the metric that trials which exited early are ranked by, so that they are
+// always among the trials that are replaced.
+const pbtExitedMetric = math.MaxFloat64
+
+type (
+	// pbtSearchState stores the state for PBT. The population is the trials in TrialParams. Metrics
+	// holds the metrics of the trials of the population that have finished the current round,
+	// negated if larger is better, so that the round ends once it holds every trial.
+	pbtSearchState struct {
+		RoundsCompleted      int                              `json:"rounds_completed"`
+		Metrics              map[model.RequestID]float64      `json:"metrics"`
+		TrialRoundsCompleted map[model.RequestID]int          `json:"trial_rounds_completed"`
+		TrialParams          map[model.RequestID]HParamSample `json:"trial_params"`
+		EarlyExitTrials      map[model.RequestID]bool         `json:"early_exit_trials"`
+		SearchMethodType     SearchMethodType                 `json:"search_method_type"`
+	}
+	// pbtSearch corresponds to Population Based Training (Jaderberg et al., 2017). It trains a
+	// population of trials in rounds. After each round, the worst truncate_fraction of the
+	// population is closed and replaced by copies of the best truncate_fraction, which start from
+	// the latest checkpoints of the trials they copy with hyperparameters that are resampled or
+	// perturbed, while the rest of the population keeps training.
+	pbtSearch struct {
+		defaultSearchMethod
+		expconf.PBTConfig
+		pbtSearchState
+		smallerIsBetter bool
+	}
+)
+
+func newPBTSearch(config expconf.PBTConfig, smallerIsBetter bool) SearchMethod {
+	return &pbtSearch{
+		PBTConfig:       config,
+		smallerIsBetter: smallerIsBetter,
+		pbtSearchState: pbtSearchState{
+			Metrics:              make(map[model.RequestID]float64),
+			TrialRoundsCompleted: make(map[model.RequestID]int),
+			TrialParams:          make(map[model.RequestID]HParamSample),
+			EarlyExitTrials:      make(map[model.RequestID]bool),
+			SearchMethodType:     PBTSearch,
+		},
+	}
+}
+
+func (s *pbtSearch) initialOperations(ctx context) ([]Operation, error) {
+	var ops []Operation
+	for trial := 0; trial < s.PopulationSize(); trial++ {
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
+		ops = append(ops, s.addTrial(create)...)
+	}
+	return ops, nil
+}
+
+// addTrial adds the trial of a Create to the population and returns the operations to train it
+// for its first round.
+func (s *pbtSearch) addTrial(create Create) []Operation {
+	s.TrialParams[create.RequestID] = create.Hparams
+	s.TrialRoundsCompleted[create.RequestID] = 0
+	return []Operation{create, NewValidateAfter(create.RequestID, s.LengthPerRound().Units)}
+}
+
+// removeTrial removes a trial from the population.
+func (s *pbtSearch) removeTrial(requestID model.RequestID) {
+	delete(s.TrialParams, requestID)
+	delete(s.TrialRoundsCompleted, requestID)
+	delete(s.EarlyExitTrials, requestID)
+}
+
+func (s *pbtSearch) validationCompleted(
+	ctx context, requestID model.RequestID, metric float64, op ValidateAfter,
+) ([]Operation, error) {
+	if _, ok := s.TrialParams[requestID]; !ok || s.EarlyExitTrials[requestID] {
+		return nil, nil
+	}
+	if !s.smallerIsBetter {
+		metric *= -1
+	}
+	s.Metrics[requestID] = metric
+	s.TrialRoundsCompleted[requestID]++
+	if len(s.Metrics) == len(s.TrialParams) {
+		return s.runNewRound(ctx)
+	}
+	return nil, nil
+}
+
+// trialExitedEarly ranks trials that exit early last in the current round, so they are replaced
+// when it ends.
+func (s *pbtSearch) trialExitedEarly(
+	ctx context, requestID model.RequestID, exitedReason model.ExitedReason,
+) ([]Operation, error) {
+	if _, ok := s.TrialParams[requestID]; !ok {
+		return nil, nil
+	}
+	s.EarlyExitTrials[requestID] = true
+	s.Metrics[requestID] = pbtExitedMetric
+	if len(s.Metrics) == len(s.TrialParams) {
+		return s.runNewRound(ctx)
+	}
+	return nil, nil
+}
+
+// runNewRound ends the current round. It closes the whole population after the last round;
+// otherwise it replaces the worst trials and trains the rest for another round.
+func (s *pbtSearch) runNewRound(ctx context) ([]Operation, error) {
+	s.RoundsCompleted++
+	ranked := make([]model.RequestID, 0, len(s.Metrics))
+	for requestID := range s.Metrics {
+		ranked = append(ranked, requestID)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		mi, mj := s.Metrics[ranked[i]], s.Metrics[ranked[j]]
+		if mi != mj {
+			return mi < mj
+		}
+		return ranked[i].Before(ranked[j])
+	})
+	s.Metrics = make(map[model.RequestID]float64)
+
+	var ops []Operation
+	if s.RoundsCompleted >= s.NumRounds() {
+		for _, requestID := range ranked {
+			if !s.EarlyExitTrials[requestID] {
+				ops = append(ops, NewClose(requestID))
+			}
+		}
+		return ops, nil
+	}
+
+	// Replace the worst truncate_fraction of the population, along with any other trials that
+	// exited early, since they can't train any further.
+	numTruncate := int(s.ReplaceFunction().TruncateFraction() * float64(len(ranked)))
+	var survivors, replaced []model.RequestID
+	for i, requestID := range ranked {
+		if i >= len(ranked)-numTruncate || s.EarlyExitTrials[requestID] {
+			replaced = append(replaced, requestID)
+		} else {
+			survivors = append(survivors, requestID)
+		}
+	}
+	parents := ranked[:mathx.Min(mathx.Max(numTruncate, 1), len(survivors))]
+
+	for _, requestID := range survivors {
+		ops = append(ops, NewValidateAfter(
+			requestID, s.LengthPerRound().Units*uint64(s.TrialRoundsCompleted[requestID]+1),
+		))
+	}
+	for i, requestID := range replaced {
+		if !s.EarlyExitTrials[requestID] {
+			ops = append(ops, NewClose(requestID))
+		}
+		var create Create
+		if len(parents) == 0 {
+			// Every trial exited early, so there is nothing to copy.
+			hparams, err := sampleAll(ctx.hparams, ctx.rand)
+			if err != nil {
+				return nil, err
+			}
+			create = NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
+		} else {
+			parent := parents[i%len(parents)]
+			params, err := sampleValid(ctx.hparams, func() HParamSample {
+				return s.exploreParams(ctx, ctx.hparams, s.TrialParams[parent])
+			})
+			if err != nil {
+				return nil, err
+			}
+			create = NewCreateFromCheckpoint(
+				ctx.rand, params, parent, model.TrialWorkloadSequencerType,
+			)
+		}
+		ops = append(ops, s.addTrial(create)...)
+	}
+	for _, requestID := range replaced {
+		s.removeTrial(requestID)
+	}
+	return ops, nil
+}
+
+// exploreParams derives the hyperparameters of a copy of a trial from the hyperparameters of the
+// trial. Each one is either resampled from its range or, if numeric, multiplied by 1 plus or minus
+// perturb_factor and clipped to its range. Hyperparameters that were inactive for the trial are
+// resampled.
+func (s *pbtSearch) exploreParams(
+	ctx context, h expconf.Hyperparameters, params map[string]interface{},
+) HParamSample {
+	explore := s.ExploreFunction()
+	results := make(HParamSample)
+	h.Each(func(name string, param expconf.Hyperparameter) {
+		switch {
+		case param.RawConstHyperparameter != nil:
+			results[name] = param.RawConstHyperparameter.Val()
+			return
+		case param.RawNestedHyperparameter != nil:
+			nested, _ := params[name].(map[string]interface{})
+			results[name] = map[string]interface{}(
+				s.exploreParams(ctx, *param.RawNestedHyperparameter, nested),
+			)
+			return
+		}
+
+		if ctx.rand.UnitInterval() < explore.ResampleProbability() {
+			results[name] = sampleOne(param, ctx.rand)
+			return
+		}
+		multiplier := 1 + explore.PerturbFactor()
+		if ctx.rand.UnitInterval() < 0.5 {
+			multiplier = 1 - explore.PerturbFactor()
+		}
+
+		if param.RawCategoricalHyperparameter != nil {
+			if current, ok := params[name]; ok {
+				results[name] = current
+			} else {
+				results[name] = sampleOne(param, ctx.rand)
+			}
+			return
+		}
+		val, ok := numericParam(params[name])
+		if !ok {
+			results[name] = sampleOne(param, ctx.rand)
+			return
+		}
+		switch {
+		case param.RawIntHyperparameter != nil:
+			p := param.RawIntHyperparameter
+			results[name] = mathx.Clamp(p.Minval(), int(math.Round(val*multiplier)), p.Maxval())
+		case param.RawDoubleHyperparameter != nil:
+			p := param.RawDoubleHyperparameter
+			results[name] = math.Max(p.Minval(), math.Min(p.Maxval(), val*multiplier))
+		case param.RawLogHyperparameter != nil:
+			p := param.RawLogHyperparameter
+			low, high := math.Pow(p.Base(), p.Minval()), math.Pow(p.Base(), p.Maxval())
+			results[name] = math.Max(low, math.Min(high, val*multiplier))
+		}
+	})
+	return results
+}
+
+// progress is the fraction of the rounds that have been completed, counting the training the
+// population has done in the current round.
+func (s *pbtSearch) progress(
+	trialProgress map[model.RequestID]PartialUnits,
+	trialsClosed map[model.RequestID]bool,
+) float64 {
+	if s.RoundsCompleted >= s.NumRounds() {
+		return 1
+	}
+	lengthPerRound := float64(s.LengthPerRound().Units)
+	roundProgress := 0.
+	for requestID, rounds := range s.TrialRoundsCompleted {
+		if _, ok := s.Metrics[requestID]; ok {
+			roundProgress += lengthPerRound
+			continue
+		}
+		trained := float64(trialProgress[requestID]) - lengthPerRound*float64(rounds)
+		roundProgress += math.Max(0, math.Min(lengthPerRound, trained))
+	}
+	roundProgress /= lengthPerRound * float64(s.PopulationSize())
+	return (float64(s.RoundsCompleted) + roundProgress) / float64(s.NumRounds())
+}
+
+func (s *pbtSearch) Snapshot() (json.RawMessage, error) {
+	return json.Marshal(s.pbtSearchState)
+}
+
+func (s *pbtSearch) Restore(state json.RawMessage) error {
+	if state == nil {
+		return nil
+	}
+	return json.Unmarshal(state, &s.pbtSearchState)
+}
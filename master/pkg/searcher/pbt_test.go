@@ -0,0 +1,189 @@
+//nolint:exhaustivestruct
+package searcher
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/nprand"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+func pbtTestHParams() expconf.Hyperparameters {
+	return schemas.WithDefaults(expconf.Hyperparameters{
+		"x": expconf.Hyperparameter{
+			RawDoubleHyperparameter: &expconf.DoubleHyperparameter{RawMinval: 0, RawMaxval: 10},
+		},
+		"n": expconf.Hyperparameter{
+			RawIntHyperparameter: &expconf.IntHyperparameter{RawMinval: 1, RawMaxval: 10},
+		},
+		"lr": expconf.Hyperparameter{
+			RawLogHyperparameter: &expconf.LogHyperparameter{
+				RawMinval: -5, RawMaxval: -1, RawBase: 10,
+			},
+		},
+		"opt": expconf.Hyperparameter{
+			RawCategoricalHyperparameter: &expconf.CategoricalHyperparameter{
+				RawVals: []interface{}{"a", "b", "c"},
+			},
+		},
+		"const": expconf.Hyperparameter{
+			RawConstHyperparameter: &expconf.ConstHyperparameter{RawVal: 64},
+		},
+	}).(expconf.Hyperparameters)
+}
+
+func pbtTestConfig(populationSize, numRounds int, truncateFraction float64) expconf.PBTConfig {
+	return schemas.WithDefaults(expconf.PBTConfig{
+		RawPopulationSize:  ptrs.Ptr(populationSize),
+		RawNumRounds:       ptrs.Ptr(numRounds),
+		RawLengthPerRound:  ptrs.Ptr(expconf.NewLengthInBatches(100)),
+		RawReplaceFunction: &expconf.PBTReplaceConfig{RawTruncateFraction: truncateFraction},
+		RawExploreFunction: &expconf.PBTExploreConfig{
+			RawResampleProbability: 0.2,
+			RawPerturbFactor:       0.2,
+		},
+	}).(expconf.PBTConfig)
+}
+
+func TestPBTSearcherWorkloads(t *testing.T) {
+	search := NewSearcher(0, newPBTSearch(pbtTestConfig(4, 3, 0.25), true), pbtTestHParams())
+	actual, err := Simulate(search, new(int64), RandomValidation, true, defaultMetric)
+	assert.NilError(t, err)
+
+	// Every round replaces the worst trial, so 3 rounds of a population of 4 create 6 trials, and
+	// the trials train for 12 rounds in total.
+	assert.Equal(t, len(actual.Results), 6)
+	rounds := 0
+	for _, ops := range actual.Results {
+		for i, op := range ops {
+			assert.Equal(t, op.Length, uint64(100*(i+1)))
+		}
+		rounds += len(ops)
+	}
+	assert.Equal(t, rounds, 12)
+}
+
+func TestPBTSearcherReproducibility(t *testing.T) {
+	conf := pbtTestConfig(10, 5, 0.3)
+	gen := func() SearchMethod { return newPBTSearch(conf, true) }
+	checkReproducibility(t, gen, pbtTestHParams(), defaultMetric)
+}
+
+func TestPBTSearcherExploit(t *testing.T) {
+	ctx := context{rand: nprand.New(0), hparams: pbtTestHParams()}
+	method := newPBTSearch(pbtTestConfig(4, 2, 0.5), true)
+	ops, err := method.initialOperations(ctx)
+	assert.NilError(t, err)
+
+	var creates []Create
+	for _, op := range ops {
+		if create, ok := op.(Create); ok {
+			creates = append(creates, create)
+		}
+	}
+	assert.Equal(t, len(creates), 4)
+
+	// Trials with lower metrics are better, so the first two trials should be copied and the last
+	// two replaced.
+	var roundOps []Operation
+	for i, create := range creates {
+		op := NewValidateAfter(create.RequestID, 100)
+		roundOps, err = method.validationCompleted(ctx, create.RequestID, float64(i), op)
+		assert.NilError(t, err)
+		assert.NilError(t, saveAndReload(method))
+	}
+
+	var closed, parents []model.RequestID
+	validated := map[model.RequestID]uint64{}
+	for _, op := range roundOps {
+		switch op := op.(type) {
+		case Close:
+			closed = append(closed, op.RequestID)
+		case Create:
+			assert.Assert(t, op.Checkpoint != nil)
+			parents = append(parents, op.Checkpoint.RequestID)
+		case ValidateAfter:
+			validated[op.RequestID] = op.Length
+		}
+	}
+	assert.DeepEqual(t, closed, []model.RequestID{creates[2].RequestID, creates[3].RequestID})
+	assert.DeepEqual(t, parents, []model.RequestID{creates[0].RequestID, creates[1].RequestID})
+	assert.Equal(t, validated[creates[0].RequestID], uint64(200))
+	assert.Equal(t, validated[creates[1].RequestID], uint64(200))
+	assert.Equal(t, len(validated), 4)
+}
+
+func TestPBTSearcherExploreParams(t *testing.T) {
+	ctx := context{rand: nprand.New(0), hparams: pbtTestHParams()}
+	method := newPBTSearch(pbtTestConfig(4, 2, 0.5), true).(*pbtSearch)
+
+	params, err := sampleAll(ctx.hparams, ctx.rand)
+	assert.NilError(t, err)
+	for i := 0; i < 100; i++ {
+		params = method.exploreParams(ctx, ctx.hparams, params)
+		assert.Equal(t, params["const"], 64)
+		x := params["x"].(float64)
+		assert.Assert(t, x >= 0 && x <= 10)
+		n := params["n"].(int)
+		assert.Assert(t, n >= 1 && n <= 10)
+		lr := params["lr"].(float64)
+		assert.Assert(t, lr >= 1e-5 && lr <= 1e-1)
+		opt := params["opt"].(string)
+		assert.Assert(t, opt == "a" || opt == "b" || opt == "c")
+	}
+
+	// Integer hyperparameters are restored from snapshots as floats, but stay integers.
+	params["n"] = 5.
+	_, ok := method.exploreParams(ctx, ctx.hparams, params)["n"].(int)
+	assert.Assert(t, ok)
+}
+
+func TestPBTSearchMethod(t *testing.T) {
+	config := expconf.SearcherConfig{
+		RawPBTConfig: &expconf.PBTConfig{
+			RawPopulationSize:  ptrs.Ptr(4),
+			RawNumRounds:       ptrs.Ptr(3),
+			RawLengthPerRound:  ptrs.Ptr(expconf.NewLengthInBatches(100)),
+			RawReplaceFunction: &expconf.PBTReplaceConfig{RawTruncateFraction: 0.25},
+			RawExploreFunction: &expconf.PBTExploreConfig{
+				RawResampleProbability: 0.2,
+				RawPerturbFactor:       0.2,
+			},
+		},
+	}
+	testCases := []valueSimulationTestCase{
+		{
+			name: "test pbt search method",
+			expectedTrials: []predefinedTrial{
+				newConstantPredefinedTrial(toOps("100B 200B 300B"), .1),
+				newConstantPredefinedTrial(toOps("100B 200B 300B"), .2),
+				newConstantPredefinedTrial(toOps("100B 200B"), .3),
+				newConstantPredefinedTrial(toOps("100B"), .4),
+				newConstantPredefinedTrial(toOps("100B 200B"), .05),
+				newConstantPredefinedTrial(toOps("100B"), .5),
+			},
+			hparams: pbtTestHParams(),
+			config:  config,
+		},
+		{
+			name: "test pbt search method with early exit",
+			expectedTrials: []predefinedTrial{
+				newConstantPredefinedTrial(toOps("100B 200B 300B"), .1),
+				newEarlyExitPredefinedTrial(toOps("100B"), .01),
+				newConstantPredefinedTrial(toOps("100B 200B 300B"), .3),
+				newConstantPredefinedTrial(toOps("100B 200B"), .4),
+				newConstantPredefinedTrial(toOps("100B 200B"), .05),
+				newConstantPredefinedTrial(toOps("100B"), .5),
+			},
+			hparams: pbtTestHParams(),
+			config:  config,
+		},
+	}
+
+	runValueSimulationTestCases(t, testCases)
+}
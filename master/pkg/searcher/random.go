@@ -2,6 +2,7 @@ package searcher
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/determined-ai/determined/master/pkg/mathx"
 	"github.com/determined-ai/determined/master/pkg/model"
@@ -21,6 +22,9 @@ type (
 		CreatedTrials    int              `json:"created_trials"`
 		PendingTrials    int              `json:"pending_trials"`
 		SearchMethodType SearchMethodType `json:"search_method_type"`
+		// TrialsToContinue are the trials of a continued search that are still to be trained to a
+		// longer max_length.
+		TrialsToContinue []model.RequestID `json:"trials_to_continue,omitempty"`
 	}
 	// randomSearch corresponds to the standard random search method. Each random trial configuration
 	// is trained for the specified number of steps, and then validation metrics are computed.
@@ -60,7 +64,11 @@ func (s *randomSearch) initialOperations(ctx context) ([]Operation, error) {
 		initialTrials = mathx.Min(s.MaxTrials(), s.MaxConcurrentTrials())
 	}
 	for trial := 0; trial < initialTrials; trial++ {
-		create := NewCreate(ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.MaxLength().Units))
 		ops = append(ops, NewClose(create.RequestID))
@@ -114,9 +122,27 @@ func (s *randomSearch) trialExitedEarly(
 
 func (s *randomSearch) trialClosed(ctx context, requestID model.RequestID) ([]Operation, error) {
 	s.PendingTrials--
+	return s.nextTrial(ctx)
+}
+
+// nextTrial returns the operations that train the next trial of a continued search that is still
+// to be trained to a longer max_length, if there is one, or else that create a new trial if there
+// are fewer than max_trials.
+func (s *randomSearch) nextTrial(ctx context) ([]Operation, error) {
 	var ops []Operation
-	if s.CreatedTrials < s.MaxTrials() {
-		create := NewCreate(ctx.rand, sampleAll(ctx.hparams, ctx.rand), model.TrialWorkloadSequencerType)
+	switch {
+	case len(s.TrialsToContinue) > 0:
+		requestID := s.TrialsToContinue[0]
+		s.TrialsToContinue = s.TrialsToContinue[1:]
+		ops = append(ops, NewValidateAfter(requestID, s.MaxLength().Units))
+		ops = append(ops, NewClose(requestID))
+		s.PendingTrials++
+	case s.CreatedTrials < s.MaxTrials():
+		hparams, err := sampleAll(ctx.hparams, ctx.rand)
+		if err != nil {
+			return nil, err
+		}
+		create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
 		ops = append(ops, create)
 		ops = append(ops, NewValidateAfter(create.RequestID, s.MaxLength().Units))
 		ops = append(ops, NewClose(create.RequestID))
@@ -126,6 +152,34 @@ func (s *randomSearch) trialClosed(ctx context, requestID model.RequestID) ([]Op
 	return ops, nil
 }
 
+// continueSearch trains the trials that were trained to a shorter max_length further, starting
+// from their latest checkpoints, and creates trials until there are max_trials of them.
+func (s *randomSearch) continueSearch(
+	ctx context, trialLengths map[model.RequestID]uint64,
+) ([]Operation, error) {
+	for requestID, length := range trialLengths {
+		if length < s.MaxLength().Units {
+			s.TrialsToContinue = append(s.TrialsToContinue, requestID)
+		}
+	}
+	sort.Slice(s.TrialsToContinue, func(i, j int) bool {
+		return s.TrialsToContinue[i].Before(s.TrialsToContinue[j])
+	})
+
+	var ops []Operation
+	for s.MaxConcurrentTrials() == 0 || s.PendingTrials < s.MaxConcurrentTrials() {
+		next, err := s.nextTrial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(next) == 0 {
+			break
+		}
+		ops = append(ops, next...)
+	}
+	return ops, nil
+}
+
 func (s *randomSearch) Snapshot() (json.RawMessage, error) {
 	return json.Marshal(s.randomSearchState)
 }
@@ -59,6 +59,10 @@ const (
 	RandomSearch SearchMethodType = "random"
 	// GridSearch is the SearchMethodType for a grid searcher.
 	GridSearch SearchMethodType = "grid"
+	// TPESearch is the SearchMethodType for a TPE searcher.
+	TPESearch SearchMethodType = "tpe"
+	// PBTSearch is the SearchMethodType for a PBT searcher.
+	PBTSearch SearchMethodType = "pbt"
 	// AdaptiveSearch is the SearchMethodType for an adaptive searcher.
 	AdaptiveSearch SearchMethodType = "adaptive"
 	// ASHASearch is the SearchMethodType for an ASHA searcher.
@@ -78,6 +82,10 @@ func NewSearchMethod(c expconf.SearcherConfig) SearchMethod {
 		return newRandomSearch(*c.RawRandomConfig)
 	case c.RawGridConfig != nil:
 		return newGridSearch(*c.RawGridConfig)
+	case c.RawTPEConfig != nil:
+		return newTPESearch(*c.RawTPEConfig, c.SmallerIsBetter())
+	case c.RawPBTConfig != nil:
+		return newPBTSearch(*c.RawPBTConfig, c.SmallerIsBetter())
 	case c.RawAsyncHalvingConfig != nil:
 		if c.RawAsyncHalvingConfig.StopOnce() {
 			return newAsyncHalvingStoppingSearch(*c.RawAsyncHalvingConfig, c.SmallerIsBetter())
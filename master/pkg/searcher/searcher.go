@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/determined-ai/determined/master/pkg/mathx"
 	"github.com/determined-ai/determined/master/pkg/model"
 	"github.com/determined-ai/determined/master/pkg/nprand"
 	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
@@ -27,6 +28,12 @@ type (
 		TrialProgress       map[model.RequestID]PartialUnits `json:"trial_progress"`
 		Shutdown            bool                             `json:"shutdown"`
 		CompletedOperations map[string]ValidateAfter         `json:"completed_operations"`
+		// TrialObjectives holds the objectives of the latest validation of each trial of a search
+		// that ranks trials by several metrics.
+		TrialObjectives map[model.RequestID][]float64 `json:"trial_objectives"`
+		// Continued is set once a completed search is continued, after which trials that were
+		// closed can be trained further.
+		Continued bool `json:"continued"`
 
 		Rand *nprand.State `json:"rand"`
 
@@ -55,6 +62,7 @@ func NewSearcher(seed uint32, method SearchMethod, hparams expconf.Hyperparamete
 			Failures:            map[model.RequestID]bool{},
 			TrialProgress:       map[model.RequestID]PartialUnits{},
 			CompletedOperations: map[string]ValidateAfter{},
+			TrialObjectives:     map[model.RequestID][]float64{},
 		},
 	}
 }
@@ -105,6 +113,7 @@ func (s *Searcher) TrialExitedEarly(
 	switch exitedReason {
 	case model.InvalidHP, model.InitInvalidHP:
 		delete(s.TrialProgress, requestID)
+		delete(s.TrialObjectives, requestID)
 	case model.UserCanceled:
 		s.Cancels[requestID] = true
 	case model.Errored:
@@ -138,15 +147,19 @@ func (s *Searcher) SetTrialProgress(requestID model.RequestID, progress PartialU
 	s.TrialProgress[requestID] = progress
 }
 
-// ValidationCompleted informs the searcher that a validation for the trial was completed.
+// ValidationCompleted informs the searcher that a validation for the trial was completed. The
+// objectives of the validation are nil unless the search ranks trials by several metrics.
 func (s *Searcher) ValidationCompleted(
-	requestID model.RequestID, metric float64, op ValidateAfter,
+	requestID model.RequestID, metric float64, objectives []float64, op ValidateAfter,
 ) ([]Operation, error) {
 	if _, ok := s.CompletedOperations[op.String()]; ok {
 		return nil, fmt.Errorf("operation %v was already completed", op)
 	}
 
-	operations, err := s.method.validationCompleted(s.context(), requestID, metric, op)
+	if objectives != nil {
+		s.TrialObjectives[requestID] = objectives
+	}
+	operations, err := completeValidation(s.method, s.context(), requestID, metric, objectives, op)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while handling a workload completed event: %s", requestID)
 	}
@@ -178,6 +191,40 @@ func (s *Searcher) TrialClosed(requestID model.RequestID) ([]Operation, error) {
 	return operations, nil
 }
 
+// Continue continues a completed search, once the searcher has been restored with a search method
+// with a larger max_trials or max_length.
+func (s *Searcher) Continue() ([]Operation, error) {
+	method, ok := s.method.(continuableSearchMethod)
+	if !ok {
+		return nil, unsupportedMethodError(s.method, "continuing a search")
+	}
+
+	trialLengths := map[model.RequestID]uint64{}
+	for _, op := range s.CompletedOperations {
+		if s.TrialsClosed[op.RequestID] && !s.Exits[op.RequestID] {
+			trialLengths[op.RequestID] = mathx.Max(trialLengths[op.RequestID], op.Length)
+		}
+	}
+	operations, err := method.continueSearch(s.context(), trialLengths)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while continuing the search")
+	}
+	if len(operations) == 0 {
+		return nil, errors.New("the search has nothing left to do")
+	}
+	s.Shutdown = false
+	s.Continued = true
+	s.Record(operations)
+	return operations, nil
+}
+
+// ParetoFront returns the trials that no other trial dominates by the objectives of their latest
+// validations, in the order of their request IDs. It is empty unless the search ranks trials by
+// several metrics.
+func (s *Searcher) ParetoFront() []model.RequestID {
+	return paretoFront(s.TrialObjectives)
+}
+
 // Progress returns experiment progress as a float between 0.0 and 1.0.
 func (s *Searcher) Progress() float64 {
 	progress := s.method.progress(s.TrialProgress, s.TrialsClosed)
@@ -208,9 +255,13 @@ func (s *Searcher) SetCustomSearcherProgress(progress float64) error {
 // Record records operations that were requested by the searcher for a specific trial.
 func (s *Searcher) Record(ops []Operation) {
 	for _, op := range ops {
-		switch op.(type) {
+		switch op := op.(type) {
 		case Create:
 			s.TrialsRequested++
+		case ValidateAfter:
+			if s.Continued && !s.Exits[op.RequestID] {
+				delete(s.TrialsClosed, op.RequestID)
+			}
 		case Shutdown:
 			s.Shutdown = true
 		}
@@ -91,6 +91,41 @@ func (s *tournamentSearch) validationCompleted(
 	return s.markCreates(subSearchID, ops), err
 }
 
+func (s *tournamentSearch) objectivesCompleted(
+	ctx context, requestID model.RequestID, metric float64, objectives []float64, op ValidateAfter,
+) ([]Operation, error) {
+	subSearchID := s.TrialTable[requestID]
+	subSearch := s.subSearches[subSearchID]
+	ops, err := completeValidation(subSearch, ctx, requestID, metric, objectives, op)
+	return s.markCreates(subSearchID, ops), err
+}
+
+// continueSearch continues the subsearches that can be continued, given how long each of their
+// trials that were closed without exiting early was trained.
+func (s *tournamentSearch) continueSearch(
+	ctx context, trialLengths map[model.RequestID]uint64,
+) ([]Operation, error) {
+	var operations []Operation
+	for subSearchID, subSearch := range s.subSearches {
+		method, ok := subSearch.(continuableSearchMethod)
+		if !ok {
+			continue
+		}
+		subSearchTrialLengths := map[model.RequestID]uint64{}
+		for rID, length := range trialLengths {
+			if subSearchID == s.TrialTable[rID] {
+				subSearchTrialLengths[rID] = length
+			}
+		}
+		ops, err := method.continueSearch(ctx, subSearchTrialLengths)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, s.markCreates(subSearchID, ops)...)
+	}
+	return operations, nil
+}
+
 // trialClosed informs the searcher that the trial has been closed as a result of a Close operation.
 func (s *tournamentSearch) trialClosed(
 	ctx context, requestID model.RequestID,
@@ -0,0 +1,422 @@
+package searcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/determined-ai/determined/master/pkg/mathx"
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/nprand"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+// tpeMaxResamples bounds how many times a draw from a Parzen estimator that falls outside of the
+// range of its hyperparameter is redrawn before it is clipped to the range instead.
+const tpeMaxResamples = 100
+
+type (
+	// tpeSearchState stores the state for TPE. Observations holds every trial that was created, in
+	// the order it was created, with the metric it validated with once it has one; the search
+	// models the trials with metrics to propose the hyperparameters of new trials. CreatedTrials
+	// and PendingTrials are tracked the same way as for random search.
+	tpeSearchState struct {
+		CreatedTrials    int              `json:"created_trials"`
+		PendingTrials    int              `json:"pending_trials"`
+		Observations     []tpeObservation `json:"observations"`
+		SearchMethodType SearchMethodType `json:"search_method_type"`
+	}
+	// tpeObservation is a trial along with its hyperparameters as the search models them: by their
+	// flattened names, with log hyperparameters as exponents and categorical hyperparameters as the
+	// indexes of their values. Constant hyperparameters are not modeled.
+	tpeObservation struct {
+		RequestID model.RequestID    `json:"request_id"`
+		Params    map[string]float64 `json:"params"`
+		Metric    *float64           `json:"metric"`
+	}
+	// tpeSearch corresponds to a Tree-structured Parzen Estimator search (Bergstra et al., 2011).
+	// The first n_startup_trials trials sample hyperparameters at random. After that, the trials
+	// with metrics are split into the best gamma fraction and the rest, a Parzen estimator is fit to
+	// the values of each hyperparameter in both groups, and new trials use the values among
+	// n_ei_candidates draws from the estimator of the best trials that are most likely under it
+	// relative to the estimator of the rest, which maximizes the expected improvement.
+	tpeSearch struct {
+		defaultSearchMethod
+		expconf.TPEConfig
+		tpeSearchState
+		smallerIsBetter bool
+	}
+)
+
+func newTPESearch(config expconf.TPEConfig, smallerIsBetter bool) SearchMethod {
+	return &tpeSearch{
+		TPEConfig:       config,
+		smallerIsBetter: smallerIsBetter,
+		tpeSearchState: tpeSearchState{
+			SearchMethodType: TPESearch,
+		},
+	}
+}
+
+func (s *tpeSearch) initialOperations(ctx context) ([]Operation, error) {
+	var ops []Operation
+	initialTrials := s.MaxTrials()
+	if s.MaxConcurrentTrials() > 0 {
+		initialTrials = mathx.Min(s.MaxTrials(), s.MaxConcurrentTrials())
+	}
+	for trial := 0; trial < initialTrials; trial++ {
+		create, err := s.createTrial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, create...)
+	}
+	return ops, nil
+}
+
+func (s *tpeSearch) validationCompleted(
+	ctx context, requestID model.RequestID, metric float64, op ValidateAfter,
+) ([]Operation, error) {
+	for i := range s.Observations {
+		if s.Observations[i].RequestID == requestID {
+			s.Observations[i].Metric = &metric
+		}
+	}
+	return nil, nil
+}
+
+func (s *tpeSearch) progress(
+	trialProgress map[model.RequestID]PartialUnits,
+	trialsClosed map[model.RequestID]bool,
+) float64 {
+	if s.MaxConcurrentTrials() > 0 && s.PendingTrials > s.MaxConcurrentTrials() {
+		panic("pending trials is greater than max_concurrent_trials")
+	}
+	// Progress is calculated the same way as for random search, since trials are also replaced
+	// after InvalidHP exits and are otherwise trained for max_length units.
+	unitsCompleted := 0.
+	for k, v := range trialProgress {
+		if trialsClosed[k] {
+			unitsCompleted += float64(s.MaxLength().Units)
+		} else {
+			unitsCompleted += float64(v)
+		}
+	}
+	unitsExpected := s.MaxLength().Units * uint64(s.MaxTrials())
+	return unitsCompleted / float64(unitsExpected)
+}
+
+// trialExitedEarly replaces trials that exited with an InvalidHP workload, like random search does.
+// Trials that exit early have no metric, so they never inform the model.
+func (s *tpeSearch) trialExitedEarly(
+	ctx context, requestID model.RequestID, exitedReason model.ExitedReason,
+) ([]Operation, error) {
+	s.PendingTrials--
+	if exitedReason == model.InvalidHP || exitedReason == model.InitInvalidHP {
+		// The replacement is created by trialClosed when the close is received for this trial.
+		s.CreatedTrials--
+	}
+	return nil, nil
+}
+
+func (s *tpeSearch) trialClosed(ctx context, requestID model.RequestID) ([]Operation, error) {
+	s.PendingTrials--
+	if s.CreatedTrials < s.MaxTrials() {
+		return s.createTrial(ctx)
+	}
+	return nil, nil
+}
+
+// createTrial proposes the hyperparameters of a new trial and returns the operations to train it.
+func (s *tpeSearch) createTrial(ctx context) ([]Operation, error) {
+	good, bad := s.splitObservations()
+	startup := len(good)+len(bad) < s.NStartupTrials()
+
+	var params map[string]float64
+	hparams, err := sampleValid(ctx.hparams, func() HParamSample {
+		params = make(map[string]float64)
+		expconf.FlattenHPs(ctx.hparams).Each(func(name string, h expconf.Hyperparameter) {
+			if h.RawConstHyperparameter != nil {
+				return
+			}
+			if startup {
+				params[name] = tpeSampleUniform(h, ctx.rand)
+			} else {
+				params[name] = tpeSuggest(
+					h, ctx.rand, observedValues(good, name), observedValues(bad, name),
+					s.NEICandidates(),
+				)
+			}
+		})
+		return tpeHParams(ctx.hparams, params, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Only the hyperparameters that are active for the trial are observed.
+	for name := range params {
+		if _, ok := hparamValue(hparams, name); !ok {
+			delete(params, name)
+		}
+	}
+
+	create := NewCreate(ctx.rand, hparams, model.TrialWorkloadSequencerType)
+	s.Observations = append(s.Observations, tpeObservation{
+		RequestID: create.RequestID,
+		Params:    params,
+	})
+	s.CreatedTrials++
+	s.PendingTrials++
+	return []Operation{
+		create,
+		NewValidateAfter(create.RequestID, s.MaxLength().Units),
+		NewClose(create.RequestID),
+	}, nil
+}
+
+// splitObservations returns the best gamma fraction of the observations that have metrics, rounded
+// up, and the rest.
+func (s *tpeSearch) splitObservations() (good, bad []tpeObservation) {
+	var completed []tpeObservation
+	for _, o := range s.Observations {
+		if o.Metric != nil {
+			completed = append(completed, o)
+		}
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		if s.smallerIsBetter {
+			return *completed[i].Metric < *completed[j].Metric
+		}
+		return *completed[i].Metric > *completed[j].Metric
+	})
+	nGood := int(math.Ceil(s.Gamma() * float64(len(completed))))
+	return completed[:nGood], completed[nGood:]
+}
+
+func (s *tpeSearch) Snapshot() (json.RawMessage, error) {
+	return json.Marshal(s.tpeSearchState)
+}
+
+func (s *tpeSearch) Restore(state json.RawMessage) error {
+	if state == nil {
+		return nil
+	}
+	return json.Unmarshal(state, &s.tpeSearchState)
+}
+
+func observedValues(observations []tpeObservation, name string) []float64 {
+	var values []float64
+	for _, o := range observations {
+		if v, ok := o.Params[name]; ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// tpeBounds returns the range that a numeric hyperparameter is modeled in. Integers are widened by
+// half on each side so that every integer is equally likely to be rounded to.
+func tpeBounds(h expconf.Hyperparameter) (low, high float64) {
+	switch {
+	case h.RawIntHyperparameter != nil:
+		p := h.RawIntHyperparameter
+		return float64(p.Minval()) - 0.5, float64(p.Maxval()) + 0.5
+	case h.RawDoubleHyperparameter != nil:
+		p := h.RawDoubleHyperparameter
+		return p.Minval(), p.Maxval()
+	case h.RawLogHyperparameter != nil:
+		p := h.RawLogHyperparameter
+		return p.Minval(), p.Maxval()
+	default:
+		panic(fmt.Sprintf("unexpected hyperparameter type: %+v", h))
+	}
+}
+
+// tpeSampleUniform samples the modeled value of a hyperparameter uniformly at random.
+func tpeSampleUniform(h expconf.Hyperparameter, rand *nprand.State) float64 {
+	switch {
+	case h.RawCategoricalHyperparameter != nil:
+		return float64(rand.Intn(len(h.RawCategoricalHyperparameter.Vals())))
+	case h.RawIntHyperparameter != nil:
+		p := h.RawIntHyperparameter
+		return float64(p.Minval() + rand.Intn(p.Maxval()-p.Minval()+1))
+	default:
+		low, high := tpeBounds(h)
+		if high <= low {
+			return low
+		}
+		return rand.Uniform(low, high)
+	}
+}
+
+// tpeSuggest returns the modeled value of a hyperparameter that maximizes the ratio of its
+// likelihood under the estimator fit to the good values to its likelihood under the estimator fit
+// to the bad ones, among nCandidates draws from the former.
+func tpeSuggest(
+	h expconf.Hyperparameter, rand *nprand.State, good, bad []float64, nCandidates int,
+) float64 {
+	var sample func() float64
+	var score func(x float64) float64
+	if h.RawCategoricalHyperparameter != nil {
+		n := len(h.RawCategoricalHyperparameter.Vals())
+		l, g := categoricalWeights(good, n), categoricalWeights(bad, n)
+		sample = func() float64 { return float64(sampleCategorical(l, rand)) }
+		score = func(x float64) float64 { return math.Log(l[int(x)]) - math.Log(g[int(x)]) }
+	} else {
+		low, high := tpeBounds(h)
+		if high <= low {
+			return low
+		}
+		l, g := newParzenEstimator(good, low, high), newParzenEstimator(bad, low, high)
+		sample = func() float64 { return l.sample(rand) }
+		score = func(x float64) float64 { return l.logPDF(x) - g.logPDF(x) }
+	}
+
+	best, bestScore := 0., math.Inf(-1)
+	for i := 0; i < nCandidates; i++ {
+		x := sample()
+		if p := h.RawIntHyperparameter; p != nil {
+			x = math.Max(float64(p.Minval()), math.Min(float64(p.Maxval()), math.Round(x)))
+		}
+		if sc := score(x); i == 0 || sc > bestScore {
+			best, bestScore = x, sc
+		}
+	}
+	return best
+}
+
+// tpeValue converts the modeled value of a hyperparameter back to the value a trial uses.
+func tpeValue(h expconf.Hyperparameter, x float64) interface{} {
+	switch {
+	case h.RawIntHyperparameter != nil:
+		return int(math.Round(x))
+	case h.RawDoubleHyperparameter != nil:
+		return x
+	case h.RawLogHyperparameter != nil:
+		return math.Pow(h.RawLogHyperparameter.Base(), x)
+	case h.RawCategoricalHyperparameter != nil:
+		return h.RawCategoricalHyperparameter.Vals()[int(x)]
+	default:
+		panic(fmt.Sprintf("unexpected hyperparameter type: %+v", h))
+	}
+}
+
+// tpeHParams builds the hyperparameters of a trial from the modeled values of its hyperparameters,
+// which are keyed by flattened names starting with prefix.
+func tpeHParams(
+	h expconf.Hyperparameters, params map[string]float64, prefix string,
+) HParamSample {
+	results := make(HParamSample)
+	h.Each(func(name string, param expconf.Hyperparameter) {
+		switch {
+		case param.RawConstHyperparameter != nil:
+			results[name] = param.RawConstHyperparameter.Val()
+		case param.RawNestedHyperparameter != nil:
+			results[name] = map[string]interface{}(tpeHParams(
+				*param.RawNestedHyperparameter, params, prefix+name+".",
+			))
+		default:
+			results[name] = tpeValue(param, params[prefix+name])
+		}
+	})
+	return results
+}
+
+// categoricalWeights returns the probability of each of n categories given the observed indexes,
+// smoothed by a uniform prior that is weighted like a single observation.
+func categoricalWeights(observed []float64, n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1 / float64(n)
+	}
+	for _, x := range observed {
+		weights[int(x)]++
+	}
+	for i := range weights {
+		weights[i] /= float64(len(observed) + 1)
+	}
+	return weights
+}
+
+func sampleCategorical(weights []float64, rand *nprand.State) int {
+	u := rand.UnitInterval()
+	for i, w := range weights {
+		if u < w {
+			return i
+		}
+		u -= w
+	}
+	return len(weights) - 1
+}
+
+// parzenEstimator is an equally weighted mixture of normal distributions truncated to [low, high]:
+// one centered on each observation, with a width set by the distance to its neighbors, and a wide
+// one centered on the range that acts as a prior.
+type parzenEstimator struct {
+	mus, sigmas []float64
+	low, high   float64
+}
+
+func newParzenEstimator(observed []float64, low, high float64) parzenEstimator {
+	mus := append([]float64{}, observed...)
+	sort.Float64s(mus)
+	sigmas := make([]float64, len(mus))
+	maxSigma := high - low
+	minSigma := maxSigma / math.Min(100, float64(len(mus)+1))
+	for i, mu := range mus {
+		left, right := mu-low, high-mu
+		if i > 0 {
+			left = mu - mus[i-1]
+		}
+		if i < len(mus)-1 {
+			right = mus[i+1] - mu
+		}
+		sigmas[i] = math.Max(minSigma, math.Min(maxSigma, math.Max(left, right)))
+	}
+	return parzenEstimator{
+		mus:    append(mus, (low+high)/2),
+		sigmas: append(sigmas, maxSigma),
+		low:    low,
+		high:   high,
+	}
+}
+
+func (p parzenEstimator) sample(rand *nprand.State) float64 {
+	i := rand.Intn(len(p.mus))
+	var x float64
+	for try := 0; try < tpeMaxResamples; try++ {
+		x = p.mus[i] + p.sigmas[i]*standardNormal(rand)
+		if x >= p.low && x <= p.high {
+			return x
+		}
+	}
+	return math.Max(p.low, math.Min(p.high, x))
+}
+
+func (p parzenEstimator) logPDF(x float64) float64 {
+	logs := make([]float64, len(p.mus))
+	maxLog := math.Inf(-1)
+	for i, mu := range p.mus {
+		sigma := p.sigmas[i]
+		z := (x - mu) / sigma
+		mass := normalCDF((p.high-mu)/sigma) - normalCDF((p.low-mu)/sigma)
+		logs[i] = -z*z/2 - math.Log(sigma*math.Sqrt(2*math.Pi)*mass)
+		maxLog = math.Max(maxLog, logs[i])
+	}
+	sum := 0.
+	for _, l := range logs {
+		sum += math.Exp(l - maxLog)
+	}
+	return maxLog + math.Log(sum/float64(len(p.mus)))
+}
+
+func normalCDF(z float64) float64 {
+	return (1 + math.Erf(z/math.Sqrt2)) / 2
+}
+
+// standardNormal draws from the standard normal distribution with the Box-Muller transform.
+func standardNormal(rand *nprand.State) float64 {
+	u1, u2 := rand.UnitInterval(), rand.UnitInterval()
+	return math.Sqrt(-2*math.Log(1-u1)) * math.Cos(2*math.Pi*u2)
+}
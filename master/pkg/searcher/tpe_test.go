@@ -0,0 +1,193 @@
+//nolint:exhaustivestruct
+package searcher
+
+import (
+	"math"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+	"github.com/determined-ai/determined/master/pkg/nprand"
+	"github.com/determined-ai/determined/master/pkg/ptrs"
+	"github.com/determined-ai/determined/master/pkg/schemas"
+	"github.com/determined-ai/determined/master/pkg/schemas/expconf"
+)
+
+func tpeTestHParams() expconf.Hyperparameters {
+	return schemas.WithDefaults(expconf.Hyperparameters{
+		"x": expconf.Hyperparameter{
+			RawDoubleHyperparameter: &expconf.DoubleHyperparameter{RawMinval: 0, RawMaxval: 10},
+		},
+		"n": expconf.Hyperparameter{
+			RawIntHyperparameter: &expconf.IntHyperparameter{RawMinval: 0, RawMaxval: 10},
+		},
+		"lr": expconf.Hyperparameter{
+			RawLogHyperparameter: &expconf.LogHyperparameter{
+				RawMinval: -5, RawMaxval: -1, RawBase: 10,
+			},
+		},
+		"opt": expconf.Hyperparameter{
+			RawCategoricalHyperparameter: &expconf.CategoricalHyperparameter{
+				RawVals: []interface{}{"a", "b", "c"},
+			},
+		},
+		"const": expconf.Hyperparameter{
+			RawConstHyperparameter: &expconf.ConstHyperparameter{RawVal: 64},
+		},
+		"nested": expconf.Hyperparameter{
+			RawNestedHyperparameter: &map[string]expconf.Hyperparameter{
+				"y": {
+					RawDoubleHyperparameter: &expconf.DoubleHyperparameter{
+						RawMinval: -1, RawMaxval: 1,
+					},
+				},
+			},
+		},
+	}).(expconf.Hyperparameters)
+}
+
+// tpeTestObjective is minimized at x=7, n=3, lr=0.01, opt=b and nested.y=0.
+func tpeTestObjective(h HParamSample) float64 {
+	metric := math.Pow(h["x"].(float64)-7, 2)/10 +
+		math.Pow(float64(h["n"].(int)-3), 2)/10 +
+		math.Pow(math.Log10(h["lr"].(float64))+2, 2) +
+		math.Pow(h["nested"].(map[string]interface{})["y"].(float64), 2)
+	if h["opt"] != "b" {
+		metric += 2
+	}
+	return metric
+}
+
+// runTPE trains the trials of a TPE search one validation at a time and returns the hyperparameters
+// and metrics of the trials in the order they were created. If reload is set, the search is
+// snapshotted and restored into a new search method after every event.
+func runTPE(
+	t *testing.T, config expconf.TPEConfig, reload bool,
+) ([]HParamSample, []float64) {
+	ctx := context{rand: nprand.New(0), hparams: tpeTestHParams()}
+	method := newTPESearch(config, true)
+
+	var samples []HParamSample
+	var metrics []float64
+	requests := map[model.RequestID]int{}
+	pending, err := method.initialOperations(ctx)
+	assert.NilError(t, err)
+	for len(pending) > 0 {
+		var ops []Operation
+		switch op := pending[0].(type) {
+		case Create:
+			requests[op.RequestID] = len(samples)
+			samples = append(samples, op.Hparams)
+			metrics = append(metrics, tpeTestObjective(op.Hparams))
+		case ValidateAfter:
+			ops, err = method.validationCompleted(ctx, op.RequestID, metrics[requests[op.RequestID]], op)
+			assert.NilError(t, err)
+		case Close:
+			ops, err = method.trialClosed(ctx, op.RequestID)
+			assert.NilError(t, err)
+		}
+		pending = append(pending[1:], ops...)
+
+		if reload {
+			state, err := method.Snapshot()
+			assert.NilError(t, err)
+			method = newTPESearch(config, true)
+			assert.NilError(t, method.Restore(state))
+		}
+	}
+	return samples, metrics
+}
+
+func TestTPESearcherBatches(t *testing.T) {
+	actual := expconf.TPEConfig{
+		RawMaxTrials: ptrs.Ptr(4), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+		RawNStartupTrials: ptrs.Ptr(2), RawMaxConcurrentTrials: ptrs.Ptr(1),
+	}
+	actual = schemas.WithDefaults(actual).(expconf.TPEConfig)
+	expected := [][]ValidateAfter{
+		toOps("300B"),
+		toOps("300B"),
+		toOps("300B"),
+		toOps("300B"),
+	}
+	checkSimulation(t, newTPESearch(actual, true), tpeTestHParams(), RandomValidation, expected)
+}
+
+func TestTPESearcherReproducibility(t *testing.T) {
+	conf := expconf.TPEConfig{
+		RawMaxTrials: ptrs.Ptr(20), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+		RawNStartupTrials: ptrs.Ptr(4), RawMaxConcurrentTrials: ptrs.Ptr(2),
+	}
+	conf = schemas.WithDefaults(conf).(expconf.TPEConfig)
+	gen := func() SearchMethod { return newTPESearch(conf, true) }
+	checkReproducibility(t, gen, tpeTestHParams(), defaultMetric)
+}
+
+func TestTPESearcherRestore(t *testing.T) {
+	conf := schemas.WithDefaults(expconf.TPEConfig{
+		RawMaxTrials: ptrs.Ptr(20), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+		RawNStartupTrials: ptrs.Ptr(4), RawMaxConcurrentTrials: ptrs.Ptr(2),
+	}).(expconf.TPEConfig)
+	samples, _ := runTPE(t, conf, false)
+	reloaded, _ := runTPE(t, conf, true)
+	assert.DeepEqual(t, samples, reloaded)
+}
+
+func TestTPESearcherImproves(t *testing.T) {
+	conf := schemas.WithDefaults(expconf.TPEConfig{
+		RawMaxTrials: ptrs.Ptr(80), RawMaxLength: ptrs.Ptr(expconf.NewLengthInBatches(300)),
+		RawNStartupTrials: ptrs.Ptr(20), RawMaxConcurrentTrials: ptrs.Ptr(1),
+	}).(expconf.TPEConfig)
+	samples, metrics := runTPE(t, conf, false)
+	assert.Equal(t, len(samples), 80)
+
+	mean := func(ms []float64) float64 {
+		sum := 0.
+		for _, m := range ms {
+			sum += m
+		}
+		return sum / float64(len(ms))
+	}
+	// The trials the model proposes should do better on average than the random startup trials.
+	assert.Assert(t, mean(metrics[60:]) < mean(metrics[:20])/2,
+		"startup mean %v, final mean %v", mean(metrics[:20]), mean(metrics[60:]))
+
+	for _, s := range samples {
+		assert.Equal(t, s["const"], 64)
+		x := s["x"].(float64)
+		assert.Assert(t, x >= 0 && x <= 10)
+		n := s["n"].(int)
+		assert.Assert(t, n >= 0 && n <= 10)
+		lr := s["lr"].(float64)
+		assert.Assert(t, lr >= 1e-5 && lr <= 1e-1)
+		y := s["nested"].(map[string]interface{})["y"].(float64)
+		assert.Assert(t, y >= -1 && y <= 1)
+	}
+}
+
+func TestTPESearchMethod(t *testing.T) {
+	testCases := []valueSimulationTestCase{
+		{
+			name: "test tpe search method",
+			expectedTrials: []predefinedTrial{
+				newConstantPredefinedTrial(toOps("500B"), .3),
+				newConstantPredefinedTrial(toOps("500B"), .1),
+				newConstantPredefinedTrial(toOps("500B"), .2),
+				newEarlyExitPredefinedTrial(toOps("500B"), .1),
+				newConstantPredefinedTrial(toOps("500B"), .4),
+			},
+			hparams: tpeTestHParams(),
+			config: expconf.SearcherConfig{
+				RawTPEConfig: &expconf.TPEConfig{
+					RawMaxLength:           ptrs.Ptr(expconf.NewLengthInBatches(500)),
+					RawMaxTrials:           ptrs.Ptr(5),
+					RawMaxConcurrentTrials: ptrs.Ptr(2),
+					RawNStartupTrials:      ptrs.Ptr(2),
+				},
+			},
+		},
+	}
+
+	runValueSimulationTestCases(t, testCases)
+}
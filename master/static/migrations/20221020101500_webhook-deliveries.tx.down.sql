@@ -0,0 +1,3 @@
+ALTER TABLE cluster_id DROP COLUMN webhook_signing_key;
+
+DROP TABLE webhook_deliveries;
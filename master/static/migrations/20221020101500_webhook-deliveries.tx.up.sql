@@ -0,0 +1,18 @@
+CREATE TABLE webhook_deliveries (
+  id SERIAL PRIMARY KEY,
+  webhook_id integer NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+  trigger_id integer REFERENCES webhook_triggers(id) ON DELETE CASCADE,
+  event_id uuid NOT NULL,
+  event_type text NOT NULL,
+  attempt integer NOT NULL,
+  payload jsonb NOT NULL,
+  status_code integer,
+  error text,
+  delivered boolean NOT NULL DEFAULT false,
+  attempted_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ix_webhook_deliveries_webhook_id_attempted_at
+  ON webhook_deliveries (webhook_id, attempted_at DESC);
+
+ALTER TABLE cluster_id ADD COLUMN webhook_signing_key text;
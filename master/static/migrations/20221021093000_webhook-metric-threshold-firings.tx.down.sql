@@ -0,0 +1 @@
+DROP TABLE webhook_metric_threshold_firings;
@@ -0,0 +1,8 @@
+-- Records which trials have already fired a metric threshold trigger, so that a trial crossing a
+-- threshold fires once rather than on every subsequent report.
+CREATE TABLE webhook_metric_threshold_firings (
+  trigger_id integer NOT NULL REFERENCES webhook_triggers(id) ON DELETE CASCADE,
+  trial_id integer NOT NULL REFERENCES trials(id) ON DELETE CASCADE,
+  fired_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (trigger_id, trial_id)
+);
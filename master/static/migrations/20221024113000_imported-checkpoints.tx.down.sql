@@ -0,0 +1 @@
+ALTER TABLE public.checkpoints_v2 DROP COLUMN storage_config;
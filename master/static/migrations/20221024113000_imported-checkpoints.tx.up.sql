@@ -0,0 +1,3 @@
+-- Checkpoints uploaded through the master are not tied to a trial, so there is no experiment
+-- config to find their storage in; record it alongside the checkpoint instead.
+ALTER TABLE public.checkpoints_v2 ADD COLUMN storage_config jsonb NULL;
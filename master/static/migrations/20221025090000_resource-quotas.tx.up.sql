@@ -0,0 +1,14 @@
+CREATE TABLE resource_quotas (
+  id SERIAL PRIMARY KEY,
+  resource_pool text NOT NULL,
+  workspace_id integer REFERENCES workspaces(id) ON DELETE CASCADE,
+  user_id integer REFERENCES users(id) ON DELETE CASCADE,
+  max_slots integer NOT NULL CHECK (max_slots >= 0),
+  max_queued_jobs integer CHECK (max_queued_jobs >= 0),
+  CHECK ((workspace_id IS NULL) <> (user_id IS NULL))
+);
+
+CREATE UNIQUE INDEX ix_resource_quotas_pool_workspace
+  ON resource_quotas (resource_pool, workspace_id) WHERE workspace_id IS NOT NULL;
+CREATE UNIQUE INDEX ix_resource_quotas_pool_user
+  ON resource_quotas (resource_pool, user_id) WHERE user_id IS NOT NULL;
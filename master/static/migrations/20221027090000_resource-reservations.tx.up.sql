@@ -0,0 +1,17 @@
+CREATE TABLE resource_reservations (
+  id SERIAL PRIMARY KEY,
+  resource_pool text NOT NULL,
+  workspace_id integer REFERENCES workspaces(id) ON DELETE CASCADE,
+  user_id integer REFERENCES users(id) ON DELETE CASCADE,
+  slots integer NOT NULL CHECK (slots > 0),
+  description text NOT NULL DEFAULT '',
+  start_time timestamptz,
+  end_time timestamptz,
+  weekdays text[],
+  daily_start text,
+  daily_end text,
+  timezone text,
+  CHECK ((workspace_id IS NULL) <> (user_id IS NULL))
+);
+
+CREATE INDEX ix_resource_reservations_resource_pool ON resource_reservations (resource_pool);
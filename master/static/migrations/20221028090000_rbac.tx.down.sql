@@ -0,0 +1,4 @@
+DROP TABLE role_assignments;
+DROP TABLE permission_assignments;
+DROP TABLE roles;
+DROP TABLE permissions;
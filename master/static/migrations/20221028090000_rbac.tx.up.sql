@@ -0,0 +1,73 @@
+CREATE TABLE permissions (
+  id integer PRIMARY KEY,
+  name text NOT NULL UNIQUE,
+  global_only boolean NOT NULL DEFAULT false
+);
+
+INSERT INTO permissions (id, name, global_only) VALUES
+  (91001, 'PERMISSION_TYPE_ADMINISTRATE_USER', true),
+  (2001, 'PERMISSION_TYPE_CREATE_EXPERIMENT', false),
+  (2002, 'PERMISSION_TYPE_VIEW_EXPERIMENT_ARTIFACTS', false),
+  (2003, 'PERMISSION_TYPE_VIEW_EXPERIMENT_METADATA', false),
+  (2004, 'PERMISSION_TYPE_UPDATE_EXPERIMENT', false),
+  (2005, 'PERMISSION_TYPE_UPDATE_EXPERIMENT_METADATA', false),
+  (2006, 'PERMISSION_TYPE_DELETE_EXPERIMENT', false),
+  (93001, 'PERMISSION_TYPE_UPDATE_GROUP', true),
+  (94001, 'PERMISSION_TYPE_CREATE_WORKSPACE', true),
+  (4002, 'PERMISSION_TYPE_VIEW_WORKSPACE', false),
+  (4003, 'PERMISSION_TYPE_UPDATE_WORKSPACE', false),
+  (4004, 'PERMISSION_TYPE_DELETE_WORKSPACE', false),
+  (4005, 'PERMISSION_TYPE_SET_WORKSPACE_AGENT_USER_GROUP', false),
+  (5001, 'PERMISSION_TYPE_CREATE_PROJECT', false),
+  (5002, 'PERMISSION_TYPE_VIEW_PROJECT', false),
+  (5003, 'PERMISSION_TYPE_UPDATE_PROJECT', false),
+  (5004, 'PERMISSION_TYPE_DELETE_PROJECT', false),
+  (96001, 'PERMISSION_TYPE_UPDATE_ROLES', true),
+  (6002, 'PERMISSION_TYPE_ASSIGN_ROLES', false),
+  (97001, 'PERMISSION_TYPE_EDIT_WEBHOOKS', true);
+
+CREATE TABLE roles (
+  id SERIAL PRIMARY KEY,
+  role_name text NOT NULL UNIQUE,
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE permission_assignments (
+  permission_id integer NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+  role_id integer NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  PRIMARY KEY (permission_id, role_id)
+);
+
+INSERT INTO roles (role_name) VALUES
+  ('ClusterAdmin'), ('WorkspaceAdmin'), ('WorkspaceCreator'), ('Editor'), ('Viewer');
+
+INSERT INTO permission_assignments (permission_id, role_id)
+SELECT p.id, r.id FROM permissions p, roles r
+WHERE r.role_name = 'ClusterAdmin'
+UNION ALL
+SELECT p.id, r.id FROM permissions p, roles r
+WHERE r.role_name = 'WorkspaceAdmin' AND p.id IN (
+  2001, 2002, 2003, 2004, 2005, 2006, 4002, 4003, 4004, 5001, 5002, 5003, 5004, 6002)
+UNION ALL
+SELECT p.id, r.id FROM permissions p, roles r
+WHERE r.role_name = 'WorkspaceCreator' AND p.id IN (94001)
+UNION ALL
+SELECT p.id, r.id FROM permissions p, roles r
+WHERE r.role_name = 'Editor' AND p.id IN (2001, 2002, 2003, 2004, 2005, 2006, 4002, 5001, 5002, 5003)
+UNION ALL
+SELECT p.id, r.id FROM permissions p, roles r
+WHERE r.role_name = 'Viewer' AND p.id IN (2002, 2003, 4002, 5002);
+
+-- Users are assigned roles through their personal groups. A role applies to the whole cluster
+-- unless it is scoped to a workspace or a project.
+CREATE TABLE role_assignments (
+  id SERIAL PRIMARY KEY,
+  group_id integer NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+  role_id integer NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  scope_workspace_id integer REFERENCES workspaces(id) ON DELETE CASCADE,
+  scope_project_id integer REFERENCES projects(id) ON DELETE CASCADE,
+  CHECK (scope_workspace_id IS NULL OR scope_project_id IS NULL)
+);
+
+CREATE UNIQUE INDEX ix_role_assignments_unique ON role_assignments
+  (group_id, role_id, COALESCE(scope_workspace_id, 0), COALESCE(scope_project_id, 0));
@@ -0,0 +1,11 @@
+DROP INDEX ix_user_sessions_access_tokens;
+
+ALTER TABLE user_sessions
+  DROP COLUMN access_token,
+  DROP COLUMN description,
+  DROP COLUMN read_only,
+  DROP COLUMN scope_workspace_id,
+  DROP COLUMN created_at,
+  DROP COLUMN last_used_at;
+
+ALTER TABLE users DROP COLUMN service_account;
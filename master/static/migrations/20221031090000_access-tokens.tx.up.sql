@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN service_account boolean NOT NULL DEFAULT false;
+
+ALTER TABLE user_sessions
+  ADD COLUMN access_token boolean NOT NULL DEFAULT false,
+  ADD COLUMN description text,
+  ADD COLUMN read_only boolean NOT NULL DEFAULT false,
+  ADD COLUMN scope_workspace_id integer REFERENCES workspaces(id) ON DELETE CASCADE,
+  ADD COLUMN created_at timestamptz NOT NULL DEFAULT now(),
+  ADD COLUMN last_used_at timestamptz;
+
+CREATE INDEX ix_user_sessions_access_tokens ON user_sessions (user_id) WHERE access_token;
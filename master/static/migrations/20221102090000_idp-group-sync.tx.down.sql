@@ -0,0 +1,3 @@
+DROP TABLE group_sync_events;
+
+ALTER TABLE user_group_membership DROP COLUMN synced;
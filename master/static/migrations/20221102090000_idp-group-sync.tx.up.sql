@@ -0,0 +1,13 @@
+ALTER TABLE user_group_membership ADD COLUMN synced boolean NOT NULL DEFAULT false;
+
+CREATE TABLE group_sync_events (
+    id integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id integer NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    group_id integer REFERENCES groups (id) ON DELETE SET NULL,
+    group_name text NOT NULL,
+    action text NOT NULL,
+    source text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ix_group_sync_events_user_id ON group_sync_events (user_id);
@@ -0,0 +1,19 @@
+CREATE TABLE audit_events (
+    id bigint PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    time timestamptz NOT NULL DEFAULT now(),
+    user_id integer,
+    username text,
+    session_id integer,
+    access_token boolean NOT NULL DEFAULT false,
+    protocol text NOT NULL,
+    method text NOT NULL,
+    path text,
+    targets jsonb,
+    outcome text NOT NULL,
+    status text NOT NULL,
+    remote_addr text
+);
+
+CREATE INDEX ix_audit_events_time ON audit_events (time);
+CREATE INDEX ix_audit_events_user_id_time ON audit_events (user_id, time);
+CREATE INDEX ix_audit_events_targets ON audit_events USING gin (targets jsonb_path_ops);
@@ -0,0 +1,5 @@
+DROP TABLE login_failures;
+
+DROP TABLE user_password_history;
+
+ALTER TABLE users DROP COLUMN password_change_required;
@@ -0,0 +1,22 @@
+ALTER TABLE users ADD COLUMN password_change_required boolean NOT NULL DEFAULT false;
+
+CREATE TABLE user_password_history (
+    id integer PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id integer NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    password_hash text NOT NULL,
+    replaced_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ix_user_password_history_user_id ON user_password_history (user_id);
+
+CREATE TABLE login_failures (
+    id bigint PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username text NOT NULL,
+    remote_addr text NOT NULL,
+    reason text NOT NULL,
+    failed_at timestamptz NOT NULL DEFAULT now(),
+    cleared boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX ix_login_failures_uncleared ON login_failures (username, remote_addr, failed_at)
+  WHERE NOT cleared;
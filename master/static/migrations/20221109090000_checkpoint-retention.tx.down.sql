@@ -0,0 +1 @@
+DROP TABLE checkpoint_retention_policies;
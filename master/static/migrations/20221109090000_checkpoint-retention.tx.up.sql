@@ -0,0 +1,9 @@
+CREATE TABLE checkpoint_retention_policies (
+  id SERIAL PRIMARY KEY,
+  workspace_id integer UNIQUE REFERENCES workspaces(id) ON DELETE CASCADE,
+  project_id integer UNIQUE REFERENCES projects(id) ON DELETE CASCADE,
+  max_age_seconds bigint CHECK (max_age_seconds > 0),
+  max_total_bytes bigint CHECK (max_total_bytes >= 0),
+  keep_registered boolean NOT NULL DEFAULT true,
+  CHECK ((workspace_id IS NULL) <> (project_id IS NULL))
+);
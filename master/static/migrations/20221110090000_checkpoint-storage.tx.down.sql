@@ -0,0 +1,7 @@
+ALTER TABLE public.experiments
+    DROP COLUMN checkpoint_size,
+    DROP COLUMN checkpoint_count;
+
+ALTER TABLE public.trials
+    DROP COLUMN checkpoint_size,
+    DROP COLUMN checkpoint_count;
@@ -0,0 +1,31 @@
+ALTER TABLE public.trials
+    ADD COLUMN checkpoint_size bigint NOT NULL DEFAULT 0,
+    ADD COLUMN checkpoint_count integer NOT NULL DEFAULT 0;
+
+ALTER TABLE public.experiments
+    ADD COLUMN checkpoint_size bigint NOT NULL DEFAULT 0,
+    ADD COLUMN checkpoint_count integer NOT NULL DEFAULT 0;
+
+WITH usage AS (
+    SELECT c.trial_id, count(*) AS count, coalesce(sum(s.size), 0) AS size
+    FROM checkpoints_view c,
+        LATERAL (
+            SELECT coalesce(sum(r.value::bigint), 0) AS size
+            FROM jsonb_each_text(c.resources) r
+        ) s
+    WHERE c.state != 'DELETED' AND c.trial_id IS NOT NULL
+    GROUP BY c.trial_id
+)
+UPDATE trials t
+SET checkpoint_size = u.size, checkpoint_count = u.count
+FROM usage u
+WHERE t.id = u.trial_id;
+
+UPDATE experiments e
+SET checkpoint_size = u.size, checkpoint_count = u.count
+FROM (
+    SELECT experiment_id, sum(checkpoint_size) AS size, sum(checkpoint_count) AS count
+    FROM trials
+    GROUP BY experiment_id
+) u
+WHERE e.id = u.experiment_id;
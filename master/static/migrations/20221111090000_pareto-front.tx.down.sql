@@ -0,0 +1 @@
+DROP TABLE experiment_pareto_fronts;
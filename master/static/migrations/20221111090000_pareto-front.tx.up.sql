@@ -0,0 +1,5 @@
+CREATE TABLE experiment_pareto_fronts (
+  experiment_id integer NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+  trial_id integer NOT NULL REFERENCES trials(id) ON DELETE CASCADE,
+  PRIMARY KEY (experiment_id, trial_id)
+);
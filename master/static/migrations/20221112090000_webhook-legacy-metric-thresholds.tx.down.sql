@@ -0,0 +1,2 @@
+-- Nothing to do for down. The conversion of legacy metric threshold conditions isn't reverted,
+-- since the typed conditions remain valid and the free-form ones never fired.
@@ -0,0 +1,13 @@
+-- Metric threshold triggers created before their conditions were typed use free-form
+-- metricName/metricValue conditions, which never fire. Convert them to typed conditions that fire
+-- when the validation metric exceeds the value.
+UPDATE webhook_triggers
+SET condition = jsonb_build_object(
+  'metric_name', condition->>'metricName',
+  'comparator', '>',
+  'threshold', condition->'metricValue',
+  'metric_type', 'validation'
+)
+WHERE trigger_type = 'METRIC_THRESHOLD_EXCEEDED'
+  AND jsonb_typeof(condition->'metricName') = 'string'
+  AND jsonb_typeof(condition->'metricValue') = 'number';
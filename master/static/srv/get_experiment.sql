@@ -18,6 +18,8 @@ SELECT
     'STATE_' || e.state AS state,
     e.archived AS archived,
     e.progress AS progress,
+    e.checkpoint_size AS checkpoint_size,
+    e.checkpoint_count AS checkpoint_count,
     e.job_id AS job_id,
     e.parent_id AS forked_from,
     e.owner_id AS user_id,
@@ -2,7 +2,9 @@ WITH pe AS (
   SELECT
     COUNT(*) AS num_experiments,
     SUM(case when state = 'ACTIVE' then 1 else 0 end) AS num_active_experiments,
-    MAX(start_time) AS last_experiment_started_at
+    MAX(start_time) AS last_experiment_started_at,
+    COALESCE(SUM(checkpoint_size), 0) AS checkpoint_size,
+    COALESCE(SUM(checkpoint_count), 0) AS checkpoint_count
   FROM experiments
   WHERE project_id = $1
 )
@@ -12,6 +14,8 @@ SELECT p.id, p.name, p.workspace_id, p.description, p.immutable, p.notes, w.name
   MAX(pe.num_experiments) AS num_experiments,
   MAX(pe.num_active_experiments) AS num_active_experiments,
   COALESCE(MAX(pe.last_experiment_started_at), NULL) AS last_experiment_started_at,
+  MAX(pe.checkpoint_size) AS checkpoint_size,
+  MAX(pe.checkpoint_count) AS checkpoint_count,
   u.username, p.user_id
 FROM pe, projects as p
   LEFT JOIN users as u ON u.id = p.user_id
@@ -3,7 +3,10 @@ WITH p AS (
   WHERE workspace_id = $1
 ),
 exp_count AS (
-  SELECT COUNT(*) AS count FROM experiments
+  SELECT COUNT(*) AS count,
+    COALESCE(SUM(checkpoint_size), 0) AS checkpoint_size,
+    COALESCE(SUM(checkpoint_count), 0) AS checkpoint_count
+  FROM experiments
   WHERE project_id IN (SELECT id FROM p)
 )
 SELECT w.id, w.name, w.archived, w.immutable, u.username, w.user_id,
@@ -13,6 +16,8 @@ SELECT w.id, w.name, w.archived, w.immutable, u.username, w.user_id,
     ELSE NULL END) as agent_user_group,
   (SELECT COUNT(*) FROM p) AS num_projects,
   (SELECT count FROM exp_count) AS num_experiments,
+  (SELECT checkpoint_size FROM exp_count) AS checkpoint_size,
+  (SELECT checkpoint_count FROM exp_count) AS checkpoint_count,
   (SELECT COUNT(*) > 0 FROM workspace_pins
     WHERE workspace_id = $1 AND user_id = $2
   ) AS pinned
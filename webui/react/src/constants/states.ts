@@ -115,6 +115,7 @@ export const V1SchedulerTypeToLabel: { [key in V1SchedulerType]: string } = {
   [V1SchedulerType.ROUNDROBIN]: 'RoundRobin',
   [V1SchedulerType.SLURM]: 'Slurm',
   [V1SchedulerType.PBS]: 'PBS',
+  [V1SchedulerType.BACKFILL]: 'Backfill',
   [V1SchedulerType.UNSPECIFIED]: 'Unspecified',
 };
 
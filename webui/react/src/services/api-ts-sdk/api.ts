@@ -4248,6 +4248,18 @@ export interface V1Job {
      * @memberof V1Job
      */
     progress?: number;
+    /**
+     * When the scheduler expects to start the job, if it has reserved resources for it.
+     * @type {Date}
+     * @memberof V1Job
+     */
+    reservedStart?: Date;
+    /**
+     * Whether the job is held back by a resource quota of its workspace or user.
+     * @type {boolean}
+     * @memberof V1Job
+     */
+    blockedByQuota?: boolean;
 }
 
 /**
@@ -7245,7 +7257,7 @@ export enum V1Scale {
 }
 
 /**
- * The type of the Scheduler.   - SCHEDULER_TYPE_UNSPECIFIED: Unspecified. This value will never actually be returned by the API, it is just an artifact of using protobuf.  - SCHEDULER_TYPE_PRIORITY: The priority scheduler.  - SCHEDULER_TYPE_FAIR_SHARE: The fair share scheduler.  - SCHEDULER_TYPE_ROUND_ROBIN: The round robin scheduler  - SCHEDULER_TYPE_KUBERNETES: The kubernetes scheduler.  - SCHEDULER_TYPE_SLURM: A slurm placeholder. When running on slurm, all scheduling behavior is delegated.  - SCHEDULER_TYPE_PBS: A PBS placeholder. When running on PBS, all scheduling behavior is delegated.
+ * The type of the Scheduler.   - SCHEDULER_TYPE_UNSPECIFIED: Unspecified. This value will never actually be returned by the API, it is just an artifact of using protobuf.  - SCHEDULER_TYPE_PRIORITY: The priority scheduler.  - SCHEDULER_TYPE_FAIR_SHARE: The fair share scheduler.  - SCHEDULER_TYPE_ROUND_ROBIN: The round robin scheduler  - SCHEDULER_TYPE_KUBERNETES: The kubernetes scheduler.  - SCHEDULER_TYPE_SLURM: A slurm placeholder. When running on slurm, all scheduling behavior is delegated.  - SCHEDULER_TYPE_PBS: A PBS placeholder. When running on PBS, all scheduling behavior is delegated.  - SCHEDULER_TYPE_BACKFILL: The backfill scheduler.
  * @export
  * @enum {string}
  */
@@ -7256,7 +7268,8 @@ export enum V1SchedulerType {
     ROUNDROBIN = <any> 'SCHEDULER_TYPE_ROUND_ROBIN',
     KUBERNETES = <any> 'SCHEDULER_TYPE_KUBERNETES',
     SLURM = <any> 'SCHEDULER_TYPE_SLURM',
-    PBS = <any> 'SCHEDULER_TYPE_PBS'
+    PBS = <any> 'SCHEDULER_TYPE_PBS',
+    BACKFILL = <any> 'SCHEDULER_TYPE_BACKFILL'
 }
 
 /**